@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/cli"
+)
+
+// exampleModeEnv makes gen-docs act on its arguments as the CLI would,
+// for verifyExamples: "parse" only resolves the command and parses its
+// flags and arguments; "run" executes it.
+const exampleModeEnv = "ANCLA_GEN_DOCS_EXAMPLE"
+
+// exampleTimeout bounds one example's run. Examples that stream or wait
+// are cut off; that they got that far is enough.
+const exampleTimeout = 10 * time.Second
+
+// exampleLink is the link context examples run in.
+const exampleLink = "workspace: my-ws\nproject: my-app\nenv: production\nservice: web\n"
+
+// example is one ancla invocation found in a command's Example.
+type example struct {
+	cmd  *cobra.Command // whose Example shows it
+	line string
+	args []string // after "ancla"
+}
+
+// verifyExamples checks every example in the command tree, reports the
+// stale ones, and returns how many there were. Each example runs in a
+// child process of its own, so flags parsed for one can't leak into the
+// next: first it must parse — resolve to a command, with flags and
+// arguments it accepts — and then it is run against a mock server from
+// a scratch HOME and working directory. The run may fail, since the mock
+// answers every request with an empty list or object and the scratch
+// directory has none of the files an example might read, but it must not
+// panic.
+func verifyExamples(root *cobra.Command) int {
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "finding gen-docs executable: %v\n", err)
+		return 1
+	}
+	home, err := os.MkdirTemp("", "ancla-examples-*")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "creating scratch HOME: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(home)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Collections end in a slash; everything else is one object.
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/") {
+			w.Write([]byte("[]"))
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	examples := collectExamples(root)
+	stale := 0
+	for _, ex := range examples {
+		if msg, err := runExample(exe, "parse", ex.args, home, srv.URL); err != nil {
+			stale++
+			reportStale(ex, "does not parse", msg, err)
+			continue
+		}
+		if msg, err := runExample(exe, "run", ex.args, home, srv.URL); err != nil && strings.Contains(msg, "panic:") {
+			stale++
+			reportStale(ex, "panics", msg, err)
+		}
+	}
+
+	if stale > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d examples are stale\n", stale, len(examples))
+	} else {
+		fmt.Printf("All %d examples parse and run\n", len(examples))
+	}
+	return stale
+}
+
+func reportStale(ex example, what, output string, err error) {
+	fmt.Fprintf(os.Stderr, "%s: example %s (%v)\n  %s\n", ex.cmd.CommandPath(), what, err, ex.line)
+	for _, l := range strings.Split(strings.TrimSpace(output), "\n") {
+		if l != "" {
+			fmt.Fprintf(os.Stderr, "    %s\n", l)
+		}
+	}
+}
+
+// runExample runs gen-docs as the CLI on args in the given mode, from a
+// fresh working directory linked to exampleLink, and returns its combined
+// output.
+func runExample(exe, mode string, args []string, home, server string) (string, error) {
+	dir, err := os.MkdirTemp(home, "cwd-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	// Most examples lean on a linked directory.
+	if err := os.Mkdir(filepath.Join(dir, ".ancla"), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".ancla", "config.yaml"), []byte(exampleLink), 0o644); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exampleTimeout)
+	defer cancel()
+	// Global flags go first so they reach ancla even when the example
+	// passes the rest of its arguments on with --. With --yes, examples
+	// that ask for confirmation go on to make their requests.
+	c := exec.CommandContext(ctx, exe, append([]string{"--non-interactive", "--yes", "--max-wait=2s"}, args...)...)
+	c.Dir = dir
+	c.Env = append(os.Environ(),
+		exampleModeEnv+"="+mode,
+		"HOME="+home,
+		"ANCLA_SERVER="+server,
+		"ANCLA_API_KEY=example-key",
+		"NO_COLOR=1",
+		// Editors return at once, leaving the file as it was.
+		"EDITOR=true",
+	)
+	var out bytes.Buffer
+	c.Stdout, c.Stderr = &out, &out
+	// Don't wait on anything the example left running with our output.
+	c.WaitDelay = time.Second
+	err = c.Run()
+	if ctx.Err() != nil {
+		return out.String(), ctx.Err()
+	}
+	return out.String(), err
+}
+
+// runExampleChild is the child side of runExample.
+func runExampleChild(mode string, args []string) int {
+	root := cli.RootCmd()
+	if mode == "run" {
+		root.SetArgs(args)
+		if err := cli.Execute(); err != nil {
+			return 1
+		}
+		return 0
+	}
+	if err := parseExample(root, args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	return 0
+}
+
+// parseExample resolves args to a command and checks its flags and
+// arguments the way cobra does before running it.
+func parseExample(root *cobra.Command, args []string) error {
+	cmd, rest, err := root.Find(args)
+	if err != nil {
+		return err
+	}
+	if err := cmd.ParseFlags(rest); err != nil {
+		return err
+	}
+	positional := cmd.Flags().Args()
+	if !cmd.Runnable() && len(positional) > 0 {
+		return fmt.Errorf("unknown command %q for %q", positional[0], cmd.CommandPath())
+	}
+	if err := cmd.ValidateArgs(positional); err != nil {
+		return err
+	}
+	if err := cmd.ValidateRequiredFlags(); err != nil {
+		return err
+	}
+	return cmd.ValidateFlagGroups()
+}
+
+// collectExamples gathers the ancla invocations from the Example of every
+// command under root, hidden ones included.
+func collectExamples(root *cobra.Command) []example {
+	var examples []example
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, line := range strings.Split(c.Example, "\n") {
+			for _, args := range invocations(line) {
+				examples = append(examples, example{cmd: c, line: strings.TrimSpace(line), args: args})
+			}
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+	return examples
+}
+
+// invocations returns the arguments of each ancla command on an example
+// line: the line itself when it starts with ancla, and any $(ancla ...)
+// substitution in it. Other commands on the line are not checked.
+func invocations(line string) [][]string {
+	var found [][]string
+	words, _ := shellWords(line)
+	if len(words) > 0 && words[0] == "ancla" {
+		found = append(found, words[1:])
+	}
+	for rest := line; ; {
+		i := strings.Index(rest, "$(ancla ")
+		if i < 0 {
+			break
+		}
+		rest = rest[i+2:]
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			break
+		}
+		if words, _ := shellWords(rest[:end]); len(words) > 0 {
+			found = append(found, words[1:])
+		}
+		rest = rest[end:]
+	}
+	return found
+}
+
+// shellWords splits the first command of a shell line into words,
+// honouring quotes. It stops at a comment or at an unquoted |, & or ;,
+// and returns what it stopped at.
+func shellWords(line string) ([]string, string) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		case r == '|' || r == '&' || r == ';' || (r == '#' && !inWord):
+			if inWord {
+				words = append(words, cur.String())
+			}
+			return words, line[i:]
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, ""
+}
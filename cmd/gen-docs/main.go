@@ -2,9 +2,14 @@
 // from the ancla CLI's cobra command tree, organized into subdirectories
 // so Starlight auto-generates grouped sidebar navigation.
 //
+// With --verify-examples it writes nothing, and instead checks that every
+// command's examples still parse and run, exiting non-zero if any are
+// stale.
+//
 // Usage:
 //
 //	go run ./cmd/gen-docs --out docs/src/content/docs/cli
+//	go run ./cmd/gen-docs --verify-examples
 package main
 
 import (
@@ -21,14 +26,28 @@ import (
 )
 
 func main() {
+	if mode := os.Getenv(exampleModeEnv); mode != "" {
+		os.Exit(runExampleChild(mode, os.Args[1:]))
+	}
+
 	out := "docs/src/content/docs/cli"
+	verify := false
 	for i, arg := range os.Args[1:] {
 		if arg == "--out" && i+1 < len(os.Args)-1 {
 			out = os.Args[i+2]
 		}
+		if arg == "--verify-examples" {
+			verify = true
+		}
 	}
 
 	rootCmd := cli.RootCmd()
+	if verify {
+		if verifyExamples(rootCmd) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
 	rootCmd.DisableAutoGenTag = true
 
 	// Pre-compute which commands have subcommands (group parents).
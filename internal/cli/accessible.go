@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+)
+
+// accessibleFlag is --accessible.
+var accessibleFlag bool
+
+// accessible reports whether output should suit screen readers, from
+// --accessible or ANCLA_ACCESSIBLE. Any value that isn't false-like turns
+// it on, so ANCLA_ACCESSIBLE=1 and ANCLA_ACCESSIBLE=yes both work.
+func accessible() bool {
+	if accessibleFlag {
+		return true
+	}
+	v := os.Getenv("ANCLA_ACCESSIBLE")
+	if v == "" {
+		return false
+	}
+	on, err := strconv.ParseBool(v)
+	return err != nil || on
+}
+
+// applyAccessibleMode switches output to what a screen reader can follow:
+// no color, words in place of symbols, and one status line per change
+// instead of spinners and redrawn progress lines. Prompts switch to huh's
+// accessible mode in themed.
+func applyAccessibleMode() {
+	applyColorPreference("never")
+	symAnchor = ""
+	symCheck = "OK"
+	symCross = "Error:"
+	symDot = "-"
+	symArrow = "->"
+	symCircle = "o"
+	symPointer = ">"
+	symBar = "|"
+	if progressMode == progressAuto {
+		progressMode = progressPlain
+	}
+}
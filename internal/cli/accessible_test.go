@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+)
+
+func TestAccessible_Env(t *testing.T) {
+	tests := []struct {
+		env  string
+		want bool
+	}{
+		{"", false},
+		{"1", true},
+		{"true", true},
+		{"yes", true},
+		{"0", false},
+		{"false", false},
+	}
+	for _, tt := range tests {
+		t.Setenv("ANCLA_ACCESSIBLE", tt.env)
+		if got := accessible(); got != tt.want {
+			t.Errorf("ANCLA_ACCESSIBLE=%q: accessible() = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestApplyAccessibleMode(t *testing.T) {
+	origSyms := []string{symAnchor, symCheck, symCross, symDot, symArrow, symCircle, symPointer, symBar}
+	origProgress := progressMode
+	origProfile, origNoColor := lipgloss.ColorProfile(), color.NoColor
+	defer func() {
+		symAnchor, symCheck, symCross, symDot = origSyms[0], origSyms[1], origSyms[2], origSyms[3]
+		symArrow, symCircle, symPointer, symBar = origSyms[4], origSyms[5], origSyms[6], origSyms[7]
+		progressMode = origProgress
+		lipgloss.SetColorProfile(origProfile)
+		color.NoColor = origNoColor
+	}()
+	progressMode = progressAuto
+
+	applyAccessibleMode()
+
+	if !progressLines() {
+		t.Error("spinners not replaced by status lines")
+	}
+	if got := heading("Status"); got != "Status" {
+		t.Errorf("heading = %q, want plain text", got)
+	}
+	var buf bytes.Buffer
+	printCard(&buf, errorCard{Title: "Not authenticated", Hints: []string{"Run ancla login"}})
+	out := buf.String()
+	if !strings.Contains(out, "| Error: Not authenticated") || !strings.Contains(out, "-> Run ancla login") {
+		t.Errorf("card not in plain text:\n%s", out)
+	}
+	for _, r := range out {
+		if r > 127 {
+			t.Fatalf("card contains non-ASCII %q:\n%s", r, out)
+		}
+	}
+}
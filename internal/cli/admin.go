@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminSweepCmd)
+	adminSweepCmd.Flags().String("prefix", "", "Delete what has a slug starting with this, e.g. tf-acc- (required)")
+	adminSweepCmd.MarkFlagRequired("prefix")
+}
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Maintain the resources of an account",
+}
+
+var adminSweepCmd = &cobra.Command{
+	Use:   "sweep --prefix <prefix>",
+	Short: "Delete resources left behind by test runs",
+	Long: `Delete every workspace, project, environment, and service the API key can
+see whose slug starts with --prefix, to clean a CI account up after test
+runs that failed before tearing down. The Terraform provider's acceptance
+tests name everything they create with the tf-acc- prefix.
+
+A matched workspace, project, or environment is deleted with everything in
+it. What will be deleted is listed before you confirm; use --dry-run to see
+it without deleting anything. A failed deletion doesn't stop the sweep, and
+the command exits non-zero once the rest are done.`,
+	Example: "  ancla admin sweep --prefix tf-acc-\n  ancla admin sweep --prefix tf-acc- --yes --json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prefix, _ := cmd.Flags().GetString("prefix")
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("--prefix can't be empty — it would sweep everything")
+		}
+
+		stop := spin("Finding resources to sweep...")
+		targets, err := planSweep(prefix)
+		stop()
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			if isJSON() {
+				return printJSON(map[string]any{"deleted": []sweepTarget{}, "failed": []sweepTarget{}})
+			}
+			if !isQuiet() {
+				fmt.Printf("Nothing to sweep — no slugs start with %q.\n", prefix)
+			}
+			return nil
+		}
+
+		if !isJSON() && !isQuiet() {
+			var rows [][]string
+			for _, t := range targets {
+				rows = append(rows, []string{t.Kind, t.Path})
+			}
+			table([]string{"KIND", "PATH"}, rows)
+		}
+		if err := confirmAction(fmt.Sprintf("Delete these %d resources and everything in them?", len(targets))); err != nil {
+			return err
+		}
+
+		deleted, failed := []sweepTarget{}, []sweepTarget{}
+		for _, t := range targets {
+			req, _ := http.NewRequest("DELETE", apiURL(t.apiPath()), nil)
+			_, err := doRequest(req)
+			var apiErr *apiError
+			switch {
+			case errors.Is(err, errDryRun):
+				continue
+			case errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound:
+				// Gone already, as when a test's destroy ran late.
+				deleted = append(deleted, t)
+			case err != nil:
+				t.Error = err.Error()
+				failed = append(failed, t)
+			default:
+				deleted = append(deleted, t)
+			}
+		}
+		if dryRun {
+			return nil
+		}
+
+		if isJSON() {
+			if err := printJSON(map[string]any{"deleted": deleted, "failed": failed}); err != nil {
+				return err
+			}
+		} else if !isQuiet() {
+			fmt.Println(stepDone(fmt.Sprintf("Swept %d resources", len(deleted))))
+			for _, t := range failed {
+				fmt.Println(stError.Render(fmt.Sprintf("  %s %s: %s", t.Kind, t.Path, t.Error)))
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("%d of %d resources could not be deleted", len(failed), len(targets))
+		}
+		return nil
+	},
+}
+
+// sweepTarget is a resource admin sweep deletes.
+type sweepTarget struct {
+	Kind  string `json:"kind"` // workspace, project, env, or service
+	Path  string `json:"path"` // e.g. ws/proj/env
+	Error string `json:"error,omitempty"`
+
+	ref config.ServiceRef
+}
+
+func (t sweepTarget) apiPath() string {
+	switch t.Kind {
+	case "workspace":
+		return "/workspaces/" + t.ref.Workspace
+	case "project":
+		return t.ref.ProjectPath()
+	case "env":
+		return t.ref.EnvPath()
+	}
+	return t.ref.ServicePath()
+}
+
+// planSweep walks every workspace the key can see and returns what has a
+// slug starting with prefix, outermost first. It doesn't descend into a
+// match, since deleting it deletes what it holds.
+func planSweep(prefix string) ([]sweepTarget, error) {
+	api := apiProvisioner{}
+	var targets []sweepTarget
+	match := func(kind string, ref config.ServiceRef, slug string) bool {
+		if !strings.HasPrefix(slug, prefix) {
+			return false
+		}
+		targets = append(targets, sweepTarget{Kind: kind, Path: ref.String(), ref: ref})
+		return true
+	}
+
+	workspaces, err := api.list("/workspaces/")
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		ref := config.ServiceRef{Workspace: ws.Slug}
+		if match("workspace", ref, ws.Slug) {
+			continue
+		}
+		projects, err := api.list("/workspaces/" + ws.Slug + "/projects/")
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range projects {
+			ref.Project = p.Slug
+			if match("project", ref, p.Slug) {
+				continue
+			}
+			envs, err := api.list(ref.ProjectPath() + "/envs/")
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range envs {
+				ref.Env = e.Slug
+				if match("env", ref, e.Slug) {
+					continue
+				}
+				services, err := api.list(ref.ServicesPath())
+				if err != nil {
+					return nil, err
+				}
+				for _, s := range services {
+					ref.Service = s.Slug
+					match("service", ref, s.Slug)
+				}
+				ref.Service = ""
+			}
+			ref.Env = ""
+		}
+	}
+	return targets, nil
+}
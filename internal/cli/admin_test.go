@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAdminSweepCassette(t *testing.T) {
+	ts := cassetteServer(t, "admin-sweep")
+	out, err := runCLI(t, cliRun{server: ts.URL}, "admin", "sweep", "--prefix", "tf-acc-", "--yes", "--json")
+	if err == nil || err.Error() != "1 of 4 resources could not be deleted" {
+		t.Errorf("err = %v, want the failed deletion counted", err)
+	}
+
+	var got struct {
+		Deleted []sweepTarget `json:"deleted"`
+		Failed  []sweepTarget `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("parsing output: %v\n%s", err, out)
+	}
+	var deleted []string
+	for _, d := range got.Deleted {
+		deleted = append(deleted, d.Kind+" "+d.Path)
+	}
+	want := []string{"service acme/shop/production/tf-acc-svc-3", "project acme/tf-acc-proj-77", "workspace tf-acc-ws-4821"}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted = %q, want %q", deleted, want)
+	}
+	if len(got.Failed) != 1 || got.Failed[0].Path != "acme/shop/tf-acc-env-19" || got.Failed[0].Error == "" {
+		t.Errorf("failed = %+v, want the environment with its error", got.Failed)
+	}
+}
@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	apiCmd.Flags().StringArrayP("field", "F", nil, "Add a typed parameter: key=value (numbers, true/false, null, @file)")
+	apiCmd.Flags().StringArrayP("raw-field", "f", nil, "Add a string parameter: key=value")
+	apiCmd.Flags().StringArrayP("header", "H", nil, "Add a request header: 'Name: value'")
+	apiCmd.Flags().Bool("paginate", false, "Fetch every page and merge the items")
+	apiCmd.Flags().String("jq", "", "Filter the response with a jq-style path, e.g. '.items[].slug'")
+	rootCmd.AddCommand(apiCmd)
+}
+
+var apiCmd = &cobra.Command{
+	Use:   "api [method] <path>",
+	Short: "Make an authenticated API request",
+	Long: `Send a request to the Ancla API with your stored credentials and print
+the response. Use it for endpoints the CLI has no command for yet.
+
+The path is relative to /api/v1. The method defaults to GET, or POST when
+fields are given. For GET and DELETE, fields become query parameters;
+otherwise they are sent as a JSON object. -F converts numbers, true,
+false, and null, and reads @file (or @- for stdin); -f always sends
+strings.
+
+--paginate follows page-numbered list responses ({"items": [...],
+"count": N}) and prints a single merged list. --jq supports paths such
+as .items[].name, .[0], and .foo.bar; strings print without quotes.`,
+	Example: `  ancla api /workspaces/
+  ancla api GET /workspaces/acme/projects/ --jq '.[].slug'
+  ancla api POST /workspaces/acme/projects/ -f name=web
+  ancla api PATCH /workspaces/acme/projects/web/envs/staging/services/api -F auto_deploy=true
+  ancla api /workspaces/acme/projects/web/envs/staging/services/api/builds/ --paginate`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method, path := "", args[0]
+		if len(args) == 2 {
+			method, path = strings.ToUpper(args[0]), args[1]
+		}
+		path = "/" + strings.TrimPrefix(strings.TrimPrefix(path, "/"), "api/v1/")
+
+		typed, _ := cmd.Flags().GetStringArray("field")
+		raw, _ := cmd.Flags().GetStringArray("raw-field")
+		params, err := apiParams(typed, raw)
+		if err != nil {
+			return err
+		}
+		if method == "" {
+			method = "GET"
+			if len(params) > 0 {
+				method = "POST"
+			}
+		}
+		headers, _ := cmd.Flags().GetStringArray("header")
+		paginate, _ := cmd.Flags().GetBool("paginate")
+		filter, _ := cmd.Flags().GetString("jq")
+
+		var body []byte
+		if paginate {
+			if method != "GET" {
+				return fmt.Errorf("--paginate only works with GET")
+			}
+			body, err = apiPaginate(path, params, headers)
+		} else {
+			body, err = apiRequest(method, path, params, headers)
+		}
+		if err != nil {
+			return err
+		}
+		return printAPIResponse(body, filter)
+	},
+}
+
+// apiParams builds the request parameters from -F and -f flags.
+func apiParams(typed, raw []string) (map[string]any, error) {
+	params := map[string]any{}
+	for _, f := range raw {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid field %q (expected key=value)", f)
+		}
+		params[k] = v
+	}
+	for _, f := range typed {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid field %q (expected key=value)", f)
+		}
+		val, err := typedFieldValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", k, err)
+		}
+		params[k] = val
+	}
+	return params, nil
+}
+
+// typedFieldValue converts a -F value the way gh api does.
+func typedFieldValue(v string) (any, error) {
+	switch v {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f, nil
+	}
+	if file, ok := strings.CutPrefix(v, "@"); ok {
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return strings.TrimSuffix(string(data), "\n"), nil
+	}
+	return v, nil
+}
+
+// apiRequest sends one request. Parameters go in the query string for GET
+// and DELETE, and in a JSON body otherwise.
+func apiRequest(method, path string, params map[string]any, headers []string) ([]byte, error) {
+	target := apiURL(path)
+	var reqBody io.Reader
+	if method == "GET" || method == "DELETE" {
+		if len(params) > 0 {
+			q := url.Values{}
+			for k, v := range params {
+				q.Set(k, fmt.Sprint(v))
+			}
+			sep := "?"
+			if strings.Contains(target, "?") {
+				sep = "&"
+			}
+			target += sep + q.Encode()
+		}
+	} else if len(params) > 0 {
+		data, _ := json.Marshal(params)
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q (expected 'Name: value')", h)
+		}
+		req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return doRequest(req)
+}
+
+// apiPaginate requests page 1, 2, ... of a list endpoint and merges the
+// items. It stops at an empty page, a page that isn't a paginated object,
+// or once count items have been collected.
+func apiPaginate(path string, params map[string]any, headers []string) ([]byte, error) {
+	var items []any
+	for page := 1; ; page++ {
+		p := map[string]any{"page": page}
+		for k, v := range params {
+			p[k] = v
+		}
+		body, err := apiRequest("GET", path, p, headers)
+		if err != nil {
+			return nil, err
+		}
+		var resp struct {
+			Items []any `json:"items"`
+			Count *int  `json:"count"`
+		}
+		if json.Unmarshal(body, &resp) != nil || resp.Items == nil {
+			if page == 1 {
+				// Not paginated — return the response as is.
+				return body, nil
+			}
+			break
+		}
+		items = append(items, resp.Items...)
+		if len(resp.Items) == 0 || (resp.Count != nil && len(items) >= *resp.Count) {
+			break
+		}
+	}
+	return json.Marshal(map[string]any{"items": items, "count": len(items)})
+}
+
+// printAPIResponse pretty-prints a JSON response, applying filter if set.
+// Non-JSON responses are printed unchanged.
+func printAPIResponse(body []byte, filter string) error {
+	var data any
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		if filter != "" {
+			return fmt.Errorf("--jq: response is not JSON")
+		}
+		os.Stdout.Write(body)
+		return nil
+	}
+	if filter == "" {
+		return printJSON(data)
+	}
+	results, err := jqPath(data, filter)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		if s, ok := r.(string); ok {
+			fmt.Println(s)
+			continue
+		}
+		out, _ := json.Marshal(r)
+		fmt.Println(string(out))
+	}
+	return nil
+}
+
+// jqPath evaluates a jq-style path: "." followed by .key, ."quoted key",
+// [n], and [] segments. [] fans out over array elements (or object
+// values); missing keys yield null, as in jq.
+func jqPath(data any, expr string) ([]any, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") {
+		return nil, fmt.Errorf("--jq %q: expression must start with '.'", expr)
+	}
+	results := []any{data}
+	rest := expr
+	for rest != "" && rest != "." {
+		var next []any
+		switch {
+		case strings.HasPrefix(rest, "[]") || strings.HasPrefix(rest, ".[]"):
+			rest = rest[strings.Index(rest, "[]")+2:]
+			for _, r := range results {
+				switch t := r.(type) {
+				case []any:
+					next = append(next, t...)
+				case map[string]any:
+					for _, v := range t {
+						next = append(next, v)
+					}
+				default:
+					return nil, fmt.Errorf("--jq: cannot iterate over %s", jqType(r))
+				}
+			}
+		case strings.HasPrefix(rest, "[") || strings.HasPrefix(rest, ".["):
+			open := strings.Index(rest, "[")
+			end := strings.Index(rest, "]")
+			if end < open {
+				return nil, fmt.Errorf("--jq %q: unclosed '['", expr)
+			}
+			idx, err := strconv.Atoi(rest[open+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("--jq %q: invalid index %q", expr, rest[open+1:end])
+			}
+			rest = rest[end+1:]
+			for _, r := range results {
+				arr, ok := r.([]any)
+				if !ok {
+					if r == nil {
+						next = append(next, nil)
+						continue
+					}
+					return nil, fmt.Errorf("--jq: cannot index %s with a number", jqType(r))
+				}
+				if idx < 0 {
+					idx += len(arr)
+				}
+				if idx < 0 || idx >= len(arr) {
+					next = append(next, nil)
+				} else {
+					next = append(next, arr[idx])
+				}
+			}
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			var key string
+			if strings.HasPrefix(rest, `"`) {
+				end := strings.Index(rest[1:], `"`)
+				if end < 0 {
+					return nil, fmt.Errorf("--jq %q: unclosed quote", expr)
+				}
+				key, rest = rest[1:end+1], rest[end+2:]
+			} else {
+				end := strings.IndexAny(rest, ".[")
+				if end < 0 {
+					end = len(rest)
+				}
+				key, rest = rest[:end], rest[end:]
+			}
+			if key == "" {
+				return nil, fmt.Errorf("--jq %q: empty key", expr)
+			}
+			for _, r := range results {
+				switch t := r.(type) {
+				case map[string]any:
+					next = append(next, t[key])
+				case nil:
+					next = append(next, nil)
+				default:
+					return nil, fmt.Errorf("--jq: cannot index %s with %q", jqType(r), key)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("--jq %q: unsupported expression near %q", expr, rest)
+		}
+		results = next
+	}
+	return results, nil
+}
+
+// jqType names a decoded JSON value's type the way jq does in errors.
+func jqType(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestJQPath(t *testing.T) {
+	var data any
+	json.Unmarshal([]byte(`{"items":[{"slug":"a","n":1},{"slug":"b","n":2}],"meta":{"next page":null}}`), &data)
+
+	tests := []struct {
+		expr string
+		want []any
+	}{
+		{".", []any{data}},
+		{".items[].slug", []any{"a", "b"}},
+		{".items[1].n", []any{float64(2)}},
+		{".items[-1].slug", []any{"b"}},
+		{".items[5]", []any{nil}},
+		{".missing.deeper", []any{nil}},
+		{`.meta."next page"`, []any{nil}},
+	}
+	for _, tt := range tests {
+		got, err := jqPath(data, tt.expr)
+		if err != nil {
+			t.Errorf("jqPath(%q) error: %v", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("jqPath(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"items", ".items[x]", ".items[].slug[]"} {
+		if _, err := jqPath(data, bad); err == nil {
+			t.Errorf("jqPath(%q) expected error", bad)
+		}
+	}
+}
+
+func TestAPIParams(t *testing.T) {
+	got, err := apiParams([]string{"count=3", "on=true", "ratio=0.5", "gone=null", "id=007x"}, []string{"name=3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"count": int64(3), "on": true, "ratio": 0.5, "gone": nil, "id": "007x", "name": "3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("apiParams = %#v, want %#v", got, want)
+	}
+	if _, err := apiParams([]string{"novalue"}, nil); err == nil {
+		t.Error("expected error for field without '='")
+	}
+}
+
+func TestAPIPaginate(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var pages []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/acme/builds/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"items":[{"id":1},{"id":2}],"count":3}`))
+		default:
+			w.Write([]byte(`{"items":[{"id":3}],"count":3}`))
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	body, err := apiPaginate("/workspaces/acme/builds/", map[string]any{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var merged struct {
+		Items []map[string]int `json:"items"`
+	}
+	json.Unmarshal(body, &merged)
+	if len(merged.Items) != 3 || merged.Items[2]["id"] != 3 {
+		t.Errorf("merged = %s", body)
+	}
+	if !reflect.DeepEqual(pages, []string{"1", "2"}) {
+		t.Errorf("requested pages %v, want [1 2]", pages)
+	}
+}
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// clientAPIVersion is the API version this CLI speaks; apiURL builds every
+// path under it.
+const clientAPIVersion = "v1"
+
+// featureAnnotation is the cobra annotation naming the server feature a
+// command needs. Servers that list their features in /api/version and
+// lack this one get a clear error instead of a 404 from a missing endpoint.
+const featureAnnotation = "ancla/feature"
+
+// versionTimeout bounds the /api/version request, so a slow server never
+// holds up the command it precedes by much.
+const versionTimeout = 3 * time.Second
+
+// serverInfo is what the server reports at /api/version.
+type serverInfo struct {
+	ServerVersion string   `json:"server_version"`
+	APIVersions   []string `json:"api_versions"`
+	MinCLIVersion string   `json:"min_cli_version,omitempty"`
+	Features      []string `json:"features,omitempty"`
+}
+
+// speaksAPI reports whether the server serves the API version this CLI
+// uses. A server that lists no versions predates the field and is assumed
+// to speak v1.
+func (s *serverInfo) speaksAPI() bool {
+	return len(s.APIVersions) == 0 || slices.Contains(s.APIVersions, clientAPIVersion)
+}
+
+// supports reports whether the server has feature. A server that lists no
+// features is given the benefit of the doubt.
+func (s *serverInfo) supports(feature string) bool {
+	return s.Features == nil || slices.Contains(s.Features, feature)
+}
+
+// tooNewForCLI reports whether the server requires a newer CLI than this
+// one. Development builds are never too old.
+func (s *serverInfo) tooNewForCLI() bool {
+	return Version != "dev" && s.MinCLIVersion != "" && isNewerVersion(s.MinCLIVersion, Version)
+}
+
+// serverVersion caches the result of asking the server for its version, so
+// each process asks at most once. known is false when the server has no
+// /api/version endpoint, which means it predates version negotiation.
+var serverVersion struct {
+	once  sync.Once
+	done  chan struct{}
+	info  *serverInfo
+	known bool
+}
+
+func init() {
+	serverVersion.done = make(chan struct{})
+}
+
+// fetchServerInfo asks the server which API versions and features it
+// supports. It returns nil when the answer is unknown: the server predates
+// /api/version, or it could not be reached.
+func fetchServerInfo() *serverInfo {
+	serverVersion.once.Do(func() {
+		defer close(serverVersion.done)
+		ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, "GET", serverURL()+"/api/version", nil)
+		resp, err := apiClient().Do(req)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			serverVersion.known = true
+			return
+		}
+		var info serverInfo
+		if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&info) != nil {
+			return
+		}
+		serverVersion.info = &info
+		serverVersion.known = true
+	})
+	return serverVersion.info
+}
+
+// cachedServerInfo returns what fetchServerInfo found, without waiting for
+// a request still in flight. ok is false when there is no answer yet.
+func cachedServerInfo() (info *serverInfo, ok bool) {
+	select {
+	case <-serverVersion.done:
+		return serverVersion.info, serverVersion.known
+	default:
+		return nil, false
+	}
+}
+
+// negotiatesVersion reports whether cmd talks to the server and so should
+// check it first. Offline commands and dry runs skip the round trip.
+func negotiatesVersion(cmd *cobra.Command) bool {
+	if dryRun || cfg == nil || cfg.APIKey == "" {
+		return false
+	}
+	switch cmd.Name() {
+	case "version", "help", "completion", "docs", "search", "login", "logout":
+		return false
+	}
+	return !strings.HasPrefix(cmd.CommandPath(), "ancla completion") &&
+		!strings.HasPrefix(cmd.CommandPath(), "ancla settings")
+}
+
+// checkServerVersion gates cmd on the server's capabilities. A command
+// that needs a feature waits for the answer and fails early if the server
+// lacks it; everything else checks in the background and only warns, so
+// the common path costs no latency.
+func checkServerVersion(cmd *cobra.Command) error {
+	if !negotiatesVersion(cmd) {
+		return nil
+	}
+	feature := requiredFeature(cmd)
+	if feature == "" {
+		go func() {
+			if info := fetchServerInfo(); info != nil && !isQuiet() {
+				warnVersionSkew(info)
+			}
+		}()
+		return nil
+	}
+	info := fetchServerInfo()
+	if info == nil {
+		return nil
+	}
+	if !info.speaksAPI() {
+		return errAPIUnsupported(info)
+	}
+	if !info.supports(feature) {
+		return fmt.Errorf("%s is not supported by this server (version %s) — upgrade the server, or use an older release of the CLI",
+			cmd.CommandPath(), orUnknown(info.ServerVersion))
+	}
+	if !isQuiet() {
+		warnVersionSkew(info)
+	}
+	return nil
+}
+
+// requiredFeature returns the server feature cmd needs, declared on it or
+// on the nearest parent, or "" when it works with any server.
+func requiredFeature(cmd *cobra.Command) string {
+	for c := cmd; c != nil; c = c.Parent() {
+		if f := c.Annotations[featureAnnotation]; f != "" {
+			return f
+		}
+	}
+	return ""
+}
+
+// warnVersionSkew prints a notice to stderr when the server and this CLI
+// have drifted apart far enough that commands may fail.
+func warnVersionSkew(info *serverInfo) {
+	switch {
+	case !info.speaksAPI():
+		fmt.Fprintln(os.Stderr, stWarning.Render("! "+errAPIUnsupported(info).Error()))
+	case info.tooNewForCLI():
+		fmt.Fprintln(os.Stderr, stWarning.Render(fmt.Sprintf("! The server (version %s) requires ancla %s or later; you have %s — upgrade the CLI",
+			orUnknown(info.ServerVersion), strings.TrimPrefix(info.MinCLIVersion, "v"), strings.TrimPrefix(Version, "v"))))
+	}
+}
+
+// errAPIUnsupported explains that the server no longer serves the API
+// version this CLI speaks.
+func errAPIUnsupported(info *serverInfo) error {
+	return fmt.Errorf("the server (version %s) serves API %s, but this CLI speaks %s — upgrade the CLI",
+		orUnknown(info.ServerVersion), strings.Join(info.APIVersions, ", "), clientAPIVersion)
+}
+
+// skewHint explains a 404 that may come from version skew rather than a
+// missing resource. It only uses an answer already in hand and returns ""
+// when the server's version gives no reason to suspect skew.
+func skewHint() string {
+	info, ok := cachedServerInfo()
+	switch {
+	case !ok:
+		return ""
+	case info == nil:
+		return tr("The server does not report its version, so it may be older than this CLI — check with your administrator or try an older release")
+	case !info.speaksAPI() || info.tooNewForCLI():
+		return tr("The server (version %s) expects a newer CLI — upgrade ancla", orUnknown(info.ServerVersion))
+	}
+	return ""
+}
+
+// orUnknown returns s, or "unknown" when s is empty.
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// resetServerVersion forgets the cached /api/version answer.
+func resetServerVersion() {
+	serverVersion.once = sync.Once{}
+	serverVersion.done = make(chan struct{})
+	serverVersion.info = nil
+	serverVersion.known = false
+}
+
+// versionServer serves body at /api/version, or 404 when body is empty.
+func versionServer(t *testing.T, body string) {
+	t.Helper()
+	origCfg := cfg
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/version" || body == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	cfg = &config.Config{Server: ts.URL, APIKey: "k"}
+	resetServerVersion()
+	t.Cleanup(func() {
+		ts.Close()
+		cfg = origCfg
+		resetServerVersion()
+	})
+}
+
+func TestCheckServerVersion_Feature(t *testing.T) {
+	parent := &cobra.Command{Use: "ancla"}
+	cmd := &cobra.Command{Use: "tunnel", Annotations: map[string]string{featureAnnotation: "tunnel"}}
+	parent.AddCommand(cmd)
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"supported", `{"server_version":"2.1.0","api_versions":["v1"],"features":["tunnel"]}`, ""},
+		{"features not listed", `{"server_version":"2.1.0","api_versions":["v1"]}`, ""},
+		{"no endpoint", "", ""},
+		{"missing feature", `{"server_version":"1.4.0","api_versions":["v1"],"features":["logs.search"]}`, "not supported by this server (version 1.4.0)"},
+		{"api dropped", `{"server_version":"3.0.0","api_versions":["v2"],"features":["tunnel"]}`, "serves API v2, but this CLI speaks v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versionServer(t, tt.body)
+			err := checkServerVersion(cmd)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequiredFeature_Inherited(t *testing.T) {
+	parent := &cobra.Command{Use: "backups", Annotations: map[string]string{featureAnnotation: "db.backups"}}
+	child := &cobra.Command{Use: "list"}
+	parent.AddCommand(child)
+	if got := requiredFeature(child); got != "db.backups" {
+		t.Errorf("requiredFeature = %q, want db.backups", got)
+	}
+}
+
+func TestSkewHint(t *testing.T) {
+	origVersion := Version
+	defer func() { Version = origVersion }()
+	Version = "1.0.0"
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"old server", "", "does not report its version"},
+		{"cli too old", `{"server_version":"2.0.0","api_versions":["v1"],"min_cli_version":"1.5.0"}`, "expects a newer CLI"},
+		{"in step", `{"server_version":"2.0.0","api_versions":["v1"],"min_cli_version":"0.9.0"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versionServer(t, tt.body)
+			if got := skewHint(); got != "" {
+				t.Fatalf("skewHint before the check = %q, want empty", got)
+			}
+			fetchServerInfo()
+			got := skewHint()
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Errorf("skewHint = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorCard_LegacyEndpoint(t *testing.T) {
+	versionServer(t, "")
+	fetchServerInfo()
+
+	e := &apiError{Status: 404, Message: "not found", Path: "/api/v1/apps/my-app"}
+	c, ok := e.card()
+	if !ok || c.Title != "Endpoint not found" {
+		t.Fatalf("card = %+v, %v", c, ok)
+	}
+	if len(c.Hints) != 1 || !strings.Contains(c.Hints[0], "older than this CLI") {
+		t.Errorf("hints = %q", c.Hints)
+	}
+}
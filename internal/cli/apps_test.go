@@ -3,8 +3,10 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/SideQuest-Group/ancla-client/internal/config"
@@ -194,6 +196,15 @@ func TestServicesGetCmd_ParsesServerResponse(t *testing.T) {
 		"github_repository":  "org/repo",
 		"auto_deploy_branch": "main",
 		"process_counts":     map[string]int{"web": 2},
+		"processes": map[string]any{
+			"web":    map[string]any{"desired": 3, "running": 2, "size": "standard-1x", "cpu_limit": 0.5, "memory_limit_mb": 512},
+			"worker": map[string]any{"desired": 1, "running": 1},
+		},
+		"autoscaling": map[string]any{
+			"enabled":          true,
+			"policies":         map[string]any{"web": map[string]any{"min_replicas": 2, "max_replicas": 6, "target_cpu_percent": 70}},
+			"last_scale_event": map[string]any{"process": "web", "from": 2, "to": 3, "at": "2026-01-02T03:04:05Z"},
+		},
 	}
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -250,3 +261,120 @@ func TestServicesStatusCmd_RequiresExactlyOneArg(t *testing.T) {
 		t.Errorf("unexpected error: %v", argErr)
 	}
 }
+
+func TestServicesCreateCmd_SendsFlags(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotPath string
+	var got map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"name":"Public API","slug":"public-api"}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	cmd := servicesCreateCmd
+	if err := cmd.ParseFlags([]string{"--build-strategy", "buildpack", "--repo", "acme/api", "--branch", "main"}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd.Flags().Set("build-strategy", "dockerfile")
+		cmd.Flags().Set("repo", "")
+		cmd.Flags().Set("branch", "")
+	}()
+
+	if err := cmd.RunE(cmd, []string{"ws/proj/staging", "Public API"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if gotPath != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/" {
+		t.Errorf("path = %q", gotPath)
+	}
+	want := map[string]any{
+		"name":               "Public API",
+		"slug":               "public-api",
+		"platform":           "wind",
+		"build_strategy":     "buildpack",
+		"github_repository":  "acme/api",
+		"auto_deploy_branch": "main",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("payload[%q] = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestServicesDeleteCmd_RequiresTypedSlug(t *testing.T) {
+	origCfg := cfg
+	origStdin := os.Stdin
+	defer func() { cfg = origCfg; os.Stdin = origStdin }()
+
+	deleted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	for _, tt := range []struct {
+		typed string
+		want  bool
+	}{
+		{"y\n", false},
+		{"my-svc\n", true},
+	} {
+		deleted = false
+		r, w, _ := os.Pipe()
+		w.WriteString(tt.typed)
+		w.Close()
+		os.Stdin = r
+
+		err := servicesDeleteCmd.RunE(servicesDeleteCmd, []string{"ws/proj/staging/my-svc"})
+		if err != nil && (tt.want || !errors.Is(err, errAborted)) {
+			t.Fatalf("RunE error: %v", err)
+		}
+		if deleted != tt.want {
+			t.Errorf("typed %q: deleted = %v, want %v", tt.typed, deleted, tt.want)
+		}
+	}
+}
+
+func TestServicesResizeCmd_SendsSizes(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotPath string
+	var got struct {
+		ProcessSizes map[string]string `json:"process_sizes"`
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	err := servicesResizeCmd.RunE(servicesResizeCmd, []string{"ws/proj/staging/svc", "web=standard-2x", "worker=small"})
+	if err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if gotPath != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/svc/resize" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if got.ProcessSizes["web"] != "standard-2x" || got.ProcessSizes["worker"] != "small" {
+		t.Errorf("process_sizes = %v", got.ProcessSizes)
+	}
+
+	for _, bad := range []string{"web", "web=", "=small"} {
+		if err := servicesResizeCmd.RunE(servicesResizeCmd, []string{"ws/proj/staging/svc", bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
@@ -1,13 +1,19 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,28 +25,49 @@ import (
 
 func init() {
 	loginCmd.Flags().Bool("manual", false, "Skip browser login and enter an API key manually")
+	loginCmd.Flags().String("callback-host", "127.0.0.1", "Address the login callback server listens on (e.g. ::1, 0.0.0.0)")
+	loginCmd.Flags().Int("callback-port", 0, "Port for the login callback server (default: random)")
 	rootCmd.AddCommand(loginCmd)
+	whoamiCmd.Flags().Bool("full", false, "Also show the API key's scopes and dates, and its role in each workspace")
 	rootCmd.AddCommand(whoamiCmd)
 }
 
 var loginCmd = &cobra.Command{
-	Use:     "login",
-	Short:   "Authenticate with the Ancla server",
-	Long:    "Log in to the Ancla server via your browser and store the API key.",
-	Example: "  ancla login\n  ancla login --manual",
+	Use:   "login",
+	Short: "Authenticate with the Ancla server",
+	Long: `Log in to the Ancla server via your browser and store the API key.
+
+The browser redirects back to a short-lived callback server on
+127.0.0.1. Inside devcontainers or WSL, forward a fixed --callback-port
+(and bind --callback-host 0.0.0.0 if needed), or paste the code the
+browser shows when it can't reach the callback.`,
+	Example: "  ancla login\n  ancla login --manual\n  ancla login --as-workspace acme\n  ancla login --callback-host 0.0.0.0 --callback-port 8765",
 	GroupID: "auth",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		manual, _ := cmd.Flags().GetBool("manual")
 		if manual {
 			return loginManual()
 		}
-		return loginBrowser()
+		host, _ := cmd.Flags().GetString("callback-host")
+		port, _ := cmd.Flags().GetInt("callback-port")
+		return loginBrowser(host, port)
 	},
 }
 
+// loginCallback is the result of a browser login, delivered either by the
+// local callback server or by exchanging a pasted code.
+type loginCallback struct {
+	apiKey   string
+	code     string
+	username string
+	email    string
+}
+
 // loginBrowser opens the browser, starts a local callback server, and waits
-// for the server to redirect back with an API key.
-func loginBrowser() error {
+// for the server to redirect back with an API key. When the browser cannot
+// reach the callback (devcontainers, WSL, remote shells), the user can paste
+// the code shown on the page instead.
+func loginBrowser(callbackHost string, callbackPort int) error {
 	// Generate a session code: 8 hex chars displayed as XXXX-XXXX
 	codeBytes := make([]byte, 4)
 	if _, err := rand.Read(codeBytes); err != nil {
@@ -49,28 +76,26 @@ func loginBrowser() error {
 	raw := hex.EncodeToString(codeBytes)
 	sessionCode := strings.ToUpper(raw[:4] + "-" + raw[4:])
 
-	// Start a temporary HTTP server on a random port, bound to localhost only
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	// Start a temporary HTTP server, bound to localhost only unless
+	// --callback-host says otherwise.
+	listener, err := net.Listen("tcp", net.JoinHostPort(callbackHost, strconv.Itoa(callbackPort)))
 	if err != nil {
-		return fmt.Errorf("starting callback server: %w", err)
+		return fmt.Errorf("starting callback server on %s: %w", net.JoinHostPort(callbackHost, strconv.Itoa(callbackPort)), err)
 	}
 	port := listener.Addr().(*net.TCPAddr).Port
 
-	type callbackResult struct {
-		apiKey   string
-		code     string
-		username string
-		email    string
-	}
-	resultCh := make(chan callbackResult, 1)
+	resultCh := make(chan loginCallback, 1)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-		resultCh <- callbackResult{
+		select {
+		case resultCh <- loginCallback{
 			apiKey:   r.URL.Query().Get("api_key"),
 			code:     r.URL.Query().Get("code"),
 			username: r.URL.Query().Get("username"),
 			email:    r.URL.Query().Get("email"),
+		}:
+		default:
 		}
 		w.Header().Set("Content-Type", "text/html")
 		fmt.Fprint(w, `<!DOCTYPE html><html><body style="font-family:system-ui;text-align:center;padding:4rem">
@@ -84,6 +109,9 @@ func loginBrowser() error {
 
 	// Open the browser
 	loginURL := fmt.Sprintf("%s/cli-auth?code=%s&port=%d", serverURL(), sessionCode, port)
+	if host := callbackRedirectHost(callbackHost); host != "" {
+		loginURL += "&host=" + url.QueryEscape(host)
+	}
 
 	fmt.Println("Opening browser to log in...")
 	fmt.Printf("Confirmation code: %s\n\n", sessionCode)
@@ -93,38 +121,144 @@ func loginBrowser() error {
 		fmt.Printf("Open this URL manually:\n  %s\n\n", loginURL)
 	}
 
+	// The callback can't be reached when the browser runs on another
+	// machine than the CLI, so also accept the code shown on the page. The
+	// reader started here owns stdin until the process exits, so the timeout
+	// fallback below reads the API key from it too rather than racing it.
 	fmt.Println("Waiting for authentication... (press Ctrl+C to cancel)")
+	var lines chan string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Print("If the browser can't reach this machine, paste the code it shows here: ")
+		lines = make(chan string)
+		go readLines(os.Stdin, lines)
+	}
 
-	// Wait for callback or timeout (5 minutes)
+	// Wait for callback, pasted code, or timeout (5 minutes)
 	timeout := time.After(5 * time.Minute)
+	var result loginCallback
 	select {
-	case result := <-resultCh:
-		if result.code != sessionCode {
-			return fmt.Errorf("session code mismatch — possible CSRF attack, aborting")
+	case result = <-resultCh:
+		fmt.Println()
+	case pasted := <-lines:
+		if pasted == "" {
+			return fmt.Errorf("no code entered")
 		}
-		if result.apiKey == "" {
-			return fmt.Errorf("no API key received from server")
+		exchanged, err := exchangeLoginCode(sessionCode, pasted)
+		if err != nil {
+			return err
 		}
-		// Key was just created by the server — save directly without re-validation
-		cfg.APIKey = result.apiKey
-		cfg.Username = result.username
-		cfg.Email = result.email
-		if err := config.Save(cfg); err != nil {
-			return fmt.Errorf("saving config: %w", err)
+		result = *exchanged
+	case <-timeout:
+		fmt.Println("\nBrowser login timed out after 5 minutes.")
+		if lines == nil {
+			fmt.Print("Falling back to manual API key entry...\n\n")
+			return loginManual()
 		}
-		if result.username != "" {
-			fmt.Printf("\n  Logged in as %s (%s)\n", result.username, result.email)
-		} else {
-			fmt.Printf("\n  Logged in successfully.\n")
+		fmt.Print("Paste an API key instead: ")
+		apiKey := <-lines
+		if apiKey == "" {
+			return fmt.Errorf("API key cannot be empty")
 		}
-		fmt.Printf("  API key saved to %s\n", config.FilePath())
-		return nil
+		return saveAndVerifyKey(apiKey)
+	}
 
-	case <-timeout:
-		fmt.Println("\nBrowser login timed out after 5 minutes.")
-		fmt.Print("Falling back to manual API key entry...\n\n")
-		return loginManual()
+	if result.code != sessionCode {
+		return fmt.Errorf("session code mismatch — possible CSRF attack, aborting")
+	}
+	if result.apiKey == "" {
+		return fmt.Errorf("no API key received from server")
+	}
+	// Key was just created by the server — save directly without re-validation
+	cfg.Username = result.username
+	cfg.Email = result.email
+	if err := saveLoginKey(result.apiKey, "username", "email"); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	if result.username != "" {
+		fmt.Printf("\n  Logged in as %s (%s)\n", result.username, result.email)
+	} else {
+		fmt.Printf("\n  Logged in successfully.\n")
+	}
+	printKeySaved()
+	return nil
+}
+
+// saveLoginKey stores a newly issued API key: under --as-workspace when it
+// is set, otherwise as the default api_key together with the named keys.
+// A workspace key leaves the default identity untouched.
+func saveLoginKey(apiKey string, keys ...string) error {
+	cfg.APIKey = apiKey
+	if asWorkspace != "" {
+		cfg.SetWorkspaceKey(asWorkspace, apiKey)
+		return config.Save(cfg, "workspace_keys")
 	}
+	return config.Save(cfg, append([]string{"api_key"}, keys...)...)
+}
+
+func printKeySaved() {
+	global, _ := config.Paths()
+	if asWorkspace != "" {
+		fmt.Printf("  API key for workspace %s saved to %s\n", asWorkspace, global)
+		return
+	}
+	fmt.Printf("  API key saved to %s\n", global)
+}
+
+// callbackRedirectHost returns the host the browser should redirect to, or
+// "" for the server default (127.0.0.1). Wildcard binds keep the default
+// because the browser reaches them through a forwarded localhost port.
+func callbackRedirectHost(bindHost string) string {
+	switch bindHost {
+	case "", "127.0.0.1", "0.0.0.0", "::":
+		return ""
+	}
+	return bindHost
+}
+
+// exchangeLoginCode trades the code displayed by the browser for an API key.
+// The server only accepts it together with the session code it was issued for.
+func exchangeLoginCode(sessionCode, pasted string) (*loginCallback, error) {
+	payload, _ := json.Marshal(map[string]string{"code": sessionCode, "token": pasted})
+	req, _ := http.NewRequest("POST", apiURL("/auth/cli-auth/exchange"), bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	// The session code is the credential here. A key already in the config
+	// may be stale or another account's, so it isn't sent.
+	client := &http.Client{
+		Transport: &apiKeyTransport{base: apiTransport},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging login code: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("exchanging login code: %w", &apiError{
+			Status:  resp.StatusCode,
+			Message: apiErrorMessage(resp.StatusCode, body),
+			Path:    req.URL.Path,
+		})
+	}
+	var result struct {
+		APIKey   string `json:"api_key"`
+		Code     string `json:"code"`
+		Username string `json:"username"`
+		Email    string `json:"email"`
+	}
+	if err := decodeJSON(body, &result); err != nil {
+		return nil, fmt.Errorf("parsing login response: %w", err)
+	}
+	return &loginCallback{apiKey: result.APIKey, code: result.Code, username: result.Username, email: result.Email}, nil
+}
+
+// readLines sends each line read from r to lines, trimmed, and closes lines
+// when r ends. A closed channel receives "", which callers treat as no input.
+func readLines(r io.Reader, lines chan<- string) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines <- strings.TrimSpace(scanner.Text())
+	}
+	close(lines)
 }
 
 // loginManual prompts the user for an API key directly.
@@ -147,7 +281,7 @@ func loginManual() error {
 // supports cookie-based auth.
 func saveAndVerifyKey(apiKey string) error {
 	client := &http.Client{
-		Transport: &apiKeyTransport{key: apiKey, base: http.DefaultTransport},
+		Transport: &apiKeyTransport{key: apiKey, base: apiTransport},
 	}
 	req, err := http.NewRequest("GET", apiURL("/workspaces/"), nil)
 	if err != nil {
@@ -166,20 +300,24 @@ func saveAndVerifyKey(apiKey string) error {
 		return fmt.Errorf("server returned %d — check your API key", resp.StatusCode)
 	}
 
-	cfg.APIKey = apiKey
-	if err := config.Save(cfg); err != nil {
+	if err := saveLoginKey(apiKey); err != nil {
 		return fmt.Errorf("saving config: %w", err)
 	}
 
 	fmt.Println("\n  Logged in successfully.")
-	fmt.Printf("  API key saved to %s\n", config.FilePath())
+	printKeySaved()
 	return nil
 }
 
 var whoamiCmd = &cobra.Command{
-	Use:     "whoami",
-	Short:   "Show the current authenticated user",
-	Example: "  ancla whoami",
+	Use:   "whoami",
+	Short: "Show the current authenticated user",
+	Long: `Show the user the API key belongs to.
+
+With --full, also show the key itself — its name, scopes, when it was
+created and last used — and every workspace it can access, with the role
+it holds in each and where that role was granted.`,
+	Example: "  ancla whoami\n  ancla whoami --full",
 	GroupID: "auth",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if cfg.APIKey == "" {
@@ -189,13 +327,28 @@ var whoamiCmd = &cobra.Command{
 
 		// Verify the key still works by hitting an authenticated endpoint
 		req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
-		_, err := doRequest(req)
+		listing, err := doRequest(req)
 		if err != nil {
 			fmt.Println("Not authenticated (API key is invalid or expired). Run 'ancla login' to re-authenticate.")
 			return nil
 		}
 
+		full, _ := cmd.Flags().GetBool("full")
+		var id identity
+		if full {
+			id = identity{Username: cfg.Username, Email: cfg.Email}
+			if id.Workspaces, err = workspaceRoles(listing); err != nil {
+				return err
+			}
+			// Only personal keys are listed; a service account's key
+			// can't list keys at all.
+			id.Key, _ = currentAPIKey()
+		}
+
 		if isJSON() {
+			if full {
+				return printJSON(id)
+			}
 			return printJSON(map[string]string{
 				"username": cfg.Username,
 				"email":    cfg.Email,
@@ -208,9 +361,15 @@ var whoamiCmd = &cobra.Command{
 		if cfg.Email != "" {
 			fmt.Printf("Email:    %s\n", cfg.Email)
 		}
+		if keyWorkspace != "" {
+			fmt.Printf("Key:      stored for workspace %s\n", keyWorkspace)
+		}
 		if cfg.Username == "" && cfg.Email == "" {
 			fmt.Println("Authenticated (re-login to populate user details)")
 		}
+		if full {
+			printIdentity(id)
+		}
 		return nil
 	},
 }
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestCallbackRedirectHost(t *testing.T) {
+	tests := []struct {
+		bind, want string
+	}{
+		{"127.0.0.1", ""},
+		{"0.0.0.0", ""},
+		{"::", ""},
+		{"::1", "::1"},
+		{"localhost", "localhost"},
+	}
+	for _, tt := range tests {
+		if got := callbackRedirectHost(tt.bind); got != tt.want {
+			t.Errorf("callbackRedirectHost(%q) = %q, want %q", tt.bind, got, tt.want)
+		}
+	}
+}
+
+func TestExchangeLoginCode(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var got map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/auth/cli-auth/exchange" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			t.Errorf("exchange sent the stored API key %q", key)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"api_key":"ancla_k","code":"AB12-CD34","username":"sam","email":"sam@example.com"}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, APIKey: "ancla_stale"}
+
+	result, err := exchangeLoginCode("AB12-CD34", "xyz-789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["code"] != "AB12-CD34" || got["token"] != "xyz-789" {
+		t.Errorf("request body = %v", got)
+	}
+	if result.apiKey != "ancla_k" || result.code != "AB12-CD34" || result.username != "sam" {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	lines := make(chan string)
+	go readLines(strings.NewReader("AB12-CD34 \nancla_key\n"), lines)
+	for _, want := range []string{"AB12-CD34", "ancla_key", ""} {
+		if got := <-lines; got != want {
+			t.Errorf("line = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestWhoamiFullCassette(t *testing.T) {
+	ts := cassetteServer(t, "whoami-full")
+	out, err := runCLI(t, cliRun{server: ts.URL}, "whoami", "--full", "--json")
+	if err != nil {
+		t.Fatalf("whoami: %v\n%s", err, out)
+	}
+	var id identity
+	if err := json.Unmarshal([]byte(out), &id); err != nil {
+		t.Fatalf("parsing output: %v\n%s", err, out)
+	}
+	if id.Key == nil || id.Key.Name != "ci" || len(id.Key.Scopes) != 2 {
+		t.Errorf("key = %+v, want the unrevoked key matching the prefix", id.Key)
+	}
+	want := []workspaceAccess{
+		{Slug: "acme", Name: "Acme", Role: "admin", Scope: "acme"},
+		{Slug: "side", Name: "Side Project", Role: "viewer", Scope: "side/blog"},
+	}
+	if !reflect.DeepEqual(id.Workspaces, want) {
+		t.Errorf("workspaces = %+v, want %+v", id.Workspaces, want)
+	}
+}
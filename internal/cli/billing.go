@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(billingCmd)
+	billingCmd.PersistentFlags().String("workspace", "", "Workspace slug (default: linked or default workspace)")
+	_ = billingCmd.RegisterFlagCompletionFunc("workspace", completeWorkspaces)
+	billingCmd.AddCommand(invoicesCmd)
+	invoicesCmd.AddCommand(invoicesListCmd)
+	invoicesCmd.AddCommand(invoicesDownloadCmd)
+	invoicesDownloadCmd.Flags().String("format", "pdf", "Invoice format: pdf or csv")
+	invoicesDownloadCmd.Flags().StringP("file", "f", "", "Write to this path (default: <invoice-id>.<format>)")
+}
+
+var billingCmd = &cobra.Command{
+	Use:   "billing",
+	Short: "View workspace billing",
+	Long: `View billing information for a workspace.
+
+Billing commands are limited to admins. The workspace defaults to the
+linked or default one; pass --workspace to pick another.`,
+	Example: "  ancla billing invoices list\n  ancla billing invoices download inv_123 --format csv",
+	GroupID: "resources",
+}
+
+var invoicesCmd = &cobra.Command{
+	Use:     "invoices",
+	Short:   "List and download invoices",
+	Example: "  ancla billing invoices list --workspace my-ws",
+}
+
+// billingWorkspace resolves the workspace for a billing command and
+// checks the caller is an admin before any billing endpoint is hit.
+func billingWorkspace(cmd *cobra.Command) (string, error) {
+	ws, _ := cmd.Flags().GetString("workspace")
+	var args []string
+	if ws != "" {
+		args = []string{ws}
+	}
+	ref, err := resolveServiceRef(args)
+	if err != nil {
+		return "", err
+	}
+	if err := requireAdmin(); err != nil {
+		return "", err
+	}
+	return ref.Workspace, nil
+}
+
+// requireAdmin fails when /auth/session reports a non-admin user. A session
+// that does not recognise the API key is not treated as a denial — the
+// billing endpoints enforce access themselves.
+func requireAdmin() error {
+	req, _ := http.NewRequest("GET", apiURL("/auth/session"), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return err
+	}
+	var session struct {
+		Authenticated bool `json:"authenticated"`
+		User          *struct {
+			Admin bool `json:"admin"`
+		} `json:"user"`
+	}
+	if err := decodeJSON(body, &session); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if session.Authenticated && session.User != nil && !session.User.Admin {
+		return fmt.Errorf("billing is only available to admins")
+	}
+	return nil
+}
+
+var invoicesListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List invoices",
+	Example: "  ancla billing invoices list",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, err := billingWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+
+		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/invoices/"), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+
+		var invoices []struct {
+			ID          string  `json:"id"`
+			Number      string  `json:"number"`
+			Status      string  `json:"status"`
+			Total       float64 `json:"total"`
+			Currency    string  `json:"currency"`
+			PeriodStart string  `json:"period_start"`
+			PeriodEnd   string  `json:"period_end"`
+		}
+		if err := decodeJSON(body, &invoices); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(invoices)
+		}
+
+		var rows [][]string
+		for _, inv := range invoices {
+			rows = append(rows, []string{
+				inv.ID,
+				inv.Number,
+				inv.PeriodStart + " " + symArrow + " " + inv.PeriodEnd,
+				fmt.Sprintf("%.2f %s", inv.Total, inv.Currency),
+				colorStatus(inv.Status),
+			})
+		}
+		table([]string{"ID", "NUMBER", "PERIOD", "TOTAL", "STATUS"}, rows)
+		return nil
+	},
+}
+
+var invoicesDownloadCmd = &cobra.Command{
+	Use:     "download <invoice-id>",
+	Short:   "Download an invoice as PDF or CSV",
+	Example: "  ancla billing invoices download inv_123\n  ancla billing invoices download inv_123 --format csv -f march.csv",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "pdf" && format != "csv" {
+			return fmt.Errorf("invalid --format %q (expected pdf or csv)", format)
+		}
+		ws, err := billingWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+
+		stop := spin("Downloading invoice...")
+		path := "/workspaces/" + ws + "/invoices/" + url.PathEscape(args[0]) + "/download?format=" + format
+		req, _ := http.NewRequest("GET", apiURL(path), nil)
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			file = args[0] + "." + format
+		}
+		if err := os.WriteFile(file, body, 0o644); err != nil {
+			return fmt.Errorf("writing invoice: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(map[string]any{"id": args[0], "format": format, "file": file, "bytes": len(body)})
+		}
+		if isQuiet() {
+			fmt.Println(file)
+			return nil
+		}
+		fmt.Println(stepDone(fmt.Sprintf("Saved %s (%d bytes)", file, len(body))))
+		return nil
+	},
+}
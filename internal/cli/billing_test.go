@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	tests := []struct {
+		name    string
+		session string
+		wantErr bool
+	}{
+		{"admin", `{"authenticated":true,"user":{"admin":true}}`, false},
+		{"member", `{"authenticated":true,"user":{"admin":false}}`, true},
+		{"key not recognised by session", `{"authenticated":false,"user":null}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.session))
+			}))
+			defer ts.Close()
+			cfg = &config.Config{Server: ts.URL}
+
+			err := requireAdmin()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requireAdmin() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvoicesDownloadCmd_WritesFile(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotURI string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/session" {
+			w.Write([]byte(`{"authenticated":true,"user":{"admin":true}}`))
+			return
+		}
+		gotURI = r.URL.RequestURI()
+		w.Write([]byte("number,total\n1,10\n"))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	out := filepath.Join(t.TempDir(), "inv.csv")
+	cmd := invoicesDownloadCmd
+	if err := cmd.ParseFlags([]string{"--workspace", "acme", "--format", "csv", "--file", out}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd.Flags().Set("workspace", "")
+		cmd.Flags().Set("format", "pdf")
+		cmd.Flags().Set("file", "")
+	}()
+
+	if err := cmd.RunE(cmd, []string{"inv_1"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if gotURI != "/api/v1/workspaces/acme/invoices/inv_1/download?format=csv" {
+		t.Errorf("request URI = %q", gotURI)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || string(data) != "number,total\n1,10\n" {
+		t.Errorf("file content = %q, err = %v", data, err)
+	}
+}
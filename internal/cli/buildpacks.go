@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func init() {
+	rootCmd.AddCommand(buildpacksCmd)
+	buildpacksCmd.AddCommand(buildpacksListCmd)
+	buildpacksCmd.AddCommand(buildpacksAddCmd)
+	buildpacksCmd.AddCommand(buildpacksRemoveCmd)
+	buildpacksCmd.AddCommand(buildpacksOrderCmd)
+	buildpacksAddCmd.Flags().Int("position", 0, "Insert at this position, counting from 1 (default: last)")
+}
+
+// serviceBuildpacks is the buildpack configuration of a service. Pinned
+// entries have the form "<id>[@<version>]"; an empty list means the
+// buildpacks are detected on each build, and Detected holds what the last
+// build used.
+type serviceBuildpacks struct {
+	Strategy string   `json:"build_strategy"`
+	Pinned   []string `json:"buildpacks"`
+	Detected []string `json:"detected_buildpacks"`
+}
+
+var buildpacksCmd = &cobra.Command{
+	Use:     "buildpacks",
+	Aliases: []string{"bp"},
+	Short:   "Pin the buildpacks a service builds with",
+	Long: `Show and pin the buildpacks used by a service with the buildpack build
+strategy. By default buildpacks are detected on every build. Once any
+buildpack is pinned, exactly the pinned buildpacks run, in order;
+removing the last one goes back to detection.
+
+Buildpacks are named by ID, such as heroku/python, optionally pinned to a
+version with @: heroku/python@0.19.1. The service is a slug in the
+linked environment or a full ws/proj/env/svc path, given before the
+buildpacks; leave it out to use the linked service.`,
+	Example: "  ancla buildpacks list\n  ancla buildpacks add heroku/nodejs --position 1\n  ancla buildpacks order heroku/nodejs heroku/python",
+	GroupID: "resources",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return buildpacksListCmd.RunE(cmd, args)
+	},
+}
+
+var buildpacksListCmd = &cobra.Command{
+	Use:     "list [<svc> | <ws>/<proj>/<env>/<svc>]",
+	Short:   "List pinned or detected buildpacks",
+	Example: "  ancla buildpacks list\n  ancla buildpacks list my-ws/my-proj/staging/api",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveBuildpackService(args)
+		if err != nil {
+			return err
+		}
+		bp, err := fetchBuildpacks(ref)
+		if err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(bp)
+		}
+
+		if bp.Strategy == "dockerfile" {
+			fmt.Println(stDim.Render(fmt.Sprintf("  %s builds from a Dockerfile, so buildpacks are not used.", ref.Service)))
+		}
+		list, source := bp.Pinned, "pinned"
+		if len(list) == 0 {
+			list, source = bp.Detected, "detected"
+		}
+		if len(list) == 0 {
+			fmt.Println("No buildpacks pinned or detected yet — they are detected on the next build.")
+			return nil
+		}
+		var rows [][]string
+		for i, entry := range list {
+			id, version := splitBuildpack(entry)
+			if version == "" {
+				version = stDim.Render("latest")
+			}
+			rows = append(rows, []string{fmt.Sprintf("%d", i+1), id, version, source})
+		}
+		table([]string{"ORDER", "BUILDPACK", "VERSION", "SOURCE"}, rows)
+		return nil
+	},
+}
+
+var buildpacksAddCmd = &cobra.Command{
+	Use:   "add [<svc> | <ws>/<proj>/<env>/<svc>] <buildpack>[@<version>]",
+	Short: "Pin a buildpack",
+	Long: `Pin a buildpack, at the end of the list or at --position. Adding a
+buildpack that is already pinned changes its version and keeps its place.
+The first pin turns off detection, so pin every buildpack the service
+needs.`,
+	Example: "  ancla buildpacks add heroku/python@0.19.1\n  ancla buildpacks add api heroku/nodejs --position 1",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveBuildpackService(args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		entry := args[len(args)-1]
+		id, _ := splitBuildpack(entry)
+		if id == "" {
+			return fmt.Errorf("invalid buildpack %q — expected <id>[@<version>]", entry)
+		}
+		position, _ := cmd.Flags().GetInt("position")
+
+		bp, err := fetchBuildpacks(ref)
+		if err != nil {
+			return err
+		}
+		if err := requireBuildpackStrategy(ref, bp); err != nil {
+			return err
+		}
+		pinned, err := addBuildpack(bp.Pinned, entry, position)
+		if err != nil {
+			return err
+		}
+		return saveBuildpacks(ref, pinned, "Pinned "+entry)
+	},
+}
+
+var buildpacksRemoveCmd = &cobra.Command{
+	Use:     "remove [<svc> | <ws>/<proj>/<env>/<svc>] <buildpack>",
+	Aliases: []string{"rm"},
+	Short:   "Unpin a buildpack",
+	Example: "  ancla buildpacks remove heroku/nodejs",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveBuildpackService(args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		id, _ := splitBuildpack(args[len(args)-1])
+
+		bp, err := fetchBuildpacks(ref)
+		if err != nil {
+			return err
+		}
+		pinned, ok := removeBuildpack(bp.Pinned, id)
+		if !ok {
+			return fmt.Errorf("%s is not pinned on %s — run `ancla buildpacks list` to see the pinned buildpacks", id, ref.Service)
+		}
+		done := "Unpinned " + id
+		if len(pinned) == 0 {
+			done += " — buildpacks are detected again from the next build"
+		}
+		return saveBuildpacks(ref, pinned, done)
+	},
+}
+
+var buildpacksOrderCmd = &cobra.Command{
+	Use:   "order [<svc> | <ws>/<proj>/<env>/<svc>] <buildpack>...",
+	Short: "Change the order of the pinned buildpacks",
+	Long: `Set the order the pinned buildpacks run in. Name every pinned buildpack
+exactly once, by ID; versions are kept.`,
+	Example: "  ancla buildpacks order heroku/nodejs heroku/python",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var spec []string
+		if len(args) > 1 && isServiceSpec(args[0]) {
+			spec, args = args[:1], args[1:]
+		}
+		ref, err := resolveBuildpackService(spec)
+		if err != nil {
+			return err
+		}
+
+		bp, err := fetchBuildpacks(ref)
+		if err != nil {
+			return err
+		}
+		pinned, err := orderBuildpacks(bp.Pinned, args)
+		if err != nil {
+			return err
+		}
+		return saveBuildpacks(ref, pinned, "Buildpacks now run in order: "+strings.Join(args, ", "))
+	},
+}
+
+// isServiceSpec reports whether arg names a service rather than a
+// buildpack. Buildpack IDs have the form namespace/name, while a service
+// is a bare slug or a full ws/proj/env/svc path.
+func isServiceSpec(arg string) bool {
+	n := strings.Count(arg, "/")
+	return (n == 0 || n == 3) && !strings.ContainsAny(arg, "@:")
+}
+
+// resolveBuildpackService resolves the optional service spec in args.
+func resolveBuildpackService(args []string) (config.ServiceRef, error) {
+	var spec string
+	if len(args) > 0 {
+		spec = args[0]
+	}
+	ref, err := resolveServiceSegments(spec)
+	if err != nil {
+		return ref, err
+	}
+	if !ref.HasService() {
+		return ref, fmt.Errorf("no service specified — provide a slug in the linked environment, <ws>/<proj>/<env>/<svc>, or run `ancla link` first")
+	}
+	return ref, nil
+}
+
+// fetchBuildpacks returns the buildpack configuration of the service.
+func fetchBuildpacks(ref config.ServiceRef) (*serviceBuildpacks, error) {
+	req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var bp serviceBuildpacks
+	if err := decodeJSON(body, &bp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &bp, nil
+}
+
+// requireBuildpackStrategy fails for a service that builds from a
+// Dockerfile, where pinned buildpacks would have no effect.
+func requireBuildpackStrategy(ref config.ServiceRef, bp *serviceBuildpacks) error {
+	if bp.Strategy == "dockerfile" {
+		return fmt.Errorf("%s builds from a Dockerfile — run `ancla services set-strategy buildpack` first", ref.Service)
+	}
+	return nil
+}
+
+// saveBuildpacks replaces the pinned buildpacks of the service.
+func saveBuildpacks(ref config.ServiceRef, pinned []string, done string) error {
+	if pinned == nil {
+		pinned = []string{}
+	}
+	data, _ := json.Marshal(map[string]any{"buildpacks": pinned})
+	req, _ := http.NewRequest("PATCH", apiURL(ref.ServicePath()), bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doRequest(req)
+	if err != nil {
+		return err
+	}
+	if isJSON() {
+		var bp serviceBuildpacks
+		if err := decodeJSON(body, &bp); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		return printJSON(bp)
+	}
+	if !isQuiet() {
+		fmt.Println(stepDone(done))
+		fmt.Println(stDim.Render("  Applies from the next build — run `ancla deploy` to rebuild."))
+	}
+	return nil
+}
+
+// splitBuildpack splits "heroku/python@0.19.1" into its ID and version.
+func splitBuildpack(entry string) (id, version string) {
+	if i := strings.LastIndex(entry, "@"); i > 0 {
+		return entry[:i], entry[i+1:]
+	}
+	return entry, ""
+}
+
+// addBuildpack returns pinned with entry added at position (1-based; 0
+// appends). An entry whose ID is already pinned replaces it in place.
+func addBuildpack(pinned []string, entry string, position int) ([]string, error) {
+	id, _ := splitBuildpack(entry)
+	out := append([]string(nil), pinned...)
+	for i, p := range out {
+		if pid, _ := splitBuildpack(p); pid == id {
+			out[i] = entry
+			return out, nil
+		}
+	}
+	if position == 0 {
+		return append(out, entry), nil
+	}
+	if position < 1 || position > len(out)+1 {
+		return nil, fmt.Errorf("--position must be between 1 and %d", len(out)+1)
+	}
+	out = append(out[:position-1], append([]string{entry}, out[position-1:]...)...)
+	return out, nil
+}
+
+// removeBuildpack returns pinned without the buildpack with the given ID,
+// and whether it was pinned.
+func removeBuildpack(pinned []string, id string) ([]string, bool) {
+	var out []string
+	found := false
+	for _, p := range pinned {
+		if pid, _ := splitBuildpack(p); pid == id {
+			found = true
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, found
+}
+
+// orderBuildpacks reorders pinned to follow ids, which must name every
+// pinned buildpack exactly once.
+func orderBuildpacks(pinned, ids []string) ([]string, error) {
+	byID := make(map[string]string, len(pinned))
+	for _, p := range pinned {
+		id, _ := splitBuildpack(p)
+		byID[id] = p
+	}
+	if len(pinned) == 0 {
+		return nil, fmt.Errorf("no buildpacks are pinned — pin them with `ancla buildpacks add` first")
+	}
+	if len(ids) != len(pinned) {
+		return nil, fmt.Errorf("name all %d pinned buildpacks, got %d", len(pinned), len(ids))
+	}
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id, _ = splitBuildpack(id)
+		p, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%s is not pinned, or is named twice", id)
+		}
+		delete(byID, id)
+		out = append(out, p)
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestAddBuildpack(t *testing.T) {
+	pinned := []string{"heroku/python@0.19.1", "heroku/procfile"}
+	tests := []struct {
+		entry    string
+		position int
+		want     []string
+	}{
+		{"heroku/nodejs", 0, []string{"heroku/python@0.19.1", "heroku/procfile", "heroku/nodejs"}},
+		{"heroku/nodejs", 1, []string{"heroku/nodejs", "heroku/python@0.19.1", "heroku/procfile"}},
+		{"heroku/python@0.20.0", 0, []string{"heroku/python@0.20.0", "heroku/procfile"}},
+	}
+	for _, tt := range tests {
+		got, err := addBuildpack(pinned, tt.entry, tt.position)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("addBuildpack(%q, %d) = %v, %v; want %v", tt.entry, tt.position, got, err, tt.want)
+		}
+	}
+	if _, err := addBuildpack(pinned, "heroku/nodejs", 4); err == nil {
+		t.Error("expected an error for a position past the end")
+	}
+	if pinned[0] != "heroku/python@0.19.1" {
+		t.Error("addBuildpack modified its input")
+	}
+}
+
+func TestRemoveAndOrderBuildpacks(t *testing.T) {
+	pinned := []string{"heroku/nodejs", "heroku/python@0.19.1"}
+
+	got, ok := removeBuildpack(pinned, "heroku/python")
+	if !ok || !reflect.DeepEqual(got, []string{"heroku/nodejs"}) {
+		t.Errorf("removeBuildpack = %v, %v", got, ok)
+	}
+	if _, ok := removeBuildpack(pinned, "heroku/go"); ok {
+		t.Error("removed a buildpack that was not pinned")
+	}
+
+	got, err := orderBuildpacks(pinned, []string{"heroku/python", "heroku/nodejs"})
+	if err != nil || !reflect.DeepEqual(got, []string{"heroku/python@0.19.1", "heroku/nodejs"}) {
+		t.Errorf("orderBuildpacks = %v, %v", got, err)
+	}
+	for _, ids := range [][]string{{"heroku/python"}, {"heroku/python", "heroku/python"}, {"heroku/python", "heroku/go"}} {
+		if _, err := orderBuildpacks(pinned, ids); err == nil {
+			t.Errorf("orderBuildpacks(%v) accepted an incomplete order", ids)
+		}
+	}
+}
+
+func TestIsServiceSpec(t *testing.T) {
+	for arg, want := range map[string]bool{
+		"api":                     true,
+		"ws/proj/staging/api":     true,
+		"heroku/python":           false,
+		"heroku/python@0.19.1":    false,
+		"docker://gcr.io/bp/node": false,
+	} {
+		if got := isServiceSpec(arg); got != want {
+			t.Errorf("isServiceSpec(%q) = %v, want %v", arg, got, want)
+		}
+	}
+}
+
+func TestBuildpacksAdd_RequiresBuildpackStrategy(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	patched := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			patched = true
+		}
+		json.NewEncoder(w).Encode(map[string]any{"build_strategy": "dockerfile"})
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	err := buildpacksAddCmd.RunE(buildpacksAddCmd, []string{"heroku/python"})
+	if err == nil || !strings.Contains(err.Error(), "set-strategy buildpack") {
+		t.Errorf("err = %v, want a hint to switch strategy", err)
+	}
+	if patched {
+		t.Error("buildpacks were changed on a Dockerfile service")
+	}
+}
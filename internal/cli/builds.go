@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -15,12 +17,50 @@ func init() {
 	buildsCmd.AddCommand(buildsListCmd)
 	buildsCmd.AddCommand(buildsTriggerCmd)
 	buildsCmd.AddCommand(buildsLogCmd)
-	buildsCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
 	buildsCmd.Flags().BoolP("follow", "f", false, "Follow build progress until complete")
 	buildsCmd.Flags().String("strategy", "", "Build strategy: dockerfile or buildpack")
 	buildsTriggerCmd.Flags().BoolP("follow", "f", false, "Follow build progress until complete")
 	buildsTriggerCmd.Flags().String("strategy", "", "Build strategy: dockerfile or buildpack")
 	buildsLogCmd.Flags().BoolP("follow", "f", false, "Poll for log updates until build completes")
+	buildsLogCmd.Flags().String("output-file", "", "Write the whole log to this file instead of the terminal, e.g. for a CI artifact")
+	buildsLogCmd.Flags().String("format", "text", "Log format: text, or json for the build's version, status, and log lines")
+	buildsLogCmd.MarkFlagsMutuallyExclusive("follow", "output-file")
+	addListFlags(buildsListCmd, buildListFields.names()...)
+	addIDOnlyFlag(buildsListCmd, "build versions")
+}
+
+// buildItem is a build as returned by the list endpoint.
+type buildItem struct {
+	ID       string  `json:"id"`
+	Version  int     `json:"version"`
+	Built    bool    `json:"built"`
+	Error    bool    `json:"error"`
+	Created  string  `json:"created"`
+	Strategy *string `json:"strategy"`
+}
+
+func (b buildItem) status() string {
+	switch {
+	case b.Error:
+		return "error"
+	case b.Built:
+		return "built"
+	}
+	return "building"
+}
+
+func (b buildItem) strategy() string {
+	if b.Strategy != nil && *b.Strategy != "" {
+		return *b.Strategy
+	}
+	return "dockerfile"
+}
+
+// buildListFields are the fields builds list sorts and filters by.
+var buildListFields = listFields[buildItem]{
+	"created":  func(b buildItem) string { return b.Created },
+	"status":   buildItem.status,
+	"strategy": buildItem.strategy,
 }
 
 var buildsCmd = &cobra.Command{
@@ -39,11 +79,11 @@ subcommand will prompt to trigger a new build. Use --yes to skip the prompt.`,
 	GroupID: "resources",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// If a service is linked, prompt to trigger a build.
-		ws, proj, env, svc, err := resolveServicePath(args)
-		if err == nil && ws != "" && proj != "" && env != "" && svc != "" {
-			path := ws + "/" + proj + "/" + env + "/" + svc
-			if !confirmAction(cmd, fmt.Sprintf("Build %s?", stAccent.Render(path))) {
-				return nil
+		ref, err := resolveServiceRef(args)
+		if err == nil && ref.HasService() {
+			path := ref.String()
+			if err := confirmAction(fmt.Sprintf("Build %s?", stAccent.Render(path))); err != nil {
+				return err
 			}
 			return buildsTriggerCmd.RunE(cmd, args)
 		}
@@ -55,75 +95,64 @@ subcommand will prompt to trigger a new build. Use --yes to skip the prompt.`,
 var buildsListCmd = &cobra.Command{
 	Use:     "list [<ws>/<proj>/<env>/<svc>]",
 	Short:   "List builds for a service",
-	Example: "  ancla builds list\n  ancla builds list my-ws/my-proj/staging/my-svc",
+	Example: "  ancla builds list\n  ancla builds list my-ws/my-proj/staging/my-svc\n  ancla builds list --filter status=error",
 	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no linked service — provide <ws>/<proj>/<env>/<svc>, or run `ancla link`")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(servicePath(ws, proj, env, svc)+"/builds/"), nil)
+		req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()+"/builds/"), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
 		}
 
 		var result struct {
-			Items []struct {
-				ID       string  `json:"id"`
-				Version  int     `json:"version"`
-				Built    bool    `json:"built"`
-				Error    bool    `json:"error"`
-				Created  string  `json:"created"`
-				Strategy *string `json:"strategy"`
-			} `json:"items"`
-		}
-		if err := json.Unmarshal(body, &result); err != nil {
+			Items []buildItem `json:"items"`
+		}
+		if err := decodeJSON(body, &result); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
+		if result.Items, err = applyListFlags(cmd, result.Items, buildListFields); err != nil {
+			return err
+		}
 
+		if idOnly(cmd) {
+			for _, b := range result.Items {
+				fmt.Println(b.Version)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(result)
 		}
 
 		var rows [][]string
 		for _, b := range result.Items {
-			status := "building"
-			if b.Error {
-				status = "error"
-			} else if b.Built {
-				status = "built"
-			}
-			id := b.ID
-			if len(id) > 8 {
-				id = id[:8]
-			}
-			strategy := "dockerfile"
-			if b.Strategy != nil && *b.Strategy != "" {
-				strategy = *b.Strategy
-			}
-			rows = append(rows, []string{fmt.Sprintf("v%d", b.Version), id, colorStatus(status), strategy, b.Created})
+			rows = append(rows, []string{fmt.Sprintf("v%d", b.Version), b.ID, colorStatus(b.status()), b.strategy(), formatTime(b.Created)})
 		}
-		table([]string{"VERSION", "ID", "STATUS", "STRATEGY", "CREATED"}, rows)
+		tableColumns([]tableColumn{{Header: "VERSION"}, shortIDColumn, {Header: "STATUS"}, {Header: "STRATEGY"}, {Header: "CREATED"}}, rows)
 		return nil
 	},
 }
 
 var buildsTriggerCmd = &cobra.Command{
-	Use:     "trigger [<ws>/<proj>/<env>/<svc>]",
-	Short:   "Trigger a build for a service",
-	Example: "  ancla builds trigger\n  ancla builds trigger my-ws/my-proj/staging/my-svc",
-	Args:    cobra.MaximumNArgs(1),
+	Use:         "trigger [<ws>/<proj>/<env>/<svc>]",
+	Short:       "Trigger a build for a service",
+	Example:     "  ancla builds trigger\n  ancla builds trigger my-ws/my-proj/staging/my-svc",
+	Annotations: map[string]string{expandAnnotation: "true"},
+	Args:        cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no linked service — provide <ws>/<proj>/<env>/<svc>, or run `ancla link`")
 		}
 
@@ -136,10 +165,10 @@ var buildsTriggerCmd = &cobra.Command{
 		}
 		var req *http.Request
 		if reqBody != nil {
-			req, _ = http.NewRequest("POST", apiURL(servicePath(ws, proj, env, svc)+"/builds/trigger"), reqBody)
+			req, _ = http.NewRequest("POST", apiURL(ref.ServicePath()+"/builds/trigger"), reqBody)
 			req.Header.Set("Content-Type", "application/json")
 		} else {
-			req, _ = http.NewRequest("POST", apiURL(servicePath(ws, proj, env, svc)+"/builds/trigger"), nil)
+			req, _ = http.NewRequest("POST", apiURL(ref.ServicePath()+"/builds/trigger"), nil)
 		}
 		body, err := doRequest(req)
 		stop()
@@ -151,7 +180,7 @@ var buildsTriggerCmd = &cobra.Command{
 			BuildID string `json:"build_id"`
 			Version int    `json:"version"`
 		}
-		if err := json.Unmarshal(body, &result); err != nil {
+		if err := decodeJSON(body, &result); err != nil {
 			fmt.Println("Build likely triggered, but the response could not be parsed (unexpected format).")
 			return nil
 		}
@@ -159,47 +188,65 @@ var buildsTriggerCmd = &cobra.Command{
 
 		follow, _ := cmd.Flags().GetBool("follow")
 		if follow && result.Version > 0 {
-			return followBuildLog(servicePath(ws, proj, env, svc), fmt.Sprintf("%d", result.Version))
+			return followBuildLog(ref.ServicePath(), fmt.Sprintf("%d", result.Version))
 		}
 		return nil
 	},
 }
 
 var buildsLogCmd = &cobra.Command{
-	Use:     "log [<ws>/<proj>/<env>/<svc>] [version]",
-	Short:   "Show build log",
-	Long:    "Show the log for a build. If no version is given, shows the latest build.",
-	Example: "  ancla builds log\n  ancla builds log 3\n  ancla builds log my-ws/my-proj/staging/my-svc 2",
+	Use:   "log [<ws>/<proj>/<env>/<svc>] [version|build-id]",
+	Short: "Show build log",
+	Long: `Show the log for a build, given by version or by build ID. A build ID may be
+shortened to any unique prefix, such as the one ` + "`builds list`" + ` shows. If no
+build is given, shows the latest build.
+
+With --output-file, the whole log is written to the file instead, fetched
+page by page if the server splits a long log, so a CI job can upload it
+as an artifact. --format json writes the build's version, status, and
+log lines as one JSON object.`,
+	Example: "  ancla builds log\n  ancla builds log 3\n  ancla builds log 9f2c1a\n  ancla builds log my-ws/my-proj/staging/my-svc 2\n  ancla builds log 3 --output-file build.log\n  ancla builds log 3 --output-file build.json --format json",
 	Args:    cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("invalid --format %q (expected text or json)", format)
+		}
+		follow, _ := cmd.Flags().GetBool("follow")
+		if follow && format == "json" {
+			return fmt.Errorf("--follow prints the log as it grows, so it can't be combined with --format json")
+		}
 		sp, version, err := resolveBuildArgs(args)
 		if err != nil {
 			return err
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(sp+"/builds/"+version+"/log"), nil)
-		body, err := doRequest(req)
+		log, err := fetchBuildLog(sp, version)
 		if err != nil {
 			return err
 		}
 
-		var result struct {
-			Status  string `json:"status"`
-			Version int    `json:"version"`
-			LogText string `json:"log_text"`
+		if file, _ := cmd.Flags().GetString("output-file"); file != "" {
+			if err := os.WriteFile(file, log.encode(format), 0o644); err != nil {
+				return fmt.Errorf("writing build log: %w", err)
+			}
+			if !isQuiet() {
+				fmt.Println(stepDone(fmt.Sprintf("Wrote the v%d build log (%s) to %s", log.Version, log.Status, file)))
+			}
+			return nil
 		}
-		if err := json.Unmarshal(body, &result); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
+		if format == "json" {
+			_, err := os.Stdout.Write(log.encode(format))
+			return err
 		}
 
-		fmt.Printf("Build v%d — %s\n\n", result.Version, result.Status)
-		if result.LogText != "" {
-			fmt.Println(result.LogText)
+		fmt.Printf("Build v%d — %s\n\n", log.Version, log.Status)
+		if log.Text != "" {
+			fmt.Println(log.Text)
 		} else {
 			fmt.Println("(no log output yet)")
 		}
 
-		follow, _ := cmd.Flags().GetBool("follow")
 		if follow {
 			return followBuildLog(sp, version)
 		}
@@ -207,34 +254,102 @@ var buildsLogCmd = &cobra.Command{
 	},
 }
 
+// buildLog is the whole log of a build.
+type buildLog struct {
+	Version int
+	Status  string
+	Text    string
+}
+
+// encode renders the log for --output-file: as the text, or as one JSON
+// object with the lines split out.
+func (l buildLog) encode(format string) []byte {
+	if format != "json" {
+		if l.Text == "" || strings.HasSuffix(l.Text, "\n") {
+			return []byte(l.Text)
+		}
+		return []byte(l.Text + "\n")
+	}
+	lines := []string{}
+	if l.Text != "" {
+		lines = strings.Split(strings.TrimSuffix(l.Text, "\n"), "\n")
+	}
+	data, _ := json.MarshalIndent(map[string]any{"version": l.Version, "status": l.Status, "lines": lines}, "", "  ")
+	return append(data, '\n')
+}
+
+// fetchBuildLog downloads a build's whole log. A server that splits a long
+// log marks each page but the last with has_more, and says where the next
+// one starts with next_offset; a server that doesn't sends it in one piece.
+func fetchBuildLog(sp, version string) (buildLog, error) {
+	var log buildLog
+	var text strings.Builder
+	offset := 0
+	for {
+		path := sp + "/builds/" + version + "/log"
+		if offset > 0 {
+			path += "?offset=" + strconv.Itoa(offset)
+		}
+		req, _ := http.NewRequest("GET", apiURL(path), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return buildLog{}, err
+		}
+		var page struct {
+			Status     string `json:"status"`
+			Version    int    `json:"version"`
+			LogText    string `json:"log_text"`
+			HasMore    bool   `json:"has_more"`
+			NextOffset int    `json:"next_offset"`
+		}
+		if err := decodeJSON(body, &page); err != nil {
+			return buildLog{}, fmt.Errorf("parsing response: %w", err)
+		}
+		log.Version, log.Status = page.Version, page.Status
+		text.WriteString(page.LogText)
+		if !page.HasMore {
+			break
+		}
+		if page.NextOffset <= offset {
+			return buildLog{}, fmt.Errorf("the server sent a log page without moving on (next_offset %d after %d)", page.NextOffset, offset)
+		}
+		offset = page.NextOffset
+	}
+	log.Text = text.String()
+	return log, nil
+}
+
 // resolveBuildArgs handles three calling conventions:
 //
 //	builds log                                        — latest build, linked service
 //	builds log <version>                              — specific version, linked service
 //	builds log <ws>/<proj>/<env>/<svc> <version>      — explicit path + version
 //
-// Returns the service path prefix and build version string.
+// A build ID, or a short prefix of one, may be given instead of the
+// version. Returns the service path prefix and build version string.
 func resolveBuildArgs(args []string) (sp, version string, err error) {
 	if len(args) == 2 {
-		ws, proj, env, svc, e := resolveServicePath(args[:1])
+		ref, e := resolveServiceRef(args[:1])
 		if e != nil {
 			return "", "", e
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return "", "", fmt.Errorf("all four segments required: <ws>/<proj>/<env>/<svc>")
 		}
-		return servicePath(ws, proj, env, svc), args[1], nil
+		version, err = resolveBuildVersion(ref.ServicePath(), args[1])
+		return ref.ServicePath(), version, err
 	}
 
 	// Resolve linked service for 0- or 1-arg forms.
-	ws, proj, env, svc, e := resolveServicePath(nil)
-	if e != nil || ws == "" || proj == "" || env == "" || svc == "" {
+	ref, e := resolveServiceRef(nil)
+	if e != nil || !ref.HasService() {
 		return "", "", fmt.Errorf("no linked service — provide <ws>/<proj>/<env>/<svc> before the version, or run `ancla link`")
 	}
-	sp = servicePath(ws, proj, env, svc)
+	sp = ref.ServicePath()
 
 	if len(args) == 1 {
-		return sp, args[0], nil
+		version, err = resolveBuildVersion(sp, args[0])
+		return sp, version, err
 	}
 
 	// 0 args — fetch latest build version.
@@ -257,7 +372,7 @@ func latestBuildVersion(sp string) (string, error) {
 			Version int `json:"version"`
 		} `json:"items"`
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := decodeJSON(body, &result); err != nil {
 		return "", fmt.Errorf("parsing builds: %w", err)
 	}
 	if len(result.Items) == 0 {
@@ -276,11 +391,15 @@ func latestBuildVersion(sp string) (string, error) {
 // followBuildLog polls the build log endpoint until the build completes or errors.
 func followBuildLog(sp, version string) error {
 	var lastLen int
+	lastStatus := ""
 	stop := spin("Building...")
 	defer stop()
 
+	p := newPoller()
 	for {
-		time.Sleep(3 * time.Second)
+		if err := p.wait("build " + version); err != nil {
+			return err
+		}
 		req, _ := http.NewRequest("GET", apiURL(sp+"/builds/"+version+"/log"), nil)
 		body, err := doRequest(req)
 		if err != nil {
@@ -290,22 +409,28 @@ func followBuildLog(sp, version string) error {
 			Status  string `json:"status"`
 			LogText string `json:"log_text"`
 		}
-		if err := json.Unmarshal(body, &result); err != nil {
+		if err := decodeJSON(body, &result); err != nil {
 			return fmt.Errorf("parsing poll response: %w", err)
 		}
 
 		// Print new log lines
 		if len(result.LogText) > lastLen {
 			stop()
-			fmt.Print(result.LogText[lastLen:])
+			printStageLog("build", version, result.LogText[lastLen:])
 			lastLen = len(result.LogText)
 			stop = spin("Building...")
 		}
+		if result.Status != lastStatus {
+			lastStatus = result.Status
+			reportProgress("build", version, result.Status, "")
+		}
 
 		switch result.Status {
 		case "success":
 			stop()
-			fmt.Println("\n" + stSuccess.Render(symCheck+" Build complete."))
+			if !progressLines() {
+				fmt.Println("\n" + stSuccess.Render(symCheck+" Build complete."))
+			}
 			return nil
 		case "error":
 			stop()
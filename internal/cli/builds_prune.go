@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	buildsCmd.AddCommand(buildsPruneCmd)
+	buildsPruneCmd.Flags().Int("keep", 20, "Number of most recent builds to keep")
+}
+
+var buildsPruneCmd = &cobra.Command{
+	Use:   "prune [<ws>/<proj>/<env>/<svc>]",
+	Short: "Delete old builds and their images",
+	Long: `Delete all but the most recent builds of a service, along with their
+images in the registry, to keep storage costs down.
+
+The build that is currently deployed is never deleted, even when it is
+older than the builds kept, and neither are builds still in progress or
+being deployed. The builds to delete are listed before you confirm; use
+--dry-run to see them without deleting anything.`,
+	Example: "  ancla builds prune --keep 20\n  ancla builds prune my-ws/my-proj/staging/my-svc --keep 5 --yes",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keep, _ := cmd.Flags().GetInt("keep")
+		if keep < 1 {
+			return fmt.Errorf("--keep must be at least 1")
+		}
+		ref, err := resolveServiceRef(args)
+		if err != nil {
+			return err
+		}
+		if !ref.HasService() {
+			return fmt.Errorf("no linked service — provide <ws>/<proj>/<env>/<svc>, or run `ancla link`")
+		}
+		sp := ref.ServicePath()
+
+		req, _ := http.NewRequest("GET", apiURL(sp+"/builds/"), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var builds struct {
+			Items []buildItem `json:"items"`
+		}
+		if err := decodeJSON(body, &builds); err != nil {
+			return fmt.Errorf("parsing builds: %w", err)
+		}
+
+		req, _ = http.NewRequest("GET", apiURL(sp+"/deploys/"), nil)
+		body, err = doRequest(req)
+		if err != nil {
+			return err
+		}
+		var deploys []deployItem
+		if err := decodeJSON(body, &deploys); err != nil {
+			return fmt.Errorf("parsing deploys: %w", err)
+		}
+		protected, err := protectedBuilds(deploys)
+		if err != nil {
+			return err
+		}
+
+		prune, held := planBuildPrune(builds.Items, keep, protected)
+		if len(prune) == 0 {
+			if isJSON() {
+				return printJSON(map[string]any{"deleted": []buildItem{}, "protected": held})
+			}
+			if !isQuiet() {
+				fmt.Printf("Nothing to prune — %d build(s), keeping %d.\n", len(builds.Items), keep)
+			}
+			return nil
+		}
+
+		if !isJSON() && !isQuiet() {
+			var rows [][]string
+			for _, b := range prune {
+				rows = append(rows, []string{fmt.Sprintf("v%d", b.Version), b.ID, colorStatus(b.status()), formatTime(b.Created)})
+			}
+			tableColumns([]tableColumn{{Header: "VERSION"}, shortIDColumn, {Header: "STATUS"}, {Header: "CREATED"}}, rows)
+			for _, b := range held {
+				fmt.Println(stDim.Render(fmt.Sprintf("Keeping v%d — %s.", b.Version, protected[b.ID])))
+			}
+		}
+		if err := confirmAction(fmt.Sprintf("Delete %d build(s) of %s?", len(prune), ref.String())); err != nil {
+			return err
+		}
+
+		for _, b := range prune {
+			req, _ := http.NewRequest("DELETE", apiURL(sp+"/builds/"+strconv.Itoa(b.Version)), nil)
+			if _, err := doRequest(req); err != nil {
+				return fmt.Errorf("deleting build v%d: %w", b.Version, err)
+			}
+		}
+
+		if isJSON() {
+			return printJSON(map[string]any{"deleted": prune, "protected": held})
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone(fmt.Sprintf("Deleted %d build(s), kept %d", len(prune), len(builds.Items)-len(prune))))
+		}
+		return nil
+	},
+}
+
+// protectedBuilds returns the builds prune must not delete, with the
+// reason: the build of the newest complete deploy, and builds of deploys
+// still running. It errors when the deploys don't say which build they
+// ran, rather than risk deleting the live build.
+func protectedBuilds(deploys []deployItem) (map[string]string, error) {
+	deploys = append([]deployItem(nil), deploys...)
+	sort.SliceStable(deploys, func(i, j int) bool { return deploys[i].Created > deploys[j].Created })
+
+	protected := map[string]string{}
+	live := false
+	for _, d := range deploys {
+		if d.Error || (d.Complete && live) {
+			continue
+		}
+		if d.BuildID == "" {
+			return nil, fmt.Errorf("the server didn't say which build deploy %s used, so the deployed build can't be protected — nothing was deleted", d.ID)
+		}
+		if d.Complete {
+			protected[d.BuildID] = "currently deployed"
+			live = true
+		} else if _, ok := protected[d.BuildID]; !ok {
+			protected[d.BuildID] = "being deployed"
+		}
+	}
+	return protected, nil
+}
+
+// planBuildPrune picks the builds to delete: all but the keep newest by
+// version, less protected builds and builds still in progress. held lists
+// the protected builds that would otherwise have been deleted.
+func planBuildPrune(builds []buildItem, keep int, protected map[string]string) (prune, held []buildItem) {
+	builds = append([]buildItem(nil), builds...)
+	sort.Slice(builds, func(i, j int) bool { return builds[i].Version > builds[j].Version })
+	if len(builds) <= keep {
+		return nil, nil
+	}
+	for _, b := range builds[keep:] {
+		switch {
+		case protected[b.ID] != "":
+			held = append(held, b)
+		case b.status() == "building":
+		default:
+			prune = append(prune, b)
+		}
+	}
+	return prune, held
+}
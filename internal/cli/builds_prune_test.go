@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestProtectedBuilds(t *testing.T) {
+	deploys := []deployItem{
+		{ID: "d1", BuildID: "b1", Complete: true, Created: "2026-10-01T09:00:00Z"},
+		{ID: "d4", BuildID: "b4", Created: "2026-10-04T09:00:00Z"}, // running
+		{ID: "d3", BuildID: "b3", Error: true, Created: "2026-10-03T09:00:00Z"},
+		{ID: "d2", BuildID: "b2", Complete: true, Created: "2026-10-02T09:00:00Z"},
+	}
+	got, err := protectedBuilds(deploys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"b2": "currently deployed", "b4": "being deployed"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Without build IDs the live build is unknown, so nothing is pruned.
+	_, err = protectedBuilds([]deployItem{{ID: "d1", Complete: true}})
+	if err == nil || !strings.Contains(err.Error(), "nothing was deleted") {
+		t.Errorf("err = %v, want refusal", err)
+	}
+}
+
+func TestPlanBuildPrune(t *testing.T) {
+	var builds []buildItem
+	for v := 1; v <= 6; v++ {
+		builds = append(builds, buildItem{ID: fmt.Sprintf("b%d", v), Version: v, Built: true})
+	}
+	builds[1].Built = false // v2 still building
+	protected := map[string]string{"b1": "currently deployed"}
+
+	prune, held := planBuildPrune(builds, 2, protected)
+	versions := func(bs []buildItem) string {
+		var s []string
+		for _, b := range bs {
+			s = append(s, fmt.Sprintf("v%d", b.Version))
+		}
+		return strings.Join(s, ",")
+	}
+	if got := versions(prune); got != "v4,v3" {
+		t.Errorf("prune = %s, want v4,v3", got)
+	}
+	if got := versions(held); got != "v1" {
+		t.Errorf("held = %s, want v1", got)
+	}
+
+	if prune, _ := planBuildPrune(builds, 6, protected); len(prune) != 0 {
+		t.Errorf("keeping all builds pruned %s", versions(prune))
+	}
+}
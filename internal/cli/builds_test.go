@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildsLogExportCassette(t *testing.T) {
+	ts := cassetteServer(t, "builds-log-export")
+	out, err := runCLI(t, cliRun{
+		server: ts.URL,
+		link:   "workspace: acme\nproject: shop\nenv: production\nservice: web\n",
+	}, "builds", "log", "3", "--output-file", "build.json", "--format", "json")
+	if err != nil {
+		t.Fatalf("builds log: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Wrote the v3 build log (built) to build.json") {
+		t.Errorf("output = %q, want where the log went", out)
+	}
+
+	data, err := os.ReadFile("build.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Version int      `json:"version"`
+		Status  string   `json:"status"`
+		Lines   []string `json:"lines"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("parsing %s: %v", data, err)
+	}
+	want := []string{
+		"Step 1/3 : FROM python:3.12",
+		"Step 2/3 : COPY . .",
+		"Step 3/3 : RUN pip install -r requirements.txt",
+		"Successfully built 9f2c1a",
+	}
+	if got.Version != 3 || got.Status != "built" || !reflect.DeepEqual(got.Lines, want) {
+		t.Errorf("exported %+v, want v3 built with both pages' lines %q", got, want)
+	}
+}
+
+func TestBuildLogEncodeText(t *testing.T) {
+	for text, want := range map[string]string{
+		"":       "",
+		"done":   "done\n",
+		"a\nb\n": "a\nb\n",
+	} {
+		if got := string(buildLog{Text: text}.encode("text")); got != want {
+			t.Errorf("encode(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
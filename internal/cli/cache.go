@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -17,7 +16,6 @@ func init() {
 	cacheCmd.AddCommand(cacheInfoCmd)
 	cacheCmd.AddCommand(cacheCliCmd)
 	cacheCmd.AddCommand(cacheFlushCmd)
-	cacheFlushCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
 }
 
 var cacheCmd = &cobra.Command{
@@ -39,15 +37,15 @@ func resolveCachePath(args []string) (apiPath string, displayPath string, err er
 	if len(args) >= 1 {
 		arg = args[0]
 	}
-	ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+	ref, err := config.ResolveServiceRef(arg, cfg)
 	if err != nil {
 		return "", "", err
 	}
-	if ws == "" || proj == "" || env == "" || svc == "" {
+	if !ref.HasService() {
 		return "", "", fmt.Errorf("no service specified — provide an argument or run `ancla link` first")
 	}
-	apiPath = "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc
-	displayPath = ws + "/" + proj + "/" + env + "/" + svc
+	apiPath = ref.ServicePath()
+	displayPath = ref.String()
 	return apiPath, displayPath, nil
 }
 
@@ -66,7 +64,7 @@ func fetchCacheInfo(svcAPIPath string) (*cacheInfo, error) {
 		return nil, fmt.Errorf("no cache service found: %w", err)
 	}
 	var info cacheInfo
-	if err := json.Unmarshal(body, &info); err != nil {
+	if err := decodeJSON(body, &info); err != nil {
 		return nil, fmt.Errorf("parsing cache info: %w", err)
 	}
 	return &info, nil
@@ -162,16 +160,8 @@ var cacheFlushCmd = &cobra.Command{
 			return err
 		}
 
-		yes, _ := cmd.Flags().GetBool("yes")
-		if !yes {
-			fmt.Printf("This will flush all cached data for %s.\n", displayPath)
-			fmt.Print("Continue? [y/N] ")
-			var answer string
-			fmt.Scanln(&answer)
-			if answer != "y" && answer != "yes" {
-				fmt.Println("Aborted.")
-				return nil
-			}
+		if err := confirmAction(fmt.Sprintf("This will flush all cached data for %s.", displayPath)); err != nil {
+			return err
 		}
 
 		stop := spin("Flushing cache...")
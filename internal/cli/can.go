@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// permissionActions are the actions the server can check with `ancla can`.
+var permissionActions = []string{
+	"view", "logs", "deploy", "scale", "exec", "config:read", "config:write", "delete", "admin",
+}
+
+func init() {
+	rootCmd.AddCommand(canCmd)
+}
+
+// permissionCheck is the server's answer to whether the current key may
+// perform an action on a resource.
+type permissionCheck struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Allowed  bool   `json:"allowed"`
+	Role     string `json:"role,omitempty"`
+	Scope    string `json:"scope,omitempty"` // where the role was granted, e.g. "my-ws/my-proj"
+	Reason   string `json:"reason,omitempty"`
+}
+
+var canCmd = &cobra.Command{
+	Use:   "can <action> [<ws>[/<proj>[/<env>[/<svc>]]]]",
+	Short: "Check whether you may perform an action",
+	Long: `Ask the server whether the current API key may perform an action on a
+resource, and which role decides it. Useful when a command fails with
+"Permission denied". The resource defaults to the linked or default
+context.
+
+Actions: view, logs, deploy, scale, exec, config:read, config:write,
+delete, admin.
+
+Exits 0 when the action is allowed and 1 when it is denied; --quiet
+prints nothing, so the exit status can be used in scripts.`,
+	Example: `  ancla can deploy my-ws/my-proj/prod/web
+  ancla can config:write
+  ancla can admin my-ws --quiet && echo "admin"`,
+	GroupID:   "auth",
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: permissionActions,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		action := args[0]
+		if !contains(permissionActions, action) {
+			return fmt.Errorf("unknown action %q — expected one of %v", action, permissionActions)
+		}
+		ref, err := resolveServiceRef(args[1:])
+		if err != nil {
+			return err
+		}
+
+		check, err := checkPermission(action, ref)
+		if err != nil {
+			return err
+		}
+
+		if isJSON() {
+			if err := printJSON(check); err != nil {
+				return err
+			}
+		} else if !isQuiet() {
+			printPermissionCheck(check, ref.Workspace)
+		}
+		if !check.Allowed {
+			return errReported
+		}
+		return nil
+	},
+}
+
+// checkPermission asks the server whether the current key may perform
+// action on the resource ref names.
+func checkPermission(action string, ref config.ServiceRef) (permissionCheck, error) {
+	q := url.Values{"action": {action}, "resource": {ref.String()}}
+	req, _ := http.NewRequest("GET", apiURL("/permissions/check?"+q.Encode()), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return permissionCheck{}, err
+	}
+	var check permissionCheck
+	if err := decodeJSON(body, &check); err != nil {
+		return check, fmt.Errorf("parsing response: %w", err)
+	}
+	if check.Action == "" {
+		check.Action = action
+	}
+	if check.Resource == "" {
+		check.Resource = ref.String()
+	}
+	return check, nil
+}
+
+// printPermissionCheck renders the result of a permission check.
+func printPermissionCheck(check permissionCheck, ws string) {
+	what := check.Action + " on " + check.Resource
+	if check.Allowed {
+		fmt.Println(stepDone("Allowed — " + what))
+	} else {
+		fmt.Println("  " + stError.Render(symCross) + " Denied — " + what)
+	}
+	if check.Role != "" {
+		role := check.Role
+		if check.Scope != "" {
+			role += " (granted on " + check.Scope + ")"
+		}
+		fmt.Println(kv("Role", role))
+	} else {
+		fmt.Println(kv("Role", stDim.Render("none")))
+	}
+	if check.Reason != "" {
+		fmt.Println(kv("Reason", check.Reason))
+	}
+	if !check.Allowed {
+		fmt.Println()
+		fmt.Println(stDim.Render("  " + tr("Ask an admin of workspace %q to grant you access", ws)))
+	}
+}
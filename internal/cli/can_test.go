@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestCanCmd(t *testing.T) {
+	origCfg, origJSON, origStdout := cfg, jsonFlag, os.Stdout
+	defer func() { cfg, jsonFlag, os.Stdout = origCfg, origJSON, origStdout }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/permissions/check" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("resource") != "ws/proj/prod/web" {
+			t.Errorf("resource = %q", q.Get("resource"))
+		}
+		allowed := q.Get("action") == "deploy"
+		json.NewEncoder(w).Encode(map[string]any{"allowed": allowed, "role": "deployer", "scope": "ws/proj"})
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "prod", Service: "web"}
+	jsonFlag = true
+
+	run := func(args ...string) (permissionCheck, error) {
+		t.Helper()
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		err := canCmd.RunE(canCmd, args)
+		w.Close()
+		out, _ := io.ReadAll(r)
+		os.Stdout = origStdout
+		var got permissionCheck
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out)
+		}
+		return got, err
+	}
+
+	got, err := run("deploy")
+	if err != nil {
+		t.Fatalf("allowed action returned %v", err)
+	}
+	if !got.Allowed || got.Action != "deploy" || got.Resource != "ws/proj/prod/web" || got.Scope != "ws/proj" {
+		t.Errorf("check = %+v", got)
+	}
+
+	got, err = run("delete", "ws/proj/prod/web")
+	if !errors.Is(err, errReported) {
+		t.Errorf("denied action returned %v, want errReported", err)
+	}
+	if got.Allowed {
+		t.Errorf("check = %+v, want denied", got)
+	}
+
+	if err := canCmd.RunE(canCmd, []string{"launch"}); err == nil {
+		t.Error("expected an error for an unknown action")
+	}
+}
@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Cassettes are recorded API conversations in testdata/cassettes. A test
+// runs a whole command against one with runCLI, and the replay server
+// fails the test if the command strays from the recording: a request it
+// doesn't have, a different payload, or recorded requests never made.
+// Repeats of a request are answered in recorded order.
+//
+// To record a cassette afresh against a real server:
+//
+//	ANCLA_API_KEY=... go test ./internal/cli -run TestDeployCassette -record https://ancla.dev
+//
+// The API key is never written to a cassette, but responses are kept
+// whole: review them for anything private before committing.
+var recordServer = flag.String("record", "", "record cassettes against this server instead of replaying them")
+
+// cassette is one recorded conversation, in the order it happened.
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"` // with the query, if any
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// cassetteServer replays testdata/cassettes/<name>.json, or records it
+// with -record. /api/version is answered as by a server that predates it
+// and kept out of the cassette: the CLI asks it once per process, so
+// whether a test sees it depends on which test ran first.
+func cassetteServer(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	file := filepath.Join("testdata", "cassettes", name+".json")
+	var mu sync.Mutex
+	var c cassette
+
+	var handler http.HandlerFunc
+	if *recordServer != "" {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			req, _ := http.NewRequest(r.Method, strings.TrimRight(*recordServer, "/")+r.URL.RequestURI(), bytes.NewReader(body))
+			req.Header = r.Header.Clone()
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("recording %s %s: %v", r.Method, r.URL.RequestURI(), err)
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			defer resp.Body.Close()
+			respBody, _ := io.ReadAll(resp.Body)
+			mu.Lock()
+			c.Interactions = append(c.Interactions, interaction{
+				Request:  recordedRequest{Method: r.Method, Path: r.URL.RequestURI(), Body: jsonOrString(body)},
+				Response: recordedResponse{Status: resp.StatusCode, Body: jsonOrString(respBody)},
+			})
+			mu.Unlock()
+			w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+			w.WriteHeader(resp.StatusCode)
+			w.Write(respBody)
+		}
+		t.Cleanup(func() {
+			data, _ := json.MarshalIndent(c, "", "  ")
+			os.MkdirAll(filepath.Dir(file), 0o755)
+			if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+				t.Errorf("writing cassette: %v", err)
+			}
+		})
+	} else {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("reading cassette: %v", err)
+		}
+		if err := json.Unmarshal(data, &c); err != nil {
+			t.Fatalf("parsing cassette %s: %v", file, err)
+		}
+		used := make([]bool, len(c.Interactions))
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			defer mu.Unlock()
+			// The first unused recording of the same request answers it, so
+			// requests the CLI sends concurrently may arrive in any order.
+			got := r.Method + " " + r.URL.RequestURI()
+			i := -1
+			for j, in := range c.Interactions {
+				if !used[j] && in.Request.Method+" "+in.Request.Path == got {
+					i = j
+					break
+				}
+			}
+			if i < 0 {
+				t.Errorf("cassette %s: request %s is not in the recording", name, got)
+				http.Error(w, "not in cassette", http.StatusInternalServerError)
+				return
+			}
+			in := c.Interactions[i]
+			used[i] = true
+			if len(in.Request.Body) > 0 && !sameJSON(body, in.Request.Body) {
+				t.Errorf("cassette %s: %s sent\n  %s\nrecorded\n  %s", name, got, body, in.Request.Body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(in.Response.Status)
+			w.Write(rawBody(in.Response.Body))
+		}
+		t.Cleanup(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			for i, in := range c.Interactions {
+				if !used[i] {
+					t.Errorf("cassette %s: recorded request %s %s was never made", name, in.Request.Method, in.Request.Path)
+				}
+			}
+		})
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			http.NotFound(w, r)
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// jsonOrString keeps a JSON body as is and quotes anything else, so the
+// cassette stays valid JSON.
+func jsonOrString(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return body
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+// rawBody undoes jsonOrString for a recorded response.
+func rawBody(body json.RawMessage) []byte {
+	var s string
+	if json.Unmarshal(body, &s) == nil {
+		return []byte(s)
+	}
+	return body
+}
+
+// sameJSON reports whether a and b are equal as JSON values, ignoring
+// key order and whitespace.
+func sameJSON(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(bytes.TrimSpace(a), bytes.TrimSpace(b))
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+// cliRun sets up where runCLI runs a command.
+type cliRun struct {
+	server string
+	link   string // .ancla/config.yaml of the working directory
+	files  map[string]string
+	stdin  string
+}
+
+// runCLI runs ancla with args as a user would — flag parsing, config
+// loading, and error reporting included — from a scratch HOME and working
+// directory, and returns what it printed to stdout. Flags and the global
+// state the run touches are reset afterwards.
+func runCLI(t *testing.T, run cliRun, args ...string) (string, error) {
+	t.Helper()
+	home, dir := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ANCLA_SERVER", run.server)
+	t.Setenv("ANCLA_API_KEY", "test-key")
+	t.Setenv("NO_COLOR", "1")
+	t.Chdir(dir)
+	files := map[string]string{}
+	for name, content := range run.files {
+		files[name] = content
+	}
+	if run.link != "" {
+		files[".ancla/config.yaml"] = run.link
+	}
+	for name, content := range files {
+		os.MkdirAll(filepath.Dir(name), 0o755)
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origCfg, origStdin, origStdout := cfg, os.Stdin, os.Stdout
+	origTried, origNo := reauthTried, noReauth
+	t.Cleanup(func() {
+		cfg, os.Stdin, os.Stdout = origCfg, origStdin, origStdout
+		reauthTried, noReauth = origTried, origNo
+		resetFlags(rootCmd)
+		rootCmd.SetArgs(nil)
+	})
+
+	in, inW, _ := os.Pipe()
+	inW.WriteString(run.stdin)
+	inW.Close()
+	os.Stdin = in
+	out, outW, _ := os.Pipe()
+	os.Stdout = outW
+	printed := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(out)
+		printed <- b
+	}()
+
+	rootCmd.SetArgs(args)
+	err := Execute()
+	outW.Close()
+	os.Stdout = origStdout
+	return string(<-printed), err
+}
+
+// resetFlags returns every flag under c that a run changed to its
+// default, since cobra keeps parsed values between executions.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+		} else if err := f.Value.Set(f.DefValue); err != nil {
+			panic(fmt.Sprintf("resetting --%s: %v", f.Name, err))
+		}
+		f.Changed = false
+	}
+	c.PersistentFlags().VisitAll(reset)
+	c.Flags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
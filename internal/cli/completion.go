@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
 )
 
 func init() {
@@ -17,7 +19,11 @@ var completionCmd = &cobra.Command{
 	Short: "Generate shell completion script",
 	Long: `Generate a shell completion script for ancla.
 
-To load completions:
+To install completions for your shell in one step:
+
+  ancla completion install
+
+To load completions by hand:
 
   bash:
     source <(ancla completion bash)
@@ -37,17 +43,7 @@ To load completions:
 	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		switch args[0] {
-		case "bash":
-			return rootCmd.GenBashCompletion(os.Stdout)
-		case "zsh":
-			return rootCmd.GenZshCompletion(os.Stdout)
-		case "fish":
-			return rootCmd.GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
-		}
-		return nil
+		return generateCompletion(args[0], os.Stdout)
 	},
 }
 
@@ -78,12 +74,16 @@ func completeWorkspaces(cmd *cobra.Command, args []string, toComplete string) ([
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
-// completeProjects fetches project slugs for the linked workspace for shell completion.
+// completeProjects fetches project slugs for the linked (or default) workspace for shell completion.
 func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	if cfg == nil || cfg.APIKey == "" || cfg.Workspace == "" {
+	if cfg == nil || cfg.APIKey == "" {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	req, err := http.NewRequest("GET", apiURL("/workspaces/"+cfg.Workspace+"/projects/"), nil)
+	ref, _ := config.ResolveServiceRef("", cfg)
+	if ref.Workspace == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	req, err := http.NewRequest("GET", apiURL("/workspaces/"+ref.Workspace+"/projects/"), nil)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -105,12 +105,16 @@ func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]s
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
-// completeEnvs fetches environment slugs for the linked workspace/project for shell completion.
+// completeEnvs fetches environment slugs for the linked (or default) workspace/project for shell completion.
 func completeEnvs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	if cfg == nil || cfg.APIKey == "" || cfg.Workspace == "" || cfg.Project == "" {
+	if cfg == nil || cfg.APIKey == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ref, _ := config.ResolveServiceRef("", cfg)
+	if ref.Workspace == "" || ref.Project == "" {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
-	req, err := http.NewRequest("GET", apiURL("/workspaces/"+cfg.Workspace+"/projects/"+cfg.Project+"/envs/"), nil)
+	req, err := http.NewRequest("GET", apiURL(ref.ProjectPath()+"/envs/"), nil)
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
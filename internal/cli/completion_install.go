@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	completionCmd.AddCommand(completionInstallCmd)
+}
+
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish|powershell]",
+	Short: "Install shell completion for your shell",
+	Long: `Write the completion script to the place your shell loads it from and,
+where the shell needs it, add a line to your shell startup file that
+sources it. The shell is detected from $SHELL unless given.
+
+  bash        ~/.local/share/bash-completion/completions/ancla, sourced from ~/.bashrc
+  zsh         ~/.zfunc/_ancla, sourced from ~/.zshrc
+  fish        ~/.config/fish/completions/ancla.fish (loaded automatically)
+  powershell  ancla-completion.ps1 next to your profile, dot-sourced from it
+
+Running it again refreshes the script and never adds the line twice.`,
+	Example:   "  ancla completion install\n  ancla completion install zsh",
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell := detectShell()
+		if len(args) == 1 {
+			shell = args[0]
+		}
+		if shell == "" {
+			return fmt.Errorf("could not detect your shell from $SHELL — pass one of bash, zsh, fish, or powershell")
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+
+		res, err := installCompletion(shell, home)
+		if err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(res)
+		}
+		if isQuiet() {
+			return nil
+		}
+		fmt.Println(stepDone(fmt.Sprintf("Wrote %s completion to %s", shell, res.Script)))
+		switch {
+		case res.RCFile == "":
+			fmt.Println(stDim.Render("  " + shell + " loads it automatically in new sessions."))
+		case res.RCUpdated:
+			fmt.Println(stepDone("Added a line to " + res.RCFile + " that loads it"))
+		default:
+			fmt.Println(stDim.Render("  " + res.RCFile + " already loads it."))
+		}
+		if res.RCFile != "" {
+			fmt.Println(stDim.Render("  Open a new shell, or run: " + reloadHint(shell, res.RCFile)))
+		}
+		return nil
+	},
+}
+
+// completionInstall describes what completion install did.
+type completionInstall struct {
+	Shell     string `json:"shell"`
+	Script    string `json:"script"`
+	RCFile    string `json:"rc_file,omitempty"`
+	RCUpdated bool   `json:"rc_updated"`
+}
+
+// rcMarker precedes the line completion install adds to a startup file.
+const rcMarker = "# ancla shell completion"
+
+// detectShell returns the user's shell from $SHELL, or powershell on
+// Windows where $SHELL is usually unset. It returns "" if unknown.
+func detectShell() string {
+	name := strings.TrimSuffix(filepath.Base(os.Getenv("SHELL")), ".exe")
+	switch name {
+	case "bash", "zsh", "fish":
+		return name
+	case "pwsh", "powershell":
+		return "powershell"
+	}
+	if runtime.GOOS == "windows" {
+		return "powershell"
+	}
+	return ""
+}
+
+// completionPaths returns where shell's completion script lives under home
+// and the startup file that must source it ("" when the shell finds the
+// script on its own).
+func completionPaths(shell, home string) (script, rc string, err error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch shell {
+	case "bash":
+		rc = filepath.Join(home, ".bashrc")
+		if runtime.GOOS == "darwin" {
+			rc = filepath.Join(home, ".bash_profile")
+		}
+		return filepath.Join(dataHome, "bash-completion", "completions", "ancla"), rc, nil
+	case "zsh":
+		zdot := os.Getenv("ZDOTDIR")
+		if zdot == "" {
+			zdot = home
+		}
+		return filepath.Join(home, ".zfunc", "_ancla"), filepath.Join(zdot, ".zshrc"), nil
+	case "fish":
+		return filepath.Join(configHome, "fish", "completions", "ancla.fish"), "", nil
+	case "powershell":
+		dir := filepath.Join(configHome, "powershell")
+		if runtime.GOOS == "windows" {
+			dir = filepath.Join(home, "Documents", "PowerShell")
+		}
+		return filepath.Join(dir, "ancla-completion.ps1"), filepath.Join(dir, "Microsoft.PowerShell_profile.ps1"), nil
+	}
+	return "", "", fmt.Errorf("unsupported shell %q — use bash, zsh, fish, or powershell", shell)
+}
+
+// sourceLine is the startup-file line that loads script.
+func sourceLine(shell, script string) string {
+	switch shell {
+	case "zsh":
+		// compdef only exists once compinit has run; most .zshrc files
+		// (and frameworks like oh-my-zsh) have done so by the last line.
+		return fmt.Sprintf("(( $+functions[compdef] )) || { autoload -Uz compinit && compinit }; [ -f %q ] && source %q", script, script)
+	case "powershell":
+		return fmt.Sprintf(`if (Test-Path "%s") { . "%s" }`, script, script)
+	}
+	return fmt.Sprintf("[ -f %q ] && . %q", script, script)
+}
+
+// reloadHint is the command that loads rc into the current session.
+func reloadHint(shell, rc string) string {
+	if shell == "powershell" {
+		return ". " + rc
+	}
+	return "source " + rc
+}
+
+// generateCompletion writes shell's completion script to w.
+func generateCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletion(w)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	}
+	return fmt.Errorf("unsupported shell %q — use bash, zsh, fish, or powershell", shell)
+}
+
+// installCompletion writes the completion script for shell under home and,
+// if the shell needs it, appends a line sourcing it to the startup file.
+// The line is only added once, so installing again just refreshes the script.
+func installCompletion(shell, home string) (*completionInstall, error) {
+	script, rc, err := completionPaths(shell, home)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := generateCompletion(shell, &buf); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(script), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(script, buf.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("writing completion script: %w", err)
+	}
+
+	res := &completionInstall{Shell: shell, Script: script, RCFile: rc}
+	if rc == "" {
+		return res, nil
+	}
+	res.RCUpdated, err = appendOnce(rc, sourceLine(shell, script))
+	if err != nil {
+		return nil, fmt.Errorf("updating %s: %w", rc, err)
+	}
+	return res, nil
+}
+
+// appendOnce appends line, under rcMarker, to the file at path unless the
+// file already contains it. It reports whether the file changed.
+func appendOnce(path, line string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if bytes.Contains(data, []byte(line)) {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	var b strings.Builder
+	if len(data) > 0 {
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(rcMarker + "\n" + line + "\n")
+	_, err = f.WriteString(b.String())
+	return err == nil, err
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallCompletion_Idempotent(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", "")
+	rc := filepath.Join(home, ".bashrc")
+	os.WriteFile(rc, []byte("alias ll='ls -l'"), 0o644)
+
+	for i := 0; i < 2; i++ {
+		res, err := installCompletion("bash", home)
+		if err != nil {
+			t.Fatalf("installCompletion error: %v", err)
+		}
+		if res.RCUpdated != (i == 0) {
+			t.Errorf("run %d: RCUpdated = %v", i, res.RCUpdated)
+		}
+		if want := filepath.Join(home, ".local", "share", "bash-completion", "completions", "ancla"); res.Script != want {
+			t.Errorf("script = %s, want %s", res.Script, want)
+		}
+	}
+
+	script, _ := os.ReadFile(filepath.Join(home, ".local", "share", "bash-completion", "completions", "ancla"))
+	if !strings.Contains(string(script), "bash completion for ancla") {
+		t.Errorf("script does not look like a bash completion:\n%.80s", script)
+	}
+	data, _ := os.ReadFile(rc)
+	if got := strings.Count(string(data), rcMarker); got != 1 {
+		t.Errorf(".bashrc has %d completion blocks, want 1:\n%s", got, data)
+	}
+	if !strings.HasPrefix(string(data), "alias ll='ls -l'\n\n"+rcMarker) {
+		t.Errorf(".bashrc = %q, want the original line kept", data)
+	}
+}
+
+func TestInstallCompletion_FishNeedsNoRC(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	res, err := installCompletion("fish", home)
+	if err != nil {
+		t.Fatalf("installCompletion error: %v", err)
+	}
+	if res.RCFile != "" || res.RCUpdated {
+		t.Errorf("fish install touched a startup file: %+v", res)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "fish", "completions", "ancla.fish")); err != nil {
+		t.Errorf("fish script not written: %v", err)
+	}
+
+	if _, err := installCompletion("tcsh", home); err == nil {
+		t.Error("installCompletion(tcsh) succeeded, want error")
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	for shell, want := range map[string]string{"/bin/zsh": "zsh", "/usr/local/bin/fish": "fish", "/usr/bin/pwsh": "powershell"} {
+		t.Setenv("SHELL", shell)
+		if got := detectShell(); got != want {
+			t.Errorf("SHELL=%s: detectShell() = %q, want %q", shell, got, want)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -23,11 +22,20 @@ func init() {
 	configImportCmd.Flags().StringP("file", "f", "", "Path to .env file to import")
 	configImportCmd.Flags().Bool("restart", false, "Trigger a config-only deploy after import")
 	configListCmd.Flags().Bool("show-secrets", false, "Show secret values instead of masking them")
-	configSetCmd.Flags().Bool("restart", false, "Trigger a config-only deploy after setting the variable")
-	configDeleteCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	addIDOnlyFlag(configListCmd, "variable names")
+	configSetCmd.Flags().Bool("restart", false, "Trigger a config-only deploy after setting the variables")
+	configSetCmd.Flags().StringArray("secret", nil, "Set a secret variable: KEY=value (repeatable)")
+	configSetCmd.Flags().StringArray("buildtime", nil, "Set a build-time variable: KEY=value (repeatable)")
+	configSetCmd.Flags().String("from", "", "Read KEY's value from a secret manager: vault:, aws-sm:, op://, or sops: reference")
+	configSetCmd.RegisterFlagCompletionFunc("secret", completeConfigVars)
+	configSetCmd.RegisterFlagCompletionFunc("buildtime", completeConfigVars)
+	configDeleteCmd.Flags().String("id", "", "Delete the variable with this ID instead of looking up a name")
+	configDeleteCmd.RegisterFlagCompletionFunc("id", completeConfigIDs)
 	configCmd.AddCommand(configApplyCmd)
+	configCmd.AddCommand(configShowCmd)
 	configApplyCmd.Flags().StringP("file", "f", "", "Path to .env file to import")
-	configApplyCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	configImportCmd.Flags().Bool("allow-secrets", false, "Import values that look like credentials as plain variables")
+	configApplyCmd.Flags().Bool("allow-secrets", false, "Import values that look like credentials as plain variables")
 }
 
 var configCmd = &cobra.Command{
@@ -50,6 +58,18 @@ list, set, delete, or bulk-import configuration from .env files.`,
 	},
 }
 
+// configShowCmd exposes the CLI's own settings under the config group for
+// discoverability; the implementation lives in settings show.
+var configShowCmd = &cobra.Command{
+	Use:     "show",
+	Short:   "Show effective CLI settings (same as `ancla settings show`)",
+	Example: "  ancla config show",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return settingsShowCmd.RunE(cmd, args)
+	},
+}
+
 // configAPIPath resolcts the API path for configuration based on the --scope
 // flag and positional argument. Returns the full API config path.
 func configAPIPath(cmd *cobra.Command, arg string) (string, error) {
@@ -58,37 +78,162 @@ func configAPIPath(cmd *cobra.Command, arg string) (string, error) {
 		scope = "service"
 	}
 
-	ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+	ref, err := config.ResolveServiceRef(arg, cfg)
 	if err != nil {
 		return "", err
 	}
 
 	switch scope {
 	case "workspace":
-		if ws == "" {
+		if ref.Workspace == "" {
 			return "", fmt.Errorf("workspace is required for --scope workspace")
 		}
-		return "/workspaces/" + ws + "/config/", nil
+		return "/workspaces/" + ref.Workspace + "/config/", nil
 	case "project":
-		if ws == "" || proj == "" {
+		if ref.Workspace == "" || ref.Project == "" {
 			return "", fmt.Errorf("workspace and project are required for --scope project")
 		}
-		return "/workspaces/" + ws + "/projects/" + proj + "/config/", nil
+		return ref.ProjectPath() + "/config/", nil
 	case "env":
-		if ws == "" || proj == "" || env == "" {
+		if !ref.HasEnv() {
 			return "", fmt.Errorf("workspace, project, and env are required for --scope env")
 		}
-		return "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/config/", nil
+		return ref.EnvPath() + "/config/", nil
 	case "service":
-		if ws == "" || proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return "", fmt.Errorf("workspace, project, env, and service are required for --scope service")
 		}
-		return "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc + "/config/", nil
+		return ref.ServicePath() + "/config/", nil
 	default:
 		return "", fmt.Errorf("invalid scope %q — use workspace, project, env, or service", scope)
 	}
 }
 
+// configVar is a configuration variable as returned by the list endpoint.
+// Scope names the level the variable is defined at when the list includes
+// inherited variables.
+type configVar struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Secret    bool   `json:"secret"`
+	Buildtime bool   `json:"buildtime"`
+	Scope     string `json:"scope,omitempty"`
+}
+
+// fetchConfigVars lists the variables at cfgPath.
+func fetchConfigVars(cfgPath string) ([]configVar, error) {
+	req, _ := http.NewRequest("GET", apiURL(cfgPath), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var configs []configVar
+	if err := decodeJSON(body, &configs); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return configs, nil
+}
+
+// resolveConfigID looks up the ID of the variable called name at cfgPath.
+// A name defined at more than one scope is an error, since deleting the
+// wrong one would silently change what the service sees.
+func resolveConfigID(cfgPath, name string) (string, error) {
+	configs, err := fetchConfigVars(cfgPath)
+	if err != nil {
+		return "", err
+	}
+	var matches []configVar
+	for _, c := range configs {
+		if c.Name == name {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no configuration variable named %s — see `ancla config list`", name)
+	case 1:
+		return matches[0].ID, nil
+	}
+	var where []string
+	for _, m := range matches {
+		if m.Scope != "" {
+			where = append(where, fmt.Sprintf("%s (%s)", m.ID, m.Scope))
+		} else {
+			where = append(where, m.ID)
+		}
+	}
+	return "", fmt.Errorf("%s is defined more than once: %s — narrow it with --scope or pass --id", name, strings.Join(where, ", "))
+}
+
+// completeConfigVars completes config variable names in the scope selected
+// by --scope and the optional path argument. set completes NAME= so the
+// value can be typed straight after, skipping names already assigned.
+func completeConfigVars(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if cfg == nil || cfg.APIKey == "" || strings.Contains(toComplete, "=") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	set := cmd.Name() == "set"
+	var arg string
+	given := map[string]bool{}
+	for i, a := range args {
+		name, _, isPair := strings.Cut(a, "=")
+		switch {
+		case set && isPair:
+			given[name] = true
+		case i == 0 && (set || strings.Contains(a, "/")):
+			arg = a
+		default:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	cfgPath, err := configAPIPath(cmd, arg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	configs, err := fetchConfigVars(cfgPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	if set {
+		for _, c := range configs {
+			if !given[c.Name] {
+				completions = append(completions, c.Name+"=")
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+	for _, c := range configs {
+		completions = append(completions, c.Name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigIDs completes --id with variable IDs described by name.
+func completeConfigIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if cfg == nil || cfg.APIKey == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var arg string
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	cfgPath, err := configAPIPath(cmd, arg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	configs, err := fetchConfigVars(cfgPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, c := range configs {
+		completions = append(completions, c.ID+"\t"+c.Name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
 var configListCmd = &cobra.Command{
 	Use:     "list [ws/proj/env/svc]",
 	Short:   "List configuration variables",
@@ -104,23 +249,11 @@ var configListCmd = &cobra.Command{
 			return err
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(cfgPath), nil)
-		body, err := doRequest(req)
+		configs, err := fetchConfigVars(cfgPath)
 		if err != nil {
 			return err
 		}
 
-		var configs []struct {
-			ID        string `json:"id"`
-			Name      string `json:"name"`
-			Value     string `json:"value"`
-			Secret    bool   `json:"secret"`
-			Buildtime bool   `json:"buildtime"`
-		}
-		if err := json.Unmarshal(body, &configs); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
-		}
-
 		showSecrets, _ := cmd.Flags().GetBool("show-secrets")
 
 		if !showSecrets {
@@ -131,6 +264,12 @@ var configListCmd = &cobra.Command{
 			}
 		}
 
+		if idOnly(cmd) {
+			for _, c := range configs {
+				fmt.Println(c.Name)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(configs)
 		}
@@ -145,17 +284,54 @@ var configListCmd = &cobra.Command{
 }
 
 var configSetCmd = &cobra.Command{
-	Use:     "set [ws/proj/env/svc] KEY=value",
-	Short:   "Set a configuration variable",
-	Example: "  ancla config set my-ws/my-proj/staging/my-svc DATABASE_URL=postgres://localhost/mydb",
-	Args:    cobra.RangeArgs(1, 2),
+	Use:   "set [ws/proj/env/svc] KEY=value...",
+	Short: "Set one or more configuration variables",
+	Long: `Set configuration variables. Pass any number of KEY=value pairs; use
+--secret KEY=value or --buildtime KEY=value (repeatable) to mark individual
+variables. Giving the same KEY=value to both flags marks it as both.
+
+A single plain variable is set directly. Several variables, or any marked
+variable, are sent together to the bulk endpoint and a summary of what was
+created and updated is printed.
+
+With --from, give a single KEY and its value is read from a secret manager
+on this machine, using that manager's CLI and your existing login, and set
+as a secret. The value is never echoed and never touches shell history or
+a file. References look like:
+
+  vault:secret/myapp#db_password        (vault kv get)
+  aws-sm:prod/myapp#db_password         (AWS Secrets Manager; #key is optional)
+  op://Production/myapp/db_password     (1Password)
+  sops:secrets.enc.yaml#db.password     (sops-encrypted file)`,
+	Example: `  ancla config set DATABASE_URL=postgres://localhost/mydb
+  ancla config set my-ws/my-proj/staging/my-svc KEY1=a KEY2=b --secret KEY3=c
+  ancla config set PIP_INDEX_URL=https://pypi.internal/simple --buildtime NPM_TOKEN=abc
+  ancla config set DB_PASSWORD --from vault:secret/myapp#db_password`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeConfigVars,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var arg, kvPair string
-		if len(args) == 2 {
-			arg = args[0]
-			kvPair = args[1]
+		from, _ := cmd.Flags().GetString("from")
+		var arg string
+		if len(args) > 0 && !strings.Contains(args[0], "=") && (from == "" || len(args) > 1) {
+			arg, args = args[0], args[1:]
+		}
+		secrets, _ := cmd.Flags().GetStringArray("secret")
+		buildtime, _ := cmd.Flags().GetStringArray("buildtime")
+		var vars []configAssignment
+		if from != "" {
+			if len(args) != 1 || strings.Contains(args[0], "=") || len(secrets)+len(buildtime) > 0 {
+				return fmt.Errorf("--from sets one variable — pass just its KEY, e.g. ancla config set DB_PASSWORD --from vault:secret/myapp#db_password")
+			}
+			value, err := resolveSecretRef(from)
+			if err != nil {
+				return err
+			}
+			vars = []configAssignment{{Name: args[0], Value: value, Secret: true}}
 		} else {
-			kvPair = args[0]
+			var err error
+			if vars, err = parseConfigAssignments(args, secrets, buildtime); err != nil {
+				return err
+			}
 		}
 
 		cfgPath, err := configAPIPath(cmd, arg)
@@ -163,42 +339,185 @@ var configSetCmd = &cobra.Command{
 			return err
 		}
 
-		parts := strings.SplitN(kvPair, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("expected KEY=value format")
-		}
-
-		payload, _ := json.Marshal(map[string]any{
-			"name":  parts[0],
-			"value": parts[1],
-		})
-		req, _ := http.NewRequest("POST", apiURL(cfgPath), bytes.NewReader(payload))
-		req.Header.Set("Content-Type", "application/json")
-		if _, err := doRequest(req); err != nil {
+		if len(vars) == 1 && !vars[0].Secret && !vars[0].Buildtime {
+			payload, _ := json.Marshal(map[string]any{
+				"name":  vars[0].Name,
+				"value": vars[0].Value,
+			})
+			req, _ := http.NewRequest("POST", apiURL(cfgPath), bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			if _, err := doRequest(req); err != nil {
+				return err
+			}
+			fmt.Printf("Set %s\n", vars[0].Name)
+		} else if err := setConfigVars(cfgPath, vars); err != nil {
 			return err
 		}
-		fmt.Printf("Set %s\n", parts[0])
 
 		restart, _ := cmd.Flags().GetBool("restart")
 		if restart {
 			return triggerConfigOnlyDeploy(cmd, arg)
 		}
+		printConfigChangeNote(cmd, arg)
 		return nil
 	},
 }
 
+// configChangeNote says when a config change at the service scope reaches
+// the running service, going by its redeploy-on-config setting. It is
+// empty for other scopes, or when the server doesn't report the setting.
+func configChangeNote(cmd *cobra.Command, arg string) string {
+	if scope, _ := cmd.Flags().GetString("scope"); scope != "" && scope != "service" {
+		return ""
+	}
+	ref, err := config.ResolveServiceRef(arg, cfg)
+	if err != nil || !ref.HasService() {
+		return ""
+	}
+	svc, err := fetchServiceConfigSettings(ref)
+	if err != nil || svc.RedeployOnConfigChange == nil {
+		return ""
+	}
+	if *svc.RedeployOnConfigChange {
+		return "Restart triggered — " + ref.Service + " redeploys with the new config."
+	}
+	return "Change will apply on next deploy — pass --restart to apply it now."
+}
+
+// printConfigChangeNote prints configChangeNote under a config change.
+func printConfigChangeNote(cmd *cobra.Command, arg string) {
+	if isQuiet() || isJSON() {
+		return
+	}
+	if note := configChangeNote(cmd, arg); note != "" {
+		fmt.Println(stDim.Render("  " + note))
+	}
+}
+
+// configAssignment is one KEY=value given to config set.
+type configAssignment struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Secret    bool   `json:"secret,omitempty"`
+	Buildtime bool   `json:"buildtime,omitempty"`
+}
+
+// parseConfigAssignments merges plain, --secret, and --buildtime KEY=value
+// pairs into one list in the order they were first given. A name repeated
+// with the same value collects both markers; a different value is an error.
+func parseConfigAssignments(plain, secret, buildtime []string) ([]configAssignment, error) {
+	var vars []configAssignment
+	index := map[string]int{}
+	add := func(pairs []string, mark func(*configAssignment)) error {
+		for _, p := range pairs {
+			name, value, ok := strings.Cut(p, "=")
+			if !ok || name == "" {
+				return fmt.Errorf("invalid assignment %q — expected KEY=value", p)
+			}
+			i, seen := index[name]
+			if !seen {
+				i = len(vars)
+				index[name] = i
+				vars = append(vars, configAssignment{Name: name, Value: value})
+			} else if vars[i].Value != value {
+				return fmt.Errorf("%s is given twice with different values", name)
+			}
+			mark(&vars[i])
+		}
+		return nil
+	}
+	if err := add(plain, func(*configAssignment) {}); err != nil {
+		return nil, err
+	}
+	if err := add(secret, func(v *configAssignment) { v.Secret = true }); err != nil {
+		return nil, err
+	}
+	if err := add(buildtime, func(v *configAssignment) { v.Buildtime = true }); err != nil {
+		return nil, err
+	}
+	if len(vars) == 0 {
+		return nil, fmt.Errorf("expected at least one KEY=value")
+	}
+	return vars, nil
+}
+
+// setConfigVars sends vars to the bulk endpoint in one request and prints
+// which were created, updated, or rejected.
+func setConfigVars(cfgPath string, vars []configAssignment) error {
+	payload, _ := json.Marshal(map[string]any{"variables": vars})
+	stop := spin(fmt.Sprintf("Setting %d variables...", len(vars)))
+	req, _ := http.NewRequest("POST", apiURL(cfgPath+"bulk"), bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doRequest(req)
+	stop()
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Created []string `json:"created"`
+		Updated []string `json:"updated"`
+		Errors  []struct {
+			Name  string `json:"name"`
+			Error string `json:"error"`
+		} `json:"errors"`
+	}
+	if err := decodeJSON(body, &result); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if isJSON() {
+		return printJSON(result)
+	}
+
+	outcome := map[string]string{}
+	for _, n := range result.Created {
+		outcome[n] = stSuccess.Render("created")
+	}
+	for _, n := range result.Updated {
+		outcome[n] = "updated"
+	}
+	for _, e := range result.Errors {
+		outcome[e.Name] = stError.Render(e.Error)
+	}
+	if !isQuiet() {
+		var rows [][]string
+		for _, v := range vars {
+			rows = append(rows, []string{v.Name, outcome[v.Name], fmt.Sprintf("%v", v.Secret), fmt.Sprintf("%v", v.Buildtime)})
+		}
+		table([]string{"NAME", "RESULT", "SECRET", "BUILDTIME"}, rows)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d of %d variables were not set", len(result.Errors), len(vars))
+	}
+	return nil
+}
+
 var configDeleteCmd = &cobra.Command{
-	Use:     "delete [ws/proj/env/svc] <config-id>",
-	Short:   "Delete a configuration variable",
-	Example: "  ancla config delete my-ws/my-proj/staging/my-svc <config-id>",
-	Args:    cobra.RangeArgs(1, 2),
+	Use:   "delete [ws/proj/env/svc] <NAME>",
+	Short: "Delete a configuration variable",
+	Long: `Delete a configuration variable by name. The name is looked up in the
+scope selected by --scope; if it matches more than one variable, the
+command lists their IDs and you can pick one with --id.`,
+	Example: `  ancla config delete DATABASE_URL
+  ancla config delete my-ws/my-proj/staging/my-svc DATABASE_URL
+  ancla config delete --id <config-id>`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if id, _ := cmd.Flags().GetString("id"); id != "" {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
+	ValidArgsFunction: completeConfigVars,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var arg, configID string
-		if len(args) == 2 {
+		configID, _ := cmd.Flags().GetString("id")
+		var arg, name string
+		switch {
+		case configID != "" && len(args) == 1:
 			arg = args[0]
-			configID = args[1]
-		} else {
-			configID = args[0]
+		case len(args) == 2:
+			arg, name = args[0], args[1]
+		case len(args) == 1:
+			name = args[0]
 		}
 
 		cfgPath, err := configAPIPath(cmd, arg)
@@ -206,22 +525,35 @@ var configDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		if !confirmAction(cmd, "This will delete the configuration variable.") {
-			fmt.Println("Aborted.")
-			return nil
+		label := configID
+		if configID == "" {
+			if configID, err = resolveConfigID(cfgPath, name); err != nil {
+				return err
+			}
+			label = name
+		}
+
+		if err := confirmAction(fmt.Sprintf("This will delete the configuration variable %s.", label)); err != nil {
+			return err
 		}
 		req, _ := http.NewRequest("DELETE", apiURL(cfgPath+configID), nil)
 		if _, err := doRequest(req); err != nil {
 			return err
 		}
-		fmt.Println("Deleted.")
+		fmt.Printf("Deleted %s.\n", label)
 		return nil
 	},
 }
 
 var configImportCmd = &cobra.Command{
-	Use:     "import [ws/proj/env/svc]",
-	Short:   "Bulk import configuration from a .env file",
+	Use:   "import [ws/proj/env/svc]",
+	Short: "Bulk import configuration from a .env file",
+	Long: `Import every variable in a .env file in one request.
+
+Values that look like credentials — private keys, AWS access and secret
+keys, JWTs — are imported as secrets; --allow-secrets imports them as
+plain variables instead. You are warned when the file is inside a git
+repository but not gitignored.`,
 	Example: "  ancla config import my-ws/my-proj/staging/my-svc --file .env",
 	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -235,19 +567,11 @@ var configImportCmd = &cobra.Command{
 			return err
 		}
 
-		filePath, _ := cmd.Flags().GetString("file")
-		if filePath == "" {
-			return fmt.Errorf("--file flag is required")
-		}
-
-		data, err := os.ReadFile(filePath)
+		fields, err := envImportPayload(cmd)
 		if err != nil {
-			return fmt.Errorf("reading file: %w", err)
+			return err
 		}
-
-		payload, _ := json.Marshal(map[string]any{
-			"raw": string(data),
-		})
+		payload, _ := json.Marshal(fields)
 		req, _ := http.NewRequest("POST", apiURL(cfgPath+"bulk"), bytes.NewReader(payload))
 		req.Header.Set("Content-Type", "application/json")
 		body, err := doRequest(req)
@@ -304,19 +628,11 @@ config-only redeploy so your changes take effect immediately.`,
 			return err
 		}
 
-		filePath, _ := cmd.Flags().GetString("file")
-		if filePath == "" {
-			return fmt.Errorf("--file flag is required")
-		}
-
-		data, err := os.ReadFile(filePath)
+		fields, err := envImportPayload(cmd)
 		if err != nil {
-			return fmt.Errorf("reading file: %w", err)
+			return err
 		}
-
-		payload, _ := json.Marshal(map[string]any{
-			"raw": string(data),
-		})
+		payload, _ := json.Marshal(fields)
 		req, _ := http.NewRequest("POST", apiURL(cfgPath+"bulk"), bytes.NewReader(payload))
 		req.Header.Set("Content-Type", "application/json")
 		body, err := doRequest(req)
@@ -353,28 +669,27 @@ config-only redeploy so your changes take effect immediately.`,
 // triggerConfigOnlyDeploy triggers a config-only deploy for the service
 // identified by the positional argument (or linked context).
 func triggerConfigOnlyDeploy(cmd *cobra.Command, arg string) error {
-	ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+	ref, err := config.ResolveServiceRef(arg, cfg)
 	if err != nil {
 		return err
 	}
-	if ws == "" || proj == "" || env == "" || svc == "" {
+	if !ref.HasService() {
 		return fmt.Errorf("full service path required for config-only deploy")
 	}
 
+	guard, err := guardDeploy(cmd, ref)
+	if err != nil {
+		return err
+	}
+
 	stop := spin("Triggering config-only deploy...")
-	payload, _ := json.Marshal(map[string]any{"config_only": true})
-	req, _ := http.NewRequest("POST", apiURL(servicePath(ws, proj, env, svc)+"/deploy"), bytes.NewReader(payload))
-	req.Header.Set("Content-Type", "application/json")
-	body, err := doRequest(req)
+	body, err := doRequest(newDeployRequest(ref, guard, map[string]any{"config_only": true}))
 	stop()
 	if err != nil {
 		return err
 	}
 
-	var result struct {
-		DeployID string `json:"deploy_id"`
-	}
-	json.Unmarshal(body, &result)
-	fmt.Printf("Config-only deploy triggered: %s\n", result.DeployID)
+	result, _ := decodeDeployResponse(body)
+	fmt.Printf("Config-only deploy triggered: %s\n", result.Deploy)
 	return nil
 }
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	configCmd.AddCommand(configEditCmd)
+	configEditCmd.Flags().Bool("restart", false, "Trigger a config-only deploy after applying the changes")
+}
+
+// maskedValue stands in for a secret's value in the edit buffer. Leaving
+// it in place keeps the secret unchanged.
+const maskedValue = "********"
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit [ws/proj/env/svc]",
+	Short: "Edit configuration variables in $EDITOR",
+	Long: `Open the variables at the selected scope in $EDITOR as a .env file.
+Add, change, or delete lines, then save and quit; the changes are shown
+as a summary and applied once you confirm. Quitting without changes does
+nothing.
+
+Secret values are shown as ******** — leave them as they are to keep the
+secret, or replace them to set a new value. New variables are created as
+plain variables; use ` + "`ancla config set --secret`" + ` for new secrets.
+Variables inherited from a higher scope are listed as comments and can
+only be edited at their own scope.`,
+	Example: "  ancla config edit\n  ancla config edit my-ws/my-proj/staging --scope env",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var arg string
+		if len(args) == 1 {
+			arg = args[0]
+		}
+		cfgPath, err := configAPIPath(cmd, arg)
+		if err != nil {
+			return err
+		}
+		if nonInteractiveFlag {
+			return fmt.Errorf("config edit opens an editor, so it can't run with --non-interactive — use `ancla config set` or `ancla config import`")
+		}
+		scope, _ := cmd.Flags().GetString("scope")
+		if scope == "" {
+			scope = "service"
+		}
+
+		vars, err := fetchConfigVars(cfgPath)
+		if err != nil {
+			return err
+		}
+		var own, inherited []configVar
+		for _, v := range vars {
+			if v.Scope == "" || v.Scope == scope {
+				own = append(own, v)
+			} else {
+				inherited = append(inherited, v)
+			}
+		}
+
+		f, err := os.CreateTemp("", "ancla-config-*.env")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		_, err = f.WriteString(formatConfigBuffer(own, inherited))
+		f.Close()
+		if err != nil {
+			return err
+		}
+		if err := openInEditor(f.Name()); err != nil {
+			return fmt.Errorf("running editor: %w", err)
+		}
+		data, err := os.ReadFile(f.Name())
+		if err != nil {
+			return err
+		}
+
+		edit, err := diffConfigBuffer(own, string(data))
+		if err != nil {
+			return fmt.Errorf("%w — no changes were applied", err)
+		}
+		if edit.empty() {
+			if !isQuiet() {
+				fmt.Println("No changes.")
+			}
+			return nil
+		}
+
+		if !isJSON() {
+			printConfigEdit(edit)
+		}
+		if err := confirmAction(fmt.Sprintf("Apply %d change(s) to %s?", edit.count(), strings.TrimSuffix(cfgPath, "/config/"))); err != nil {
+			return err
+		}
+		if len(edit.Set) > 0 {
+			if err := setConfigVars(cfgPath, edit.Set); err != nil {
+				return err
+			}
+		}
+		for _, v := range edit.Deleted {
+			req, _ := http.NewRequest("DELETE", apiURL(cfgPath+v.ID), nil)
+			if _, err := doRequest(req); err != nil {
+				return fmt.Errorf("deleting %s: %w", v.Name, err)
+			}
+			if !isQuiet() && !isJSON() {
+				fmt.Printf("Deleted %s.\n", v.Name)
+			}
+		}
+
+		if restart, _ := cmd.Flags().GetBool("restart"); restart {
+			return triggerConfigOnlyDeploy(cmd, arg)
+		}
+		printConfigChangeNote(cmd, arg)
+		return nil
+	},
+}
+
+// openInEditor opens path in $EDITOR, or vi, attached to the terminal.
+func openInEditor(path string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	c := exec.Command(editor, path)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// formatConfigBuffer writes own as KEY=value lines parseEnvFile reads back,
+// with secrets masked and inherited variables as comments.
+func formatConfigBuffer(own, inherited []configVar) string {
+	var b strings.Builder
+	b.WriteString("# Edit the variables below, then save and quit. Delete a line to delete\n")
+	b.WriteString("# the variable. Secrets show as " + maskedValue + " — leave that to keep the value.\n\n")
+	for _, v := range own {
+		value := v.Value
+		if v.Secret {
+			value = maskedValue
+		}
+		b.WriteString(v.Name + "=" + quoteEnvValue(value) + "\n")
+	}
+	if len(inherited) > 0 {
+		b.WriteString("\n# Inherited, edit at their own scope:\n")
+		for _, v := range inherited {
+			fmt.Fprintf(&b, "#   %s (%s)\n", v.Name, v.Scope)
+		}
+	}
+	return b.String()
+}
+
+// quoteEnvValue double-quotes values parseEnvFile would otherwise trim or
+// cut short.
+func quoteEnvValue(v string) string {
+	if v == "" || strings.ContainsAny(v, "\n#") || strings.TrimSpace(v) != v || v[0] == '"' || v[0] == '\'' {
+		return `"` + v + `"`
+	}
+	return v
+}
+
+// configEdit is the difference between the variables at a scope and the
+// edited buffer.
+type configEdit struct {
+	Set     []configAssignment // created or updated, in buffer order
+	Created map[string]bool
+	Deleted []configVar
+}
+
+func (e configEdit) empty() bool { return len(e.Set) == 0 && len(e.Deleted) == 0 }
+func (e configEdit) count() int  { return len(e.Set) + len(e.Deleted) }
+
+// diffConfigBuffer compares the edited buffer with the variables it was
+// written from. Updated variables keep their secret and build-time flags.
+func diffConfigBuffer(own []configVar, buffer string) (configEdit, error) {
+	existing := map[string]configVar{}
+	for _, v := range own {
+		existing[v.Name] = v
+	}
+	edit := configEdit{Created: map[string]bool{}}
+	seen := map[string]int{}
+	for _, e := range parseEnvFile(buffer) {
+		if !configKeyRe.MatchString(e.Name) {
+			return configEdit{}, fmt.Errorf("line %d: invalid variable name %q", e.Line, e.Name)
+		}
+		if line, dup := seen[e.Name]; dup {
+			return configEdit{}, fmt.Errorf("line %d: %s is already set on line %d", e.Line, e.Name, line)
+		}
+		seen[e.Name] = e.Line
+
+		old, ok := existing[e.Name]
+		switch {
+		case !ok:
+			edit.Created[e.Name] = true
+			edit.Set = append(edit.Set, configAssignment{Name: e.Name, Value: e.Value})
+		case old.Secret && e.Value == maskedValue, old.Value == e.Value:
+		default:
+			edit.Set = append(edit.Set, configAssignment{Name: e.Name, Value: e.Value, Secret: old.Secret, Buildtime: old.Buildtime})
+		}
+	}
+	for _, v := range own {
+		if _, ok := seen[v.Name]; !ok {
+			edit.Deleted = append(edit.Deleted, v)
+		}
+	}
+	sort.Slice(edit.Deleted, func(i, j int) bool { return edit.Deleted[i].Name < edit.Deleted[j].Name })
+	return edit, nil
+}
+
+// printConfigEdit shows the changes about to be applied. Values are left
+// out so secrets aren't echoed.
+func printConfigEdit(e configEdit) {
+	for _, v := range e.Set {
+		if e.Created[v.Name] {
+			fmt.Println(stSuccess.Render("+ " + v.Name))
+		} else {
+			fmt.Println(stWarning.Render("~ " + v.Name))
+		}
+	}
+	for _, v := range e.Deleted {
+		fmt.Println(stError.Render("- " + v.Name))
+	}
+}
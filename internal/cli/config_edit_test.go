@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigBufferRoundTrip(t *testing.T) {
+	own := []configVar{
+		{ID: "1", Name: "DEBUG", Value: "false"},
+		{ID: "2", Name: "GREETING", Value: " hello # world"},
+		{ID: "3", Name: "DB_PASSWORD", Value: "hunter2", Secret: true},
+		{ID: "4", Name: "EMPTY", Value: ""},
+	}
+	buf := formatConfigBuffer(own, []configVar{{Name: "SENTRY_DSN", Scope: "workspace"}})
+	if strings.Contains(buf, "hunter2") {
+		t.Fatalf("buffer shows a secret value:\n%s", buf)
+	}
+	edit, err := diffConfigBuffer(own, buf)
+	if err != nil || !edit.empty() {
+		t.Errorf("unedited buffer: edit = %+v, err = %v; want no changes", edit, err)
+	}
+}
+
+func TestDiffConfigBuffer(t *testing.T) {
+	own := []configVar{
+		{ID: "1", Name: "DEBUG", Value: "false"},
+		{ID: "2", Name: "LEGACY", Value: "1"},
+		{ID: "3", Name: "DB_PASSWORD", Value: "hunter2", Secret: true},
+		{ID: "4", Name: "NPM_TOKEN", Value: "abc", Secret: true, Buildtime: true},
+	}
+	edit, err := diffConfigBuffer(own, "DEBUG=true\nDB_PASSWORD=********\nNPM_TOKEN=def\nPORT=8000\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []configAssignment{
+		{Name: "DEBUG", Value: "true"},
+		{Name: "NPM_TOKEN", Value: "def", Secret: true, Buildtime: true},
+		{Name: "PORT", Value: "8000"},
+	}
+	if len(edit.Set) != len(want) {
+		t.Fatalf("Set = %+v, want %+v", edit.Set, want)
+	}
+	for i := range want {
+		if edit.Set[i] != want[i] {
+			t.Errorf("Set[%d] = %+v, want %+v", i, edit.Set[i], want[i])
+		}
+	}
+	if !edit.Created["PORT"] || edit.Created["DEBUG"] {
+		t.Errorf("Created = %v, want only PORT", edit.Created)
+	}
+	if len(edit.Deleted) != 1 || edit.Deleted[0].ID != "2" {
+		t.Errorf("Deleted = %+v, want LEGACY", edit.Deleted)
+	}
+
+	for _, bad := range []string{"DEBUG=true\nDEBUG=false\n", "MY-VAR=1\n"} {
+		if _, err := diffConfigBuffer(own, bad); err == nil {
+			t.Errorf("diffConfigBuffer(%q) accepted an invalid buffer", bad)
+		}
+	}
+}
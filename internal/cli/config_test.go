@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestCompleteConfigVars(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api/config/" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"c1","name":"DATABASE_URL"},{"id":"c2","name":"DEBUG"}]`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, APIKey: "k", Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	got, dir := completeConfigVars(configDeleteCmd, nil, "")
+	if len(got) != 2 || got[0] != "DATABASE_URL" || dir != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("delete completions = %q (%v)", got, dir)
+	}
+
+	got, _ = completeConfigIDs(configDeleteCmd, nil, "")
+	if len(got) != 2 || got[0] != "c1\tDATABASE_URL" {
+		t.Errorf("--id completions = %q", got)
+	}
+
+	got, dir = completeConfigVars(configSetCmd, []string{"ws/proj/staging/api"}, "DA")
+	if len(got) != 2 || got[0] != "DATABASE_URL=" || dir&cobra.ShellCompDirectiveNoSpace == 0 {
+		t.Errorf("set completions = %q (%v)", got, dir)
+	}
+
+	if got, _ := completeConfigVars(configSetCmd, nil, "DEBUG="); got != nil {
+		t.Errorf("completions after '=' = %q, want none", got)
+	}
+}
+
+func TestConfigDeleteCmd_ByName(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var deleted string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = r.URL.Path
+			return
+		}
+		w.Write([]byte(`[{"id":"c1","name":"DATABASE_URL","scope":"service"},{"id":"c2","name":"DEBUG","scope":"service"}]`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	configDeleteCmd.ParseFlags([]string{"--yes"})
+	defer configDeleteCmd.Flags().Set("yes", "false")
+
+	if err := configDeleteCmd.RunE(configDeleteCmd, []string{"DEBUG"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if want := "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api/config/c2"; deleted != want {
+		t.Errorf("deleted %s, want %s", deleted, want)
+	}
+
+	err := configDeleteCmd.RunE(configDeleteCmd, []string{"MISSING"})
+	if err == nil || !strings.Contains(err.Error(), "no configuration variable named MISSING") {
+		t.Errorf("error = %v, want not-found error", err)
+	}
+}
+
+func TestResolveConfigID_Ambiguous(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"c1","name":"LOG_LEVEL","scope":"env"},{"id":"c2","name":"LOG_LEVEL","scope":"service"}]`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	_, err := resolveConfigID("/config/", "LOG_LEVEL")
+	if err == nil || !strings.Contains(err.Error(), "c1 (env), c2 (service)") || !strings.Contains(err.Error(), "--id") {
+		t.Errorf("error = %v, want ambiguity error listing both IDs", err)
+	}
+}
+
+func TestParseConfigAssignments(t *testing.T) {
+	vars, err := parseConfigAssignments([]string{"A=1", "B=x=y"}, []string{"C=3", "A=1"}, []string{"C=3"})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	want := []configAssignment{
+		{Name: "A", Value: "1", Secret: true},
+		{Name: "B", Value: "x=y"},
+		{Name: "C", Value: "3", Secret: true, Buildtime: true},
+	}
+	if len(vars) != len(want) {
+		t.Fatalf("vars = %+v, want %+v", vars, want)
+	}
+	for i := range want {
+		if vars[i] != want[i] {
+			t.Errorf("vars[%d] = %+v, want %+v", i, vars[i], want[i])
+		}
+	}
+
+	for _, bad := range [][]string{{"NOVALUE"}, {"=x"}, {"A=1", "A=2"}, {}} {
+		if _, err := parseConfigAssignments(bad, nil, nil); err == nil {
+			t.Errorf("parseConfigAssignments(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestConfigSetCmd_BulkWithMarkers(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotPath string
+	var got struct {
+		Variables []configAssignment `json:"variables"`
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"slug":"api"}`))
+			return
+		}
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"created":["KEY1","KEY3"],"updated":["KEY2"]}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	if err := configSetCmd.ParseFlags([]string{"--secret", "KEY3=c"}); err != nil {
+		t.Fatal(err)
+	}
+	defer configSetCmd.Flags().Lookup("secret").Value.(pflag.SliceValue).Replace(nil)
+
+	if err := configSetCmd.RunE(configSetCmd, []string{"KEY1=a", "KEY2=b"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if want := "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api/config/bulk"; gotPath != want {
+		t.Errorf("path = %s, want %s", gotPath, want)
+	}
+	if len(got.Variables) != 3 || got.Variables[2] != (configAssignment{Name: "KEY3", Value: "c", Secret: true}) {
+		t.Errorf("variables = %+v", got.Variables)
+	}
+}
+
+func TestConfigChangeNote(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	setting := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"slug":"api"` + setting + `}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	for _, tt := range []struct{ setting, want string }{
+		{"", ""},
+		{`,"redeploy_on_config_change":true`, "Restart triggered"},
+		{`,"redeploy_on_config_change":false`, "Change will apply on next deploy"},
+	} {
+		setting = tt.setting
+		note := configChangeNote(configSetCmd, "")
+		if tt.want == "" && note != "" || !strings.HasPrefix(note, tt.want) {
+			t.Errorf("with %q: note = %q, want %q", tt.setting, note, tt.want)
+		}
+	}
+}
+
+func TestConfigImportCassette(t *testing.T) {
+	ts := cassetteServer(t, "config-import")
+	out, err := runCLI(t, cliRun{
+		server: ts.URL,
+		link:   "workspace: acme\nproject: shop\nenv: production\nservice: web\n",
+		files: map[string]string{
+			".env": "PORT=8080\nLOG_LEVEL=info\nAWS_SECRET_ACCESS_KEY=wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY\n",
+		},
+	}, "config", "import", "--file", ".env")
+	if err != nil {
+		t.Fatalf("config import: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Created: 3 variables") {
+		t.Errorf("output = %q, want 3 variables created", out)
+	}
+}
@@ -2,26 +2,92 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+)
 
-	"github.com/spf13/cobra"
+var (
+	// yesFlag is --yes: answer yes to every confirmation prompt.
+	yesFlag bool
+	// nonInteractiveFlag is --non-interactive: never prompt, and fail
+	// where a prompt would be needed.
+	nonInteractiveFlag bool
 )
 
-// confirmAction prompts the user with "Are you sure? [y/N]" and returns true
-// only if they type "y" or "yes". It defaults to No on empty input or any
-// other response. If the --yes flag is set on the command, it skips the prompt
-// and returns true immediately.
-func confirmAction(cmd *cobra.Command, message string) bool {
-	yes, _ := cmd.Flags().GetBool("yes")
-	if yes {
-		return true
-	}
-
-	fmt.Fprintf(os.Stderr, "%s Are you sure? [y/N] ", message)
-	reader := bufio.NewReader(os.Stdin)
-	answer, _ := reader.ReadString('\n')
-	answer = strings.TrimSpace(strings.ToLower(answer))
-	return answer == "y" || answer == "yes"
+// errAborted is returned when the user declines a confirmation prompt.
+// Declining is not a failure: Execute prints "Aborted." and exits 0.
+var errAborted = errors.New("aborted")
+
+// canPrompt reports whether the CLI may stop and ask the user something.
+// --non-interactive and --quiet both rule it out, so a script never hangs
+// on a prompt it cannot see.
+func canPrompt() bool {
+	return !nonInteractiveFlag && !isQuiet()
+}
+
+// errPromptDisabled explains why a confirmation could not be asked.
+func errPromptDisabled(message string) error {
+	flag := "--non-interactive"
+	if !nonInteractiveFlag {
+		flag = "--quiet"
+	}
+	return fmt.Errorf("%s Prompts are off with %s — pass --yes to confirm", message, flag)
+}
+
+// confirmAction prompts the user with "Are you sure? [y/N]" and returns nil
+// only if they type "y" or "yes" (or the locale's equivalent); any other
+// answer returns errAborted. --yes and --dry-run skip the prompt, since
+// nothing is sent under a dry run. When prompting is disabled it fails
+// rather than guess.
+func confirmAction(message string) error {
+	if yesFlag || dryRun {
+		return nil
+	}
+	if !canPrompt() {
+		return errPromptDisabled(message)
+	}
+
+	fmt.Fprint(os.Stderr, tr("%s Are you sure? [y/N] ", message))
+	answer, _ := stdinReader().ReadString('\n')
+	if !isYes(answer) {
+		return errAborted
+	}
+	return nil
+}
+
+// confirmTyped asks the user to type want back before a destructive action.
+// Like confirmAction, --yes and --dry-run skip the prompt.
+func confirmTyped(message, want string) error {
+	if yesFlag || dryRun {
+		return nil
+	}
+	if !canPrompt() {
+		return errPromptDisabled(message)
+	}
+
+	fmt.Fprint(os.Stderr, tr("%s Type %q to confirm: ", message, want))
+	answer, _ := stdinReader().ReadString('\n')
+	if strings.TrimSpace(answer) != want {
+		return errAborted
+	}
+	return nil
+}
+
+// stdin buffers os.Stdin once for every prompt in the process, so a
+// confirmation doesn't swallow input meant for the next question.
+var stdin struct {
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// stdinReader returns the shared reader for os.Stdin, starting a new one
+// if os.Stdin has been replaced.
+func stdinReader() *bufio.Reader {
+	if stdin.file != os.Stdin {
+		stdin.file = os.Stdin
+		stdin.reader = bufio.NewReader(os.Stdin)
+	}
+	return stdin.reader
 }
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// withStdin replaces os.Stdin with input for the rest of the test.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	orig := os.Stdin
+	r, w, _ := os.Pipe()
+	w.WriteString(input)
+	w.Close()
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = orig })
+}
+
+func TestConfirmAction(t *testing.T) {
+	defer func() { yesFlag, nonInteractiveFlag, quietFlag = false, false, false }()
+
+	tests := []struct {
+		name                string
+		yes, nonInteractive bool
+		quiet               bool
+		input               string
+		wantErr             string
+	}{
+		{name: "confirmed", input: "y\n"},
+		{name: "declined", input: "n\n", wantErr: "aborted"},
+		{name: "empty answer", input: "\n", wantErr: "aborted"},
+		{name: "yes flag", yes: true},
+		{name: "non-interactive", nonInteractive: true, wantErr: "Prompts are off with --non-interactive"},
+		{name: "quiet", quiet: true, wantErr: "Prompts are off with --quiet"},
+		{name: "yes wins over non-interactive", yes: true, nonInteractive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yesFlag, nonInteractiveFlag, quietFlag = tt.yes, tt.nonInteractive, tt.quiet
+			withStdin(t, tt.input)
+			err := confirmAction("Delete it.")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirm_SharesStdin(t *testing.T) {
+	withStdin(t, "y\nmy-svc\n")
+	if err := confirmAction("First."); err != nil {
+		t.Fatalf("first prompt: %v", err)
+	}
+	if err := confirmTyped("Second.", "my-svc"); err != nil {
+		t.Fatalf("second prompt lost its input: %v", err)
+	}
+	if err := confirmAction("Third."); !errors.Is(err, errAborted) {
+		t.Errorf("prompt at end of input = %v, want errAborted", err)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func init() {
+	consoleCmd.Flags().StringP("process", "p", "web", "Process type to connect to")
+	consoleCmd.Flags().String("repl", "sh", "Console to start: django, rails, or sh")
+	consoleCmd.Flags().StringP("command", "c", "", "Command to run instead of a --repl preset")
+	consoleCmd.Flags().Bool("record", false, "Record the session so it can be reviewed later")
+	rootCmd.AddCommand(consoleCmd)
+}
+
+// consolePresets maps --repl names to the command started in the container.
+var consolePresets = map[string]string{
+	"django": "python manage.py shell",
+	"rails":  "bin/rails console",
+	"sh":     "/bin/sh",
+}
+
+var consoleCmd = &cobra.Command{
+	Use:         "console [ws/proj/env/svc]",
+	Short:       "Open a remote console (Django shell, Rails console, sh)",
+	Annotations: map[string]string{featureAnnotation: "console"},
+	Long: `Open an interactive console in a running service container.
+
+The session is bridged over a WebSocket to the platform, so no SSH client
+or keys are needed. Pick a framework console with --repl, or run any
+command with --command. Sessions are only recorded when --record is set.`,
+	Example: `  ancla console
+  ancla console --repl django
+  ancla console my-ws/my-proj/staging/my-svc -c "bundle exec irb"
+  ancla console --repl rails --record`,
+	GroupID: "workflow",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveService(args)
+		if err != nil {
+			return err
+		}
+
+		process, _ := cmd.Flags().GetString("process")
+		record, _ := cmd.Flags().GetBool("record")
+		command, _ := cmd.Flags().GetString("command")
+		if command == "" {
+			repl, _ := cmd.Flags().GetString("repl")
+			var ok bool
+			if command, ok = consolePresets[repl]; !ok {
+				return fmt.Errorf("unknown --repl %q (expected django, rails, or sh)", repl)
+			}
+		}
+
+		payload := map[string]any{
+			"process": process,
+			"command": command,
+			"record":  record,
+		}
+		stdinFd := int(os.Stdin.Fd())
+		if cols, rows, err := term.GetSize(stdinFd); err == nil {
+			payload["cols"], payload["rows"] = cols, rows
+		}
+		data, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", apiURL(ref.ServicePath()+"/console"), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+
+		stop := spin("Starting console...")
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return fmt.Errorf("console not available: %w", err)
+		}
+
+		var session struct {
+			WebSocketURL string `json:"websocket_url"`
+			RecordingID  string `json:"recording_id"`
+		}
+		if err := decodeJSON(body, &session); err != nil {
+			return fmt.Errorf("parsing console response: %w", err)
+		}
+		if session.WebSocketURL == "" {
+			return fmt.Errorf("console session did not return a WebSocket URL")
+		}
+
+		if term.IsTerminal(stdinFd) {
+			state, err := term.MakeRaw(stdinFd)
+			if err != nil {
+				return fmt.Errorf("setting terminal mode: %w", err)
+			}
+			defer onTerminalRestore(func() { term.Restore(stdinFd, state) })()
+		}
+
+		err = bridgeWebSocket(websocketURL(session.WebSocketURL), os.Stdin, os.Stdout)
+		if session.RecordingID != "" {
+			fmt.Fprintf(os.Stderr, "\r\nSession recorded: %s\r\n", session.RecordingID)
+		}
+		return err
+	},
+}
+
+// bridgeWebSocket copies in to the socket and socket messages to out until
+// the remote side closes the session. A normal close is not an error.
+func bridgeWebSocket(url string, in io.Reader, out io.Writer) error {
+	conn, err := dialWebSocket(url)
+	if err != nil {
+		return fmt.Errorf("connecting to console: %w", err)
+	}
+	defer conn.Close()
+
+	// Stdin is forwarded from a goroutine that stays blocked on Read after
+	// the session ends, which is fine because the process exits next.
+	stdio := struct {
+		io.Reader
+		io.Writer
+	}{in, out}
+	if err := pipeWebSocket(conn, stdio); err != nil {
+		return fmt.Errorf("console: %w", err)
+	}
+	return nil
+}
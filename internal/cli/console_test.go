@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestWebsocketURL(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	tests := []struct {
+		server, raw, want string
+	}{
+		{"https://ancla.dev", "/ws/console/abc", "wss://ancla.dev/ws/console/abc"},
+		{"http://localhost:8000", "/ws/console/abc", "ws://localhost:8000/ws/console/abc"},
+		{"https://ancla.dev", "wss://edge.ancla.dev/c/abc", "wss://edge.ancla.dev/c/abc"},
+	}
+	for _, tt := range tests {
+		cfg = &config.Config{Server: tt.server}
+		if got := websocketURL(tt.raw); got != tt.want {
+			t.Errorf("websocketURL(%q) with %s = %q, want %q", tt.raw, tt.server, got, tt.want)
+		}
+	}
+}
+
+func TestBridgeWebSocket(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotKey string
+	upgrader := websocket.Upgrader{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-API-Key")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Echo one line back, then end the session.
+		_, msg, _ := conn.ReadMessage()
+		conn.WriteMessage(websocket.BinaryMessage, append([]byte(">>> "), msg...))
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, APIKey: "secret"}
+
+	var out bytes.Buffer
+	err := bridgeWebSocket("ws"+strings.TrimPrefix(ts.URL, "http")+"/console", strings.NewReader("print(1)\n"), &out)
+	if err != nil {
+		t.Fatalf("bridgeWebSocket() error: %v", err)
+	}
+	if out.String() != ">>> print(1)\n" {
+		t.Errorf("output = %q", out.String())
+	}
+	if gotKey != "secret" {
+		t.Errorf("X-API-Key = %q", gotKey)
+	}
+
+	// A session URL on another host must not receive the key.
+	cfg = &config.Config{Server: "https://ancla.dev", APIKey: "secret"}
+	out.Reset()
+	if err := bridgeWebSocket("ws"+strings.TrimPrefix(ts.URL, "http")+"/console", strings.NewReader("print(1)\n"), &out); err != nil {
+		t.Fatalf("bridgeWebSocket() error: %v", err)
+	}
+	if gotKey != "" {
+		t.Errorf("X-API-Key sent to another host: %q", gotKey)
+	}
+}
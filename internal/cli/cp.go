@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cpCmd.Flags().StringP("process", "p", "web", "Process type to copy to or from")
+	rootCmd.AddCommand(cpCmd)
+}
+
+var cpCmd = &cobra.Command{
+	Use:         "cp <src> <dst>",
+	Short:       "Copy files to or from a running container",
+	Annotations: map[string]string{featureAnnotation: "cp"},
+	Long: `Copy a file between your machine and a running service container.
+
+Exactly one side is remote, written as <service>:<path>. The service is a
+slug in the linked environment or a full ws/proj/env/svc path; leave it
+empty (":/tmp/x") to use the linked service. A remote path ending in "/"
+or a local directory keeps the source file name.
+
+Transfers run over the same authenticated WebSocket transport as
+"ancla console" and show progress on a terminal.`,
+	Example: `  ancla cp ./dump.sql my-svc:/tmp/dump.sql
+  ancla cp my-svc:/app/logs/error.log .
+  ancla cp :/tmp/report.csv ./report.csv
+  ancla cp ./seed.json my-ws/my-proj/staging/my-svc:/tmp/ -p worker`,
+	GroupID: "workflow",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcSvc, srcPath, srcRemote := splitRemoteSpec(args[0])
+		dstSvc, dstPath, dstRemote := splitRemoteSpec(args[1])
+		if srcRemote == dstRemote {
+			return fmt.Errorf("exactly one of <src> and <dst> must be remote (<service>:<path>)")
+		}
+		process, _ := cmd.Flags().GetString("process")
+
+		if srcRemote {
+			svcAPIPath, err := resolveCopyService(srcSvc)
+			if err != nil {
+				return err
+			}
+			if info, err := os.Stat(dstPath); err == nil && info.IsDir() {
+				dstPath = filepath.Join(dstPath, path.Base(srcPath))
+			}
+			return copyFromService(svcAPIPath, process, srcPath, dstPath)
+		}
+
+		svcAPIPath, err := resolveCopyService(dstSvc)
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(dstPath, "/") {
+			dstPath += filepath.Base(srcPath)
+		}
+		return copyToService(svcAPIPath, process, srcPath, dstPath)
+	},
+}
+
+// splitRemoteSpec splits "<service>:<path>". Windows drive letters
+// ("C:\dump.sql") are treated as local paths.
+func splitRemoteSpec(arg string) (svc, p string, remote bool) {
+	before, after, ok := strings.Cut(arg, ":")
+	if !ok || strings.ContainsAny(before, `\`) {
+		return "", arg, false
+	}
+	if runtime.GOOS == "windows" && len(before) == 1 {
+		return "", arg, false
+	}
+	return before, after, true
+}
+
+// resolveCopyService returns the API path of the service named in a remote
+// spec: empty for the linked service, a bare slug within the linked
+// environment, or a full ws/proj/env/svc path.
+func resolveCopyService(spec string) (string, error) {
+	ref, err := resolveServiceSegments(spec)
+	if err != nil {
+		return "", err
+	}
+	if !ref.HasService() {
+		return "", fmt.Errorf("cannot resolve service %q — use ws/proj/env/svc:<path> or run `ancla link` first", spec)
+	}
+	return ref.ServicePath(), nil
+}
+
+// openFileTransfer asks the API for a file transfer stream and dials it.
+func openFileTransfer(svcAPIPath string, payload map[string]any) (*websocket.Conn, int64, error) {
+	data, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", apiURL(svcAPIPath+"/files"), bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("file transfer not available: %w", err)
+	}
+	var session struct {
+		WebSocketURL string `json:"websocket_url"`
+		Size         int64  `json:"size"`
+	}
+	if err := decodeJSON(body, &session); err != nil {
+		return nil, 0, fmt.Errorf("parsing transfer response: %w", err)
+	}
+	if session.WebSocketURL == "" {
+		return nil, 0, fmt.Errorf("file transfer did not return a WebSocket URL")
+	}
+	conn, err := dialWebSocket(websocketURL(session.WebSocketURL))
+	if err != nil {
+		return nil, 0, fmt.Errorf("connecting file transfer: %w", err)
+	}
+	return conn, session.Size, nil
+}
+
+func copyToService(svcAPIPath, process, src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory — only single files can be copied", src)
+	}
+
+	conn, _, err := openFileTransfer(svcAPIPath, map[string]any{
+		"direction": "upload",
+		"process":   process,
+		"path":      dst,
+		"size":      info.Size(),
+		"mode":      info.Mode().Perm(),
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	prog := newTransferProgress(filepath.Base(src), info.Size())
+	buf := make([]byte, 32*1024)
+	for {
+		n, rerr := f.Read(buf)
+		if n > 0 {
+			if err := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); err != nil {
+				prog.finish()
+				return fmt.Errorf("uploading: %w", err)
+			}
+			prog.add(n)
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			prog.finish()
+			return rerr
+		}
+	}
+	// Signal end of file, then wait for the server to confirm the write
+	// by closing normally (or to report why it failed).
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if err = closeError(err); err != nil {
+				prog.finish()
+				return err
+			}
+			prog.copied()
+			return nil
+		}
+	}
+}
+
+func copyFromService(svcAPIPath, process, src, dst string) error {
+	conn, size, err := openFileTransfer(svcAPIPath, map[string]any{
+		"direction": "download",
+		"process":   process,
+		"path":      src,
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	prog := newTransferProgress(path.Base(src), size)
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			err = closeError(err)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				prog.finish()
+				os.Remove(dst)
+				return err
+			}
+			prog.copied()
+			return nil
+		}
+		if _, err := f.Write(msg); err != nil {
+			f.Close()
+			os.Remove(dst)
+			prog.finish()
+			return err
+		}
+		prog.add(len(msg))
+	}
+}
+
+// transferProgress draws a single updating progress line on stderr. It is
+// silent when stderr is not a terminal or output is quiet/JSON.
+type transferProgress struct {
+	name    string
+	total   int64
+	sent    int64
+	enabled bool
+	last    time.Time
+	clear   func()
+}
+
+func newTransferProgress(name string, total int64) *transferProgress {
+	p := &transferProgress{name: name, total: total, enabled: isTTY() && !isQuiet() && !isJSON() && !accessible()}
+	if p.enabled {
+		p.clear = onTerminalRestore(func() { fmt.Fprint(os.Stderr, "\r\x1b[K") })
+	}
+	return p
+}
+
+func (p *transferProgress) add(n int) {
+	p.sent += int64(n)
+	if p.enabled && time.Since(p.last) >= 100*time.Millisecond {
+		p.last = time.Now()
+		p.draw()
+	}
+}
+
+// Write counts len(b) bytes so the progress can sit behind an io.TeeReader.
+func (p *transferProgress) Write(b []byte) (int, error) {
+	p.add(len(b))
+	return len(b), nil
+}
+
+func (p *transferProgress) draw() {
+	line := fmt.Sprintf("  %s  %s", p.name, formatBytes(p.sent))
+	if p.total > 0 {
+		line += fmt.Sprintf(" / %s  %3d%%", formatBytes(p.total), p.sent*100/p.total)
+	}
+	fmt.Fprintf(os.Stderr, "\r\x1b[K%s", line)
+}
+
+// finish clears the progress line.
+func (p *transferProgress) finish() {
+	if p.enabled {
+		p.clear()
+	}
+}
+
+// copied reports a successful copy once the progress line is cleared.
+func (p *transferProgress) copied() {
+	p.finish()
+	if !isQuiet() {
+		fmt.Println(stepDone(fmt.Sprintf("Copied %s (%s)", p.name, formatBytes(p.sent))))
+	}
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "12.3 MiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
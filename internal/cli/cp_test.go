@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestSplitRemoteSpec(t *testing.T) {
+	tests := []struct {
+		arg        string
+		svc, path  string
+		wantRemote bool
+	}{
+		{"./dump.sql", "", "./dump.sql", false},
+		{"my-svc:/tmp/dump.sql", "my-svc", "/tmp/dump.sql", true},
+		{":/tmp/x", "", "/tmp/x", true},
+		{"ws/p/e/s:/tmp/", "ws/p/e/s", "/tmp/", true},
+		{`dir\file:x`, "", `dir\file:x`, false},
+	}
+	for _, tt := range tests {
+		svc, p, remote := splitRemoteSpec(tt.arg)
+		if svc != tt.svc || p != tt.path || remote != tt.wantRemote {
+			t.Errorf("splitRemoteSpec(%q) = %q, %q, %v", tt.arg, svc, p, remote)
+		}
+	}
+}
+
+func TestResolveCopyService(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+	cfg = &config.Config{Workspace: "ws", Project: "p", Env: "e", Service: "web"}
+
+	tests := []struct{ spec, want string }{
+		{"", "/workspaces/ws/projects/p/envs/e/services/web"},
+		{"worker", "/workspaces/ws/projects/p/envs/e/services/worker"},
+		{"o/q/prod/api", "/workspaces/o/projects/q/envs/prod/services/api"},
+	}
+	for _, tt := range tests {
+		got, err := resolveCopyService(tt.spec)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveCopyService(%q) = %q, %v; want %q", tt.spec, got, err, tt.want)
+		}
+	}
+
+	cfg = &config.Config{}
+	if _, err := resolveCopyService("worker"); err == nil {
+		t.Error("expected error without a linked environment")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		2048:            "2.0 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+// fileServer fakes the file transfer API: uploads are stored in files,
+// downloads are served from it.
+func fileServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+	var pending struct {
+		Direction string `json:"direction"`
+		Path      string `json:"path"`
+	}
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/workspaces/ws/projects/p/envs/e/services/s/files", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&pending)
+		json.NewEncoder(w).Encode(map[string]any{"websocket_url": "/ws/files", "size": len(files[pending.Path])})
+	})
+	mux.HandleFunc("/ws/files", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		normal := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		if pending.Direction == "download" {
+			data, ok := files[pending.Path]
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "no such file"))
+				return
+			}
+			conn.WriteMessage(websocket.BinaryMessage, data)
+			conn.WriteMessage(websocket.CloseMessage, normal)
+			return
+		}
+		var buf bytes.Buffer
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+			buf.Write(msg)
+		}
+		files[pending.Path] = buf.Bytes()
+		conn.WriteMessage(websocket.CloseMessage, normal)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCopyRoundTrip(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	files := map[string][]byte{}
+	ts := fileServer(t, files)
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "dump.sql")
+	os.WriteFile(src, []byte("select 1;"), 0o644)
+	svcAPIPath := servicePath("ws", "p", "e", "s")
+
+	if err := copyToService(svcAPIPath, "web", src, "/tmp/dump.sql"); err != nil {
+		t.Fatalf("copyToService() error: %v", err)
+	}
+	if string(files["/tmp/dump.sql"]) != "select 1;" {
+		t.Errorf("uploaded %q", files["/tmp/dump.sql"])
+	}
+
+	dst := filepath.Join(dir, "back.sql")
+	if err := copyFromService(svcAPIPath, "web", "/tmp/dump.sql", dst); err != nil {
+		t.Fatalf("copyFromService() error: %v", err)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "select 1;" {
+		t.Errorf("downloaded %q", data)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	if err := copyFromService(svcAPIPath, "web", "/nope", missing); err == nil {
+		t.Error("expected error for a missing remote file")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Error("partial download was not removed")
+	}
+}
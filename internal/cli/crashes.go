@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// crashWindow is how far back status looks for crash events.
+const crashWindow = "24h"
+
+// crashEvent is a container that exited unexpectedly: an OOM kill, a
+// non-zero exit, or a process the platform has flagged as restart-looping.
+type crashEvent struct {
+	Process     string `json:"process"`
+	Instance    string `json:"instance,omitempty"`
+	Reason      string `json:"reason,omitempty"` // e.g. OOMKilled, Error
+	ExitCode    int    `json:"exit_code"`
+	Restarts    int    `json:"restarts"`
+	RestartLoop bool   `json:"restart_loop"`
+	Message     string `json:"message,omitempty"` // last log line before exit
+	At          string `json:"at"`
+}
+
+// fetchCrashes returns the service's crash events from the last
+// crashWindow, most recent first.
+func fetchCrashes(ws, proj, env, svc string) ([]crashEvent, error) {
+	q := url.Values{"since": {crashWindow}}
+	req, _ := http.NewRequest("GET", apiURL(servicePath(ws, proj, env, svc)+"/crashes?"+q.Encode()), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var events []crashEvent
+	if err := decodeJSON(body, &events); err != nil {
+		return nil, fmt.Errorf("parsing crashes: %w", err)
+	}
+	return events, nil
+}
+
+// exitReason describes how a container exited, e.g. "OOMKilled (exit 137)"
+// or "SIGSEGV (exit 139)" when the platform gave no reason.
+func exitReason(e crashEvent) string {
+	reason := e.Reason
+	if reason == "" {
+		switch e.ExitCode {
+		case 137:
+			reason = "SIGKILL"
+		case 139:
+			reason = "SIGSEGV"
+		case 143:
+			reason = "SIGTERM"
+		default:
+			reason = "exited"
+		}
+	}
+	return reason + " (exit " + strconv.Itoa(e.ExitCode) + ")"
+}
+
+// printCrashes renders crash events as a table, flagging restart loops.
+func printCrashes(events []crashEvent) {
+	fmt.Println(stError.Render(symCross) + " " + stBold.Render(fmt.Sprintf("Recent crashes (last %s)", crashWindow)))
+	var rows [][]string
+	for _, e := range events {
+		restarts := strconv.Itoa(e.Restarts)
+		if e.RestartLoop {
+			restarts = stError.Render(restarts + " · restart loop")
+		}
+		rows = append(rows, []string{e.Process, exitReason(e), restarts, formatTime(e.At)})
+	}
+	table([]string{"PROCESS", "LAST EXIT", "RESTARTS", "AT"}, rows)
+	if last := events[0]; last.Message != "" {
+		fmt.Println(stDim.Render("  Last output from " + last.Process + ": " + last.Message))
+	}
+}
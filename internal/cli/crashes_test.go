@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestExitReason(t *testing.T) {
+	tests := []struct {
+		e    crashEvent
+		want string
+	}{
+		{crashEvent{Reason: "OOMKilled", ExitCode: 137}, "OOMKilled (exit 137)"},
+		{crashEvent{ExitCode: 139}, "SIGSEGV (exit 139)"},
+		{crashEvent{ExitCode: 1}, "exited (exit 1)"},
+	}
+	for _, tt := range tests {
+		if got := exitReason(tt.e); got != tt.want {
+			t.Errorf("exitReason(%+v) = %q, want %q", tt.e, got, tt.want)
+		}
+	}
+}
+
+func TestStatusCmd_IncludesCrashes(t *testing.T) {
+	origCfg, origJSON, origStdout := cfg, jsonFlag, os.Stdout
+	defer func() { cfg, jsonFlag, os.Stdout = origCfg, origJSON, origStdout }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api/crashes" {
+			if got := r.URL.Query().Get("since"); got != crashWindow {
+				t.Errorf("since = %q, want %s", got, crashWindow)
+			}
+			w.Write([]byte(`[{"process":"web","reason":"OOMKilled","exit_code":137,"restarts":6,"restart_loop":true,"at":"2026-10-16T09:12:00Z"}]`))
+			return
+		}
+		w.Write([]byte(`{"build":{"status":"success"},"deploy":{"status":"running"}}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+	jsonFlag = true
+
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := statusCmd.RunE(statusCmd, nil)
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = origStdout
+	if err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+
+	var got struct {
+		Deploy  string       `json:"deploy"`
+		Crashes []crashEvent `json:"crashes"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if got.Deploy != "running" || len(got.Crashes) != 1 || !got.Crashes[0].RestartLoop || got.Crashes[0].Reason != "OOMKilled" {
+		t.Errorf("status = %+v", got)
+	}
+}
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbBackupsCmd)
+	dbBackupsCmd.AddCommand(dbBackupsListCmd)
+	dbBackupsCmd.AddCommand(dbBackupsCreateCmd)
+	dbBackupsCmd.AddCommand(dbBackupsRestoreCmd)
+	dbBackupsCmd.AddCommand(dbBackupsDownloadCmd)
+	dbBackupsDownloadCmd.Flags().StringP("file", "f", "", "Write to this path (default: <backup-id>.dump)")
+}
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the service's database",
+	Long: `Manage the database attached to your service.
+
+Use "ancla dbshell" for an interactive session. Requires a linked service
+or an explicit ws/proj/env/svc path.`,
+	Example: "  ancla db backups list\n  ancla db backups create",
+	GroupID: "workflow",
+}
+
+var dbBackupsCmd = &cobra.Command{
+	Use:         "backups",
+	Short:       "List, create, restore, and download backups",
+	Annotations: map[string]string{featureAnnotation: "db.backups"},
+	Example: `  ancla db backups list
+  ancla db backups create
+  ancla db backups restore bk_123
+  ancla db backups download bk_123 -f prod.dump`,
+}
+
+// dbBackup is a database backup as returned by the API.
+type dbBackup struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"` // "scheduled" or "manual"
+	Status    string `json:"status"`
+	SizeBytes int64  `json:"size_bytes"`
+	SHA256    string `json:"sha256"`
+	Created   string `json:"created"`
+}
+
+// backupsPath returns the backups API path for the service in args[0], or
+// the linked service when args is empty.
+func backupsPath(args []string) (string, error) {
+	ref, err := resolveService(args)
+	if err != nil {
+		return "", err
+	}
+	return ref.ServicePath() + "/database/backups/", nil
+}
+
+var dbBackupsListCmd = &cobra.Command{
+	Use:     "list [ws/proj/env/svc]",
+	Short:   "List backups and the backup schedule",
+	Example: "  ancla db backups list",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := backupsPath(args)
+		if err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("GET", apiURL(path), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+
+		var result struct {
+			Schedule *struct {
+				Enabled       bool   `json:"enabled"`
+				Frequency     string `json:"frequency"`
+				RetentionDays int    `json:"retention_days"`
+				LastRun       string `json:"last_run"`
+				LastStatus    string `json:"last_status"`
+				NextRun       string `json:"next_run"`
+			} `json:"schedule"`
+			Backups []dbBackup `json:"backups"`
+		}
+		if err := decodeJSON(body, &result); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(result)
+		}
+
+		if s := result.Schedule; s != nil {
+			if s.Enabled {
+				fmt.Println(kv("Schedule", fmt.Sprintf("%s, kept %d days", s.Frequency, s.RetentionDays)))
+				if s.LastRun != "" {
+					fmt.Println(kv("Last run", s.LastRun+"  "+colorStatus(s.LastStatus)))
+				}
+				if s.NextRun != "" {
+					fmt.Println(kv("Next run", s.NextRun))
+				}
+			} else {
+				fmt.Println(kv("Schedule", stWarning.Render("disabled")))
+			}
+			fmt.Println()
+		}
+
+		var rows [][]string
+		for _, b := range result.Backups {
+			rows = append(rows, []string{b.ID, formatTime(b.Created), b.Kind, formatBytes(b.SizeBytes), colorStatus(b.Status)})
+		}
+		table([]string{"ID", "CREATED", "KIND", "SIZE", "STATUS"}, rows)
+		return nil
+	},
+}
+
+var dbBackupsCreateCmd = &cobra.Command{
+	Use:     "create [ws/proj/env/svc]",
+	Short:   "Start a manual backup",
+	Example: "  ancla db backups create",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := backupsPath(args)
+		if err != nil {
+			return err
+		}
+		stop := spin("Starting backup...")
+		req, _ := http.NewRequest("POST", apiURL(path), nil)
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		var b dbBackup
+		if err := decodeJSON(body, &b); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(b)
+		}
+		if isQuiet() {
+			fmt.Println(b.ID)
+			return nil
+		}
+		fmt.Printf("Backup started: %s (%s)\n", b.ID, b.Status)
+		return nil
+	},
+}
+
+var dbBackupsRestoreCmd = &cobra.Command{
+	Use:   "restore <backup-id> [ws/proj/env/svc]",
+	Short: "Restore the database from a backup",
+	Long: `Restore the service's database from a backup. This overwrites the
+current data, so you are asked to type the backup ID back to confirm;
+--yes skips the prompt.`,
+	Example: "  ancla db backups restore bk_123\n  ancla db backups restore bk_123 my-ws/my-proj/staging/my-svc --yes",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		path, err := backupsPath(args[1:])
+		if err != nil {
+			return err
+		}
+
+		msg := fmt.Sprintf("Restoring %s overwrites the current database.", id)
+		if err := confirmTyped(msg, id); err != nil {
+			return err
+		}
+
+		stop := spin("Starting restore...")
+		req, _ := http.NewRequest("POST", apiURL(path+url.PathEscape(id)+"/restore"), nil)
+		_, err = doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+		fmt.Println("Restore started. The database is read-only until it completes.")
+		return nil
+	},
+}
+
+var dbBackupsDownloadCmd = &cobra.Command{
+	Use:   "download <backup-id> [ws/proj/env/svc]",
+	Short: "Download a backup and verify its checksum",
+	Long: `Stream a backup to a local file. The SHA-256 of the download is checked
+against the checksum recorded by the platform, and the file is only kept
+when they match.`,
+	Example: "  ancla db backups download bk_123\n  ancla db backups download bk_123 -f prod.dump",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		path, err := backupsPath(args[1:])
+		if err != nil {
+			return err
+		}
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			file = id + ".dump"
+		}
+
+		req, _ := http.NewRequest("GET", apiURL(path+url.PathEscape(id)), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var b dbBackup
+		if err := decodeJSON(body, &b); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if err := downloadBackup(path+url.PathEscape(id)+"/download", file, b); err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(map[string]any{"id": id, "file": file, "sha256": b.SHA256})
+		}
+		if isQuiet() {
+			fmt.Println(file)
+			return nil
+		}
+		fmt.Println(stepDone(fmt.Sprintf("Saved %s (%s, sha256 verified)", file, formatBytes(b.SizeBytes))))
+		return nil
+	},
+}
+
+// downloadBackup streams a backup into a temp file next to dst, hashing it
+// on the way, and renames it into place only if the checksum matches.
+func downloadBackup(path, dst string, b dbBackup) error {
+	if b.SHA256 == "" {
+		return fmt.Errorf("backup %s has no recorded checksum — it may still be in progress", b.ID)
+	}
+
+	req, _ := http.NewRequest("GET", apiURL(path), nil)
+	resp, err := doStreamRequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".ancla-backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	prog := newTransferProgress(b.ID, b.SizeBytes)
+	_, err = io.Copy(io.MultiWriter(tmp, hash, prog), resp.Body)
+	prog.finish()
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("downloading backup: %w", err)
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(got, b.SHA256) {
+		return fmt.Errorf("checksum mismatch for %s: got %s, expected %s — download discarded", b.ID, got, b.SHA256)
+	}
+	return os.Rename(tmp.Name(), dst)
+}
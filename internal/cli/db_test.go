@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestDownloadBackup_VerifiesChecksum(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	data := []byte("PGDMP backup contents")
+	sum := sha256.Sum256(data)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.dump")
+	b := dbBackup{ID: "bk_1", SHA256: hex.EncodeToString(sum[:]), SizeBytes: int64(len(data))}
+	if err := downloadBackup("/x/download", good, b); err != nil {
+		t.Fatalf("downloadBackup() error: %v", err)
+	}
+	if got, _ := os.ReadFile(good); string(got) != string(data) {
+		t.Errorf("file content = %q", got)
+	}
+
+	bad := filepath.Join(dir, "bad.dump")
+	b.SHA256 = "00"
+	if err := downloadBackup("/x/download", bad, b); err == nil {
+		t.Error("expected checksum mismatch error")
+	}
+	if _, err := os.Stat(bad); !os.IsNotExist(err) {
+		t.Error("mismatched download was kept")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temp files left behind: %d entries", len(entries))
+	}
+}
+
+func TestDbBackupsRestoreCmd_RequiresTypedID(t *testing.T) {
+	origCfg := cfg
+	origStdin := os.Stdin
+	defer func() { cfg = origCfg; os.Stdin = origStdin }()
+
+	var restored string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			restored = r.URL.Path
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	for _, tt := range []struct {
+		typed string
+		want  string
+	}{
+		{"yes\n", ""},
+		{"bk_123\n", "/api/v1/workspaces/ws/projects/p/envs/e/services/s/database/backups/bk_123/restore"},
+	} {
+		restored = ""
+		r, w, _ := os.Pipe()
+		w.WriteString(tt.typed)
+		w.Close()
+		os.Stdin = r
+
+		err := dbBackupsRestoreCmd.RunE(dbBackupsRestoreCmd, []string{"bk_123", "ws/p/e/s"})
+		if err != nil && (tt.want != "" || !errors.Is(err, errAborted)) {
+			t.Fatalf("RunE error: %v", err)
+		}
+		if restored != tt.want {
+			t.Errorf("typed %q: restored %q, want %q", tt.typed, restored, tt.want)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -33,16 +32,16 @@ launches the appropriate client (psql, mysql).`,
 		if len(args) == 1 {
 			arg = args[0]
 		}
-		ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+		ref, err := config.ResolveServiceRef(arg, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" || proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no service specified — provide an argument or run `ancla link` first")
 		}
 
 		// Fetch database connection info from the API
-		svcPath := "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc
+		svcPath := ref.ServicePath()
 		req, _ := http.NewRequest("GET", apiURL(svcPath+"/database"), nil)
 		stop := spin("Fetching database credentials...")
 		body, err := doRequest(req)
@@ -60,7 +59,7 @@ launches the appropriate client (psql, mysql).`,
 			Password string `json:"password"`
 			URL      string `json:"url"`
 		}
-		if err := json.Unmarshal(body, &db); err != nil {
+		if err := decodeJSON(body, &db); err != nil {
 			return fmt.Errorf("parsing database info: %w", err)
 		}
 
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// snippetLen caps how much of a response body a decode error quotes.
+const snippetLen = 160
+
+// decodeError explains why an API response could not be decoded: which
+// field had the wrong type, or where the JSON broke, plus a piece of the
+// body around that point. --debug-body includes the whole body instead.
+type decodeError struct {
+	Field    string // dotted path of the mismatched field, if known
+	Expected string // Go type the CLI expected
+	Got      string // JSON type the server sent
+	Offset   int64  // byte offset of the problem, or -1
+	Snippet  string
+	Err      error
+}
+
+func (e *decodeError) Error() string {
+	var b strings.Builder
+	switch {
+	case e.Field != "":
+		fmt.Fprintf(&b, "field %q: expected %s, got %s", e.Field, e.Expected, e.Got)
+	case e.Got != "":
+		fmt.Fprintf(&b, "expected %s, got %s", e.Expected, e.Got)
+	default:
+		b.WriteString(e.Err.Error())
+	}
+	if e.Offset >= 0 {
+		fmt.Fprintf(&b, " at offset %d", e.Offset)
+	}
+	if e.Snippet != "" {
+		fmt.Fprintf(&b, "\nresponse: %s", e.Snippet)
+	}
+	return b.String()
+}
+
+func (e *decodeError) Unwrap() error { return e.Err }
+
+// decodeJSON unmarshals an API response into v. On failure it returns a
+// *decodeError naming the mismatched field and quoting the body.
+func decodeJSON(body []byte, v any) error {
+	err := json.Unmarshal(body, v)
+	if err == nil {
+		return nil
+	}
+
+	de := &decodeError{Offset: -1, Err: err}
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		de.Field = typeErr.Field
+		de.Expected = typeErr.Type.String()
+		de.Got = typeErr.Value
+		de.Offset = typeErr.Offset
+	case errors.As(err, &syntaxErr):
+		de.Offset = syntaxErr.Offset
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '<' {
+			de.Expected, de.Got = "JSON", "HTML — is --server pointing at the API?"
+			de.Offset = -1
+		}
+	}
+	de.Snippet = bodySnippet(body, de.Offset)
+	return de
+}
+
+// bodySnippet returns the body for an error message: all of it under
+// --debug-body, otherwise about snippetLen bytes around offset.
+func bodySnippet(body []byte, offset int64) string {
+	if debugBody {
+		return string(body)
+	}
+	if len(body) == 0 {
+		return "(empty body)"
+	}
+	start, end := 0, len(body)
+	if end > snippetLen {
+		if offset > snippetLen/2 {
+			start = int(offset) - snippetLen/2
+		}
+		if start+snippetLen < end {
+			end = start + snippetLen
+		}
+	}
+	s := strings.Join(strings.Fields(string(body[start:end])), " ")
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(body) {
+		s += "… (--debug-body shows all of it)"
+	}
+	return s
+}
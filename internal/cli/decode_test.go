@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON_TypeMismatch(t *testing.T) {
+	var v struct {
+		Items []struct {
+			Status string `json:"status"`
+		} `json:"items"`
+	}
+	err := decodeJSON([]byte(`{"items":[{"status":3}]}`), &v)
+	var de *decodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("expected *decodeError, got %T: %v", err, err)
+	}
+	// Newer Go versions include the array index ("items.0.status").
+	if !strings.HasPrefix(de.Field, "items.") || !strings.HasSuffix(de.Field, ".status") {
+		t.Errorf("Field = %q", de.Field)
+	}
+	if de.Expected != "string" || de.Got != "number" {
+		t.Errorf("decodeError = %+v", de)
+	}
+	if !strings.Contains(err.Error(), "expected string, got number") || !strings.Contains(err.Error(), `response: {"items"`) {
+		t.Errorf("message = %q", err.Error())
+	}
+}
+
+func TestDecodeJSON_HTML(t *testing.T) {
+	var v map[string]any
+	err := decodeJSON([]byte("<!DOCTYPE html><html><body>Login</body></html>"), &v)
+	if err == nil || !strings.Contains(err.Error(), "got HTML") {
+		t.Errorf("expected HTML hint, got %v", err)
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	origDebug := debugBody
+	defer func() { debugBody = origDebug }()
+
+	long := `{"a":"` + strings.Repeat("x", 400) + `"}`
+	debugBody = false
+	s := bodySnippet([]byte(long), 200)
+	if !strings.HasPrefix(s, "…") || !strings.Contains(s, "--debug-body") {
+		t.Errorf("snippet = %q", s)
+	}
+	if len(s) > snippetLen+60 {
+		t.Errorf("snippet too long: %d bytes", len(s))
+	}
+
+	debugBody = true
+	if got := bodySnippet([]byte(long), 200); got != long {
+		t.Errorf("--debug-body should return the whole body")
+	}
+}
@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -19,6 +19,12 @@ import (
 func init() {
 	rootCmd.AddCommand(deployActionCmd)
 	deployActionCmd.Flags().Bool("no-follow", false, "Fire and forget — don't stream build logs")
+	deployActionCmd.Flags().Bool("attach", false, "Resume following the pipeline already in progress instead of starting one")
+	deployActionCmd.Flags().StringP("selector", "l", "", "Deploy every service in the environment matching a label selector")
+	deployActionCmd.Flags().Bool("no-lint", false, "Skip the Dockerfile lint pass before the build")
+	deployActionCmd.Flags().Bool("confirm-production", false, "Confirm a deploy to a protected environment without a prompt")
+	deployActionCmd.Flags().Bool("override-freeze", false, "Deploy during a freeze window (admins only)")
+	deployActionCmd.Flags().Bool("no-github-status", false, "Don't post the deploy status to the commit on GitHub")
 	// Suppress cobra usage dump on RunE errors — deploy errors are handled
 	// with styled error cards, not usage text.
 	deployActionCmd.SilenceUsage = true
@@ -36,14 +42,45 @@ interactively. For Python projects it can also scaffold a Dockerfile.
 
 Once linked, subsequent runs skip straight to the deploy.
 
-Use --no-follow to trigger the deploy without streaming build logs.`,
-	Example: "  ancla deploy\n  ancla deploy my-ws/my-proj/staging/my-svc\n  ancla deploy --no-follow",
-	GroupID: "workflow",
-	Args:    cobra.MaximumNArgs(1),
-	RunE:    runDeploy,
+Use --no-follow to trigger the deploy without streaming build logs, and
+--attach to pick up a build or deploy that is already running — for
+example after your terminal closed mid-deploy.
+
+With --selector (-l), deploy triggers every service in the environment
+whose labels match, after a confirmation, and reports one line per
+service without following the pipelines. The argument, if any, is then
+the environment: <ws>/<proj>/<env>.
+
+Before a Dockerfile build, the Dockerfile in the current directory is
+linted for an unpinned base image, copying the whole context before
+installing dependencies, a missing EXPOSE, and running as root. Findings
+are shown in the deploy card as warnings and never block the deploy;
+--no-lint skips the pass.
+
+Deploys to a protected environment (see ` + "`ancla envs protect`" + `) must be
+confirmed with --confirm-production or by typing the environment name,
+and wait for approval when the environment requires reviewers. During a
+freeze window (see ` + "`ancla freeze`" + `) deploys are refused unless an
+admin passes --override-freeze.
+
+With a github_token setting (or ANCLA_GITHUB_TOKEN), the deploy's progress
+is posted as a commit status on the checked-out commit, so pull requests
+show it; --no-github-status skips that for one deploy.`,
+	Example:     "  ancla deploy\n  ancla deploy my-ws/my-proj/staging/my-svc\n  ancla deploy --no-follow\n  ancla deploy --attach\n  ancla deploy -l team=payments",
+	GroupID:     "workflow",
+	Annotations: map[string]string{expandAnnotation: "true"},
+	Args:        cobra.MaximumNArgs(1),
+	RunE:        runDeploy,
 }
 
 func runDeploy(cmd *cobra.Command, args []string) error {
+	if attach, _ := cmd.Flags().GetBool("attach"); attach {
+		return attachDeploy(args)
+	}
+	if expr, _ := cmd.Flags().GetString("selector"); expr != "" {
+		return deploySelected(cmd, args, expr)
+	}
+
 	// If an explicit path was given, skip the wizard entirely.
 	if len(args) > 0 {
 		return deployDirect(cmd, args)
@@ -52,57 +89,61 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 	// --- Preflight ensure chain ---
 	changed := false
 
-	ws, proj, env, svc := cfg.Workspace, cfg.Project, cfg.Env, cfg.Service
-
-	var err error
+	// Outside a linked directory, the global defaults seed the chain.
+	ref, err := config.ResolveServiceRef("", cfg)
+	if err != nil {
+		return err
+	}
 
 	// 1. Ensure logged in
-	if err = ensureLoggedIn(); err != nil {
+	p := newProvisioner()
+	if err = p.ensureLoggedIn(); err != nil {
 		return err
 	}
+	p.verify(ref)
 
 	// 2. Ensure workspace
-	ws, err = ensureWorkspace(ws)
+	ref.Workspace, err = p.ensureWorkspace(ref.Workspace)
 	if err != nil {
 		return err
 	}
-	if ws != cfg.Workspace {
-		cfg.Workspace = ws
+	if ref.Workspace != cfg.Workspace {
+		cfg.Workspace = ref.Workspace
 		changed = true
 	}
 
 	// 3. Ensure project
-	proj, err = ensureProject(ws, proj)
+	ref.Project, err = p.ensureProject(ref.Workspace, ref.Project)
 	if err != nil {
 		return err
 	}
-	if proj != cfg.Project {
-		cfg.Project = proj
+	if ref.Project != cfg.Project {
+		cfg.Project = ref.Project
 		changed = true
 	}
 
 	// 4. Ensure environment
-	env, err = ensureEnv(ws, proj, env)
+	ref.Env, err = p.ensureEnv(ref.Workspace, ref.Project, ref.Env)
 	if err != nil {
 		return err
 	}
-	if env != cfg.Env {
-		cfg.Env = env
+	if ref.Env != cfg.Env {
+		cfg.Env = ref.Env
 		changed = true
 	}
 
 	// 5. Ensure service
-	svc, err = ensureService(ws, proj, env, svc)
+	ref.Service, err = p.ensureService(ref.Workspace, ref.Project, ref.Env, ref.Service)
 	if err != nil {
 		return err
 	}
-	if svc != cfg.Service {
-		cfg.Service = svc
+	if ref.Service != cfg.Service {
+		cfg.Service = ref.Service
 		changed = true
 	}
 
 	// 6. Ensure Dockerfile (skip for buildpack services)
-	strategy := fetchServiceBuildStrategy(ws, proj, env, svc)
+	strategy := fetchServiceBuildStrategy(ref.Workspace, ref.Project, ref.Env, ref.Service)
 	if strategy != "buildpack" {
 		if err = ensureDockerfile(); err != nil {
 			return err
@@ -111,11 +152,11 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 
 	// 7. Save link context if anything changed
 	if changed {
-		cfg.Workspace = ws
-		cfg.Project = proj
-		cfg.Env = env
-		cfg.Service = svc
-		if err := config.SaveLocal(cfg); err != nil {
+		cfg.Workspace = ref.Workspace
+		cfg.Project = ref.Project
+		cfg.Env = ref.Env
+		cfg.Service = ref.Service
+		if err := config.SaveLocal(cfg, config.LinkKeys...); err != nil {
 			return fmt.Errorf("saving link context: %w", err)
 		}
 	}
@@ -124,51 +165,75 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		if changed {
 			fmt.Println(stDim.Render("  Linked → saved to .ancla/config.yaml"))
 		}
-		renderDeployCard(ws, proj, env, svc, strategy)
+		renderDeployCard(ref.Workspace, ref.Project, ref.Env, ref.Service, strategy, deployLintWarnings(cmd, strategy))
 	}
 
 	// --- Existing deploy logic ---
-	return triggerAndFollow(cmd, ws, proj, env, svc)
+	return triggerAndFollow(cmd, ref.Workspace, ref.Project, ref.Env, ref.Service)
 }
 
 // deployDirect handles the case where the user gave an explicit ws/proj/env/svc argument.
 func deployDirect(cmd *cobra.Command, args []string) error {
-	ws, proj, env, svc, err := resolveServicePath(args)
+	ref, err := resolveServiceRef(args)
 	if err != nil {
 		return err
 	}
-	if proj == "" || env == "" || svc == "" {
+	if !ref.HasService() {
 		return fmt.Errorf("all four segments required: <ws>/<proj>/<env>/<svc>")
 	}
 
 	if !isQuiet() {
-		strategy := fetchServiceBuildStrategy(ws, proj, env, svc)
-		renderDeployCard(ws, proj, env, svc, strategy)
+		strategy := fetchServiceBuildStrategy(ref.Workspace, ref.Project, ref.Env, ref.Service)
+		// The local Dockerfile only says something about the linked service.
+		var warnings []string
+		if cfg.Service == ref.Service && cfg.Env == ref.Env && cfg.Project == ref.Project && cfg.Workspace == ref.Workspace {
+			warnings = deployLintWarnings(cmd, strategy)
+		}
+		renderDeployCard(ref.Workspace, ref.Project, ref.Env, ref.Service, strategy, warnings)
 	}
 
-	return triggerAndFollow(cmd, ws, proj, env, svc)
+	return triggerAndFollow(cmd, ref.Workspace, ref.Project, ref.Env, ref.Service)
 }
 
 // triggerAndFollow POSTs the deploy and polls builds/deploys until complete.
 func triggerAndFollow(cmd *cobra.Command, ws, proj, env, svc string) error {
+	ref := config.ServiceRef{Workspace: ws, Project: proj, Env: env, Service: svc}
+	if err := preflightRequiredConfig(ref); err != nil {
+		return err
+	}
+	guard, err := guardDeploy(cmd, ref)
+	if err != nil {
+		return err
+	}
+
+	gh := newGitHubStatus(cmd, ref)
+
 	stop := spin("Triggering deploy...")
-	req, _ := http.NewRequest("POST", apiURL(servicePath(ws, proj, env, svc)+"/deploy"), nil)
-	body, err := doRequest(req)
+	body, err := doRequest(newDeployRequest(ref, guard, nil))
 	stop()
 	if err != nil {
 		return err
 	}
 
-	// Parse whatever the server returns — field names vary.
-	var result map[string]any
-	if err := json.Unmarshal(body, &result); err != nil {
+	result, err := decodeDeployResponse(body)
+	if err != nil {
 		fmt.Println("Deploy triggered, but the response could not be parsed.")
 		return nil
 	}
 
+	status := result.Status
+	if status == "pending_approval" {
+		gh.post("pending", "Waiting for approval to deploy to "+env)
+	} else {
+		gh.post("pending", "Deploying to "+env)
+	}
+
 	if isJSON() {
 		return printJSON(result)
 	}
+	if pendingApproval(status, guard) {
+		return nil
+	}
 
 	noFollow, _ := cmd.Flags().GetBool("no-follow")
 	if noFollow {
@@ -176,8 +241,48 @@ func triggerAndFollow(cmd *cobra.Command, ws, proj, env, svc string) error {
 		return nil
 	}
 
-	// Poll builds list + deploys list to track the pipeline.
-	return followPipeline(ws, proj, env, svc)
+	// Poll the pipeline status for exactly the build/deploy just started.
+	err = followPipeline(ws, proj, env, svc, result.pipelineIDs)
+	gh.finish(err)
+	return err
+}
+
+// pipelineIDs identifies the pipeline run a deploy trigger started. Either
+// field may be empty when the server does not report it.
+type pipelineIDs struct {
+	Build  string
+	Deploy string
+}
+
+// pipelineStage is one stage of the pipeline status response.
+type pipelineStage struct {
+	ID          string  `json:"id"`
+	BuildID     string  `json:"build_id"`
+	Version     int     `json:"version"`
+	Status      string  `json:"status"`
+	ErrorDetail *string `json:"error_detail"`
+}
+
+// stageDetail returns the stage's error detail, if any.
+func stageDetail(s *pipelineStage) string {
+	if s.ErrorDetail == nil {
+		return ""
+	}
+	return *s.ErrorDetail
+}
+
+// renderPipelineError shows a pipeline failure as an error card, except
+// under --progress json where the failure event already carries it.
+func renderPipelineError(e *pipelineError) {
+	if progressMode != progressJSON {
+		renderErrorCard(e)
+	}
+}
+
+// pipelineStatus is the pipeline status response for one service.
+type pipelineStatus struct {
+	Build  *pipelineStage `json:"build"`
+	Deploy *pipelineStage `json:"deploy"`
 }
 
 // pipelineStatusPath returns the project-level pipeline status URL with
@@ -186,50 +291,88 @@ func pipelineStatusPath(ws, proj, env, svc string) string {
 	return fmt.Sprintf("/workspaces/%s/projects/%s/pipeline/status?service=%s&env=%s", ws, proj, svc, env)
 }
 
+// pipelineStatusPathFor narrows the pipeline status to a specific run.
+// Servers that don't support the filters ignore them; followPipeline
+// still checks the returned IDs.
+func pipelineStatusPathFor(ws, proj, env, svc string, ids pipelineIDs) string {
+	path := pipelineStatusPath(ws, proj, env, svc)
+	if ids.Build != "" {
+		path += "&build_id=" + url.QueryEscape(ids.Build)
+	}
+	if ids.Deploy != "" {
+		path += "&deploy_id=" + url.QueryEscape(ids.Deploy)
+	}
+	return path
+}
+
 // followPipeline polls the pipeline status endpoint until both the build
 // and deploy phases complete (or one errors).
 //
-// Important: the deploy stage is only evaluated AFTER the build completes,
-// because until a new deploy record is created (which happens post-build),
-// the pipeline returns the previous deploy's status — which may be "success".
-func followPipeline(ws, proj, env, svc string) error {
-	type stageStatus struct {
-		Status      string  `json:"status"`
-		ErrorDetail *string `json:"error_detail"`
-	}
-
+// When ids carries the build/deploy started by the trigger, stages whose
+// IDs don't match are treated as not-yet-visible rather than as this run's
+// result. Without IDs it falls back to ordering: the deploy stage is only
+// evaluated AFTER the build completes, because until a new deploy record
+// is created (which happens post-build), the pipeline returns the previous
+// deploy's status — which may be "success".
+func followPipeline(ws, proj, env, svc string, ids pipelineIDs) error {
 	buildDone := false
 	prevBuildStatus := ""
 	prevDeployStatus := ""
 	stop := spin("Building...")
 	defer stop()
 
+	p := newPoller()
 	for first := true; ; first = false {
 		if !first {
-			time.Sleep(3 * time.Second)
+			if err := p.wait("pipeline"); err != nil {
+				stop()
+				reportProgress("pipeline", "", "timeout", err.Error())
+				renderPipelineError(&pipelineError{
+					Kind:      errTimeout,
+					Detail:    err.Error(),
+					Workspace: ws, Project: proj, Env: env, Service: svc,
+				})
+				return err
+			}
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(pipelineStatusPath(ws, proj, env, svc)), nil)
+		req, _ := http.NewRequest("GET", apiURL(pipelineStatusPathFor(ws, proj, env, svc, ids)), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
 		}
 
-		var status struct {
-			Build  *stageStatus `json:"build"`
-			Deploy *stageStatus `json:"deploy"`
-		}
-		if err := json.Unmarshal(body, &status); err != nil {
+		var status pipelineStatus
+		if err := decodeJSON(body, &status); err != nil {
 			return fmt.Errorf("parsing pipeline status: %w", err)
 		}
 
+		// Drop stages that belong to another run.
+		if b := status.Build; b != nil && ids.Build != "" && b.ID != "" && b.ID != ids.Build {
+			status.Build = nil
+		}
+		if d := status.Deploy; d != nil {
+			switch {
+			case ids.Deploy != "" && d.ID != "" && d.ID != ids.Deploy:
+				status.Deploy = nil
+			case ids.Deploy == "" && ids.Build != "" && d.BuildID != "" && d.BuildID != ids.Build:
+				status.Deploy = nil
+			case ids.Deploy == "" && ids.Build != "" && d.BuildID == ids.Build && d.ID != "":
+				// First deploy of our build — latch onto it.
+				ids.Deploy = d.ID
+			}
+		}
+
 		// Track build phase.
 		if !buildDone && status.Build != nil && status.Build.Status != prevBuildStatus {
 			prevBuildStatus = status.Build.Status
+			reportProgress("build", status.Build.ID, status.Build.Status, stageDetail(status.Build))
 			switch status.Build.Status {
 			case "success":
 				stop()
-				fmt.Println(stepDone("Build complete"))
+				if !progressLines() {
+					fmt.Println(stepDone("Build complete"))
+				}
 				buildDone = true
 				// Reset deploy tracking — ignore any stale deploy status
 				// from before this build. The new deploy will appear shortly.
@@ -241,10 +384,8 @@ func followPipeline(ws, proj, env, svc string) error {
 					Kind:      errBuild,
 					Workspace: ws, Project: proj, Env: env, Service: svc,
 				}
-				if status.Build.ErrorDetail != nil {
-					pe.Detail = *status.Build.ErrorDetail
-				}
-				renderErrorCard(pe)
+				pe.Detail = stageDetail(status.Build)
+				renderPipelineError(pe)
 				return fmt.Errorf("build failed")
 			}
 		}
@@ -252,11 +393,14 @@ func followPipeline(ws, proj, env, svc string) error {
 		// Track deploy phase — only after build is done.
 		if buildDone && status.Deploy != nil && status.Deploy.Status != prevDeployStatus {
 			prevDeployStatus = status.Deploy.Status
+			reportProgress("deploy", status.Deploy.ID, status.Deploy.Status, stageDetail(status.Deploy))
 			switch status.Deploy.Status {
 			case "success":
 				stop()
-				fmt.Println(stepDone("Deploy complete"))
-				fmt.Println("\n" + stSuccess.Render(symCheck+" Deploy pipeline complete."))
+				if !progressLines() {
+					fmt.Println(stepDone("Deploy complete"))
+					fmt.Println("\n" + stSuccess.Render(symCheck+" Deploy pipeline complete."))
+				}
 				return nil
 			case "error":
 				stop()
@@ -264,385 +408,36 @@ func followPipeline(ws, proj, env, svc string) error {
 					Kind:      errDeploy,
 					Workspace: ws, Project: proj, Env: env, Service: svc,
 				}
-				if status.Deploy.ErrorDetail != nil {
-					pe.Detail = *status.Deploy.ErrorDetail
-				}
-				renderErrorCard(pe)
+				pe.Detail = stageDetail(status.Deploy)
+				renderPipelineError(pe)
 				return fmt.Errorf("deploy failed")
 			}
 		}
 	}
 }
 
-// --- Preflight ensure steps ---
-
-// ensureLoggedIn checks that we have a valid API key. If not, triggers the
-// browser login flow.
-func ensureLoggedIn() error {
-	if cfg.APIKey != "" {
-		req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
-		if _, err := doRequest(req); err == nil {
-			return nil
-		}
-		if !isQuiet() {
-			fmt.Println(stepActive("API key is invalid or expired."))
-		}
-	} else if !isQuiet() {
-		fmt.Println(stepActive("Not logged in."))
-	}
-
-	if !isQuiet() {
-		fmt.Println(stDim.Render("  Opening browser to log in..."))
-	}
-	if err := loginBrowser(); err != nil {
-		return fmt.Errorf("login failed: %w", err)
-	}
-	fmt.Println(stepDone("Logged in"))
-	return nil
-}
-
-// ensureWorkspace ensures a workspace is selected. Returns the workspace slug.
-func ensureWorkspace(current string) (string, error) {
-	if current != "" {
-		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+current+"/"), nil)
-		if _, err := doRequest(req); err == nil {
-			return current, nil
-		}
-		if !isQuiet() {
-			fmt.Println(stepActive(fmt.Sprintf("Workspace %q not found, re-selecting...", current)))
-		}
-	}
-
-	req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("fetching workspaces: %w", err)
-	}
-
-	var workspaces []struct {
-		Name     string `json:"name"`
-		Slug     string `json:"slug"`
-		Personal bool   `json:"personal"`
-	}
-	if err := json.Unmarshal(body, &workspaces); err != nil {
-		return "", fmt.Errorf("parsing workspaces: %w", err)
-	}
-
-	switch len(workspaces) {
-	case 0:
-		if !isQuiet() {
-			fmt.Println(stepActive("No workspaces found. Creating a personal workspace..."))
-		}
-		name := cfg.Username
-		if name == "" {
-			name = "personal"
-		}
-		return createWorkspace(name+"'s workspace", true)
-
-	case 1:
-		ws := workspaces[0]
-		if !isQuiet() {
-			fmt.Println(stepDone("Workspace: " + stAccent.Render(ws.Slug)))
-		}
-		return ws.Slug, nil
-
-	default:
-		items := make([]promptItem, len(workspaces))
-		for i, w := range workspaces {
-			items[i] = promptItem{Slug: w.Slug, Name: w.Name}
-		}
-		slug, existing, err := promptSelectOrCreate("Select a workspace:", items, "Create new workspace")
-		if err != nil {
-			return "", err
-		}
-		if existing {
-			fmt.Println(stepDone("Workspace: " + stAccent.Render(slug)))
-			return slug, nil
-		}
-		name, err := promptInput("  Workspace name", "")
-		if err != nil {
-			return "", err
-		}
-		if name == "" {
-			return "", fmt.Errorf("workspace name is required")
-		}
-		return createWorkspace(name, false)
-	}
+// createdService is the subset of the create-service response the CLI uses.
+type createdService struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Platform string `json:"platform"`
 }
 
-func createWorkspace(name string, personal bool) (string, error) {
-	payload, _ := json.Marshal(map[string]any{
-		"name":     name,
-		"personal": personal,
-	})
-	req, _ := http.NewRequest("POST", apiURL("/workspaces/"), bytes.NewReader(payload))
-	req.Header.Set("Content-Type", "application/json")
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("creating workspace: %w", err)
-	}
-	var ws struct {
-		Slug string `json:"slug"`
-		Name string `json:"name"`
-	}
-	if err := json.Unmarshal(body, &ws); err != nil {
-		return "", fmt.Errorf("parsing workspace response: %w", err)
-	}
-	fmt.Println(stepDone("Workspace: " + stAccent.Render(ws.Slug)))
-	return ws.Slug, nil
-}
-
-// ensureProject ensures a project is selected within the workspace.
-func ensureProject(ws, current string) (string, error) {
-	if current != "" {
-		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/projects/"+current+"/"), nil)
-		if _, err := doRequest(req); err == nil {
-			return current, nil
-		}
-		if !isQuiet() {
-			fmt.Println(stepActive(fmt.Sprintf("Project %q not found, re-selecting...", current)))
-		}
-	}
-
-	req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/projects/"), nil)
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("fetching projects: %w", err)
-	}
-
-	var projects []struct {
-		Name string `json:"name"`
-		Slug string `json:"slug"`
-	}
-	if err := json.Unmarshal(body, &projects); err != nil {
-		return "", fmt.Errorf("parsing projects: %w", err)
-	}
-
-	items := make([]promptItem, len(projects))
-	for i, p := range projects {
-		items[i] = promptItem{Slug: p.Slug, Name: p.Name}
-	}
-	slug, action, err := promptSelectCreateSkip("Select a project:", items, "Create new project", "Link to workspace only")
-	if err != nil {
-		return "", err
-	}
-	switch action {
-	case "existing":
-		fmt.Println(stepDone("Project: " + stAccent.Render(slug)))
-		return slug, nil
-	case "skip":
-		return "", nil
-	}
-
-	defaultName := currentDirName()
-	name, err := promptInput("  Project name", defaultName)
-	if err != nil {
-		return "", err
-	}
-	if name == "" {
-		return "", fmt.Errorf("project name is required")
-	}
-
-	return createProject(ws, name)
-}
-
-func createProject(ws, name string) (string, error) {
-	slug := slugify(name)
-	payload, _ := json.Marshal(map[string]any{
-		"name": name,
-		"slug": slug,
-	})
-	req, _ := http.NewRequest("POST", apiURL("/workspaces/"+ws+"/projects/"), bytes.NewReader(payload))
-	req.Header.Set("Content-Type", "application/json")
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("creating project: %w", err)
-	}
-	var proj struct {
-		Slug string `json:"slug"`
-		Name string `json:"name"`
-	}
-	if err := json.Unmarshal(body, &proj); err != nil {
-		return "", fmt.Errorf("parsing project response: %w", err)
-	}
-	fmt.Println(stepDone("Created project " + stAccent.Render(proj.Name) + stDim.Render(" (environments: production, staging, development)")))
-	return proj.Slug, nil
-}
-
-// ensureEnv ensures an environment is selected within the project.
-func ensureEnv(ws, proj, current string) (string, error) {
-	if current != "" {
-		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+current+"/"), nil)
-		if _, err := doRequest(req); err == nil {
-			return current, nil
-		}
-		if !isQuiet() {
-			fmt.Println(stepActive(fmt.Sprintf("Environment %q not found, re-selecting...", current)))
-		}
-	}
-
-	req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"), nil)
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("fetching environments: %w", err)
-	}
-
-	var envs []struct {
-		Name string `json:"name"`
-		Slug string `json:"slug"`
-	}
-	if err := json.Unmarshal(body, &envs); err != nil {
-		return "", fmt.Errorf("parsing environments: %w", err)
-	}
-
-	items := make([]promptItem, len(envs))
-	for i, e := range envs {
-		items[i] = promptItem{Slug: e.Slug, Name: e.Name}
-	}
-	slug, action, err := promptSelectCreateSkip("Select an environment:", items, "Create new environment", "Link to project only")
-	if err != nil {
-		return "", err
-	}
-	switch action {
-	case "existing":
-		fmt.Println(stepDone("Environment: " + stAccent.Render(slug)))
-		return slug, nil
-	case "skip":
-		return "", nil
-	}
-
-	// Create new environment
-	name, err := promptInput("  Environment name", "production")
-	if err != nil {
-		return "", err
-	}
-	if name == "" {
-		return "", fmt.Errorf("environment name is required")
-	}
-
-	return createEnv(ws, proj, name)
-}
-
-// createEnv creates a new environment via the API and returns its slug.
-func createEnv(ws, proj, name string) (string, error) {
-	payload, _ := json.Marshal(map[string]string{"name": name})
-	req, _ := http.NewRequest("POST", apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"), bytes.NewReader(payload))
-	req.Header.Set("Content-Type", "application/json")
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("creating environment: %w", err)
-	}
-	var e struct {
-		Slug string `json:"slug"`
-		Name string `json:"name"`
-	}
-	if err := json.Unmarshal(body, &e); err != nil {
-		return "", fmt.Errorf("parsing environment response: %w", err)
-	}
-	fmt.Println(stepDone("Created environment " + stAccent.Render(e.Name)))
-	return e.Slug, nil
-}
-
-// ensureService ensures a service is selected within the environment.
-func ensureService(ws, proj, env, current string) (string, error) {
-	if current != "" {
-		req, _ := http.NewRequest("GET", apiURL(servicePath(ws, proj, env, current)), nil)
-		if _, err := doRequest(req); err == nil {
-			return current, nil
-		}
-		if !isQuiet() {
-			fmt.Println(stepActive(fmt.Sprintf("Service %q not found, re-selecting...", current)))
-		}
-	}
-
-	basePath := serviceBasePath(ws, proj, env)
-	req, _ := http.NewRequest("GET", apiURL(basePath), nil)
-	body, err := doRequest(req)
-	if err != nil {
-		return "", fmt.Errorf("fetching services: %w", err)
-	}
-
-	var services []struct {
-		Name string `json:"name"`
-		Slug string `json:"slug"`
-	}
-	if err := json.Unmarshal(body, &services); err != nil {
-		return "", fmt.Errorf("parsing services: %w", err)
-	}
-
-	items := make([]promptItem, len(services))
-	for i, s := range services {
-		items[i] = promptItem{Slug: s.Slug, Name: s.Name}
-	}
-	slug, action, err := promptSelectCreateSkip("Select a service:", items, "Create new service", "Link to environment only")
-	if err != nil {
-		return "", err
-	}
-	switch action {
-	case "existing":
-		fmt.Println(stepDone("Service: " + stAccent.Render(slug)))
-		return slug, nil
-	case "skip":
-		return "", nil
-	}
-
-	// Create new service
-	defaultName := cfg.Project
-	if defaultName == "" {
-		defaultName = currentDirName()
-	}
-	name, err := promptInput("  Service name", defaultName)
-	if err != nil {
-		return "", err
-	}
-	if name == "" {
-		return "", fmt.Errorf("service name is required")
-	}
-
-	return createService(ws, proj, env, name)
-}
-
-// createService creates a new service via the API and returns its slug.
-func createService(ws, proj, env, name string) (string, error) {
-	slug := slugify(name)
-	payload := map[string]any{
-		"name":     name,
-		"slug":     slug,
-		"platform": "wind",
-	}
-
-	// Try to detect GitHub repo
-	if repo := detectGitHubRepo(); repo != "" {
-		payload["github_repository"] = repo
-	}
-
-	// Ask for build strategy
-	strategyItems := []promptItem{
-		{Slug: "dockerfile", Name: "Dockerfile — build from your Dockerfile"},
-		{Slug: "buildpack", Name: "Buildpack — automatic detection, no Dockerfile required"},
-	}
-	strategy, err := promptSelect("  Build strategy:", strategyItems, "dockerfile")
-	if err == nil && strategy != "" {
-		payload["build_strategy"] = strategy
-	}
-
+// postService creates a service in the environment from payload.
+func postService(ws, proj, env string, payload map[string]any) (*createdService, error) {
 	data, _ := json.Marshal(payload)
-	basePath := serviceBasePath(ws, proj, env)
-	req, _ := http.NewRequest("POST", apiURL(basePath), bytes.NewReader(data))
+	req, _ := http.NewRequest("POST", apiURL(serviceBasePath(ws, proj, env)), bytes.NewReader(data))
 	req.Header.Set("Content-Type", "application/json")
 	body, err := doRequest(req)
 	if err != nil {
-		return "", fmt.Errorf("creating service: %w", err)
-	}
-	var svc struct {
-		Slug string `json:"slug"`
-		Name string `json:"name"`
+		return nil, fmt.Errorf("creating service: %w", err)
 	}
-	if err := json.Unmarshal(body, &svc); err != nil {
-		return "", fmt.Errorf("parsing service response: %w", err)
+	var svc createdService
+	if err := decodeJSON(body, &svc); err != nil {
+		return nil, fmt.Errorf("parsing service response: %w", err)
 	}
-	fmt.Println(stepDone("Created service " + stAccent.Render(svc.Name)))
-	return svc.Slug, nil
+	return &svc, nil
 }
 
 // fetchServiceBuildStrategy fetches the build_strategy for a service.
@@ -656,7 +451,7 @@ func fetchServiceBuildStrategy(ws, proj, env, svc string) string {
 	var detail struct {
 		BuildStrategy *string `json:"build_strategy"`
 	}
-	if err := json.Unmarshal(body, &detail); err != nil {
+	if err := decodeJSON(body, &detail); err != nil {
 		return ""
 	}
 	if detail.BuildStrategy == nil || *detail.BuildStrategy == "" {
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestDecodeDeployResponse(t *testing.T) {
+	// One fixture per format a server has replied to a deploy in.
+	tests := []struct {
+		fixture string
+		format  string
+		want    pipelineIDs
+		status  string
+	}{
+		{"legacy-id.json", deployFormatLegacy, pipelineIDs{Build: "3f1c9b2e-build"}, ""},
+		{"legacy-build-id.json", deployFormatLegacy, pipelineIDs{Build: "3f1c9b2e-build"}, "queued"},
+		{"flat.json", deployFormatFlat, pipelineIDs{Build: "3f1c9b2e-build", Deploy: "7a8d0c41-deploy"}, "queued"},
+		{"flat-pending-approval.json", deployFormatFlat, pipelineIDs{Deploy: "7a8d0c41-deploy"}, "pending_approval"},
+		{"camel.json", deployFormatCamel, pipelineIDs{Build: "3f1c9b2e-build", Deploy: "7a8d0c41-deploy"}, "queued"},
+		{"envelope.json", deployFormatEnvelope, pipelineIDs{Build: "3f1c9b2e-build", Deploy: "7a8d0c41-deploy"}, "queued"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", "deploy_responses", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := decodeDeployResponse(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Format != tt.format || got.pipelineIDs != tt.want || got.Status != tt.status {
+				t.Errorf("decoded %s %+v status %q, want %s %+v status %q", got.Format, got.pipelineIDs, got.Status, tt.format, tt.want, tt.status)
+			}
+
+			// --json prints the reply as it came.
+			out, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want bytes.Buffer
+			json.Compact(&want, body)
+			if string(out) != want.String() {
+				t.Errorf("marshaled %s, want %s", out, want.String())
+			}
+		})
+	}
+}
+
+func TestDecodeDeployResponseErrors(t *testing.T) {
+	for _, body := range []string{`{"build_id":7}`, `{"deploy":{"id":7}}`, `<html>`, `[]`} {
+		if _, err := decodeDeployResponse([]byte(body)); err == nil {
+			t.Errorf("decodeDeployResponse(%s) succeeded, want an error", body)
+		}
+	}
+}
+
+func TestFollowPipeline_IgnoresStaleDeploy(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	// The previous run's deploy reports success until ours shows up on
+	// the third poll.
+	responses := []string{
+		`{"build":{"id":"b-new","status":"building"},"deploy":{"id":"d-old","build_id":"b-old","status":"success"}}`,
+		`{"build":{"id":"b-new","status":"success"},"deploy":{"id":"d-old","build_id":"b-old","status":"success"}}`,
+		`{"build":{"id":"b-new","status":"success"},"deploy":{"id":"d-new","build_id":"b-new","status":"deploying"}}`,
+		`{"build":{"id":"b-new","status":"success"},"deploy":{"id":"d-new","build_id":"b-new","status":"success"}}`,
+	}
+	polls := 0
+	var gotBuildIDs []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBuildIDs = append(gotBuildIDs, r.URL.Query().Get("build_id"))
+		w.Write([]byte(responses[min(polls, len(responses)-1)]))
+		polls++
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, PollInterval: "1ms"}
+
+	if err := followPipeline("ws", "proj", "staging", "svc", pipelineIDs{Build: "b-new"}); err != nil {
+		t.Fatalf("followPipeline() error: %v", err)
+	}
+	if polls != len(responses) {
+		t.Errorf("polls = %d, want %d (stopped on a stale deploy?)", polls, len(responses))
+	}
+	for _, id := range gotBuildIDs {
+		if id != "b-new" {
+			t.Errorf("build_id filter = %q, want b-new", id)
+		}
+	}
+}
+
+func TestAttachDeploy_ReplaysLogAndFollows(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var paths []string
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/workspaces/ws/projects/proj/envs/staging/services/svc/builds/7/log":
+			w.Write([]byte(`{"status":"building","log_text":"Step 1/3\n"}`))
+		case "/api/v1/workspaces/ws/projects/proj/pipeline/status":
+			polls++
+			if polls == 1 {
+				w.Write([]byte(`{"build":{"id":"b7","version":7,"status":"building"},"deploy":{"id":"d6","build_id":"b6","status":"success"}}`))
+				return
+			}
+			if r.URL.Query().Get("build_id") != "b7" {
+				t.Errorf("follow build_id = %q, want b7", r.URL.Query().Get("build_id"))
+			}
+			w.Write([]byte(`{"build":{"id":"b7","status":"success"},"deploy":{"id":"d7","build_id":"b7","status":"success"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, PollInterval: "1ms"}
+
+	if err := attachDeploy([]string{"ws/proj/staging/svc"}); err != nil {
+		t.Fatalf("attachDeploy() error: %v", err)
+	}
+	if len(paths) < 2 || paths[1] != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/svc/builds/7/log" {
+		t.Errorf("requests = %v, want the build log replayed after the status lookup", paths)
+	}
+}
+
+func TestAttachDeploy_NothingRunning(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"build":{"id":"b7","status":"success"},"deploy":{"id":"d7","build_id":"b7","status":"success"}}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	err := attachDeploy([]string{"ws/proj/staging/svc"})
+	if err == nil || !strings.Contains(err.Error(), "no build or deploy in progress") {
+		t.Errorf("attachDeploy() error = %v, want nothing in progress", err)
+	}
+}
+
+func TestDeployCassette_Linked(t *testing.T) {
+	ts := cassetteServer(t, "deploy-linked")
+	out, err := runCLI(t, cliRun{
+		server: ts.URL,
+		link:   "workspace: acme\nproject: shop\nenv: production\nservice: web\n",
+	}, "deploy", "--non-interactive", "--no-github-status", "--poll-interval", "10ms")
+	if err != nil {
+		t.Fatalf("deploy: %v\n%s", err, out)
+	}
+	for _, want := range []string{"acme / shop / production / web", "Build complete", "Deploy complete", "Deploy pipeline complete"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// pipelineDone reports whether a stage status is final.
+func pipelineDone(status string) bool {
+	switch status {
+	case "", "success", "complete", "error", "failed", "cancelled":
+		return true
+	}
+	return false
+}
+
+// activePipeline picks the run to attach to from a pipeline status: a
+// running build, or else a running deploy. ok is false when neither stage
+// is in progress.
+func activePipeline(status pipelineStatus) (ids pipelineIDs, stage *pipelineStage, ok bool) {
+	if b := status.Build; b != nil && !pipelineDone(b.Status) {
+		return pipelineIDs{Build: b.ID}, b, true
+	}
+	if d := status.Deploy; d != nil && !pipelineDone(d.Status) {
+		return pipelineIDs{Build: d.BuildID, Deploy: d.ID}, d, true
+	}
+	return pipelineIDs{}, nil, false
+}
+
+// attachDeploy finds the pipeline in progress for the service, replays the
+// log of its current stage, and resumes following it like `ancla deploy`.
+func attachDeploy(args []string) error {
+	ref, err := resolveService(args)
+	if err != nil {
+		return err
+	}
+
+	req, _ := http.NewRequest("GET", apiURL(pipelineStatusPath(ref.Workspace, ref.Project, ref.Env, ref.Service)), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return err
+	}
+	var status pipelineStatus
+	if err := decodeJSON(body, &status); err != nil {
+		return fmt.Errorf("parsing pipeline status: %w", err)
+	}
+	ids, stage, ok := activePipeline(status)
+	if !ok {
+		return fmt.Errorf("no build or deploy in progress for %s/%s/%s/%s", ref.Workspace, ref.Project, ref.Env, ref.Service)
+	}
+
+	if isJSON() {
+		return printJSON(map[string]any{
+			"build_id":  ids.Build,
+			"deploy_id": ids.Deploy,
+			"status":    stage.Status,
+		})
+	}
+
+	if ids.Deploy == "" {
+		if !progressLines() {
+			fmt.Println(stDim.Render("  Attaching to build " + ids.Build + " (" + stage.Status + ")"))
+		}
+		sp := ref.ServicePath()
+		version := strconv.Itoa(stage.Version)
+		if stage.Version == 0 {
+			// The running build is the newest one.
+			if version, err = latestBuildVersion(sp); err != nil {
+				return err
+			}
+		}
+		replayLog("build", ids.Build, sp+"/builds/"+version+"/log")
+	} else {
+		if !progressLines() {
+			fmt.Println(stDim.Render("  Attaching to deploy " + ids.Deploy + " (" + stage.Status + ")"))
+		}
+		replayLog("deploy", ids.Deploy, ref.EnvPath()+"/deploys/"+ids.Deploy+"/log")
+	}
+
+	return followPipeline(ref.Workspace, ref.Project, ref.Env, ref.Service, ids)
+}
+
+// replayLog prints the log text a stage has emitted so far. A missing log
+// is not fatal; following continues without it.
+func replayLog(stage, id, path string) {
+	req, _ := http.NewRequest("GET", apiURL(path), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return
+	}
+	var result struct {
+		LogText string `json:"log_text"`
+	}
+	if decodeJSON(body, &result) == nil && result.LogText != "" {
+		printStageLog(stage, id, result.LogText)
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// The shapes servers have answered a deploy trigger in. A new shape gets
+// a new format and a fixture in testdata/deploy_responses, rather than
+// another key tried in passing.
+const (
+	// deployFormatLegacy is the pre-pipeline reply: the build it queued,
+	// as "id" or "build", and sometimes "build_id" and a status.
+	deployFormatLegacy = "legacy"
+	// deployFormatFlat is the pipeline reply: {"build_id", "deploy_id",
+	// "status"}.
+	deployFormatFlat = "flat"
+	// deployFormatCamel is the flat reply with camelCase keys, as some
+	// gateways in front of the API rewrite it.
+	deployFormatCamel = "camel"
+	// deployFormatEnvelope wraps the deploy in an object:
+	// {"deploy": {"id", "build_id", "status"}}.
+	deployFormatEnvelope = "envelope"
+)
+
+// deployResponse is the reply to a deploy trigger, whatever its shape.
+type deployResponse struct {
+	pipelineIDs
+	Status string // e.g. pending_approval; empty when not reported
+	Format string // which deployFormat it was decoded as
+
+	raw json.RawMessage // as the server sent it
+}
+
+// MarshalJSON prints the response as the server sent it, so --json output
+// doesn't change under scripts when the CLI learns a new shape.
+func (r deployResponse) MarshalJSON() ([]byte, error) {
+	if r.raw == nil {
+		return []byte("null"), nil
+	}
+	return r.raw, nil
+}
+
+// decodeDeployResponse decodes a deploy trigger reply, first telling which
+// format it is from its keys.
+func decodeDeployResponse(body []byte) (deployResponse, error) {
+	var keys map[string]json.RawMessage
+	if err := decodeJSON(body, &keys); err != nil {
+		return deployResponse{}, err
+	}
+	r := deployResponse{raw: json.RawMessage(body), Format: deployResponseFormat(keys)}
+
+	switch r.Format {
+	case deployFormatEnvelope:
+		var v struct {
+			Deploy struct {
+				ID      string `json:"id"`
+				BuildID string `json:"build_id"`
+				Status  string `json:"status"`
+			} `json:"deploy"`
+		}
+		if err := decodeJSON(body, &v); err != nil {
+			return deployResponse{}, err
+		}
+		r.Build, r.Deploy, r.Status = v.Deploy.BuildID, v.Deploy.ID, v.Deploy.Status
+	case deployFormatCamel:
+		var v struct {
+			BuildID  string `json:"buildId"`
+			DeployID string `json:"deployId"`
+			Status   string `json:"status"`
+		}
+		if err := decodeJSON(body, &v); err != nil {
+			return deployResponse{}, err
+		}
+		r.Build, r.Deploy, r.Status = v.BuildID, v.DeployID, v.Status
+	case deployFormatFlat:
+		var v struct {
+			BuildID  string `json:"build_id"`
+			DeployID string `json:"deploy_id"`
+			Status   string `json:"status"`
+		}
+		if err := decodeJSON(body, &v); err != nil {
+			return deployResponse{}, err
+		}
+		r.Build, r.Deploy, r.Status = v.BuildID, v.DeployID, v.Status
+	default:
+		var v struct {
+			BuildID string `json:"build_id"`
+			Build   string `json:"build"`
+			ID      string `json:"id"`
+			Status  string `json:"status"`
+		}
+		if err := decodeJSON(body, &v); err != nil {
+			return deployResponse{}, err
+		}
+		r.Build, r.Status = firstNonEmpty(v.BuildID, v.Build, v.ID), v.Status
+	}
+	return r, nil
+}
+
+// deployResponseFormat tells a deploy reply's format from its top-level
+// keys.
+func deployResponseFormat(keys map[string]json.RawMessage) string {
+	has := func(k string) bool { _, ok := keys[k]; return ok }
+	switch {
+	case has("deploy") && isJSONObject(keys["deploy"]):
+		return deployFormatEnvelope
+	case has("buildId") || has("deployId"):
+		return deployFormatCamel
+	case has("deploy_id"):
+		return deployFormatFlat
+	default:
+		return deployFormatLegacy
+	}
+}
+
+func isJSONObject(v json.RawMessage) bool {
+	v = bytes.TrimSpace(v)
+	return len(v) > 0 && v[0] == '{'
+}
+
+func firstNonEmpty(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +15,34 @@ func init() {
 	deploysCmd.AddCommand(deploysLogCmd)
 	deploysGetCmd.Flags().BoolP("follow", "f", false, "Follow deployment progress until complete")
 	deploysLogCmd.Flags().BoolP("follow", "f", false, "Poll for log updates until deployment completes")
+	addListFlags(deploysListCmd, deployListFields.names()...)
+	addIDOnlyFlag(deploysListCmd, "deploy IDs")
+	addIDOnlyFlag(deploysGetCmd, "deploy ID")
+}
+
+// deployItem is a deploy as returned by the list endpoint.
+type deployItem struct {
+	ID       string `json:"id"`
+	BuildID  string `json:"build_id,omitempty"`
+	Complete bool   `json:"complete"`
+	Error    bool   `json:"error"`
+	Created  string `json:"created"`
+}
+
+func (d deployItem) status() string {
+	switch {
+	case d.Error:
+		return "error"
+	case d.Complete:
+		return "complete"
+	}
+	return "in progress"
+}
+
+// deployListFields are the fields deploys list sorts and filters by.
+var deployListFields = listFields[deployItem]{
+	"created": func(d deployItem) string { return d.Created },
+	"status":  deployItem.status,
 }
 
 var deploysCmd = &cobra.Command{
@@ -26,7 +53,9 @@ var deploysCmd = &cobra.Command{
 
 Deploys represent the rollout of a build to your infrastructure. Each deploy
 tracks its progress and can be inspected for status, errors, and logs.
-Use sub-commands to list deploys, view details, or stream deploy logs.`,
+Use sub-commands to list deploys, view details, or stream deploy logs.
+Deploy IDs may be shortened to any unique prefix, such as the one
+` + "`deploys list`" + ` shows.`,
 	Example: "  ancla deploys list my-ws/my-proj/staging/my-svc\n  ancla deploys get <ws>/<proj>/<env>/<svc> <deploy-id>\n  ancla deploys log <ws>/<proj>/<env>/<svc> <deploy-id>",
 	GroupID: "resources",
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -37,52 +66,46 @@ Use sub-commands to list deploys, view details, or stream deploy logs.`,
 var deploysListCmd = &cobra.Command{
 	Use:     "list [<ws>/<proj>/<env>/<svc>]",
 	Short:   "List deploys for a service",
-	Example: "  ancla deploys list\n  ancla deploys list my-ws/my-proj/staging/my-svc",
+	Example: "  ancla deploys list\n  ancla deploys list my-ws/my-proj/staging/my-svc\n  ancla deploys list --filter status=error --sort -created\n  ancla deploys list --id-only | head -1",
 	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no linked service — provide <ws>/<proj>/<env>/<svc>, or run `ancla link`")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(servicePath(ws, proj, env, svc)+"/deploys/"), nil)
+		req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()+"/deploys/"), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
 		}
 
-		var items []struct {
-			ID       string `json:"id"`
-			Complete bool   `json:"complete"`
-			Error    bool   `json:"error"`
-			Created  string `json:"created"`
-		}
-		if err := json.Unmarshal(body, &items); err != nil {
+		var items []deployItem
+		if err := decodeJSON(body, &items); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
+		if items, err = applyListFlags(cmd, items, deployListFields); err != nil {
+			return err
+		}
 
+		if idOnly(cmd) {
+			for _, d := range items {
+				fmt.Println(d.ID)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(items)
 		}
 
 		var rows [][]string
 		for _, d := range items {
-			status := "in progress"
-			if d.Error {
-				status = "error"
-			} else if d.Complete {
-				status = "complete"
-			}
-			id := d.ID
-			if len(id) > 8 {
-				id = id[:8]
-			}
-			rows = append(rows, []string{id, colorStatus(status), d.Created})
+			rows = append(rows, []string{d.ID, colorStatus(d.status()), formatTime(d.Created)})
 		}
-		table([]string{"ID", "STATUS", "CREATED"}, rows)
+		tableColumns([]tableColumn{shortIDColumn, {Header: "STATUS"}, {Header: "CREATED"}}, rows)
 		return nil
 	},
 }
@@ -113,10 +136,14 @@ var deploysGetCmd = &cobra.Command{
 			Created  string `json:"created"`
 			Updated  string `json:"updated"`
 		}
-		if err := json.Unmarshal(body, &dpl); err != nil {
+		if err := decodeJSON(body, &dpl); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			fmt.Println(dpl.ID)
+			return nil
+		}
 		if isJSON() {
 			return printJSON(dpl)
 		}
@@ -134,7 +161,7 @@ var deploysGetCmd = &cobra.Command{
 			fmt.Printf("Error: %s\n", dpl.ErrorDtl)
 		}
 		if dpl.Created != "" {
-			fmt.Printf("Created: %s\n", dpl.Created)
+			fmt.Printf("Created: %s\n", formatTime(dpl.Created))
 		}
 		if dpl.Updated != "" {
 			fmt.Printf("Updated: %s\n", dpl.Updated)
@@ -191,33 +218,41 @@ var deploysLogCmd = &cobra.Command{
 //	deploys get <deploy-id>                         — uses linked service context
 //	deploys get <ws>/<proj>/<env>/<svc> <deploy-id> — explicit path
 //
-// Returns the env-level path prefix and deploy ID.
+// The deploy ID may be a short prefix, as shown by `deploys list`; it is
+// expanded when the service is known. Returns the env-level path prefix
+// and deploy ID.
 func resolveDeployArgs(args []string) (ep, deployID string, err error) {
 	if len(args) == 2 {
-		ws, proj, env, _, e := resolveServicePath(args[:1])
+		ref, e := resolveServiceRef(args[:1])
 		if e != nil {
 			return "", "", e
 		}
-		if proj == "" || env == "" {
+		if !ref.HasEnv() {
 			return "", "", fmt.Errorf("at least <ws>/<proj>/<env> required")
 		}
-		return envPath(ws, proj, env), args[1], nil
+		deployID, err = resolveDeployID(ref, args[1])
+		return ref.EnvPath(), deployID, err
 	}
 	// Single arg — deploy ID, resolve from linked config.
-	ws, proj, env, svc, e := resolveServicePath(nil)
-	if e != nil || ws == "" || proj == "" || env == "" || svc == "" {
+	ref, e := resolveServiceRef(nil)
+	if e != nil || !ref.HasService() {
 		return "", "", fmt.Errorf("no linked service — provide <ws>/<proj>/<env>/<svc> before the deploy ID, or run `ancla link`")
 	}
-	return envPath(ws, proj, env), args[0], nil
+	deployID, err = resolveDeployID(ref, args[0])
+	return ref.EnvPath(), deployID, err
 }
 
 // followDeploy polls deploy status until complete or error.
 func followDeploy(ep, deployID string) error {
+	lastStatus := ""
 	stop := spin("Deploying...")
 	defer stop()
 
+	p := newPoller()
 	for {
-		time.Sleep(3 * time.Second)
+		if err := p.wait("deploy " + deployID); err != nil {
+			return err
+		}
 		req, _ := http.NewRequest("GET", apiURL(ep+"/deploys/"+deployID), nil)
 		body, err := doRequest(req)
 		if err != nil {
@@ -230,6 +265,18 @@ func followDeploy(ep, deployID string) error {
 		}
 		json.Unmarshal(body, &dpl)
 
+		status := "deploying"
+		switch {
+		case dpl.Error:
+			status = "error"
+		case dpl.Complete:
+			status = "success"
+		}
+		if status != lastStatus {
+			lastStatus = status
+			reportProgress("deploy", deployID, status, dpl.ErrorDtl)
+		}
+
 		if dpl.Error {
 			stop()
 			if dpl.ErrorDtl != "" {
@@ -239,7 +286,9 @@ func followDeploy(ep, deployID string) error {
 		}
 		if dpl.Complete {
 			stop()
-			fmt.Println("\n" + stSuccess.Render(symCheck+" Deploy complete."))
+			if !progressLines() {
+				fmt.Println("\n" + stSuccess.Render(symCheck+" Deploy complete."))
+			}
 			return nil
 		}
 	}
@@ -248,11 +297,15 @@ func followDeploy(ep, deployID string) error {
 // followDeployLog polls deploy logs until complete or error.
 func followDeployLog(ep, deployID string) error {
 	var lastLen int
+	lastStatus := ""
 	stop := spin("Deploying...")
 	defer stop()
 
+	p := newPoller()
 	for {
-		time.Sleep(3 * time.Second)
+		if err := p.wait("deploy " + deployID); err != nil {
+			return err
+		}
 		req, _ := http.NewRequest("GET", apiURL(ep+"/deploys/"+deployID+"/log"), nil)
 		body, err := doRequest(req)
 		if err != nil {
@@ -266,15 +319,21 @@ func followDeployLog(ep, deployID string) error {
 
 		if len(result.LogText) > lastLen {
 			stop()
-			fmt.Print(result.LogText[lastLen:])
+			printStageLog("deploy", deployID, result.LogText[lastLen:])
 			lastLen = len(result.LogText)
 			stop = spin("Deploying...")
 		}
+		if result.Status != lastStatus {
+			lastStatus = result.Status
+			reportProgress("deploy", deployID, result.Status, "")
+		}
 
 		switch result.Status {
 		case "complete", "success":
 			stop()
-			fmt.Println("\n" + stSuccess.Render(symCheck+" Deploy complete."))
+			if !progressLines() {
+				fmt.Println("\n" + stSuccess.Render(symCheck+" Deploy complete."))
+			}
 			return nil
 		case "error", "failed":
 			stop()
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// maxExchanges is how many of the most recent HTTP exchanges a
+// diagnostics bundle includes.
+const maxExchanges = 20
+
+// redacted replaces secrets in a diagnostics bundle.
+const redacted = "[redacted]"
+
+func init() {
+	rootCmd.AddCommand(debugCmd)
+	debugCmd.AddCommand(debugBundleCmd)
+	debugBundleCmd.Flags().StringP("file", "f", "", "Write the bundle to this file (default: ancla-diagnostics-<time>.json)")
+}
+
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Troubleshoot the CLI",
+}
+
+var debugBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Write a diagnostics bundle to attach to a bug report",
+	Long: `Write a diagnostics bundle: the CLI version and platform, this command
+and its flags, the effective settings, and a test request to the server.
+API keys, tokens, and values passed as KEY=value are redacted, so the file
+is safe to attach to a bug report.
+
+ancla offers to write the same bundle when it crashes or the server
+fails unexpectedly; that one also has the stack trace and the last
+requests the failing command made.`,
+	Example: "  ancla debug bundle\n  ancla debug bundle -f bug.json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		// The bundle's only request shows whether the server is reachable
+		// and the key accepted; its outcome is recorded, not returned.
+		req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
+		_, _ = doRequest(req)
+
+		path, err := writeBundle(newBundle(nil, ""), file)
+		if err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(map[string]string{"file": path})
+		}
+		fmt.Println(stepDone("Wrote diagnostics bundle to " + path))
+		return nil
+	},
+}
+
+// httpExchange is one API request and how it went.
+type httpExchange struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// exchanges keeps the process's last maxExchanges API requests for a
+// diagnostics bundle.
+var exchanges struct {
+	mu   sync.Mutex
+	list []httpExchange
+}
+
+// recordExchange adds a finished request to exchanges. Bodies are never
+// kept; they carry config values and secrets.
+func recordExchange(req *http.Request, resp *http.Response, err error, start time.Time) {
+	e := httpExchange{
+		Time:       start.UTC(),
+		Method:     req.Method,
+		URL:        req.URL.Redacted(),
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if resp != nil {
+		e.Status = resp.StatusCode
+		e.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	exchanges.mu.Lock()
+	defer exchanges.mu.Unlock()
+	exchanges.list = append(exchanges.list, e)
+	if len(exchanges.list) > maxExchanges {
+		exchanges.list = exchanges.list[len(exchanges.list)-maxExchanges:]
+	}
+}
+
+// recentExchanges returns a copy of the recorded exchanges, oldest first.
+func recentExchanges() []httpExchange {
+	exchanges.mu.Lock()
+	defer exchanges.mu.Unlock()
+	return append([]httpExchange(nil), exchanges.list...)
+}
+
+// diagnosticsBundle is what a bug report needs to reproduce a failure.
+type diagnosticsBundle struct {
+	Time      time.Time         `json:"time"`
+	CLI       versionInfo       `json:"cli"`
+	Command   []string          `json:"command"`
+	Flags     map[string]string `json:"flags,omitempty"`
+	Settings  []bundleSetting   `json:"settings"`
+	Error     string            `json:"error,omitempty"`
+	Panic     string            `json:"panic,omitempty"`
+	Stack     string            `json:"stack,omitempty"`
+	Exchanges []httpExchange    `json:"http_exchanges"`
+}
+
+type bundleSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Source string `json:"source"`
+}
+
+// newBundle collects a diagnostics bundle for the running command. failure
+// is the error or panic value it ended with, if any, and stack the
+// goroutine stack of a panic.
+func newBundle(failure any, stack string) diagnosticsBundle {
+	b := diagnosticsBundle{
+		Time: time.Now().UTC(),
+		CLI: versionInfo{
+			Version:   Version,
+			Commit:    Commit,
+			Date:      Date,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		},
+		Command:   redactArgs(os.Args),
+		Flags:     changedFlags(),
+		Stack:     stack,
+		Exchanges: recentExchanges(),
+	}
+	switch f := failure.(type) {
+	case nil:
+	case error:
+		if stack != "" {
+			b.Panic = f.Error()
+		} else {
+			b.Error = f.Error()
+		}
+	default:
+		b.Panic = fmt.Sprint(f)
+	}
+	if cfg != nil {
+		values := settingValues()
+		origins := config.Origins()
+		for _, k := range config.Keys {
+			b.Settings = append(b.Settings, bundleSetting{Key: k, Value: values[k], Source: string(origins[k].Source)})
+		}
+	}
+	return b
+}
+
+// changedFlags returns the flags set on the command line, by name, with
+// the API key redacted.
+func changedFlags() map[string]string {
+	cmd, _, err := rootCmd.Find(os.Args[1:])
+	if err != nil {
+		return nil
+	}
+	flags := map[string]string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		v := f.Value.String()
+		if f.Name == "api-key" {
+			v = redacted
+		}
+		flags[f.Name] = v
+	})
+	return flags
+}
+
+// redactArgs returns the command line with the secrets it may carry
+// replaced: the --api-key value and the value of every KEY=value
+// argument, as config set takes them.
+func redactArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		switch {
+		case i > 0 && args[i-1] == "--api-key":
+			out[i] = redacted
+		case strings.HasPrefix(a, "--api-key="):
+			out[i] = "--api-key=" + redacted
+		case !strings.HasPrefix(a, "-") && strings.Contains(a, "="):
+			k, _, _ := strings.Cut(a, "=")
+			out[i] = k + "=" + redacted
+		default:
+			out[i] = a
+		}
+	}
+	return out
+}
+
+// writeBundle writes b as indented JSON to file, or to a timestamped file
+// in the current directory, and returns the path written. The file may
+// name workspaces and services, so only its owner can read it.
+func writeBundle(b diagnosticsBundle, file string) (string, error) {
+	if file == "" {
+		file = "ancla-diagnostics-" + b.Time.Format("20060102-150405") + ".json"
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding diagnostics bundle: %w", err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("writing diagnostics bundle: %w", err)
+	}
+	return file, nil
+}
+
+// isFatal reports whether err is a failure the user can't fix: the server
+// erred or sent a response the CLI could not read.
+func isFatal(err error) bool {
+	var ae *apiError
+	var de *decodeError
+	return (errors.As(err, &ae) && ae.Status >= 500) || errors.As(err, &de)
+}
+
+// askBundle asks whether to write a diagnostics bundle. It is a variable
+// so tests can answer it.
+var askBundle = func(what string) bool {
+	if !canPrompt() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	fmt.Fprint(os.Stderr, tr("%s Write a diagnostics bundle for a bug report? [y/N] ", what))
+	answer, _ := stdinReader().ReadString('\n')
+	return isYes(answer)
+}
+
+// offerBundle offers, after a crash or fatal error, to write a diagnostics
+// bundle, and writes it if the user agrees.
+func offerBundle(what string, failure any, stack string) {
+	if !askBundle(what) {
+		return
+	}
+	path, err := writeBundle(newBundle(failure, stack), "")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, stWarning.Render(err.Error()))
+		return
+	}
+	fmt.Fprintln(os.Stderr, tr("Wrote %s — attach it to your bug report.", path))
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestRedactArgs(t *testing.T) {
+	got := redactArgs([]string{
+		"ancla", "--api-key", "sk-1", "config", "set", "DB_PASSWORD=hunter2",
+		"--api-key=sk-2", "--output=json", "-o", "json",
+	})
+	want := []string{
+		"ancla", "--api-key", redacted, "config", "set", "DB_PASSWORD=" + redacted,
+		"--api-key=" + redacted, "--output=json", "-o", "json",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redactArgs = %q, want %q", got, want)
+	}
+}
+
+func TestRecordExchangeKeepsTheLatest(t *testing.T) {
+	origCfg, origList := cfg, exchanges.list
+	t.Cleanup(func() { cfg, exchanges.list = origCfg, origList })
+	exchanges.list = nil
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-"+r.URL.Query().Get("n"))
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, APIKey: "secret-key"}
+
+	for i := range maxExchanges + 5 {
+		req, _ := http.NewRequest("GET", ts.URL+"/api/v1/x?n="+string(rune('a'+i)), nil)
+		resp, err := apiClient().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	got := recentExchanges()
+	if len(got) != maxExchanges {
+		t.Fatalf("kept %d exchanges, want %d", len(got), maxExchanges)
+	}
+	first := got[0]
+	if first.Status != http.StatusTeapot || first.RequestID != "req-f" || first.Method != "GET" {
+		t.Errorf("oldest kept = %+v, want the sixth request", first)
+	}
+}
+
+func TestDebugBundle(t *testing.T) {
+	origCfg, origList := cfg, exchanges.list
+	t.Cleanup(func() { cfg, exchanges.list = origCfg, origList })
+	exchanges.list = nil
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, APIKey: "sk-live-abcdef", Workspace: "acme"}
+	noReauth = true
+	t.Cleanup(func() { noReauth = false })
+
+	file := filepath.Join(t.TempDir(), "bundle.json")
+	debugBundleCmd.Flags().Set("file", file)
+	t.Cleanup(func() { debugBundleCmd.Flags().Set("file", "") })
+	if err := debugBundleCmd.RunE(debugBundleCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "sk-live-abcdef") {
+		t.Errorf("bundle contains the API key:\n%s", data)
+	}
+	var b diagnosticsBundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Exchanges) != 1 || b.Exchanges[0].Status != http.StatusUnauthorized {
+		t.Errorf("exchanges = %+v, want the probe's 401", b.Exchanges)
+	}
+	settings := map[string]string{}
+	for _, s := range b.Settings {
+		settings[s.Key] = s.Value
+	}
+	if settings["workspace"] != "acme" || settings["api_key"] != maskSecret("sk-live-abcdef") {
+		t.Errorf("settings = %v, want the workspace and a masked key", settings)
+	}
+}
+
+func TestIsFatal(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&apiError{Status: 500}, true},
+		{&apiError{Status: 502}, true},
+		{&apiError{Status: 404}, false},
+		{&decodeError{Err: errors.New("unexpected EOF"), Offset: -1}, true},
+		{errAborted, false},
+	}
+	for _, tt := range tests {
+		if got := isFatal(tt.err); got != tt.want {
+			t.Errorf("isFatal(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// lintWarning is one finding of the Dockerfile lint pass.
+type lintWarning struct {
+	Line    int
+	Message string
+}
+
+// dependencyInstallRe matches RUN commands that install dependencies from
+// a manifest or lockfile.
+var dependencyInstallRe = regexp.MustCompile(`\b(pip3? install|uv (sync|pip install)|poetry install|pipenv install|npm (install|ci)|yarn install|pnpm install|bundle install|go mod download|composer install|cargo fetch)\b`)
+
+// dockerInstruction is one instruction with its continuation lines joined.
+type dockerInstruction struct {
+	Line int
+	Cmd  string
+	Args string
+}
+
+// parseDockerfile splits a Dockerfile into instructions, joining lines
+// that end in a backslash and skipping blanks and comments.
+func parseDockerfile(r io.Reader) []dockerInstruction {
+	var out []dockerInstruction
+	var cur strings.Builder
+	start := 0
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if cur.Len() == 0 {
+			start = n
+		}
+		if cont, ok := strings.CutSuffix(line, "\\"); ok {
+			cur.WriteString(cont + " ")
+			continue
+		}
+		cur.WriteString(line)
+		cmd, args, _ := strings.Cut(cur.String(), " ")
+		out = append(out, dockerInstruction{start, strings.ToUpper(cmd), strings.TrimSpace(args)})
+		cur.Reset()
+	}
+	return out
+}
+
+// lintDockerfile runs the built-in checks over a Dockerfile: an unpinned
+// base image, copying the whole context before installing dependencies
+// (which defeats the layer cache), and, for the final stage, no EXPOSE
+// and running as root.
+func lintDockerfile(r io.Reader) []lintWarning {
+	var warnings []lintWarning
+	stages := map[string]bool{}
+	var (
+		fromLine  int
+		exposed   bool
+		user      string
+		userLine  int
+		copiedAll int
+		installed bool
+	)
+	for _, in := range parseDockerfile(r) {
+		fields := strings.Fields(in.Args)
+		switch in.Cmd {
+		case "FROM":
+			fromLine, exposed, user, userLine, copiedAll, installed = in.Line, false, "", 0, 0, false
+			image, alias := fromImage(fields)
+			if alias != "" {
+				stages[strings.ToLower(alias)] = true
+			}
+			if !stages[strings.ToLower(image)] && !imagePinned(image) {
+				warnings = append(warnings, lintWarning{in.Line, fmt.Sprintf("base image %s is not pinned — use a version tag or digest so rebuilds are reproducible", image)})
+			}
+		case "EXPOSE":
+			exposed = true
+		case "USER":
+			if len(fields) > 0 {
+				user, userLine = fields[0], in.Line
+			}
+		case "COPY", "ADD":
+			if copiedAll == 0 && copiesContext(in.Args) {
+				copiedAll = in.Line
+			}
+		case "RUN":
+			if !dependencyInstallRe.MatchString(in.Args) {
+				break
+			}
+			// A second install after the copy is fine once the
+			// dependencies already have a layer of their own.
+			if copiedAll > 0 && !installed {
+				warnings = append(warnings, lintWarning{copiedAll, "the whole context is copied before dependencies are installed — copy the manifest or lockfile first so code changes don't reinstall them"})
+			}
+			installed = true
+		}
+	}
+	if fromLine == 0 {
+		return warnings
+	}
+	if !exposed {
+		warnings = append(warnings, lintWarning{fromLine, "no EXPOSE — the port the service listens on is not declared"})
+	}
+	switch name, _, _ := strings.Cut(user, ":"); name {
+	case "":
+		warnings = append(warnings, lintWarning{fromLine, "no USER — the container runs as root unless the base image sets a user"})
+	case "root", "0":
+		warnings = append(warnings, lintWarning{userLine, "the container runs as root — switch to an unprivileged USER"})
+	}
+	return warnings
+}
+
+// fromImage returns the image and stage alias of a FROM instruction.
+func fromImage(fields []string) (image, alias string) {
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return "", ""
+	}
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "as") {
+		alias = fields[2]
+	}
+	return fields[0], alias
+}
+
+// imagePinned reports whether image names a specific version. scratch and
+// images built from ARG variables are treated as pinned.
+func imagePinned(image string) bool {
+	if image == "" || image == "scratch" || strings.Contains(image, "$") || strings.Contains(image, "@") {
+		return true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, ok := strings.Cut(name, ":")
+	return ok && tag != "latest"
+}
+
+// copiesContext reports whether a COPY or ADD copies the whole build
+// context rather than named files or another stage's output.
+func copiesContext(args string) bool {
+	var parts []string
+	if strings.HasPrefix(args, "[") {
+		if json.Unmarshal([]byte(args), &parts) != nil {
+			return false
+		}
+	} else {
+		for _, f := range strings.Fields(args) {
+			if strings.HasPrefix(f, "--from") {
+				return false
+			}
+			if !strings.HasPrefix(f, "--") {
+				parts = append(parts, f)
+			}
+		}
+	}
+	if len(parts) < 2 {
+		return false
+	}
+	for _, src := range parts[:len(parts)-1] {
+		if src == "." || src == "./" {
+			return true
+		}
+	}
+	return false
+}
+
+// deployLintWarnings lints the Dockerfile in the working directory for the
+// deploy card. It returns nothing for buildpack services, when there is no
+// Dockerfile, or with --no-lint.
+func deployLintWarnings(cmd *cobra.Command, strategy string) []string {
+	if noLint, _ := cmd.Flags().GetBool("no-lint"); noLint || strategy == "buildpack" {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	file := scanBuildFiles(cwd).Dockerfile
+	if file == "" {
+		return nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var out []string
+	for _, w := range lintDockerfile(f) {
+		out = append(out, fmt.Sprintf("%s:%d: %s", file, w.Line, w.Message))
+	}
+	return out
+}
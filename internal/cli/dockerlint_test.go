@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintDockerfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		wantLines []int
+		wantMsgs  []string
+	}{
+		{
+			name: "clean multi-stage",
+			file: `FROM node:20-alpine AS build
+WORKDIR /app
+COPY package.json package-lock.json ./
+RUN npm ci
+COPY . .
+RUN npm run build
+
+FROM nginx@sha256:abc123
+COPY --from=build /app/dist /usr/share/nginx/html
+USER nginx
+EXPOSE 8080
+`,
+		},
+		{
+			name: "every rule",
+			file: `FROM python
+WORKDIR /app
+COPY . .
+RUN pip install \
+    -r requirements.txt
+`,
+			wantLines: []int{1, 3, 1, 1},
+			wantMsgs:  []string{"base image python is not pinned", "whole context is copied", "no EXPOSE", "no USER"},
+		},
+		{
+			name: "latest tag, build stage reference and root user",
+			file: `FROM --platform=linux/amd64 registry.local:5000/base:latest AS base
+FROM base
+# back to root for the entrypoint
+USER root:root
+EXPOSE 80
+`,
+			wantLines: []int{1, 4},
+			wantMsgs:  []string{"base image registry.local:5000/base:latest is not pinned", "runs as root"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lintDockerfile(strings.NewReader(tt.file))
+			if len(got) != len(tt.wantMsgs) {
+				t.Fatalf("got %d warnings, want %d: %+v", len(got), len(tt.wantMsgs), got)
+			}
+			for i, w := range got {
+				if w.Line != tt.wantLines[i] || !strings.Contains(w.Message, tt.wantMsgs[i]) {
+					t.Errorf("warning %d = line %d %q, want line %d containing %q", i, w.Line, w.Message, tt.wantLines[i], tt.wantMsgs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLintDockerfile_GeneratedDockerfileIsClean(t *testing.T) {
+	for _, pm := range []string{"uv", "pip"} {
+		p := &pythonProject{PythonVersion: "3.12", PackageManager: pm}
+		if got := lintDockerfile(strings.NewReader(generateDockerfile(p))); len(got) > 0 {
+			t.Errorf("generated %s Dockerfile has lint warnings: %+v", pm, got)
+		}
+	}
+}
@@ -1,13 +1,10 @@
 package cli
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -15,12 +12,9 @@ import (
 )
 
 func init() {
-	downCmd.Flags().BoolVarP(&downYes, "yes", "y", false, "Skip confirmation prompt")
 	rootCmd.AddCommand(downCmd)
 }
 
-var downYes bool
-
 var downCmd = &cobra.Command{
 	Use:   "down [ws/proj/env/svc]",
 	Short: "Scale all processes to 0 for a service",
@@ -42,16 +36,16 @@ Use --yes to skip the confirmation prompt (useful in scripts and CI).`,
 		if len(args) == 1 {
 			arg = args[0]
 		}
-		ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+		ref, err := config.ResolveServiceRef(arg, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" || proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no service specified — pass ws/proj/env/svc or link a directory first")
 		}
 
-		svcPath := "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc
-		displayPath := ws + "/" + proj + "/" + env + "/" + svc
+		svcPath := ref.ServicePath()
+		displayPath := ref.String()
 
 		// Fetch the service to discover current process types.
 		req, err := http.NewRequest("GET", apiURL(svcPath), nil)
@@ -66,7 +60,7 @@ Use --yes to skip the confirmation prompt (useful in scripts and CI).`,
 		var service struct {
 			ProcessCounts map[string]int `json:"process_counts"`
 		}
-		if err := json.Unmarshal(body, &service); err != nil {
+		if err := decodeJSON(body, &service); err != nil {
 			return fmt.Errorf("parsing service response: %w", err)
 		}
 
@@ -75,17 +69,8 @@ Use --yes to skip the confirmation prompt (useful in scripts and CI).`,
 			return nil
 		}
 
-		// Warn and confirm.
-		fmt.Printf("This will scale all processes to 0 for %s\n", displayPath)
-		if !downYes {
-			fmt.Print("Continue? [y/N] ")
-			reader := bufio.NewReader(os.Stdin)
-			answer, _ := reader.ReadString('\n')
-			answer = strings.TrimSpace(strings.ToLower(answer))
-			if answer != "y" && answer != "yes" {
-				fmt.Println("Aborted.")
-				return nil
-			}
+		if err := confirmAction(fmt.Sprintf("This will scale all processes to 0 for %s.", displayPath)); err != nil {
+			return err
 		}
 
 		// Build zero-scaled process counts.
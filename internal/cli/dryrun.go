@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// errDryRun stops a command at its first mutating request under --dry-run.
+// Execute treats it as success.
+var errDryRun = errors.New("dry run: request not sent")
+
+// secretFields are payload keys whose string values are masked when a
+// dry run prints a request body. Config var values are masked whether or
+// not the variable is marked secret, since the payload may be shared.
+var secretFields = map[string]bool{
+	"api_key":     true,
+	"password":    true,
+	"private_key": true,
+	"secret_key":  true,
+	"token":       true,
+	"value":       true,
+}
+
+// isMutating reports whether a request changes state and is therefore
+// held back by --dry-run.
+func isMutating(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+		return false
+	}
+	return true
+}
+
+// printDryRun prints the request that would have been sent and returns
+// errDryRun. JSON bodies are printed with secret fields masked.
+func printDryRun(req *http.Request) error {
+	var payload any
+	var raw string
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		req.Body.Close()
+		if json.Unmarshal(data, &payload) == nil {
+			payload = maskSecrets(payload)
+		} else if len(data) > 0 {
+			raw = fmt.Sprintf("<%d bytes>", len(data))
+		}
+	}
+
+	path := req.URL.Path
+	if req.URL.RawQuery != "" {
+		path += "?" + req.URL.RawQuery
+	}
+
+	if isJSON() {
+		out := map[string]any{"dry_run": true, "method": req.Method, "path": path}
+		if payload != nil {
+			out["body"] = payload
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+		return errDryRun
+	}
+
+	fmt.Printf("%s %s %s\n", stWarning.Render("[dry-run]"), stAccent.Render(req.Method), path)
+	switch {
+	case payload != nil:
+		data, _ := json.MarshalIndent(payload, "", "  ")
+		for _, line := range strings.Split(string(data), "\n") {
+			fmt.Println("  " + line)
+		}
+	case raw != "":
+		fmt.Println("  " + stDim.Render(raw))
+	}
+	return errDryRun
+}
+
+// maskSecrets returns v with the string values of secretFields replaced,
+// recursing into nested objects and arrays.
+func maskSecrets(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if s, ok := val.(string); ok && secretFields[strings.ToLower(k)] && s != "" {
+				t[k] = "********"
+				continue
+			}
+			t[k] = maskSecrets(val)
+		}
+	case []any:
+		for i := range t {
+			t[i] = maskSecrets(t[i])
+		}
+	}
+	return v
+}
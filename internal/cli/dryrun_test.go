@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestDoRequest_DryRun(t *testing.T) {
+	origCfg, origDry := cfg, dryRun
+	defer func() { cfg, dryRun = origCfg, origDry }()
+
+	var hits []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, r.Method)
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+	dryRun = true
+
+	req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
+	if _, err := doRequest(req); err != nil {
+		t.Fatalf("GET under dry run: %v", err)
+	}
+
+	req, _ = http.NewRequest("POST", apiURL("/workspaces/ws/config/"), bytes.NewReader([]byte(`{"name":"DB_URL","value":"postgres://u:p@db"}`)))
+	if _, err := doRequest(req); !errors.Is(err, errDryRun) {
+		t.Fatalf("POST under dry run: err = %v, want errDryRun", err)
+	}
+	if len(hits) != 1 || hits[0] != "GET" {
+		t.Errorf("server saw %v, want only the GET", hits)
+	}
+}
+
+func TestMaskSecrets(t *testing.T) {
+	in := map[string]any{
+		"name":   "DB_URL",
+		"value":  "postgres://u:p@db",
+		"secret": true,
+		"items":  []any{map[string]any{"Token": "abc", "id": "1"}},
+	}
+	out := maskSecrets(in).(map[string]any)
+	if out["value"] != "********" || out["name"] != "DB_URL" || out["secret"] != true {
+		t.Errorf("masked = %v", out)
+	}
+	item := out["items"].([]any)[0].(map[string]any)
+	if item["Token"] != "********" || item["id"] != "1" {
+		t.Errorf("nested item = %v", item)
+	}
+}
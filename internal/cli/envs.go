@@ -17,6 +17,8 @@ func init() {
 	envsCmd.AddCommand(envsListCmd)
 	envsCmd.AddCommand(envsGetCmd)
 	envsCmd.AddCommand(envsCreateCmd)
+	addIDOnlyFlag(envsListCmd, "environment slugs")
+	addIDOnlyFlag(envsGetCmd, "environment slug")
 }
 
 var envsCmd = &cobra.Command{
@@ -28,7 +30,7 @@ var envsCmd = &cobra.Command{
 Environments represent deployment targets (e.g. staging, production) for
 the services in a project. Each environment can have its own configuration,
 releases, and scaling settings.
-Use sub-commands to list, inspect, or create environments.`,
+Use sub-commands to list, inspect, create, or protect environments.`,
 	Example: "  ancla envs list my-ws/my-proj\n  ancla envs get my-ws/my-proj/staging\n  ancla envs create my-ws/my-proj production",
 	GroupID: "resources",
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -46,15 +48,15 @@ var envsListCmd = &cobra.Command{
 		if len(args) == 1 {
 			arg = args[0]
 		}
-		ws, proj, _, _, err := config.ResolveServicePath(arg, cfg)
+		ref, err := config.ResolveServiceRef(arg, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" || proj == "" {
+		if ref.Workspace == "" || ref.Project == "" {
 			return fmt.Errorf("workspace and project are required\n\n  ancla envs <workspace>/<project>\n\n  Hierarchy: workspace → project → env → service\n  Hint: run `ancla link` to set defaults")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"), nil)
+		req, _ := http.NewRequest("GET", apiURL(ref.ProjectPath()+"/envs/"), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
@@ -67,17 +69,23 @@ var envsListCmd = &cobra.Command{
 			ServiceCount int    `json:"service_count"`
 			Created      string `json:"created"`
 		}
-		if err := json.Unmarshal(body, &envs); err != nil {
+		if err := decodeJSON(body, &envs); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			for _, e := range envs {
+				fmt.Println(e.Slug)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(envs)
 		}
 
 		var rows [][]string
 		for _, e := range envs {
-			rows = append(rows, []string{e.Slug, e.Name, fmt.Sprintf("%d", e.ServiceCount), e.Created})
+			rows = append(rows, []string{e.Slug, e.Name, fmt.Sprintf("%d", e.ServiceCount), formatTime(e.Created)})
 		}
 		table([]string{"SLUG", "NAME", "SERVICES", "CREATED"}, rows)
 		return nil
@@ -103,17 +111,22 @@ var envsGetCmd = &cobra.Command{
 		}
 
 		var e struct {
-			ID           string `json:"id"`
-			Name         string `json:"name"`
-			Slug         string `json:"slug"`
-			ServiceCount int    `json:"service_count"`
-			Created      string `json:"created"`
-			Updated      string `json:"updated"`
-		}
-		if err := json.Unmarshal(body, &e); err != nil {
+			ID           string         `json:"id"`
+			Name         string         `json:"name"`
+			Slug         string         `json:"slug"`
+			ServiceCount int            `json:"service_count"`
+			Created      string         `json:"created"`
+			Updated      string         `json:"updated"`
+			Protection   *envProtection `json:"protection,omitempty"`
+		}
+		if err := decodeJSON(body, &e); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			fmt.Println(e.Slug)
+			return nil
+		}
 		if isJSON() {
 			return printJSON(e)
 		}
@@ -121,11 +134,15 @@ var envsGetCmd = &cobra.Command{
 		fmt.Printf("Environment: %s (%s)\n", e.Name, e.Slug)
 		fmt.Printf("Services: %d\n", e.ServiceCount)
 		if e.Created != "" {
-			fmt.Printf("Created: %s\n", e.Created)
+			fmt.Printf("Created: %s\n", formatTime(e.Created))
 		}
 		if e.Updated != "" {
 			fmt.Printf("Updated: %s\n", e.Updated)
 		}
+		if e.Protection.enabled() {
+			fmt.Println("Protection:")
+			printEnvProtection(e.Protection)
+		}
 		return nil
 	},
 }
@@ -159,7 +176,7 @@ var envsCreateCmd = &cobra.Command{
 			Name string `json:"name"`
 			Slug string `json:"slug"`
 		}
-		if err := json.Unmarshal(body, &e); err != nil {
+		if err := decodeJSON(body, &e); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
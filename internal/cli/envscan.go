@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// envEntry is one variable read from a .env file.
+type envEntry struct {
+	Name  string
+	Value string
+	Line  int
+}
+
+// parseEnvFile reads KEY=value lines the way the bulk endpoint does:
+// blank lines and # comments are skipped, a leading "export " is dropped,
+// and quoted values may span several lines (as PEM keys often do).
+func parseEnvFile(data string) []envEntry {
+	var entries []envEntry
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		e := envEntry{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value), Line: i + 1}
+		if v := e.Value; v != "" && (v[0] == '"' || v[0] == '\'') {
+			quote := v[:1]
+			for !(len(e.Value) > 1 && strings.HasSuffix(e.Value, quote)) && i+1 < len(lines) {
+				i++
+				e.Value += "\n" + lines[i]
+			}
+			e.Value = strings.TrimSuffix(strings.TrimPrefix(e.Value, quote), quote)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// secretPatterns recognise values that are almost certainly credentials.
+// They are deliberately narrow: a false positive marks a variable secret,
+// and a noisy scanner just gets --allow-secrets added out of habit. When
+// nameHint is set, the variable name must contain it too.
+var secretPatterns = []struct {
+	kind     string
+	nameHint string
+	re       *regexp.Regexp
+}{
+	{"private key", "", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"AWS access key", "", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"AWS secret key", "SECRET", regexp.MustCompile(`^[A-Za-z0-9/+]{40}$`)},
+	{"JWT", "", regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`)},
+}
+
+// envFinding is a variable whose value looks like a credential.
+type envFinding struct {
+	Name string
+	Kind string
+	Line int
+}
+
+// scanEnvSecrets reports the entries that match a secret pattern.
+func scanEnvSecrets(entries []envEntry) []envFinding {
+	var findings []envFinding
+	for _, e := range entries {
+		for _, p := range secretPatterns {
+			if p.nameHint != "" && !strings.Contains(strings.ToUpper(e.Name), p.nameHint) {
+				continue
+			}
+			if p.re.MatchString(e.Value) {
+				findings = append(findings, envFinding{Name: e.Name, Kind: p.kind, Line: e.Line})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// envGitignored reports whether git ignores path. known is false when
+// path is outside a git repository or git is not installed.
+func envGitignored(path string) (ignored, known bool) {
+	c := exec.Command("git", "check-ignore", "-q", filepath.Base(path))
+	c.Dir = filepath.Dir(path)
+	err := c.Run()
+	if err == nil {
+		return true, true
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, true
+	}
+	return false, false
+}
+
+// envImportPayload reads the .env file named by --file and builds the bulk
+// import payload. Values that look like credentials are sent as secrets
+// unless --allow-secrets is set, and a file that git would commit gets a
+// warning, since it is now the second copy of those values.
+func envImportPayload(cmd *cobra.Command) (map[string]any, error) {
+	filePath, _ := cmd.Flags().GetString("file")
+	if filePath == "" {
+		return nil, fmt.Errorf("--file flag is required")
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	if ignored, known := envGitignored(filePath); known && !ignored {
+		fmt.Fprintf(os.Stderr, "%s %s is not gitignored — add it to .gitignore so its values are never committed\n",
+			stWarning.Render("!"), filePath)
+	}
+
+	payload := map[string]any{"raw": string(data)}
+	findings := scanEnvSecrets(parseEnvFile(string(data)))
+	if len(findings) == 0 {
+		return payload, nil
+	}
+	allow, _ := cmd.Flags().GetBool("allow-secrets")
+	if allow {
+		return payload, nil
+	}
+
+	var names []string
+	fmt.Fprintf(os.Stderr, "%s %s contains values that look like credentials; importing them as secrets:\n",
+		stWarning.Render("!"), filePath)
+	for _, f := range findings {
+		names = append(names, f.Name)
+		fmt.Fprintf(os.Stderr, "    %s  %s\n", f.Name, stDim.Render(fmt.Sprintf("%s, line %d", f.Kind, f.Line)))
+	}
+	fmt.Fprintln(os.Stderr, stDim.Render("  Pass --allow-secrets to import them as plain variables."))
+	payload["secrets"] = names
+	return payload, nil
+}
@@ -1,10 +1,17 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
 )
 
 // ─── Error Card System ─────────────────────────────────────────
@@ -52,15 +59,15 @@ func (e *pipelineError) dashboardURL() string {
 func (e *pipelineError) title() string {
 	switch e.Kind {
 	case errBuild:
-		return "Build failed"
+		return tr("Build failed")
 	case errDeploy:
-		return "Deploy failed"
+		return tr("Deploy failed")
 	case errTimeout:
-		return "Pipeline timed out"
+		return tr("Pipeline timed out")
 	case errAuth:
-		return "Authentication failed"
+		return tr("Authentication failed")
 	default:
-		return "Pipeline error"
+		return tr("Pipeline error")
 	}
 }
 
@@ -68,41 +75,41 @@ func (e *pipelineError) title() string {
 func (e *pipelineError) hints() []string {
 	switch e.Kind {
 	case errBuild:
-		hints := []string{"Check the build log for details"}
+		hints := []string{tr("Check the build log for details")}
 		if strings.Contains(strings.ToLower(e.Detail), "procfile") {
-			hints = append(hints, "Add a Procfile to your project root")
+			hints = append(hints, tr("Add a Procfile to your project root"))
 		}
 		if strings.Contains(strings.ToLower(e.Detail), "dockerfile") {
-			hints = append(hints, "Verify your Dockerfile builds locally")
+			hints = append(hints, tr("Verify your Dockerfile builds locally"))
 		}
 		if strings.Contains(strings.ToLower(e.Detail), "timeout") {
-			hints = append(hints, "Build exceeded time limit — try optimizing layers or caching")
+			hints = append(hints, tr("Build exceeded time limit — try optimizing layers or caching"))
 		}
-		hints = append(hints, "Run "+stAccent.Render("ancla builds log")+" to view full output")
+		hints = append(hints, tr("Run %s to view full output", stAccent.Render("ancla builds log")))
 		return hints
 	case errDeploy:
 		return []string{
-			"Check deploy logs for crash details",
-			"Verify health check endpoint responds",
-			"Run " + stAccent.Render("ancla deploys log") + " to view output",
+			tr("Check deploy logs for crash details"),
+			tr("Verify health check endpoint responds"),
+			tr("Run %s to view output", stAccent.Render("ancla deploys log")),
 		}
 	case errTimeout:
 		return []string{
-			"The build or deploy did not complete in time",
-			"Check server status and try again",
-			"Run " + stAccent.Render("ancla deploys list") + " to check current state",
+			tr("The build or deploy did not complete in time"),
+			tr("Check server status and try again"),
+			tr("Run %s to check current state", stAccent.Render("ancla deploys list")),
 		}
 	case errAuth:
 		return []string{
-			"Your session may have expired",
-			"Run " + stAccent.Render("ancla login") + " to re-authenticate",
+			tr("Your session may have expired"),
+			tr("Run %s to re-authenticate", stAccent.Render("ancla login")),
 		}
 	default:
-		return []string{"Check the dashboard for details"}
+		return []string{tr("Check the dashboard for details")}
 	}
 }
 
-// renderErrorCard prints a styled error card to stderr.
+// renderErrorCard prints a styled error card for a pipeline failure.
 // Layout:
 //
 //	▌ ✗ Build failed
@@ -116,24 +123,45 @@ func (e *pipelineError) hints() []string {
 //	▌ Dashboard
 //	▌   https://ancla.dev/ws/proj/services/svc/builds
 func renderErrorCard(e *pipelineError) {
+	printCard(os.Stdout, errorCard{
+		Title:     e.title(),
+		Detail:    e.Detail,
+		Hints:     e.hints(),
+		URL:       e.dashboardURL(),
+		IsWarning: e.Kind == errTimeout,
+	})
+}
+
+// errorCard is the renderable content of a card, independent of where
+// the error came from.
+type errorCard struct {
+	Title     string
+	Detail    string
+	Hints     []string
+	URL       string // optional dashboard link
+	IsWarning bool   // amber stripe instead of red
+}
+
+// printCard writes a styled error card to w.
+func printCard(w io.Writer, c errorCard) {
 	// The left-edge stripe — 1 char wide, colored by severity.
 	barColor := brandError
-	if e.Kind == errTimeout {
+	if c.IsWarning {
 		barColor = brandWarning
 	}
-	bar := lipgloss.NewStyle().Foreground(barColor).Render("▌")
+	bar := lipgloss.NewStyle().Foreground(barColor).Render(symBar)
 
 	var lines []string
 
 	// ── Header: ▌ ✗ Build failed ──
-	header := stError.Bold(true).Render(symCross + " " + e.title())
+	header := stError.Bold(true).Render(symCross + " " + c.Title)
 	lines = append(lines, bar+" "+header)
 	lines = append(lines, bar)
 
 	// ── Detail (what happened) ──
-	if e.Detail != "" {
+	if c.Detail != "" {
 		// Wrap long detail text, indent each line behind the bar.
-		for _, dl := range strings.Split(strings.TrimSpace(e.Detail), "\n") {
+		for _, dl := range strings.Split(strings.TrimSpace(c.Detail), "\n") {
 			dl = strings.TrimSpace(dl)
 			if dl != "" {
 				lines = append(lines, bar+"  "+stDim.Render(dl))
@@ -143,24 +171,207 @@ func renderErrorCard(e *pipelineError) {
 	}
 
 	// ── Next steps ──
-	hints := e.hints()
-	if len(hints) > 0 {
-		lines = append(lines, bar+"  "+stMuted.Bold(true).Render("Next steps"))
-		for _, h := range hints {
+	if len(c.Hints) > 0 {
+		lines = append(lines, bar+"  "+stMuted.Bold(true).Render(tr("Next steps")))
+		for _, h := range c.Hints {
 			lines = append(lines, bar+"    "+stDim.Render(symArrow)+" "+h)
 		}
 		lines = append(lines, bar)
 	}
 
 	// ── Dashboard link ──
-	url := e.dashboardURL()
-	lines = append(lines, bar+"  "+stMuted.Bold(true).Render("Dashboard"))
-	lines = append(lines, bar+"    "+stAccent.Underline(true).Render(url))
+	if c.URL != "" {
+		lines = append(lines, bar+"  "+stMuted.Bold(true).Render(tr("Dashboard")))
+		lines = append(lines, bar+"    "+stAccent.Underline(true).Render(c.URL))
+	}
 
 	// Print with a blank line above for breathing room.
-	fmt.Println()
+	fmt.Fprintln(w)
 	for _, l := range lines {
-		fmt.Println(l)
+		fmt.Fprintln(w, l)
+	}
+	fmt.Fprintln(w)
+}
+
+// ─── API errors ────────────────────────────────────────────────
+
+// apiError is returned by doRequest for any HTTP error response. Its
+// Error() text is the short one-line message; the request path is kept
+// so the top-level handler can render a card with a targeted fix.
+type apiError struct {
+	Status  int
+	Message string
+	Path    string // request URL path, e.g. /api/v1/workspaces/ws/projects/p
+
+	// missing is filled in by reportError for 404s once the path has
+	// been walked to find which segment does not exist.
+	missing *missingSegment
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+// isQuotaError reports whether the response signals an exhausted plan
+// limit rather than an ordinary validation failure. Rate limiting (429)
+// is transient and never counts, whatever its message says.
+func (e *apiError) isQuotaError() bool {
+	switch e.Status {
+	case http.StatusPaymentRequired:
+		return true
+	case http.StatusTooManyRequests:
+		return false
+	}
+	msg := strings.ToLower(e.Message)
+	return strings.Contains(msg, "quota") || strings.Contains(msg, "limit exceeded") || strings.Contains(msg, "plan limit")
+}
+
+// parseAPIPath extracts the workspace/project/env/service slugs from a
+// nested API path. Segments that are absent are left empty.
+func parseAPIPath(path string) config.ServiceRef {
+	var ref config.ServiceRef
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		val := parts[i+1]
+		switch parts[i] {
+		case "workspaces":
+			ref.Workspace = val
+		case "projects":
+			ref.Project = val
+		case "envs":
+			ref.Env = val
+		case "services":
+			ref.Service = val
+		default:
+			continue
+		}
+		i++
+	}
+	return ref
+}
+
+// card builds the error card for an API error, or returns false when the
+// error has no more to say than its one-line message.
+func (e *apiError) card() (errorCard, bool) {
+	ref := parseAPIPath(e.Path)
+	switch {
+	case e.Status == http.StatusUnauthorized:
+		hints := []string{tr("Run %s to authenticate", stAccent.Render("ancla login"))}
+		if cfg != nil && cfg.APIKey != "" {
+			hints = append([]string{tr("Your API key may have expired or been revoked")}, hints...)
+		}
+		return errorCard{
+			Title: tr("Not authenticated"),
+			Hints: hints,
+			URL:   serverURL() + "/login",
+		}, true
+	case e.Status == http.StatusForbidden:
+		hints := []string{tr("Run %s to check which account is active", stAccent.Render("ancla whoami"))}
+		if ref.Workspace != "" {
+			hints = append(hints, tr("Ask an admin of workspace %q to grant you access", ref.Workspace))
+		}
+		return errorCard{Title: tr("Permission denied"), Detail: ref.String(), Hints: hints}, true
+	case e.Status == http.StatusNotFound && e.missing != nil:
+		m := e.missing
+		return errorCard{
+			Title:  tr("%s not found", capitalize(tr(m.Kind))),
+			Detail: m.message(),
+			Hints:  notFoundHints(m.Ref),
+		}, true
+	case e.Status == http.StatusNotFound && ref.Workspace != "":
+		hints := notFoundHints(ref)
+		if h := skewHint(); h != "" {
+			hints = append(hints, h)
+		}
+		return errorCard{
+			Title:  tr("Not found"),
+			Detail: tr("%s does not exist or is not visible to you", ref.String()),
+			Hints:  hints,
+		}, true
+	case e.Status == http.StatusNotFound && skewHint() != "":
+		return errorCard{
+			Title:  tr("Endpoint not found"),
+			Detail: e.Message,
+			Hints:  []string{skewHint()},
+		}, true
+	case e.Status == http.StatusTooManyRequests:
+		return errorCard{
+			Title:     tr("Rate limited"),
+			Detail:    e.Message,
+			Hints:     []string{tr("Too many requests in a short time — wait a moment and retry")},
+			IsWarning: true,
+		}, true
+	case e.isQuotaError():
+		return errorCard{
+			Title:     tr("Plan limit reached"),
+			Detail:    e.Message,
+			Hints:     []string{tr("Remove unused resources or upgrade your plan")},
+			IsWarning: true,
+		}, true
+	}
+	return errorCard{}, false
+}
+
+// notFoundHints suggests the list command for the deepest segment in the
+// path, plus a re-link when the directory's link points at that path.
+func notFoundHints(r config.ServiceRef) []string {
+	var list, parent string
+	switch {
+	case r.Service != "":
+		parent = r.Workspace + "/" + r.Project + "/" + r.Env
+		list = "ancla services list " + parent
+	case r.Env != "":
+		parent = r.Workspace + "/" + r.Project
+		list = "ancla envs list " + parent
+	case r.Project != "":
+		parent = r.Workspace
+		list = "ancla projects list " + parent
+	default:
+		list = "ancla workspaces list"
+	}
+	hints := []string{tr("Run %s to see what exists", stAccent.Render(list))}
+	if cfg != nil && cfg.Workspace == r.Workspace && (r.Project == "" || cfg.Project == r.Project) {
+		cmd := "ancla link"
+		if parent != "" {
+			cmd += " " + parent
+		}
+		hints = append(hints, tr("If this directory's link is stale, run %s", stAccent.Render(cmd)))
+	}
+	return hints
+}
+
+// reportError prints a command failure to w, as a card when the error is
+// an API error we know how to explain and plain text otherwise. JSON mode
+// skips the did-you-mean lookup so scripts get the failure immediately.
+func reportError(w io.Writer, err error) {
+	// Shown without the request wrapping around it: nothing was sent.
+	var ro *readOnlyError
+	if errors.As(err, &ro) {
+		fmt.Fprintln(w, stError.Render(symCross)+" "+ro.Error())
+		return
+	}
+	var ae *apiError
+	if errors.As(err, &ae) {
+		if ae.Status == http.StatusNotFound && !isJSON() {
+			stop := func() {}
+			if !isQuiet() {
+				stop = spin(tr("Checking path..."))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+			ae.missing = locateMissing(ctx, parseAPIPath(ae.Path))
+			cancel()
+			stop()
+		}
+		if !isJSON() && !isQuiet() {
+			if c, ok := ae.card(); ok {
+				printCard(w, c)
+				return
+			}
+		}
+		if ae.missing != nil {
+			fmt.Fprintln(w, stError.Render(symCross)+" "+ae.missing.message())
+			return
+		}
 	}
-	fmt.Println()
+	fmt.Fprintln(w, stError.Render(symCross)+" "+err.Error())
 }
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestParseAPIPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want config.ServiceRef
+	}{
+		{"/api/v1/workspaces/", config.ServiceRef{}},
+		{"/api/v1/workspaces/ws", config.ServiceRef{Workspace: "ws"}},
+		{"/api/v1/workspaces/ws/projects/p/envs/e", config.ServiceRef{Workspace: "ws", Project: "p", Env: "e"}},
+		{"/api/v1/workspaces/ws/projects/p/envs/e/services/s/builds/", config.ServiceRef{Workspace: "ws", Project: "p", Env: "e", Service: "s"}},
+	}
+	for _, tt := range tests {
+		if got := parseAPIPath(tt.path); got != tt.want {
+			t.Errorf("parseAPIPath(%q) = %+v, want %+v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDoRequest_ReturnsAPIError(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	req, _ := http.NewRequest("GET", apiURL("/workspaces/ws/projects/p"), nil)
+	_, err := doRequest(req)
+
+	var ae *apiError
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &ae) {
+		t.Fatalf("expected *apiError, got %T", err)
+	}
+	if ae.Status != http.StatusNotFound || ae.Path != "/api/v1/workspaces/ws/projects/p" {
+		t.Errorf("got status %d path %q", ae.Status, ae.Path)
+	}
+}
+
+func TestAPIErrorCard(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+	cfg = &config.Config{Server: "https://ancla.dev", Workspace: "ws", Project: "p", Env: "e", Service: "s"}
+
+	t.Run("404 service suggests list and re-link", func(t *testing.T) {
+		e := &apiError{Status: 404, Message: "not found", Path: "/api/v1/workspaces/ws/projects/p/envs/e/services/s/"}
+		c, ok := e.card()
+		if !ok {
+			t.Fatal("expected a card")
+		}
+		joined := strings.Join(c.Hints, "\n")
+		if !strings.Contains(joined, "ancla services list ws/p/e") {
+			t.Errorf("hints missing list command: %q", joined)
+		}
+		if !strings.Contains(joined, "ancla link ws/p/e") {
+			t.Errorf("hints missing link command: %q", joined)
+		}
+	})
+
+	t.Run("404 outside linked workspace skips re-link", func(t *testing.T) {
+		e := &apiError{Status: 404, Message: "not found", Path: "/api/v1/workspaces/other/projects/x"}
+		c, _ := e.card()
+		joined := strings.Join(c.Hints, "\n")
+		if !strings.Contains(joined, "ancla projects list other") {
+			t.Errorf("hints missing list command: %q", joined)
+		}
+		if strings.Contains(joined, "ancla link") {
+			t.Errorf("unexpected link hint: %q", joined)
+		}
+	})
+
+	t.Run("401 suggests login", func(t *testing.T) {
+		c, ok := (&apiError{Status: 401}).card()
+		if !ok || !strings.Contains(strings.Join(c.Hints, "\n"), "ancla login") {
+			t.Errorf("expected login hint, got %+v", c)
+		}
+	})
+
+	t.Run("quota message", func(t *testing.T) {
+		e := &apiError{Status: 400, Message: "Service quota exceeded for plan", Path: "/api/v1/workspaces/ws/projects/p/envs/e/services/"}
+		c, ok := e.card()
+		if !ok || c.Title != "Plan limit reached" {
+			t.Fatalf("expected quota card, got %+v", c)
+		}
+	})
+
+	t.Run("429 is rate limiting, not quota", func(t *testing.T) {
+		e := &apiError{Status: 429, Message: "Rate limit exceeded", Path: "/api/v1/workspaces/ws/projects/"}
+		c, ok := e.card()
+		if !ok || c.Title != "Rate limited" {
+			t.Fatalf("expected rate limit card, got %+v", c)
+		}
+		if !strings.Contains(strings.Join(c.Hints, "\n"), "retry") {
+			t.Errorf("expected retry hint, got %q", c.Hints)
+		}
+	})
+
+	t.Run("plain validation error has no card", func(t *testing.T) {
+		if _, ok := (&apiError{Status: 422, Message: "validation failed"}).card(); ok {
+			t.Error("expected no card for 422")
+		}
+	})
+}
+
+func TestReportError(t *testing.T) {
+	origCfg, origJSON := cfg, jsonFlag
+	defer func() { cfg, jsonFlag = origCfg, origJSON }()
+
+	var listings int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/workspaces/":
+			listings++
+			w.Write([]byte(`[{"slug":"ws"}]`))
+		case "/api/v1/workspaces/ws/projects/":
+			listings++
+			w.Write([]byte(`[{"slug":"webapp"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	notFound := func() error {
+		req, _ := http.NewRequest("GET", apiURL("/workspaces/ws/projects/web-app"), nil)
+		_, err := doRequest(req)
+		return fmt.Errorf("getting project: %w", err)
+	}
+
+	t.Run("404 card names the missing segment", func(t *testing.T) {
+		var buf strings.Builder
+		reportError(&buf, notFound())
+		if got := buf.String(); !strings.Contains(got, "project 'web-app' not found — did you mean 'webapp'?") {
+			t.Errorf("output missing suggestion:\n%s", got)
+		}
+	})
+
+	t.Run("json mode skips lookup", func(t *testing.T) {
+		jsonFlag = true
+		defer func() { jsonFlag = false }()
+		listings = 0
+		var buf strings.Builder
+		reportError(&buf, notFound())
+		if listings != 0 {
+			t.Errorf("made %d listing requests in JSON mode", listings)
+		}
+		if got := buf.String(); !strings.Contains(got, "getting project: not found") {
+			t.Errorf("output = %q", got)
+		}
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		var buf strings.Builder
+		reportError(&buf, errors.New("boom"))
+		if got := buf.String(); !strings.Contains(got, "boom") {
+			t.Errorf("output = %q", got)
+		}
+	})
+}
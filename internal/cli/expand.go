@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// expandAnnotation marks a command whose arguments and string flags may
+// use the ${env:NAME} and ${file:path} substitutions config files take, so
+// CI systems that run it without a shell can still pass in image tags and
+// secrets.
+const expandAnnotation = "ancla/expand"
+
+// expandCommandLine replaces the substitutions in args and in the string
+// flags set on cmd, in place, when cmd is marked with expandAnnotation. A
+// relative file path is read from the current directory. Unlike in a
+// config file, a substitution that can't be expanded fails the command,
+// since it is this command's own input.
+func expandCommandLine(cmd *cobra.Command, args []string) error {
+	if cmd.Annotations[expandAnnotation] == "" {
+		return nil
+	}
+	var err error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if err != nil || f.Value.Type() != "string" {
+			return
+		}
+		expanded, ferr := config.ExpandReferences(f.Value.String(), "")
+		if ferr != nil {
+			err = fmt.Errorf("--%s: %w", f.Name, ferr)
+			return
+		}
+		err = f.Value.Set(expanded)
+	})
+	if err != nil {
+		return err
+	}
+	for i, arg := range args {
+		expanded, err := config.ExpandReferences(arg, "")
+		if err != nil {
+			return err
+		}
+		args[i] = expanded
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExpandCommandLine(t *testing.T) {
+	t.Setenv("CI_SELECTOR", "team=payments")
+	t.Setenv("CI_ENV", "staging")
+
+	cmd := &cobra.Command{Use: "deploy", Annotations: map[string]string{expandAnnotation: "true"}}
+	cmd.Flags().StringP("selector", "l", "", "")
+	if err := cmd.ParseFlags([]string{"-l", "${env:CI_SELECTOR}"}); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"ws/proj/${env:CI_ENV}"}
+	if err := expandCommandLine(cmd, args); err != nil {
+		t.Fatalf("expandCommandLine() error: %v", err)
+	}
+	if got, _ := cmd.Flags().GetString("selector"); got != "team=payments" {
+		t.Errorf("--selector = %q, want the variable's value", got)
+	}
+	if args[0] != "ws/proj/staging" {
+		t.Errorf("args[0] = %q, want the variable's value", args[0])
+	}
+
+	err := expandCommandLine(cmd, []string{"ws/proj/${env:CI_UNSET_ENV}"})
+	if err == nil || !strings.Contains(err.Error(), "CI_UNSET_ENV is not set") {
+		t.Errorf("err = %v, want one naming the unset variable", err)
+	}
+
+	// Commands without the annotation take their input as written.
+	plain := &cobra.Command{Use: "config"}
+	args = []string{"KEY=${env:CI_ENV}"}
+	if err := expandCommandLine(plain, args); err != nil || args[0] != "KEY=${env:CI_ENV}" {
+		t.Errorf("args[0] = %q, err = %v, want the argument untouched", args[0], err)
+	}
+}
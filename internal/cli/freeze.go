@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func init() {
+	rootCmd.AddCommand(freezeCmd)
+	freezeCmd.AddCommand(freezeListCmd)
+	freezeCmd.AddCommand(freezeSetCmd)
+	freezeCmd.AddCommand(freezeRemoveCmd)
+	freezeSetCmd.Flags().String("reason", "", "Why deploys are frozen, shown to anyone who tries to deploy")
+	freezeSetCmd.Flags().String("tz", "UTC", "Time zone the window is in, e.g. Europe/London")
+}
+
+// weekMinutes is the length of the weekly cycle freeze windows repeat in.
+const weekMinutes = 7 * 24 * 60
+
+// weekdayNames are the days of the week from Monday, the start of a
+// freeze window's week.
+var weekdayNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// envFreeze is a weekly window during which deploys to an environment are
+// refused, e.g. "Fri 17:00-Mon 09:00" in Timezone.
+type envFreeze struct {
+	ID       string `json:"id,omitempty"`
+	Window   string `json:"window"`
+	Timezone string `json:"timezone,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// freezeWindow is a parsed freeze window, in minutes from Monday 00:00.
+// A window whose end is before its start wraps around the week.
+type freezeWindow struct {
+	start, end int
+}
+
+// parseFreezeWindow parses "<day> <HH:MM>-<day> <HH:MM>". Days may be
+// abbreviated to three letters.
+func parseFreezeWindow(s string) (freezeWindow, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return freezeWindow{}, fmt.Errorf("invalid freeze window %q — expected <day> <HH:MM>-<day> <HH:MM>, e.g. \"Fri 17:00-Mon 09:00\"", s)
+	}
+	start, err := parseWeekTime(from)
+	if err != nil {
+		return freezeWindow{}, err
+	}
+	end, err := parseWeekTime(to)
+	if err != nil {
+		return freezeWindow{}, err
+	}
+	if start == end {
+		return freezeWindow{}, fmt.Errorf("freeze window %q starts and ends at the same time", s)
+	}
+	return freezeWindow{start, end}, nil
+}
+
+// parseWeekTime parses "Fri 17:00" into minutes from Monday 00:00.
+func parseWeekTime(s string) (int, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid time %q — expected <day> <HH:MM>", strings.TrimSpace(s))
+	}
+	day := strings.ToLower(fields[0])
+	idx := -1
+	for i, name := range weekdayNames {
+		if len(day) >= 3 && strings.HasPrefix(name, day) {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return 0, fmt.Errorf("invalid day %q — expected Mon, Tue, Wed, Thu, Fri, Sat or Sun", fields[0])
+	}
+	t, err := time.Parse("15:04", fields[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q — expected HH:MM", fields[1])
+	}
+	return idx*24*60 + t.Hour()*60 + t.Minute(), nil
+}
+
+// String formats w the way parseFreezeWindow reads it.
+func (w freezeWindow) String() string {
+	format := func(m int) string {
+		day := weekdayNames[m/(24*60)]
+		return fmt.Sprintf("%s%s %02d:%02d", strings.ToUpper(day[:1]), day[1:3], m/60%24, m%60)
+	}
+	return format(w.start) + "-" + format(w.end)
+}
+
+// active reports whether t falls inside w, and if so when w ends. t
+// should already be in the window's time zone.
+func (w freezeWindow) active(t time.Time) (time.Time, bool) {
+	m := (int(t.Weekday())+6)%7*24*60 + t.Hour()*60 + t.Minute()
+	in := m >= w.start && m < w.end
+	if w.end < w.start {
+		in = m >= w.start || m < w.end
+	}
+	if !in {
+		return time.Time{}, false
+	}
+	left := (w.end - m + weekMinutes) % weekMinutes
+	return t.Truncate(time.Minute).Add(time.Duration(left) * time.Minute), true
+}
+
+// activeFreeze returns the first of freezes in effect at now, and when it
+// ends. Windows that don't parse are skipped.
+func activeFreeze(freezes []envFreeze, now time.Time) (*envFreeze, time.Time) {
+	for i, f := range freezes {
+		w, err := parseFreezeWindow(f.Window)
+		if err != nil {
+			continue
+		}
+		loc := time.UTC
+		if l, err := time.LoadLocation(f.Timezone); err == nil && f.Timezone != "" {
+			loc = l
+		}
+		if end, ok := w.active(now.In(loc)); ok {
+			return &freezes[i], end
+		}
+	}
+	return nil, time.Time{}
+}
+
+// checkFreezes refuses a deploy to ref during an active freeze, showing
+// the freeze and its reason. With --override-freeze it goes ahead after
+// the server confirms the user is an admin of the environment, and
+// reports true so the override is sent with the deploy.
+func checkFreezes(cmd *cobra.Command, ref config.ServiceRef, freezes []envFreeze, now time.Time) (bool, error) {
+	f, end := activeFreeze(freezes, now)
+	if f == nil {
+		return false, nil
+	}
+	env := config.ServiceRef{Workspace: ref.Workspace, Project: ref.Project, Env: ref.Env}
+	msg := fmt.Sprintf("deploys to %s are frozen until %s (%s)", env, end.Format("Mon 15:04 MST"), f.Window)
+	if f.Reason != "" {
+		msg += ": " + f.Reason
+	}
+	if !boolFlag(cmd, "override-freeze") {
+		return false, fmt.Errorf("%s — an admin can deploy anyway with --override-freeze", msg)
+	}
+	if dryRun {
+		return true, nil
+	}
+	check, err := checkPermission("admin", env)
+	if err != nil {
+		return false, err
+	}
+	if !check.Allowed {
+		return false, fmt.Errorf("%s — overriding a freeze needs the admin role on %s", msg, env)
+	}
+	if !isQuiet() {
+		fmt.Fprintln(os.Stderr, stWarning.Render("! Overriding the freeze: "+msg))
+	}
+	return true, nil
+}
+
+var freezeCmd = &cobra.Command{
+	Use:   "freeze",
+	Short: "Manage deploy freeze windows",
+	Long: `Freeze windows are weekly periods during which deploys to an environment
+are refused, such as "Fri 17:00-Mon 09:00" to keep weekends quiet. An
+environment can have several. A deploy during a freeze fails with the
+window and its reason; an admin of the environment can deploy anyway with
+--override-freeze. The server enforces freezes for every client.`,
+	Example:     "  ancla freeze list\n  ancla freeze set \"Fri 17:00-Mon 09:00\" --reason \"No weekend deploys\"\n  ancla freeze remove fz_123",
+	GroupID:     "workflow",
+	Annotations: map[string]string{featureAnnotation: "envs.freezes"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return freezeListCmd.RunE(cmd, args)
+	},
+}
+
+var freezeListCmd = &cobra.Command{
+	Use:     "list [<ws>/<proj>/<env>]",
+	Short:   "List the freeze windows of an environment",
+	Example: "  ancla freeze list my-ws/my-proj/production",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveEnv(args)
+		if err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("GET", apiURL(ref.EnvPath()+"/freezes/"), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var freezes []envFreeze
+		if err := decodeJSON(body, &freezes); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(freezes)
+		}
+		if len(freezes) == 0 {
+			fmt.Println("No freeze windows — deploys are allowed at any time.")
+			return nil
+		}
+
+		active, end := activeFreeze(freezes, time.Now())
+		var rows [][]string
+		for i, f := range freezes {
+			status := ""
+			if active == &freezes[i] {
+				status = stWarning.Render("active until " + end.Format("Mon 15:04"))
+			}
+			tz := f.Timezone
+			if tz == "" {
+				tz = "UTC"
+			}
+			rows = append(rows, []string{f.ID, f.Window, tz, f.Reason, status})
+		}
+		table([]string{"ID", "WINDOW", "TIMEZONE", "REASON", "STATUS"}, rows)
+		return nil
+	},
+}
+
+var freezeSetCmd = &cobra.Command{
+	Use:   "set [<ws>/<proj>/<env>] <window>",
+	Short: "Add a freeze window",
+	Long: `Add a weekly freeze window to an environment. The window is
+"<day> <HH:MM>-<day> <HH:MM>" in the time zone given with --tz, and may
+wrap around the end of the week.`,
+	Example: "  ancla freeze set \"Fri 17:00-Mon 09:00\" --reason \"No weekend deploys\"\n  ancla freeze set my-ws/my-proj/production \"Mon 00:00-Mon 06:00\" --tz Europe/London",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveEnv(args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		w, err := parseFreezeWindow(args[len(args)-1])
+		if err != nil {
+			return err
+		}
+		tz, _ := cmd.Flags().GetString("tz")
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("unknown time zone %q — use an IANA name such as Europe/London", tz)
+		}
+		reason, _ := cmd.Flags().GetString("reason")
+
+		data, _ := json.Marshal(envFreeze{Window: w.String(), Timezone: tz, Reason: reason})
+		req, _ := http.NewRequest("POST", apiURL(ref.EnvPath()+"/freezes/"), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var f envFreeze
+		if err := decodeJSON(body, &f); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(f)
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone(fmt.Sprintf("Deploys to %s are frozen %s %s", ref, f.Window, f.Timezone)))
+			if active, end := activeFreeze([]envFreeze{f}, time.Now()); active != nil {
+				fmt.Println(stDim.Render("  The window is active now, until " + end.Format("Mon 15:04 MST") + "."))
+			}
+		}
+		return nil
+	},
+}
+
+var freezeRemoveCmd = &cobra.Command{
+	Use:     "remove [<ws>/<proj>/<env>] <id>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a freeze window",
+	Example: "  ancla freeze remove fz_123",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveEnv(args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		id := args[len(args)-1]
+		req, _ := http.NewRequest("DELETE", apiURL(ref.EnvPath()+"/freezes/"+id), nil)
+		if _, err := doRequest(req); err != nil {
+			return err
+		}
+		if !isQuiet() && !isJSON() {
+			fmt.Println(stepDone("Removed freeze window " + id))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestParseFreezeWindow(t *testing.T) {
+	for in, want := range map[string]string{
+		"Fri 17:00-Mon 09:00":        "Fri 17:00-Mon 09:00",
+		"friday 17:00 - monday 9:00": "Fri 17:00-Mon 09:00",
+		"Sat 00:00-Sun 23:59":        "Sat 00:00-Sun 23:59",
+		"thu 22:30-Thursday 23:45":   "Thu 22:30-Thu 23:45",
+	} {
+		w, err := parseFreezeWindow(in)
+		if err != nil || w.String() != want {
+			t.Errorf("parseFreezeWindow(%q) = %q, %v; want %q", in, w, err, want)
+		}
+	}
+	for _, bad := range []string{"Fri 17:00", "Fr 17:00-Mon 09:00", "Fri 25:00-Mon 09:00", "Mon 09:00-Mon 09:00"} {
+		if _, err := parseFreezeWindow(bad); err == nil {
+			t.Errorf("parseFreezeWindow(%q) accepted an invalid window", bad)
+		}
+	}
+}
+
+func TestActiveFreeze(t *testing.T) {
+	freezes := []envFreeze{
+		{ID: "weekend", Window: "Fri 17:00-Mon 09:00"},
+		{ID: "london", Window: "Wed 09:00-Wed 10:00", Timezone: "Europe/London"},
+	}
+	tests := []struct {
+		now     string
+		wantID  string
+		wantEnd string
+	}{
+		{"2026-10-16T18:00:00Z", "weekend", "2026-10-19T09:00:00Z"}, // Friday evening
+		{"2026-10-19T08:59:00Z", "weekend", "2026-10-19T09:00:00Z"}, // Monday morning, wrapped
+		{"2026-10-19T09:00:00Z", "", ""},
+		{"2026-10-14T08:30:00Z", "london", "2026-10-14T09:00:00Z"}, // 09:30 BST
+		{"2026-10-14T09:30:00Z", "", ""},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(time.RFC3339, tt.now)
+		f, end := activeFreeze(freezes, now)
+		switch {
+		case tt.wantID == "" && f != nil:
+			t.Errorf("at %s: freeze %s is active, want none", tt.now, f.ID)
+		case tt.wantID != "" && (f == nil || f.ID != tt.wantID):
+			t.Errorf("at %s: active freeze = %v, want %s", tt.now, f, tt.wantID)
+		case tt.wantID != "" && !end.Equal(mustTime(tt.wantEnd)):
+			t.Errorf("at %s: freeze ends %s, want %s", tt.now, end.UTC().Format(time.RFC3339), tt.wantEnd)
+		}
+	}
+}
+
+func mustTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCheckFreezes_OverrideNeedsAdmin(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	allowed := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action") != "admin" {
+			t.Errorf("permission check for %q, want admin", r.URL.Query().Get("action"))
+		}
+		if allowed {
+			w.Write([]byte(`{"allowed":true,"role":"admin"}`))
+		} else {
+			w.Write([]byte(`{"allowed":false,"role":"deployer"}`))
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	ref := config.ServiceRef{Workspace: "ws", Project: "proj", Env: "production", Service: "api"}
+	freezes := []envFreeze{{Window: "Fri 17:00-Mon 09:00", Reason: "Quarter close"}}
+	friday := mustTime("2026-10-16T18:00:00Z")
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("override-freeze", false, "")
+	_, err := checkFreezes(cmd, ref, freezes, friday)
+	if err == nil || !strings.Contains(err.Error(), "Quarter close") || !strings.Contains(err.Error(), "--override-freeze") {
+		t.Errorf("err = %v, want the reason and a hint to override", err)
+	}
+
+	cmd.Flags().Set("override-freeze", "true")
+	if _, err := checkFreezes(cmd, ref, freezes, friday); err == nil || !strings.Contains(err.Error(), "admin role") {
+		t.Errorf("err = %v, want the override refused for a non-admin", err)
+	}
+	allowed = true
+	override, err := checkFreezes(cmd, ref, freezes, friday)
+	if err != nil || !override {
+		t.Errorf("checkFreezes() = %v, %v; want an override", override, err)
+	}
+}
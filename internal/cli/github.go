@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func init() {
+	rootCmd.AddCommand(githubCmd)
+	githubCmd.AddCommand(githubConnectCmd)
+	githubCmd.AddCommand(githubDisconnectCmd)
+	githubCmd.AddCommand(githubBranchesCmd)
+	githubConnectCmd.Flags().String("repo", "", "GitHub repository as owner/repo (default: the origin remote of the current directory)")
+
+	servicesCmd.AddCommand(servicesSetAutodeployCmd)
+	servicesSetAutodeployCmd.Flags().String("branch", "", "Branch whose pushes deploy the service")
+	servicesSetAutodeployCmd.Flags().Bool("off", false, "Stop deploying on push")
+	servicesSetAutodeployCmd.MarkFlagsMutuallyExclusive("branch", "off")
+	servicesSetAutodeployCmd.MarkFlagsOneRequired("branch", "off")
+}
+
+// githubRepoRe matches an owner/repo GitHub repository name.
+var githubRepoRe = regexp.MustCompile(`^[A-Za-z0-9-]+/[A-Za-z0-9._-]+$`)
+
+var githubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Connect services to GitHub repositories",
+	Long: `Manage the GitHub repository a service builds from and the branch that
+deploys it on push.
+
+Builds clone the connected repository through the Ancla GitHub App, so
+the app must be installed on the repository's owner. Once connected, set
+an auto-deploy branch with ` + "`ancla services set-autodeploy`" + `.`,
+	Example: "  ancla github connect\n  ancla github connect --repo acme/api my-ws/my-proj/staging/api\n  ancla github branches",
+	GroupID: "config",
+}
+
+var githubConnectCmd = &cobra.Command{
+	Use:   "connect [<ws>/<proj>/<env>/<svc>]",
+	Short: "Connect a service to a GitHub repository",
+	Long: `Connect a service to a GitHub repository, replacing any repository it
+was connected to. The repository defaults to the origin remote of the
+current directory.`,
+	Example: "  ancla github connect\n  ancla github connect --repo acme/api\n  ancla github connect --repo acme/api my-ws/my-proj/staging/api",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, _ := cmd.Flags().GetString("repo")
+		if repo == "" {
+			if repo = detectGitHubRepo(); repo == "" {
+				return fmt.Errorf("no GitHub origin remote in the current directory — pass --repo owner/repo")
+			}
+		}
+		if !githubRepoRe.MatchString(repo) {
+			return fmt.Errorf("invalid repository %q — expected owner/repo", repo)
+		}
+		ref, err := resolveService(args)
+		if err != nil {
+			return err
+		}
+
+		svc, err := patchServiceGitHub(ref, map[string]any{"github_repository": repo})
+		if err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(svc)
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone(fmt.Sprintf("%s is connected to %s", ref.Service, stAccent.Render(repo))))
+			if svc.AutoDeployBranch == "" {
+				fmt.Println(stDim.Render("  Deploy on push with `ancla services set-autodeploy --branch main`"))
+			}
+		}
+		return nil
+	},
+}
+
+var githubDisconnectCmd = &cobra.Command{
+	Use:     "disconnect [<ws>/<proj>/<env>/<svc>]",
+	Short:   "Disconnect a service from its GitHub repository",
+	Long:    "Disconnect a service from its GitHub repository and clear its auto-deploy\nbranch, so pushes no longer deploy it.",
+	Example: "  ancla github disconnect\n  ancla github disconnect my-ws/my-proj/staging/api",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveService(args)
+		if err != nil {
+			return err
+		}
+		if err := confirmAction(fmt.Sprintf("Disconnect %s from GitHub?", ref.String())); err != nil {
+			return err
+		}
+		svc, err := patchServiceGitHub(ref, map[string]any{"github_repository": nil, "auto_deploy_branch": nil})
+		if err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(svc)
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone(ref.Service + " is disconnected from GitHub"))
+		}
+		return nil
+	},
+}
+
+var githubBranchesCmd = &cobra.Command{
+	Use:   "branches [<ws>/<proj>/<env>/<svc>]",
+	Short: "List the branches of a service's repository",
+	Long: `List the branches of the repository a service is connected to, as the
+Ancla GitHub App sees them. The auto-deploy branch is marked.`,
+	Example: "  ancla github branches\n  ancla github branches my-ws/my-proj/staging/api",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveService(args)
+		if err != nil {
+			return err
+		}
+		svc, err := fetchServiceGitHub(ref)
+		if err != nil {
+			return err
+		}
+		if svc.GithubRepository == "" {
+			return errNotConnected(ref)
+		}
+
+		req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()+"/github/branches"), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var branches []struct {
+			Name      string `json:"name"`
+			CommitSHA string `json:"commit_sha"`
+			Protected bool   `json:"protected"`
+		}
+		if err := decodeJSON(body, &branches); err != nil {
+			return fmt.Errorf("parsing branches: %w", err)
+		}
+		if isJSON() {
+			return printJSON(branches)
+		}
+		if len(branches) == 0 {
+			fmt.Printf("No branches found in %s.\n", svc.GithubRepository)
+			return nil
+		}
+
+		var rows [][]string
+		for _, b := range branches {
+			var notes []string
+			if b.Name == svc.AutoDeployBranch {
+				notes = append(notes, stSuccess.Render("auto-deploy"))
+			}
+			if b.Protected {
+				notes = append(notes, "protected")
+			}
+			rows = append(rows, []string{b.Name, b.CommitSHA, strings.Join(notes, ", ")})
+		}
+		tableColumns([]tableColumn{{Header: "BRANCH"}, {Header: "COMMIT", Max: 7, Ellipsis: ellipsisNone}, {Header: "NOTES"}}, rows)
+		return nil
+	},
+}
+
+var servicesSetAutodeployCmd = &cobra.Command{
+	Use:   "set-autodeploy [<ws>/<proj>/<env>/<svc>] (--branch <branch> | --off)",
+	Short: "Choose the branch that deploys a service on push",
+	Long: `Deploy the service whenever a commit is pushed to a branch of its
+GitHub repository, or stop deploying on push with --off. The service must
+be connected to a repository first — see ` + "`ancla github connect`" + `.`,
+	Example: "  ancla services set-autodeploy --branch main\n  ancla services set-autodeploy my-ws/my-proj/staging/api --branch develop\n  ancla services set-autodeploy --off",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveService(args)
+		if err != nil {
+			return err
+		}
+		branch, _ := cmd.Flags().GetString("branch")
+
+		var value any
+		if branch != "" {
+			svc, err := fetchServiceGitHub(ref)
+			if err != nil {
+				return err
+			}
+			if svc.GithubRepository == "" {
+				return errNotConnected(ref)
+			}
+			value = branch
+		}
+		svc, err := patchServiceGitHub(ref, map[string]any{"auto_deploy_branch": value})
+		if err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(svc)
+		}
+		if isQuiet() {
+			return nil
+		}
+		if branch == "" {
+			fmt.Println(stepDone(ref.Service + " no longer deploys on push"))
+		} else {
+			fmt.Println(stepDone(fmt.Sprintf("Pushes to %s of %s now deploy %s", stAccent.Render(branch), svc.GithubRepository, ref.Service)))
+		}
+		return nil
+	},
+}
+
+// serviceGitHub is the GitHub wiring of a service.
+type serviceGitHub struct {
+	Slug             string `json:"slug"`
+	GithubRepository string `json:"github_repository"`
+	AutoDeployBranch string `json:"auto_deploy_branch"`
+}
+
+// fetchServiceGitHub reads the GitHub wiring of the service ref.
+func fetchServiceGitHub(ref config.ServiceRef) (*serviceGitHub, error) {
+	req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var svc serviceGitHub
+	if err := decodeJSON(body, &svc); err != nil {
+		return nil, fmt.Errorf("parsing service: %w", err)
+	}
+	return &svc, nil
+}
+
+// patchServiceGitHub updates the GitHub fields of the service ref; nil
+// values clear them.
+func patchServiceGitHub(ref config.ServiceRef, fields map[string]any) (*serviceGitHub, error) {
+	data, _ := json.Marshal(fields)
+	req, _ := http.NewRequest("PATCH", apiURL(ref.ServicePath()), bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var svc serviceGitHub
+	if err := decodeJSON(body, &svc); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &svc, nil
+}
+
+// errNotConnected is returned for GitHub commands on a service without a
+// repository.
+func errNotConnected(ref config.ServiceRef) error {
+	return fmt.Errorf("%s isn't connected to a GitHub repository — run `ancla github connect` first", ref.Service)
+}
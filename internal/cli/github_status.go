@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// githubAPI is the GitHub REST API deploy statuses are posted to.
+var githubAPI = "https://api.github.com"
+
+// githubStatus posts the progress of one deploy as a commit status on the
+// commit being deployed, so pull requests and branches on GitHub show it.
+// A nil *githubStatus posts nothing.
+type githubStatus struct {
+	token   string
+	repo    string // owner/repo
+	sha     string
+	env     string
+	context string // "ancla/<env>/<svc>", one status line per target
+	target  string // dashboard page of the service
+	failed  bool
+}
+
+// newGitHubStatus returns a reporter for a deploy of ref, or nil when no
+// github_token is set, --no-github-status or --dry-run is given, or the
+// repository or commit can't be determined. Only the missing repository
+// or commit is worth a warning; without a token reporting is simply off.
+func newGitHubStatus(cmd *cobra.Command, ref config.ServiceRef) *githubStatus {
+	if cfg.GitHubToken == "" || dryRun {
+		return nil
+	}
+	if off, _ := cmd.Flags().GetBool("no-github-status"); off {
+		return nil
+	}
+	repo := ""
+	if svc, err := fetchServiceGitHub(ref); err == nil {
+		repo = svc.GithubRepository
+	}
+	if repo == "" {
+		repo = detectGitHubRepo()
+	}
+	sha := gitHeadCommit()
+	if repo == "" || sha == "" {
+		warnGitHubStatus("no GitHub repository or commit to report on")
+		return nil
+	}
+	return &githubStatus{
+		token:   cfg.GitHubToken,
+		repo:    repo,
+		sha:     sha,
+		env:     ref.Env,
+		context: "ancla/" + ref.Env + "/" + ref.Service,
+		target:  serverURL() + "/workspaces/" + ref.Workspace + "/" + ref.Project + "/services/" + ref.Service + "?env=" + ref.Env,
+	}
+}
+
+// gitHeadCommit returns the commit checked out in the current directory.
+func gitHeadCommit() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// post sets the commit status to state (pending, success, failure, or
+// error). A failure to post is reported once and never fails the deploy.
+func (g *githubStatus) post(state, description string) {
+	if g == nil || g.failed {
+		return
+	}
+	if r := []rune(description); len(r) > 140 { // GitHub's limit
+		description = string(r[:139]) + "…"
+	}
+	if err := g.send(state, description); err != nil {
+		g.failed = true
+		warnGitHubStatus(err.Error())
+	}
+}
+
+// finish posts the outcome of a followed deploy.
+func (g *githubStatus) finish(err error) {
+	if g == nil {
+		return
+	}
+	if err == nil {
+		g.post("success", "Deployed to "+g.env)
+	} else {
+		g.post("failure", err.Error())
+	}
+}
+
+func (g *githubStatus) send(state, description string) error {
+	data, _ := json.Marshal(map[string]string{
+		"state":       state,
+		"target_url":  g.target,
+		"description": description,
+		"context":     g.context,
+	})
+	req, _ := http.NewRequest("POST", githubAPI+"/repos/"+g.repo+"/statuses/"+g.sha, bytes.NewReader(data))
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		var body struct {
+			Message string `json:"message"`
+		}
+		raw, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(raw, &body)
+		if body.Message == "" {
+			body.Message = resp.Status
+		}
+		return fmt.Errorf("GitHub returned %d for %s: %s", resp.StatusCode, g.repo, body.Message)
+	}
+	return nil
+}
+
+// warnGitHubStatus tells the user the commit status wasn't posted.
+func warnGitHubStatus(reason string) {
+	if isQuiet() {
+		return
+	}
+	fmt.Fprintln(os.Stderr, stWarning.Render("! Not posting the deploy status to GitHub: "+reason))
+}
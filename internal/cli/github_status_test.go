@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestGitHubStatus_Post(t *testing.T) {
+	origAPI := githubAPI
+	defer func() { githubAPI = origAPI }()
+
+	var posted []map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/api/statuses/abc123" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer ghp_test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		posted = append(posted, body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+	githubAPI = ts.URL
+
+	g := &githubStatus{token: "ghp_test", repo: "acme/api", sha: "abc123", env: "staging", context: "ancla/staging/api", target: "https://ancla.dev/x"}
+	g.post("pending", "Deploying to staging")
+	g.finish(errors.New(strings.Repeat("x", 200)))
+
+	if len(posted) != 2 {
+		t.Fatalf("posted %d statuses, want 2", len(posted))
+	}
+	if posted[0]["state"] != "pending" || posted[0]["context"] != "ancla/staging/api" || posted[0]["target_url"] != "https://ancla.dev/x" {
+		t.Errorf("first status = %v", posted[0])
+	}
+	if posted[1]["state"] != "failure" || len([]rune(posted[1]["description"])) != 140 {
+		t.Errorf("second status = %v, want a failure cut to 140 characters", posted[1])
+	}
+}
+
+func TestGitHubStatus_StopsAfterFailure(t *testing.T) {
+	origAPI := githubAPI
+	defer func() { githubAPI = origAPI }()
+
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer ts.Close()
+	githubAPI = ts.URL
+
+	g := &githubStatus{token: "t", repo: "acme/api", sha: "abc123"}
+	if err := g.send("pending", ""); err == nil || !strings.Contains(err.Error(), "Not Found") {
+		t.Errorf("err = %v, want GitHub's message", err)
+	}
+	g.post("pending", "")
+	g.finish(nil)
+	if calls != 2 {
+		t.Errorf("%d calls, want no posts after the first failure", calls)
+	}
+
+	var none *githubStatus
+	none.post("pending", "")
+	none.finish(nil)
+}
+
+func TestNewGitHubStatus_Disabled(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+	ref := config.ServiceRef{Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	cfg = &config.Config{}
+	if g := newGitHubStatus(&cobra.Command{}, ref); g != nil {
+		t.Error("reporter created without a token")
+	}
+
+	cfg = &config.Config{GitHubToken: "t"}
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("no-github-status", false, "")
+	cmd.Flags().Set("no-github-status", "true")
+	if g := newGitHubStatus(cmd, ref); g != nil {
+		t.Error("reporter created with --no-github-status")
+	}
+}
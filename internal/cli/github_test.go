@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestGithubConnectCmd(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var patched map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &patched)
+		w.Write([]byte(`{"slug":"api","github_repository":"acme/api"}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	githubConnectCmd.Flags().Set("repo", "acme/api")
+	defer githubConnectCmd.Flags().Set("repo", "")
+	if err := githubConnectCmd.RunE(githubConnectCmd, nil); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if patched["github_repository"] != "acme/api" {
+		t.Errorf("patched = %v", patched)
+	}
+
+	githubConnectCmd.Flags().Set("repo", "https://github.com/acme/api")
+	if err := githubConnectCmd.RunE(githubConnectCmd, nil); err == nil || !strings.Contains(err.Error(), "expected owner/repo") {
+		t.Errorf("err = %v, want invalid repository", err)
+	}
+}
+
+func TestServicesSetAutodeployCmd(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	repo := ""
+	var patched map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			body, _ := io.ReadAll(r.Body)
+			patched = nil
+			json.Unmarshal(body, &patched)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"slug": "api", "github_repository": repo})
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	cmd := servicesSetAutodeployCmd
+	cmd.Flags().Set("branch", "main")
+	defer cmd.Flags().Set("branch", "")
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "ancla github connect") {
+		t.Errorf("err = %v, want not connected", err)
+	}
+	if patched != nil {
+		t.Errorf("patched %v for a service without a repository", patched)
+	}
+
+	repo = "acme/api"
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if patched["auto_deploy_branch"] != "main" {
+		t.Errorf("patched = %v", patched)
+	}
+
+	// --off clears the branch.
+	cmd.Flags().Set("branch", "")
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if v, ok := patched["auto_deploy_branch"]; !ok || v != nil {
+		t.Errorf("patched = %v, want auto_deploy_branch null", patched)
+	}
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// hookedCmd is the command whose pre hook has run, so Execute knows to run
+// its post hook. Nil when hooks are disabled or the command never started.
+var (
+	hookedCmd  *cobra.Command
+	hookedArgs []string
+)
+
+// hookName returns the config key for a command's hook, e.g. "pre_deploy"
+// or "post_services_scale".
+func hookName(phase string, cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
+	return phase + "_" + strings.Join(strings.Fields(path), "_")
+}
+
+// hookEnv describes the command to a hook through ANCLA_* variables. The
+// service path segments use the same names the config reads, so an ancla
+// invoked from the hook targets the same service. Post hooks also get
+// ANCLA_RESULT (success or failure) and, on failure, ANCLA_ERROR.
+func hookEnv(phase string, cmd *cobra.Command, args []string, result error) []string {
+	var arg string
+	for _, a := range args {
+		if strings.Contains(a, "/") {
+			arg = a
+			break
+		}
+	}
+	ref, _ := config.ResolveServiceRef(arg, cfg)
+	path := strings.Trim(strings.Join([]string{ref.Workspace, ref.Project, ref.Env, ref.Service}, "/"), "/")
+
+	vars := []string{
+		"ANCLA_COMMAND=" + strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		"ANCLA_WORKSPACE=" + ref.Workspace,
+		"ANCLA_PROJECT=" + ref.Project,
+		"ANCLA_ENV=" + ref.Env,
+		"ANCLA_SERVICE=" + ref.Service,
+		"ANCLA_SERVICE_PATH=" + path,
+	}
+	if phase == "post" {
+		if result != nil {
+			vars = append(vars, "ANCLA_RESULT=failure", "ANCLA_ERROR="+result.Error())
+		} else {
+			vars = append(vars, "ANCLA_RESULT=success")
+		}
+	}
+	return vars
+}
+
+// runHook runs the configured hook for phase ("pre" or "post"), if any.
+// Hook output goes to stderr so it never mixes with --json output.
+func runHook(phase string, cmd *cobra.Command, args []string, result error) error {
+	name := hookName(phase, cmd)
+	script := cfg.Hooks[name]
+	if script == "" {
+		return nil
+	}
+	if !isQuiet() {
+		fmt.Fprintln(os.Stderr, stDim.Render("  Running "+name+" hook: "+script))
+	}
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", script)
+	} else {
+		c = exec.Command("sh", "-c", script)
+	}
+	c.Env = append(os.Environ(), hookEnv(phase, cmd, args, result)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", name, err)
+	}
+	return nil
+}
+
+// runPreHook runs the command's pre hook and remembers the command so its
+// post hook runs once the command finishes. Dry runs skip hooks.
+func runPreHook(cmd *cobra.Command, args []string) error {
+	if noHooks || dryRun {
+		return nil
+	}
+	if err := runHook("pre", cmd, args, nil); err != nil {
+		return err
+	}
+	hookedCmd, hookedArgs = cmd, args
+	return nil
+}
+
+// runPostHook runs the post hook of the command started by runPreHook. It
+// runs whether the command succeeded or failed; result is passed through.
+func runPostHook(result error) error {
+	if hookedCmd == nil {
+		return result
+	}
+	cmd := hookedCmd
+	hookedCmd = nil
+	if err := runHook("post", cmd, hookedArgs, result); err != nil && result == nil {
+		return err
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestHookName(t *testing.T) {
+	if got := hookName("pre", servicesScaleCmd); got != "pre_services_scale" {
+		t.Errorf("hookName = %q", got)
+	}
+}
+
+func TestHooks_RunAroundCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run through sh")
+	}
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	out := filepath.Join(t.TempDir(), "hook.log")
+	cfg = &config.Config{
+		Workspace: "ws", Project: "proj", Env: "staging", Service: "api",
+		Hooks: map[string]string{
+			"pre_services_scale":  `echo "pre $ANCLA_SERVICE_PATH" >> ` + out,
+			"post_services_scale": `echo "post $ANCLA_RESULT $ANCLA_ERROR" >> ` + out,
+		},
+	}
+
+	if err := runPreHook(servicesScaleCmd, nil); err != nil {
+		t.Fatalf("runPreHook error: %v", err)
+	}
+	cmdErr := errors.New("boom")
+	if err := runPostHook(cmdErr); err != cmdErr {
+		t.Errorf("runPostHook should pass the command error through, got %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	want := "pre ws/proj/staging/api\npost failure boom\n"
+	if string(data) != want {
+		t.Errorf("hook log = %q, want %q", data, want)
+	}
+
+	// A failing pre hook stops the command, and no post hook runs.
+	cfg.Hooks["pre_services_scale"] = "exit 3"
+	err := runPreHook(servicesScaleCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "pre_services_scale hook failed") {
+		t.Errorf("expected pre hook failure, got %v", err)
+	}
+	if err := runPostHook(nil); err != nil {
+		t.Errorf("runPostHook after failed pre hook = %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// locale is the language prompts and error cards are shown in. ANCLA_LANG
+// wins over the POSIX locale, so a team can read the CLI in Spanish without
+// changing the rest of their system.
+var locale = detectLocale(os.Getenv)
+
+// detectLocale returns the language code for the first locale variable
+// that is set, or "en" when none is, or it names a language without a
+// catalog. "es_MX.UTF-8" and "es" both select Spanish.
+func detectLocale(getenv func(string) string) string {
+	for _, name := range []string{"ANCLA_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := getenv(name)
+		if v == "" {
+			continue
+		}
+		lang, _, _ := strings.Cut(strings.ToLower(v), ".")
+		lang, _, _ = strings.Cut(lang, "_")
+		lang, _, _ = strings.Cut(lang, "-")
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+		return "en"
+	}
+	return "en"
+}
+
+// tr translates an English message into the current locale and formats it
+// with args. The English text is the key, so untranslated messages, and
+// every message in English, come out as written.
+func tr(msg string, args ...any) string {
+	if t, ok := catalogs[locale][msg]; ok {
+		msg = t
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// capitalize upper-cases the first letter of s, for a translated word
+// that starts a title.
+func capitalize(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// yesAnswers are the replies, besides "y" and "yes", that confirm a prompt
+// in each locale.
+var yesAnswers = map[string][]string{
+	"es": {"s", "si", "sí"},
+}
+
+// isYes reports whether answer confirms a [y/N] prompt.
+func isYes(answer string) bool {
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	if answer == "y" || answer == "yes" {
+		return true
+	}
+	for _, a := range yesAnswers[locale] {
+		if answer == a {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogs maps a language code to translations of English messages.
+// Format verbs must appear in the same order as in the English text.
+var catalogs = map[string]map[string]string{
+	"en": {},
+	"es": {
+		// Confirmation prompts.
+		"%s Are you sure? [y/N] ": "%s ¿Seguro? [s/N] ",
+		"%s Type %q to confirm: ": "%s Escribe %q para confirmar: ",
+
+		// Error card layout.
+		"Next steps": "Siguientes pasos",
+		"Dashboard":  "Panel",
+
+		// Pipeline failures.
+		"Build failed":                          "Falló la compilación",
+		"Deploy failed":                         "Falló el despliegue",
+		"Pipeline timed out":                    "Se agotó el tiempo del pipeline",
+		"Authentication failed":                 "Falló la autenticación",
+		"Pipeline error":                        "Error del pipeline",
+		"Check the build log for details":       "Revisa el registro de compilación para ver los detalles",
+		"Add a Procfile to your project root":   "Añade un Procfile en la raíz del proyecto",
+		"Verify your Dockerfile builds locally": "Comprueba que tu Dockerfile compila en local",
+		"Build exceeded time limit — try optimizing layers or caching": "La compilación superó el tiempo límite — optimiza las capas o usa la caché",
+		"Run %s to view full output":                                   "Ejecuta %s para ver la salida completa",
+		"Check deploy logs for crash details":                          "Revisa los registros del despliegue para ver por qué falló",
+		"Verify health check endpoint responds":                        "Comprueba que el endpoint de health check responde",
+		"Run %s to view output":                                        "Ejecuta %s para ver la salida",
+		"The build or deploy did not complete in time":                 "La compilación o el despliegue no terminó a tiempo",
+		"Check server status and try again":                            "Comprueba el estado del servidor e inténtalo de nuevo",
+		"Run %s to check current state":                                "Ejecuta %s para ver el estado actual",
+		"Your session may have expired":                                "Puede que tu sesión haya caducado",
+		"Run %s to re-authenticate":                                    "Ejecuta %s para volver a autenticarte",
+		"Check the dashboard for details":                              "Consulta el panel para ver los detalles",
+
+		// API errors.
+		"Not authenticated":                                "No autenticado",
+		"Run %s to authenticate":                           "Ejecuta %s para autenticarte",
+		"Your API key may have expired or been revoked":    "Puede que tu clave de API haya caducado o se haya revocado",
+		"Permission denied":                                "Permiso denegado",
+		"Run %s to check which account is active":          "Ejecuta %s para ver qué cuenta está activa",
+		"Ask an admin of workspace %q to grant you access": "Pide a un administrador del espacio de trabajo %q que te dé acceso",
+
+		// Not-found errors.
+		"Not found":    "No encontrado",
+		"%s not found": "%s no encontrado",
+		"%s does not exist or is not visible to you": "%s no existe o no tienes acceso",
+		"%s '%s' not found":                          "%s '%s' no encontrado",
+		" — did you mean '%s'?":                      " — ¿quisiste decir '%s'?",
+		"Run %s to see what exists":                  "Ejecuta %s para ver qué existe",
+		"If this directory's link is stale, run %s":  "Si el enlace de este directorio está desactualizado, ejecuta %s",
+		"Checking path...":                           "Comprobando la ruta...",
+		"Endpoint not found":                         "Endpoint no encontrado",
+		"Rate limited":                               "Límite de peticiones alcanzado",
+		"Too many requests in a short time — wait a moment and retry": "Demasiadas peticiones en poco tiempo — espera un momento y reinténtalo",
+		"Plan limit reached":                                          "Límite del plan alcanzado",
+		"Remove unused resources or upgrade your plan":                "Elimina los recursos que no uses o mejora tu plan",
+		"The server (version %s) expects a newer CLI — upgrade ancla": "El servidor (versión %s) espera una CLI más reciente — actualiza ancla",
+		"The server does not report its version, so it may be older than this CLI — check with your administrator or try an older release": "El servidor no informa de su versión, así que puede ser más antiguo que esta CLI — consulta con tu administrador o prueba una versión anterior",
+
+		// Path segments, as named in not-found messages.
+		"workspace":   "espacio de trabajo",
+		"project":     "proyecto",
+		"environment": "entorno",
+		"service":     "servicio",
+	},
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"nothing set", nil, "en"},
+		{"LANG", map[string]string{"LANG": "es_ES.UTF-8"}, "es"},
+		{"ANCLA_LANG wins", map[string]string{"ANCLA_LANG": "en", "LANG": "es_ES.UTF-8"}, "en"},
+		{"LC_ALL over LANG", map[string]string{"LC_ALL": "es_MX", "LANG": "en_US.UTF-8"}, "es"},
+		{"bare code", map[string]string{"ANCLA_LANG": "ES"}, "es"},
+		{"no catalog", map[string]string{"LANG": "fr_FR.UTF-8"}, "en"},
+		{"C locale", map[string]string{"LC_ALL": "C", "LANG": "es_ES.UTF-8"}, "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLocale(func(k string) string { return tt.env[k] }); got != tt.want {
+				t.Errorf("detectLocale = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCatalogVerbs guards against translations that would misformat their
+// arguments: each must use the same verbs, in order, as its English key.
+func TestCatalogVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z]`)
+	for lang, catalog := range catalogs {
+		for en, translated := range catalog {
+			if got, want := verbs.FindAllString(translated, -1), verbs.FindAllString(en, -1); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, translated, got, want)
+			}
+		}
+	}
+}
+
+func TestTr_Spanish(t *testing.T) {
+	origLocale := locale
+	defer func() { locale = origLocale }()
+	locale = "es"
+
+	if got := tr("Run %s to authenticate", "ancla login"); got != "Ejecuta ancla login para autenticarte" {
+		t.Errorf("tr = %q", got)
+	}
+	if got := tr("No translation for %d", 3); got != "No translation for 3" {
+		t.Errorf("untranslated message = %q, want the English text", got)
+	}
+
+	e := &apiError{Status: 404, Path: "/api/v1/workspaces/ws/projects/p"}
+	e.missing = &missingSegment{Kind: "project", Slug: "p", Suggestion: "pp"}
+	c, _ := e.card()
+	if c.Title != "Proyecto no encontrado" || !strings.Contains(c.Detail, "¿quisiste decir 'pp'?") {
+		t.Errorf("card = %+v", c)
+	}
+}
+
+func TestIsYes(t *testing.T) {
+	origLocale := locale
+	defer func() { locale = origLocale }()
+
+	locale = "en"
+	if !isYes("Yes\n") || isYes("si") || isYes("") {
+		t.Error("English answers misread")
+	}
+	locale = "es"
+	if !isYes("sí\n") || !isYes("y") || isYes("no") {
+		t.Error("Spanish answers misread")
+	}
+}
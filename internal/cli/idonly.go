@@ -0,0 +1,17 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// addIDOnlyFlag registers --id-only on a list or get command, whose
+// primary identifier is described by what. It has no short form: -q is
+// --quiet.
+func addIDOnlyFlag(cmd *cobra.Command, what string) {
+	cmd.Flags().Bool("id-only", false, "Print only the "+what+", one per line, for scripts")
+}
+
+// idOnly reports whether --id-only was given. It takes precedence over
+// --output, so a configured output format doesn't break pipelines.
+func idOnly(cmd *cobra.Command) bool {
+	v, _ := cmd.Flags().GetBool("id-only")
+	return v
+}
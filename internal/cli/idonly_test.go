@@ -0,0 +1,18 @@
+package cli
+
+import "testing"
+
+func TestDeploysListIDOnlyCassette(t *testing.T) {
+	ts := cassetteServer(t, "deploys-id-only")
+	out, err := runCLI(t, cliRun{
+		server: ts.URL,
+		link:   "workspace: acme\nproject: shop\nenv: production\nservice: web\n",
+	}, "deploys", "list", "--id-only", "--filter", "status=complete", "-o", "json")
+	if err != nil {
+		t.Fatalf("deploys list: %v\n%s", err, out)
+	}
+	want := "d91c6e02-5b3a-4f7e-9a11-2c8d0f4b7e55\na2e87d34-0c5f-49b1-8d6a-e3f2b1c09a47\n"
+	if out != want {
+		t.Errorf("output = %q, want full IDs one per line, ignoring -o json:\n%q", out, want)
+	}
+}
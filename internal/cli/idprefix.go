@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// fullIDLen is the length from which an argument is taken as a whole ID
+// and sent as is, without looking it up.
+const fullIDLen = 32
+
+// resolveIDPrefix returns the ID in ids that prefix abbreviates, the way
+// git resolves short hashes. An exact match wins; a prefix matching
+// several IDs is an error listing them. A prefix matching none is returned
+// unchanged for the server to judge, since ids may be only the most
+// recent page.
+func resolveIDPrefix(kind, prefix string, ids []string) (string, error) {
+	var matches []string
+	for _, id := range ids {
+		if id == prefix {
+			return id, nil
+		}
+		if strings.HasPrefix(id, prefix) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return prefix, nil
+	case 1:
+		return matches[0], nil
+	}
+	shown := strings.Join(matches, ", ")
+	if len(matches) > 5 {
+		shown = strings.Join(matches[:5], ", ") + ", …"
+	}
+	return "", fmt.Errorf("%s ID %q is ambiguous — it matches %d %ss: %s\n\n  Use more characters of the ID",
+		kind, prefix, len(matches), kind, shown)
+}
+
+// resolveDeployID expands a short deploy ID of the service ref to the full
+// ID through the deploys list. Full-length IDs, and IDs for a ref without
+// a service to list, are returned unchanged.
+func resolveDeployID(ref config.ServiceRef, id string) (string, error) {
+	if len(id) >= fullIDLen || !ref.HasService() {
+		return id, nil
+	}
+	req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()+"/deploys/"), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("looking up deploy %s: %w", id, err)
+	}
+	var items []deployItem
+	if err := decodeJSON(body, &items); err != nil {
+		return "", fmt.Errorf("parsing deploys: %w", err)
+	}
+	ids := make([]string, len(items))
+	for i, d := range items {
+		ids[i] = d.ID
+	}
+	return resolveIDPrefix("deploy", id, ids)
+}
+
+// resolveBuildVersion turns a build argument into the version the build
+// endpoints take. A number, with or without a leading "v", is a version;
+// anything else is a build ID or a short prefix of one, looked up in the
+// builds list.
+func resolveBuildVersion(sp, arg string) (string, error) {
+	if n, err := strconv.Atoi(strings.TrimPrefix(arg, "v")); err == nil && n > 0 {
+		return strconv.Itoa(n), nil
+	}
+	req, _ := http.NewRequest("GET", apiURL(sp+"/builds/"), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("looking up build %s: %w", arg, err)
+	}
+	var result struct {
+		Items []buildItem `json:"items"`
+	}
+	if err := decodeJSON(body, &result); err != nil {
+		return "", fmt.Errorf("parsing builds: %w", err)
+	}
+	ids := make([]string, len(result.Items))
+	for i, b := range result.Items {
+		ids[i] = b.ID
+	}
+	id, err := resolveIDPrefix("build", arg, ids)
+	if err != nil {
+		return "", err
+	}
+	for _, b := range result.Items {
+		if b.ID == id {
+			return strconv.Itoa(b.Version), nil
+		}
+	}
+	return "", fmt.Errorf("no build with ID %q among the %d most recent builds — see `ancla builds list`", arg, len(result.Items))
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestResolveIDPrefix(t *testing.T) {
+	ids := []string{"3fa85f64-aaaa", "3fa8c001-bbbb", "9f2c1a00-cccc", "9f2c"}
+	tests := []struct {
+		prefix, want, err string
+	}{
+		{"3fa85", "3fa85f64-aaaa", ""},
+		{"9f2c", "9f2c", ""}, // an exact match beats longer IDs
+		{"9f2c1", "9f2c1a00-cccc", ""},
+		{"ffff", "ffff", ""}, // unknown, left to the server
+		{"3fa8", "", "matches 2 deploys: 3fa85f64-aaaa, 3fa8c001-bbbb"},
+	}
+	for _, tt := range tests {
+		got, err := resolveIDPrefix("deploy", tt.prefix, ids)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: err = %v, want %q", tt.prefix, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: got %q, %v; want %q", tt.prefix, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveDeployArgs_Prefix(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api/deploys/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"3fa85f64-5717-4562-b3fc-2c963f66afa6"},{"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7"}]`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	ep, id, err := resolveDeployArgs([]string{"7c9e"})
+	if err != nil || id != "7c9e6679-7425-40de-944b-e07fc1f90ae7" {
+		t.Errorf("got %q, %v; want the full ID", id, err)
+	}
+	if ep != "/workspaces/ws/projects/proj/envs/staging" {
+		t.Errorf("ep = %q", ep)
+	}
+}
+
+func TestResolveBuildVersion(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"items":[{"id":"9f2c1a00-0000","version":7},{"id":"9f3b0000-0000","version":6}]}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	for arg, want := range map[string]string{"3": "3", "v12": "12", "9f2c": "7", "9f3b0000-0000": "6"} {
+		got, err := resolveBuildVersion("/svc", arg)
+		if err != nil || got != want {
+			t.Errorf("%s: got %q, %v; want %s", arg, got, err, want)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("%d list requests, want 2 — versions shouldn't be looked up", requests)
+	}
+	if _, err := resolveBuildVersion("/svc", "9f"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("err = %v, want ambiguous", err)
+	}
+	if _, err := resolveBuildVersion("/svc", "abc"); err == nil || !strings.Contains(err.Error(), "no build with ID") {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
@@ -2,10 +2,8 @@ package cli
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 
@@ -35,17 +33,15 @@ requiring explicit arguments.`,
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
-	reader := bufio.NewReader(os.Stdin)
+	if nonInteractiveFlag {
+		return fmt.Errorf("init is interactive — in scripts, run %s instead", stAccent.Render("ancla link <ws>/<proj>/<env>/<svc>"))
+	}
+	reader := stdinReader()
 
 	// Step 1: Check if already linked
 	if cfg.IsLinked() {
-		fmt.Printf("This directory is already linked to: %s\n", cfg.ServicePath())
-		fmt.Print("Continue and re-link? [y/N] ")
-		answer, _ := reader.ReadString('\n')
-		answer = strings.TrimSpace(strings.ToLower(answer))
-		if answer != "y" && answer != "yes" {
-			fmt.Println("Aborted.")
-			return nil
+		if err := confirmAction(fmt.Sprintf("This directory is already linked to %s; re-link it?", cfg.ServicePath())); err != nil {
+			return err
 		}
 	}
 
@@ -79,7 +75,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	cfg.Env = envSlug
 	cfg.Service = svcSlug
 
-	if err := config.SaveLocal(cfg); err != nil {
+	if err := config.SaveLocal(cfg, config.LinkKeys...); err != nil {
 		return fmt.Errorf("saving local config: %w", err)
 	}
 
@@ -107,7 +103,7 @@ func selectWorkspace(reader *bufio.Reader) (string, error) {
 		Name string `json:"name"`
 		Slug string `json:"slug"`
 	}
-	if err := json.Unmarshal(body, &workspaces); err != nil {
+	if err := decodeJSON(body, &workspaces); err != nil {
 		return "", fmt.Errorf("parsing workspaces: %w", err)
 	}
 
@@ -154,7 +150,7 @@ func selectProject(reader *bufio.Reader, wsSlug string) (string, error) {
 		Name string `json:"name"`
 		Slug string `json:"slug"`
 	}
-	if err := json.Unmarshal(body, &projects); err != nil {
+	if err := decodeJSON(body, &projects); err != nil {
 		return "", fmt.Errorf("parsing projects: %w", err)
 	}
 
@@ -199,7 +195,7 @@ func selectEnv(reader *bufio.Reader, wsSlug, projectSlug string) (string, error)
 		Name string `json:"name"`
 		Slug string `json:"slug"`
 	}
-	if err := json.Unmarshal(body, &envs); err != nil {
+	if err := decodeJSON(body, &envs); err != nil {
 		return "", fmt.Errorf("parsing environments: %w", err)
 	}
 
@@ -244,7 +240,7 @@ func selectService(reader *bufio.Reader, wsSlug, projectSlug, envSlug string) (s
 		Name string `json:"name"`
 		Slug string `json:"slug"`
 	}
-	if err := json.Unmarshal(body, &services); err != nil {
+	if err := decodeJSON(body, &services); err != nil {
 		return "", fmt.Errorf("parsing services: %w", err)
 	}
 
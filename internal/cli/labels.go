@@ -0,0 +1,381 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	servicesCmd.AddCommand(servicesLabelCmd)
+	servicesListCmd.Flags().StringP("selector", "l", "", "Only list services matching a label selector, e.g. team=payments,tier!=batch")
+	addListFlags(servicesListCmd, serviceListFields.names()...)
+	addIDOnlyFlag(servicesListCmd, "service slugs")
+	addIDOnlyFlag(servicesGetCmd, "service slug")
+}
+
+// labelKeyRe matches label keys: letters, digits, '-', '_', '.', and an
+// optional '/'-separated prefix, as in Kubernetes.
+var labelKeyRe = regexp.MustCompile(`^([a-z0-9.-]+/)?[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+
+// labelRequirement is one comma-separated term of a label selector.
+type labelRequirement struct {
+	Key   string
+	Op    string // "=", "!=", "exists", or "!exists"
+	Value string
+}
+
+// labelSelector matches services whose labels satisfy every requirement.
+type labelSelector []labelRequirement
+
+// parseSelector parses a selector such as "team=payments,tier!=batch,canary".
+// A bare key requires the label to be present; "!key" requires it absent.
+func parseSelector(s string) (labelSelector, error) {
+	var sel labelSelector
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		var r labelRequirement
+		switch {
+		case strings.Contains(term, "!="):
+			r.Key, r.Value, _ = strings.Cut(term, "!=")
+			r.Op = "!="
+		case strings.Contains(term, "="):
+			r.Key, r.Value, _ = strings.Cut(term, "=")
+			r.Key = strings.TrimSuffix(r.Key, "=") // allow "=="
+			r.Value = strings.TrimPrefix(r.Value, "=")
+			r.Op = "="
+		case strings.HasPrefix(term, "!"):
+			r.Key, r.Op = term[1:], "!exists"
+		default:
+			r.Key, r.Op = term, "exists"
+		}
+		r.Key, r.Value = strings.TrimSpace(r.Key), strings.TrimSpace(r.Value)
+		if !labelKeyRe.MatchString(r.Key) {
+			return nil, fmt.Errorf("invalid label selector %q: bad key %q", s, r.Key)
+		}
+		sel = append(sel, r)
+	}
+	if len(sel) == 0 {
+		return nil, fmt.Errorf("empty label selector")
+	}
+	return sel, nil
+}
+
+// Matches reports whether labels satisfy the selector.
+func (sel labelSelector) Matches(labels map[string]string) bool {
+	for _, r := range sel {
+		v, ok := labels[r.Key]
+		switch r.Op {
+		case "=":
+			if !ok || v != r.Value {
+				return false
+			}
+		case "!=":
+			if ok && v == r.Value {
+				return false
+			}
+		case "exists":
+			if !ok {
+				return false
+			}
+		case "!exists":
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String formats the selector for the label_selector query parameter.
+func (sel labelSelector) String() string {
+	terms := make([]string, len(sel))
+	for i, r := range sel {
+		switch r.Op {
+		case "exists":
+			terms[i] = r.Key
+		case "!exists":
+			terms[i] = "!" + r.Key
+		default:
+			terms[i] = r.Key + r.Op + r.Value
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// formatLabels renders labels as sorted key=value pairs, or "-" for none.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + labels[k]
+	}
+	return strings.Join(pairs, ",")
+}
+
+// labeledService is the part of a service list entry used for selection.
+type labeledService struct {
+	Name     string            `json:"name"`
+	Slug     string            `json:"slug"`
+	Platform string            `json:"platform"`
+	Status   string            `json:"status,omitempty"`
+	Created  string            `json:"created,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// serviceListFields are the fields services list sorts and filters by.
+var serviceListFields = listFields[labeledService]{
+	"name":     func(s labeledService) string { return s.Name },
+	"slug":     func(s labeledService) string { return s.Slug },
+	"platform": func(s labeledService) string { return s.Platform },
+	"status":   func(s labeledService) string { return s.Status },
+	"created":  func(s labeledService) string { return s.Created },
+}
+
+// listServices returns the services in an environment, narrowed by sel when
+// it is non-nil. The selector is sent to the server and also applied here,
+// so servers that ignore it still return the right services.
+func listServices(ws, proj, env string, sel labelSelector) ([]labeledService, error) {
+	path := serviceBasePath(ws, proj, env)
+	if sel != nil {
+		path += "?label_selector=" + url.QueryEscape(sel.String())
+	}
+	req, _ := http.NewRequest("GET", apiURL(path), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var services []labeledService
+	if err := decodeJSON(body, &services); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	if sel == nil {
+		return services, nil
+	}
+	matched := services[:0]
+	for _, s := range services {
+		if sel.Matches(s.Labels) {
+			matched = append(matched, s)
+		}
+	}
+	return matched, nil
+}
+
+// isLabelArg reports whether a services label argument is a label change
+// (key=value or key-) rather than the service to label.
+func isLabelArg(arg string) bool {
+	return strings.Contains(arg, "=") || strings.HasSuffix(arg, "-")
+}
+
+var servicesLabelCmd = &cobra.Command{
+	Use:   "label [<svc> | <ws>/<proj>/<env>/<svc>] [key=value ...] [key- ...]",
+	Short: "Show or change a service's labels",
+	Long: `Add, change, or remove labels on a service. key=value sets a label and
+key- removes it. With no changes, the current labels are printed.
+
+The service is a slug in the linked environment or a full
+ws/proj/env/svc path; leave it out to label the linked service. Labels
+select services for bulk commands such as "ancla services list -l" and
+"ancla deploy -l".`,
+	Example: `  ancla services label my-svc team=payments
+  ancla services label my-ws/my-proj/staging/api tier=web canary-
+  ancla services label`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec := ""
+		if len(args) > 0 && !isLabelArg(args[0]) {
+			spec, args = args[0], args[1:]
+		}
+		ref, err := resolveServiceSegments(spec)
+		if err != nil {
+			return err
+		}
+		if !ref.HasService() {
+			return fmt.Errorf("no service specified — provide a slug in the linked environment, <ws>/<proj>/<env>/<svc>, or run `ancla link` first")
+		}
+		sp := ref.ServicePath()
+
+		set := map[string]string{}
+		var remove []string
+		for _, arg := range args {
+			if k, v, ok := strings.Cut(arg, "="); ok {
+				if !labelKeyRe.MatchString(k) {
+					return fmt.Errorf("invalid label key %q", k)
+				}
+				set[k] = v
+				continue
+			}
+			k := strings.TrimSuffix(arg, "-")
+			if k == arg || !labelKeyRe.MatchString(k) {
+				return fmt.Errorf("invalid label argument %q (expected key=value or key-)", arg)
+			}
+			remove = append(remove, k)
+		}
+
+		req, _ := http.NewRequest("GET", apiURL(sp), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var current labeledService
+		if err := decodeJSON(body, &current); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		labels := current.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		if len(set) > 0 || len(remove) > 0 {
+			for k, v := range set {
+				labels[k] = v
+			}
+			for _, k := range remove {
+				delete(labels, k)
+			}
+			payload, _ := json.Marshal(map[string]any{"labels": labels})
+			req, _ := http.NewRequest("PATCH", apiURL(sp), bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			if _, err := doRequest(req); err != nil {
+				return err
+			}
+		}
+
+		if isJSON() {
+			return printJSON(labels)
+		}
+		if isQuiet() {
+			return nil
+		}
+		if len(set) > 0 || len(remove) > 0 {
+			fmt.Println(stepDone("Labels updated for " + ref.Service))
+		}
+		fmt.Println(kv("Labels", formatLabels(labels)))
+		return nil
+	},
+}
+
+// bulkDeployResult is the outcome of one deploy triggered by deploy -l.
+type bulkDeployResult struct {
+	Service string `json:"service"`
+	BuildID string `json:"build_id,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// deploySelected triggers a deploy for every service in the environment
+// whose labels match expr. It does not follow the pipelines; a failed
+// trigger is reported and the rest still run.
+func deploySelected(cmd *cobra.Command, args []string, expr string) error {
+	sel, err := parseSelector(expr)
+	if err != nil {
+		return err
+	}
+	ref, err := resolveEnv(args)
+	if err != nil {
+		return err
+	}
+
+	services, err := listServices(ref.Workspace, ref.Project, ref.Env, sel)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		return fmt.Errorf("no services in %s/%s/%s match %q", ref.Workspace, ref.Project, ref.Env, sel)
+	}
+	slugs := make([]string, len(services))
+	for i, s := range services {
+		slugs[i] = s.Slug
+	}
+	// Check every target before asking, so one service missing a config
+	// var stops the batch rather than leaving it half deployed.
+	var blocked []string
+	for _, slug := range slugs {
+		target := ref
+		target.Service = slug
+		missing, err := missingRequiredConfig(target)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			blocked = append(blocked, fmt.Sprintf("  %s: %s", slug, strings.Join(missing, ", ")))
+		}
+	}
+	if len(blocked) > 0 {
+		return fmt.Errorf("%d of %d services are missing required config vars:\n%s\n\n  Set them with `ancla config set <ws/proj/env/svc> KEY=value`, then deploy again",
+			len(blocked), len(slugs), strings.Join(blocked, "\n"))
+	}
+
+	msg := fmt.Sprintf("Deploy %d services in %s/%s/%s: %s.", len(slugs), ref.Workspace, ref.Project, ref.Env, strings.Join(slugs, ", "))
+	guard, err := guardDeploy(cmd, ref)
+	if err != nil {
+		return err
+	}
+	if err := confirmAction(msg); err != nil {
+		return err
+	}
+
+	var results []bulkDeployResult
+	failed := 0
+	for _, slug := range slugs {
+		r := bulkDeployResult{Service: slug}
+		target := ref
+		target.Service = slug
+		body, err := doRequest(newDeployRequest(target, guard, nil))
+		switch {
+		case errors.Is(err, errDryRun):
+			continue
+		case err != nil:
+			r.Error = err.Error()
+			failed++
+		default:
+			if out, err := decodeDeployResponse(body); err == nil {
+				r.BuildID = out.Build
+			}
+		}
+		results = append(results, r)
+	}
+	if dryRun {
+		return nil
+	}
+
+	if isJSON() {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	} else {
+		var rows [][]string
+		for _, r := range results {
+			outcome := stSuccess.Render("triggered")
+			if r.Error != "" {
+				outcome = stError.Render(r.Error)
+			}
+			build := r.BuildID
+			if build == "" {
+				build = "-"
+			}
+			rows = append(rows, []string{r.Service, build, outcome})
+		}
+		table([]string{"SERVICE", "BUILD", "RESULT"}, rows)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deploys failed to start", failed, len(results))
+	}
+	return nil
+}
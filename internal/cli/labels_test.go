@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"team": "payments", "tier": "web"}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"team=payments", true},
+		{"team==payments", true},
+		{"team=search", false},
+		{"team=payments,tier=web", true},
+		{"team=payments,tier!=web", false},
+		{"tier!=batch", true},
+		{"canary", false},
+		{"!canary", true},
+		{"team", true},
+	}
+	for _, tt := range tests {
+		sel, err := parseSelector(tt.expr)
+		if err != nil {
+			t.Fatalf("parseSelector(%q) error: %v", tt.expr, err)
+		}
+		if got := sel.Matches(labels); got != tt.want {
+			t.Errorf("%q.Matches() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "=x", "bad key=x"} {
+		if _, err := parseSelector(bad); err == nil {
+			t.Errorf("parseSelector(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestServicesLabelCmd_MergesLabels(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var patched map[string]map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.Method == "PATCH" {
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &patched)
+		}
+		w.Write([]byte(`{"slug":"api","labels":{"team":"search","canary":"true"}}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging"}
+
+	if err := servicesLabelCmd.RunE(servicesLabelCmd, []string{"api", "team=payments", "canary-"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	want := map[string]string{"team": "payments"}
+	if got := patched["labels"]; len(got) != 1 || got["team"] != want["team"] {
+		t.Errorf("patched labels = %v, want %v", got, want)
+	}
+}
+
+func TestDeploySelected_DeploysMatchingServices(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var deployed []string
+	var gotSelector string
+	workerRequires := `[]`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/envs/staging") {
+			w.Write([]byte(`{"slug":"staging"}`))
+			return
+		}
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/config/") {
+			w.Write([]byte(`[{"name":"DATABASE_URL","scope":"env"}]`))
+			return
+		}
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/services/worker") {
+			w.Write([]byte(`{"slug":"worker","required_config":` + workerRequires + `}`))
+			return
+		}
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/services/api") {
+			w.Write([]byte(`{"slug":"api","required_config":["DATABASE_URL"]}`))
+			return
+		}
+		if r.Method == "GET" {
+			gotSelector = r.URL.Query().Get("label_selector")
+			// This server ignores the selector; the CLI filters too.
+			w.Write([]byte(`[
+				{"slug":"api","labels":{"team":"payments"}},
+				{"slug":"web","labels":{"team":"search"}},
+				{"slug":"worker","labels":{"team":"payments"}}
+			]`))
+			return
+		}
+		parts := strings.Split(r.URL.Path, "/")
+		deployed = append(deployed, parts[len(parts)-2])
+		w.Write([]byte(`{"build_id":"b1"}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	cmd := deployActionCmd
+	if err := cmd.ParseFlags([]string{"--yes"}); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Flags().Set("yes", "false")
+
+	if err := deploySelected(cmd, []string{"ws/proj/staging"}, "team=payments"); err != nil {
+		t.Fatalf("deploySelected() error: %v", err)
+	}
+	if gotSelector != "team=payments" {
+		t.Errorf("label_selector = %q, want team=payments", gotSelector)
+	}
+	sort.Strings(deployed)
+	if strings.Join(deployed, ",") != "api,worker" {
+		t.Errorf("deployed = %v, want [api worker]", deployed)
+	}
+
+	// A service missing a required config var stops the whole batch.
+	workerRequires = `["DATABASE_URL","SECRET_KEY"]`
+	deployed = nil
+	err := deploySelected(cmd, []string{"ws/proj/staging"}, "team=payments")
+	if err == nil || !strings.Contains(err.Error(), "worker: SECRET_KEY") || strings.Contains(err.Error(), "api:") {
+		t.Errorf("err = %v, want only worker reported missing SECRET_KEY", err)
+	}
+	if len(deployed) > 0 {
+		t.Errorf("deployed %v despite missing config", deployed)
+	}
+}
@@ -49,7 +49,7 @@ without requiring explicit arguments.`,
 				cfg.Service = parts[3]
 			}
 
-			if err := config.SaveLocal(cfg); err != nil {
+			if err := config.SaveLocal(cfg, config.LinkKeys...); err != nil {
 				return fmt.Errorf("saving link: %w", err)
 			}
 
@@ -58,17 +58,19 @@ without requiring explicit arguments.`,
 		}
 
 		// Interactive mode — walk through the ensure chain
-		if err := ensureLoggedIn(); err != nil {
+		p := newProvisioner()
+		if err := p.ensureLoggedIn(); err != nil {
 			return err
 		}
+		p.verify(config.ServiceRef{Workspace: cfg.Workspace, Project: cfg.Project, Env: cfg.Env, Service: cfg.Service})
 
-		ws, err := ensureWorkspace(cfg.Workspace)
+		ws, err := p.ensureWorkspace(cfg.Workspace)
 		if err != nil {
 			return err
 		}
 		cfg.Workspace = ws
 
-		proj, err := ensureProject(ws, cfg.Project)
+		proj, err := p.ensureProject(ws, cfg.Project)
 		if err != nil {
 			return err
 		}
@@ -79,7 +81,7 @@ without requiring explicit arguments.`,
 			return saveAndPrintLink(cfg)
 		}
 
-		env, err := ensureEnv(ws, proj, cfg.Env)
+		env, err := p.ensureEnv(ws, proj, cfg.Env)
 		if err != nil {
 			return err
 		}
@@ -89,7 +91,7 @@ without requiring explicit arguments.`,
 			return saveAndPrintLink(cfg)
 		}
 
-		svc, err := ensureService(ws, proj, env, cfg.Service)
+		svc, err := p.ensureService(ws, proj, env, cfg.Service)
 		if err != nil {
 			return err
 		}
@@ -102,7 +104,7 @@ without requiring explicit arguments.`,
 // saveAndPrintLink saves the link context and prints a summary showing
 // which levels are linked.
 func saveAndPrintLink(c *config.Config) error {
-	if err := config.SaveLocal(c); err != nil {
+	if err := config.SaveLocal(c, config.LinkKeys...); err != nil {
 		return fmt.Errorf("saving link: %w", err)
 	}
 
@@ -65,7 +65,7 @@ var listCmd = &cobra.Command{
 		}
 
 		// Display projects grouped by workspace, highlighting the linked project
-		fmt.Println(stHeading.Render(symAnchor + " Your Projects"))
+		fmt.Println(heading("Your Projects"))
 		fmt.Println()
 		for _, ws := range workspaces {
 			fmt.Println(stBold.Render(ws.Name))
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// listFields maps the fields a list can be sorted and filtered by to how
+// each is read from an item. Values compare as strings, so timestamps
+// must be ISO 8601.
+type listFields[T any] map[string]func(T) string
+
+// names returns the field names in order, for help and errors.
+func (f listFields[T]) names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addListFlags registers --sort and --filter on a list command that
+// supports the given fields.
+func addListFlags(cmd *cobra.Command, fields ...string) {
+	cmd.Flags().String("sort", "", "Sort by "+strings.Join(fields, ", ")+"; prefix with - to reverse")
+	cmd.Flags().StringArray("filter", nil, "Only show items where FIELD=VALUE or FIELD!=VALUE (repeatable)")
+	cmd.RegisterFlagCompletionFunc("sort", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return fields, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// listFilter is one --filter condition.
+type listFilter struct {
+	field, value string
+	negate       bool
+}
+
+// applyListFlags filters items by --filter and sorts them by --sort, after
+// they are fetched and before they are printed, so table and JSON output
+// agree. Sorting is stable: items that compare equal keep the server's
+// order.
+func applyListFlags[T any](cmd *cobra.Command, items []T, fields listFields[T]) ([]T, error) {
+	exprs, _ := cmd.Flags().GetStringArray("filter")
+	var filters []listFilter
+	for _, expr := range exprs {
+		field, value, ok := strings.Cut(expr, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid filter %q — expected FIELD=VALUE or FIELD!=VALUE", expr)
+		}
+		f := listFilter{field: strings.TrimSuffix(field, "!"), value: value, negate: strings.HasSuffix(field, "!")}
+		if _, ok := fields[f.field]; !ok {
+			return nil, fmt.Errorf("can't filter by %q — use %s", f.field, strings.Join(fields.names(), ", "))
+		}
+		filters = append(filters, f)
+	}
+	if len(filters) > 0 {
+		kept := make([]T, 0, len(items))
+		for _, item := range items {
+			match := true
+			for _, f := range filters {
+				if strings.EqualFold(fields[f.field](item), f.value) == f.negate {
+					match = false
+					break
+				}
+			}
+			if match {
+				kept = append(kept, item)
+			}
+		}
+		items = kept
+	}
+
+	by, _ := cmd.Flags().GetString("sort")
+	if by == "" {
+		return items, nil
+	}
+	field, desc := strings.TrimPrefix(by, "-"), strings.HasPrefix(by, "-")
+	get, ok := fields[field]
+	if !ok {
+		return nil, fmt.Errorf("can't sort by %q — use %s", field, strings.Join(fields.names(), ", "))
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := strings.ToLower(get(items[i])), strings.ToLower(get(items[j]))
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+	return items, nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestApplyListFlags(t *testing.T) {
+	items := []deployItem{
+		{ID: "a", Complete: true, Created: "2026-10-14T09:00:00Z"},
+		{ID: "b", Error: true, Created: "2026-10-16T09:00:00Z"},
+		{ID: "c", Complete: true, Created: "2026-10-15T09:00:00Z"},
+		{ID: "d", Error: true, Created: "2026-10-13T09:00:00Z"},
+	}
+	ids := func(items []deployItem) string {
+		var s []string
+		for _, d := range items {
+			s = append(s, d.ID)
+		}
+		return strings.Join(s, ",")
+	}
+	tests := []struct {
+		flags []string
+		want  string
+	}{
+		{nil, "a,b,c,d"},
+		{[]string{"--sort", "created"}, "d,a,c,b"},
+		{[]string{"--sort", "-created"}, "b,c,a,d"},
+		{[]string{"--sort", "status"}, "a,c,b,d"}, // stable within a status
+		{[]string{"--filter", "status=ERROR"}, "b,d"},
+		{[]string{"--filter", "status!=error", "--sort", "-created"}, "c,a"},
+		{[]string{"--filter", "status=complete", "--filter", "created=2026-10-15T09:00:00Z"}, "c"},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		addListFlags(cmd, deployListFields.names()...)
+		if err := cmd.ParseFlags(tt.flags); err != nil {
+			t.Fatal(err)
+		}
+		got, err := applyListFlags(cmd, append([]deployItem(nil), items...), deployListFields)
+		if err != nil || ids(got) != tt.want {
+			t.Errorf("%v: got %s, %v; want %s", tt.flags, ids(got), err, tt.want)
+		}
+	}
+
+	for _, bad := range [][]string{{"--sort", "name"}, {"--filter", "name=api"}, {"--filter", "status"}} {
+		cmd := &cobra.Command{}
+		addListFlags(cmd, deployListFields.names()...)
+		cmd.ParseFlags(bad)
+		if _, err := applyListFlags(cmd, items, deployListFields); err == nil {
+			t.Errorf("%v: expected an error", bad)
+		}
+	}
+}
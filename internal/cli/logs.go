@@ -6,30 +6,55 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
 )
 
 func init() {
 	rootCmd.AddCommand(logsCmd)
-	logsCmd.Flags().BoolP("follow", "f", false, "Follow log output until deployment completes")
+	logsCmd.Flags().BoolP("follow", "f", false, "Follow log output as it arrives")
+	logsCmd.Flags().Bool("all", false, "Tail the runtime logs of every service in the environment")
+	logsCmd.Flags().StringP("selector", "l", "", "Tail the runtime logs of services matching a label selector")
+	logsCmd.Flags().StringArray("exclude", nil, "Skip services whose slug matches this glob (repeatable)")
+	logsCmd.Flags().Int("tail", 100, "Lines of history to show per service with --all or --selector")
 }
 
 var logsCmd = &cobra.Command{
-	Use:   "logs",
+	Use:   "logs [ws/proj/env]",
 	Short: "Show logs for the linked service's latest deployment",
 	Long: `Show deployment logs for the currently linked service.
 
 Requires a fully linked directory (workspace/project/env/service). Fetches
 the latest deployment and displays its log output. Use --follow to stream
-updates.`,
-	Example: "  ancla logs\n  ancla logs -f",
+updates.
+
+With --all or --selector, tail the runtime logs of several services in the
+environment at once instead. Each line is prefixed with its service name,
+colored per service; --exclude skips services by slug glob and --tail sets
+how much history to show. With --follow, lines are dropped (and counted)
+rather than stalling the other streams when output can't keep up.`,
+	Example: `  ancla logs
+  ancla logs -f
+  ancla logs --all -f
+  ancla logs -l tier=web --exclude 'worker-*' -f`,
 	GroupID: "workflow",
+	Args:    cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if cfg.Workspace == "" || cfg.Project == "" || cfg.Env == "" || cfg.Service == "" {
+		all, _ := cmd.Flags().GetBool("all")
+		if sel, _ := cmd.Flags().GetString("selector"); all || sel != "" {
+			return tailSelectedLogs(cmd, args)
+		}
+		if len(args) > 0 {
+			return fmt.Errorf("a path argument is only used with --all or --selector")
+		}
+
+		ref, _ := config.ResolveServiceRef("", cfg)
+		if !ref.HasService() {
 			return fmt.Errorf("not fully linked — run `ancla link <ws>/<proj>/<env>/<svc>` first")
 		}
 
 		// Get latest deploy from the deploys list.
-		svcPath := servicePath(cfg.Workspace, cfg.Project, cfg.Env, cfg.Service)
+		svcPath := ref.ServicePath()
 		req, _ := http.NewRequest("GET", apiURL(svcPath+"/deploys/"), nil)
 		body, err := doRequest(req)
 		if err != nil {
@@ -39,7 +64,7 @@ updates.`,
 		var deploys []struct {
 			ID string `json:"id"`
 		}
-		if err := json.Unmarshal(body, &deploys); err != nil {
+		if err := decodeJSON(body, &deploys); err != nil {
 			return fmt.Errorf("parsing deploys: %w", err)
 		}
 		if len(deploys) == 0 || deploys[0].ID == "" {
@@ -48,7 +73,7 @@ updates.`,
 		}
 
 		deployID := deploys[0].ID
-		ep := envPath(cfg.Workspace, cfg.Project, cfg.Env)
+		ep := ref.EnvPath()
 
 		// Fetch deployment logs (env-level endpoint).
 		logReq, _ := http.NewRequest("GET", apiURL(ep+"/deploys/"+deployID+"/log"), nil)
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	logsCmd.AddCommand(logsSearchCmd)
+	logsSearchCmd.Flags().String("since", "1h", "Only search logs newer than this duration, e.g. 30m, 6h")
+	logsSearchCmd.Flags().IntP("context", "C", 2, "Lines of context to show around each match")
+	logsSearchCmd.Flags().Int("limit", 100, "Maximum number of matches to return")
+}
+
+// logMatch is one search hit with the lines around it.
+type logMatch struct {
+	Service   string   `json:"service"`
+	Timestamp string   `json:"timestamp"`
+	Line      string   `json:"line"`
+	Before    []string `json:"before"`
+	After     []string `json:"after"`
+}
+
+var logsSearchCmd = &cobra.Command{
+	Use:         "search <query> [ws/proj/env[/svc]]",
+	Short:       "Search runtime logs",
+	Annotations: map[string]string{featureAnnotation: "logs.search"},
+	Long: `Search runtime logs with a structured query. The query is passed to the
+log backend as is: key=value terms match structured fields (level=error,
+status=500), and other words match the message text. All terms must match.
+
+Searches the linked service, or every service in the environment when the
+path stops at the environment. Matching terms are highlighted and each
+match is shown with --context lines before and after it.`,
+	Example: `  ancla logs search "level=error status=500" --since 1h
+  ancla logs search timeout my-ws/my-proj/staging -C 5
+  ancla logs search "level=error" --json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := strings.TrimSpace(args[0])
+		if query == "" {
+			return fmt.Errorf("empty query")
+		}
+		ref, err := resolveEnv(args[1:])
+		if err != nil {
+			return err
+		}
+		if len(args) == 2 && len(strings.Split(strings.Trim(args[1], "/"), "/")) < 4 {
+			// An explicit environment path searches the whole environment,
+			// even inside a directory linked to one service.
+			ref.Service = ""
+		}
+
+		since, _ := cmd.Flags().GetString("since")
+		if d, err := time.ParseDuration(since); err != nil || d <= 0 {
+			return fmt.Errorf("invalid --since %q — use a duration like 30m or 6h", since)
+		}
+		contextLines, _ := cmd.Flags().GetInt("context")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		q := url.Values{}
+		q.Set("q", query)
+		q.Set("since", since)
+		q.Set("context", strconv.Itoa(contextLines))
+		q.Set("limit", strconv.Itoa(limit))
+		if ref.Service != "" {
+			q.Set("service", ref.Service)
+		}
+
+		stop := spin("Searching logs...")
+		req, _ := http.NewRequest("GET", apiURL(ref.EnvPath()+"/logs/search?"+q.Encode()), nil)
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+		var result struct {
+			Matches   []logMatch `json:"matches"`
+			Truncated bool       `json:"truncated"`
+		}
+		if err := decodeJSON(body, &result); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(result)
+		}
+		if len(result.Matches) == 0 {
+			fmt.Printf("No matches in the last %s.\n", since)
+			return nil
+		}
+
+		terms := queryTerms(query)
+		for i, m := range result.Matches {
+			if i > 0 {
+				fmt.Println(stDim.Render("--"))
+			}
+			fmt.Println(stAccent.Render(m.Service) + "  " + stDim.Render(m.Timestamp))
+			for _, l := range m.Before {
+				fmt.Println(stDim.Render("  " + l))
+			}
+			fmt.Println(stMatch.Render(symPointer) + " " + highlightTerms(m.Line, terms))
+			for _, l := range m.After {
+				fmt.Println(stDim.Render("  " + l))
+			}
+		}
+		if !isQuiet() {
+			fmt.Println()
+			summary := fmt.Sprintf("%d matches in the last %s", len(result.Matches), since)
+			if result.Truncated {
+				summary += fmt.Sprintf(" (limited to %d — raise --limit or narrow the query)", limit)
+			}
+			fmt.Println(stDim.Render(summary))
+		}
+		return nil
+	},
+}
+
+// queryTerms returns the strings to highlight for a search query: each
+// word, and for key=value terms also the value on its own, since many log
+// lines print just the value.
+func queryTerms(query string) []string {
+	var terms []string
+	for _, f := range strings.Fields(query) {
+		f = strings.Trim(f, `"'`)
+		if f == "" {
+			continue
+		}
+		terms = append(terms, f)
+		if _, v, ok := strings.Cut(f, "="); ok && v != "" {
+			terms = append(terms, v)
+		}
+	}
+	return terms
+}
+
+// highlightTerms renders every case-insensitive occurrence of terms in line
+// with stMatch. Overlapping occurrences are merged into one span.
+func highlightTerms(line string, terms []string) string {
+	lower := strings.ToLower(line)
+	if len(lower) != len(line) {
+		// Case folding changed byte offsets; leave the line as is.
+		return line
+	}
+	var spans [][2]int
+	for _, t := range terms {
+		t = strings.ToLower(t)
+		for from := 0; t != ""; {
+			i := strings.Index(lower[from:], t)
+			if i < 0 {
+				break
+			}
+			start := from + i
+			spans = append(spans, [2]int{start, start + len(t)})
+			from = start + len(t)
+		}
+	}
+	if len(spans) == 0 {
+		return line
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+
+	var b strings.Builder
+	pos := 0
+	for i := 0; i < len(spans); {
+		start, end := spans[i][0], spans[i][1]
+		for i++; i < len(spans) && spans[i][0] <= end; i++ {
+			end = max(end, spans[i][1])
+		}
+		b.WriteString(line[pos:start])
+		b.WriteString(stMatch.Render(line[start:end]))
+		pos = end
+	}
+	b.WriteString(line[pos:])
+	return b.String()
+}
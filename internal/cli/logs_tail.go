@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// logBufferLines is how many lines the multi-service tail buffers between
+// the streams and the terminal before --follow starts dropping lines.
+const logBufferLines = 512
+
+// logPrefixColors cycle across services, as docker compose logs does.
+var logPrefixColors = []lipgloss.Color{"6", "3", "2", "5", "4", "14", "11", "10", "13", "12"}
+
+// logLine is one line from a service's runtime log stream. Dropped is set
+// instead of Line when lines had to be skipped to keep up.
+type logLine struct {
+	Service string `json:"service"`
+	Line    string `json:"line,omitempty"`
+	Dropped int    `json:"dropped,omitempty"`
+}
+
+// tailSelectedLogs tails the runtime logs of every service in the
+// environment that matches --selector and none of the --exclude patterns.
+func tailSelectedLogs(cmd *cobra.Command, args []string) error {
+	ref, err := resolveEnv(args)
+	if err != nil {
+		return err
+	}
+
+	var sel labelSelector
+	if expr, _ := cmd.Flags().GetString("selector"); expr != "" {
+		if sel, err = parseSelector(expr); err != nil {
+			return err
+		}
+	}
+	services, err := listServices(ref.Workspace, ref.Project, ref.Env, sel)
+	if err != nil {
+		return err
+	}
+	excludes, _ := cmd.Flags().GetStringArray("exclude")
+	slugs, err := filterExcluded(services, excludes)
+	if err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return fmt.Errorf("no services in %s/%s/%s to tail", ref.Workspace, ref.Project, ref.Env)
+	}
+
+	tail, _ := cmd.Flags().GetInt("tail")
+	follow, _ := cmd.Flags().GetBool("follow")
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	return tailServices(ctx, ref.Workspace, ref.Project, ref.Env, slugs, tail, follow, os.Stdout)
+}
+
+// filterExcluded returns the slugs of services that match none of the
+// exclude globs.
+func filterExcluded(services []labeledService, excludes []string) ([]string, error) {
+	var slugs []string
+outer:
+	for _, s := range services {
+		for _, pattern := range excludes {
+			ok, err := path.Match(pattern, s.Slug)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --exclude pattern %q: %w", pattern, err)
+			}
+			if ok {
+				continue outer
+			}
+		}
+		slugs = append(slugs, s.Slug)
+	}
+	return slugs, nil
+}
+
+// tailServices streams the logs of each service concurrently and writes
+// them to out with a colored, aligned service prefix. Without follow every
+// stream is read to the end and a slow out slows the readers down; with
+// follow a full buffer drops lines instead, so one noisy service can't
+// stall the rest, and a marker says how many were lost.
+func tailServices(ctx context.Context, ws, proj, env string, slugs []string, tail int, follow bool, out io.Writer) error {
+	lines := make(chan logLine, logBufferLines)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed []string
+
+	for _, svc := range slugs {
+		wg.Add(1)
+		go func(svc string) {
+			defer wg.Done()
+			if err := streamServiceLog(ctx, ws, proj, env, svc, tail, follow, lines); err != nil && ctx.Err() == nil {
+				mu.Lock()
+				failed = append(failed, svc)
+				mu.Unlock()
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", stError.Render(symCross), svc, err)
+			}
+		}(svc)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	width := 0
+	styles := map[string]lipgloss.Style{}
+	for i, svc := range slugs {
+		width = max(width, len(svc))
+		styles[svc] = lipgloss.NewStyle().Foreground(logPrefixColors[i%len(logPrefixColors)])
+	}
+	enc := json.NewEncoder(out)
+	for l := range lines {
+		if isJSON() {
+			enc.Encode(l)
+			continue
+		}
+		prefix := styles[l.Service].Render(fmt.Sprintf("%-*s |", width, l.Service))
+		if l.Dropped > 0 {
+			fmt.Fprintf(out, "%s %s\n", prefix, stWarning.Render(fmt.Sprintf("… %d lines dropped (output could not keep up)", l.Dropped)))
+			continue
+		}
+		fmt.Fprintf(out, "%s %s\n", prefix, l.Line)
+	}
+
+	if len(failed) == len(slugs) {
+		return fmt.Errorf("could not stream logs for any service")
+	}
+	return nil
+}
+
+// streamServiceLog reads one service's log stream line by line into lines.
+func streamServiceLog(ctx context.Context, ws, proj, env, svc string, tail int, follow bool, lines chan<- logLine) error {
+	q := url.Values{}
+	q.Set("tail", strconv.Itoa(tail))
+	if follow {
+		q.Set("follow", "true")
+	}
+	req, _ := http.NewRequestWithContext(ctx, "GET", apiURL(servicePath(ws, proj, env, svc)+"/logs?"+q.Encode()), nil)
+	resp, err := doStreamRequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	dropped := 0
+	for scanner.Scan() {
+		l := logLine{Service: svc, Line: strings.TrimRight(scanner.Text(), "\r")}
+		if !follow {
+			select {
+			case lines <- l:
+			case <-ctx.Done():
+				return nil
+			}
+			continue
+		}
+		if dropped > 0 {
+			select {
+			case lines <- logLine{Service: svc, Dropped: dropped}:
+				dropped = 0
+			default:
+				dropped++
+				continue
+			}
+		}
+		select {
+		case lines <- l:
+		default:
+			dropped++
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestTailServices_PrefixesEachService(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("tail"); got != "10" {
+			t.Errorf("tail = %q, want 10", got)
+		}
+		switch r.URL.Path {
+		case "/api/v1/workspaces/ws/projects/proj/envs/staging/services/api/logs":
+			w.Write([]byte("GET /health 200\nGET /orders 500\n"))
+		case "/api/v1/workspaces/ws/projects/proj/envs/staging/services/worker/logs":
+			w.Write([]byte("job 1 done\r\n"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	var out bytes.Buffer
+	if err := tailServices(context.Background(), "ws", "proj", "staging", []string{"api", "worker"}, 10, false, &out); err != nil {
+		t.Fatalf("tailServices error: %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(out.String()), "\n")
+	var api []string
+	for _, l := range got {
+		if strings.HasPrefix(l, "api    | ") {
+			api = append(api, strings.TrimPrefix(l, "api    | "))
+		}
+	}
+	if len(got) != 3 || strings.Join(api, ",") != "GET /health 200,GET /orders 500" || !strings.Contains(out.String(), "worker | job 1 done\n") {
+		t.Errorf("output =\n%s", out.String())
+	}
+}
+
+func TestFilterExcluded(t *testing.T) {
+	services := []labeledService{{Slug: "api"}, {Slug: "worker-email"}, {Slug: "worker-pdf"}, {Slug: "web"}}
+	got, err := filterExcluded(services, []string{"worker-*", "web"})
+	if err != nil {
+		t.Fatalf("filterExcluded error: %v", err)
+	}
+	if strings.Join(got, ",") != "api" {
+		t.Errorf("filterExcluded = %v, want [api]", got)
+	}
+	if _, err := filterExcluded(services, []string{"[bad"}); err == nil {
+		t.Error("filterExcluded with a bad glob succeeded, want error")
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	orig := stMatch
+	defer func() { stMatch = orig }()
+	stMatch = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+
+	terms := queryTerms(`level=error status=500 "timeout"`)
+	if want := "level=error,error,status=500,500,timeout"; strings.Join(terms, ",") != want {
+		t.Errorf("queryTerms = %v, want %s", terms, want)
+	}
+
+	tests := []struct{ line, want string }{
+		{"level=error status=500 path=/orders", "[level=error] [status=500] path=/orders"},
+		{"ERROR: upstream Timeout after 30s", "[ERROR]: upstream [Timeout] after 30s"},
+		{"all good", "all good"},
+	}
+	for _, tt := range tests {
+		if got := highlightTerms(tt.line, terms); got != tt.want {
+			t.Errorf("highlightTerms(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestLogsSearchCmd_SendsQuery(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var got url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws/projects/proj/envs/staging/logs/search" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		got = r.URL.Query()
+		w.Write([]byte(`{"matches":[{"service":"api","line":"level=error status=500","before":["a"],"after":["b"]}]}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+
+	if err := logsSearchCmd.RunE(logsSearchCmd, []string{"level=error status=500"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if got.Get("q") != "level=error status=500" || got.Get("since") != "1h" || got.Get("service") != "api" || got.Get("context") != "2" {
+		t.Errorf("query = %v", got)
+	}
+
+	if err := logsSearchCmd.RunE(logsSearchCmd, []string{"timeout", "ws/proj/staging"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if got.Has("service") {
+		t.Errorf("environment path still scoped to service %q", got.Get("service"))
+	}
+
+	logsSearchCmd.Flags().Set("since", "yesterday")
+	defer logsSearchCmd.Flags().Set("since", "1h")
+	if err := logsSearchCmd.RunE(logsSearchCmd, []string{"x"}); err == nil || !strings.Contains(err.Error(), "invalid --since") {
+		t.Errorf("error = %v, want invalid --since", err)
+	}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPollInterval = 3 * time.Second
+	defaultMaxWait      = 30 * time.Minute
+)
+
+// ExitTimeout is the exit status when --max-wait runs out, matching
+// timeout(1) so scripts can tell a hung pipeline from a failed one.
+const ExitTimeout = 124
+
+// waitTimeoutError reports that following an operation hit --max-wait.
+type waitTimeoutError struct {
+	what  string
+	after time.Duration
+}
+
+func (e *waitTimeoutError) Error() string {
+	return fmt.Sprintf("%s still running after %s — gave up waiting (--max-wait)", e.what, e.after)
+}
+
+// ExitCode returns the process exit status for an error from Execute.
+func ExitCode(err error) int {
+	var te *waitTimeoutError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &te):
+		return ExitTimeout
+	}
+	return 1
+}
+
+// poller paces a --follow loop and enforces --max-wait.
+type poller struct {
+	interval time.Duration
+	maxWait  time.Duration // 0 means no limit
+	start    time.Time
+}
+
+// newPoller reads the poll interval and max wait from the command-line
+// flags, then the config, then the built-in defaults.
+func newPoller() *poller {
+	p := &poller{interval: defaultPollInterval, maxWait: defaultMaxWait, start: time.Now()}
+	if d, err := time.ParseDuration(cfg.PollInterval); err == nil && d > 0 {
+		p.interval = d
+	}
+	if cfg.MaxWait == "0" {
+		p.maxWait = 0
+	} else if d, err := time.ParseDuration(cfg.MaxWait); err == nil && d >= 0 {
+		p.maxWait = d
+	}
+	if rootCmd.PersistentFlags().Changed("poll-interval") && pollIntervalFlag > 0 {
+		p.interval = pollIntervalFlag
+	}
+	if rootCmd.PersistentFlags().Changed("max-wait") {
+		p.maxWait = maxWaitFlag
+	}
+	return p
+}
+
+// wait sleeps for one interval, then returns a *waitTimeoutError naming
+// what if the max wait has passed.
+func (p *poller) wait(what string) error {
+	time.Sleep(p.interval)
+	if p.maxWait > 0 && time.Since(p.start) >= p.maxWait {
+		return &waitTimeoutError{what: what, after: p.maxWait}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestNewPoller_ConfigDefaults(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	cfg = &config.Config{}
+	p := newPoller()
+	if p.interval != defaultPollInterval || p.maxWait != defaultMaxWait {
+		t.Errorf("defaults = %v/%v, want %v/%v", p.interval, p.maxWait, defaultPollInterval, defaultMaxWait)
+	}
+
+	cfg = &config.Config{PollInterval: "10s", MaxWait: "0"}
+	p = newPoller()
+	if p.interval != 10*time.Second || p.maxWait != 0 {
+		t.Errorf("from config = %v/%v, want 10s/0", p.interval, p.maxWait)
+	}
+}
+
+func TestFollowPipeline_MaxWait(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"build":{"id":"b1","status":"building"}}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, PollInterval: "1ms", MaxWait: "5ms"}
+
+	err := followPipeline("ws", "proj", "staging", "svc", pipelineIDs{Build: "b1"})
+	var te *waitTimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("followPipeline() error = %v, want *waitTimeoutError", err)
+	}
+	if got := ExitCode(err); got != ExitTimeout {
+		t.Errorf("ExitCode() = %d, want %d", got, ExitTimeout)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(nil); got != 0 {
+		t.Errorf("ExitCode(nil) = %d, want 0", got)
+	}
+	if got := ExitCode(errors.New("boom")); got != 1 {
+		t.Errorf("ExitCode(boom) = %d, want 1", got)
+	}
+	wrapped := fmt.Errorf("following: %w", &waitTimeoutError{what: "build", after: time.Minute})
+	if got := ExitCode(wrapped); got != ExitTimeout {
+		t.Errorf("ExitCode(wrapped timeout) = %d, want %d", got, ExitTimeout)
+	}
+}
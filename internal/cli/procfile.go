@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(procfileCmd)
+	procfileCmd.AddCommand(procfileCheckCmd)
+	procfileCheckCmd.Flags().StringP("file", "f", "", "Procfile to check (default: Procfile.ancla or Procfile in the current directory)")
+}
+
+// processNameRe matches Procfile process types, as the platform accepts them.
+var processNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// procfileEntry is one process type declared in a Procfile.
+type procfileEntry struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Line    int    `json:"line"`
+}
+
+// procfileProblem is a syntax error or warning, tied to a line when it
+// comes from the file itself.
+type procfileProblem struct {
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// parseProcfile reads "name: command" lines, skipping blanks and # comments.
+// Lines that don't parse are returned as errors rather than stopping the
+// parse, so every problem is reported at once.
+func parseProcfile(r io.Reader) ([]procfileEntry, []procfileProblem) {
+	var entries []procfileEntry
+	var errs []procfileProblem
+	seen := map[string]int{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, command, ok := strings.Cut(line, ":")
+		name, command = strings.TrimSpace(name), strings.TrimSpace(command)
+		switch {
+		case !ok:
+			errs = append(errs, procfileProblem{n, "expected <process>: <command>"})
+		case !processNameRe.MatchString(name):
+			errs = append(errs, procfileProblem{n, fmt.Sprintf("invalid process name %q — use letters, digits, '-' and '_'", name)})
+		case command == "":
+			errs = append(errs, procfileProblem{n, fmt.Sprintf("process %q has no command", name)})
+		case seen[name] > 0:
+			errs = append(errs, procfileProblem{n, fmt.Sprintf("process %q is already declared on line %d", name, seen[name])})
+		default:
+			seen[name] = n
+			entries = append(entries, procfileEntry{name, command, n})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, procfileProblem{Message: err.Error()})
+	}
+	return entries, errs
+}
+
+// procfileWarnings cross-references declared processes with the service's
+// process counts. A process scaled above zero that the Procfile no longer
+// declares keeps its replicas but has nothing to run on the next deploy.
+// svc is the service path shown in the suggested scale command.
+func procfileWarnings(entries []procfileEntry, counts map[string]int, svc string) []procfileProblem {
+	declared := map[string]bool{}
+	for _, e := range entries {
+		declared[e.Name] = true
+	}
+	var names []string
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var warnings []procfileProblem
+	for _, name := range names {
+		if counts[name] > 0 && !declared[name] {
+			warnings = append(warnings, procfileProblem{Message: fmt.Sprintf(
+				"%s is scaled to %d but not in the Procfile — scale it down with `ancla services scale %s %s=0`", name, counts[name], svc, name)})
+		}
+	}
+	if len(entries) > 0 && !declared["web"] {
+		warnings = append(warnings, procfileProblem{Message: "no web process — the service will not receive HTTP traffic"})
+	}
+	return warnings
+}
+
+var procfileCmd = &cobra.Command{
+	Use:     "procfile",
+	Short:   "Work with the Procfile that declares process types",
+	Example: "  ancla procfile check",
+	GroupID: "workflow",
+}
+
+var procfileCheckCmd = &cobra.Command{
+	Use:   "check [<svc> | <ws>/<proj>/<env>/<svc>]",
+	Short: "Validate the Procfile against the service's processes",
+	Long: `Parse Procfile.ancla, or Procfile when there is none, and report syntax
+errors. When a service is linked or named, the declared processes are
+compared with its current process counts, and processes still scaled
+above zero that the Procfile no longer declares are reported as warnings.
+
+Exits 1 when the Procfile has errors; warnings alone exit 0.`,
+	Example: "  ancla procfile check\n  ancla procfile check my-ws/my-proj/staging/api\n  ancla procfile check -f deploy/Procfile",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			file = scanBuildFiles(cwd).Procfile
+			if file == "" {
+				return fmt.Errorf("no Procfile.ancla or Procfile in this directory — pass --file to check another one")
+			}
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		entries, errs := parseProcfile(f)
+		f.Close()
+
+		var spec string
+		if len(args) > 0 {
+			spec = args[0]
+		}
+		ref, _ := resolveServiceSegments(spec)
+		var counts map[string]int
+		if ref.HasService() {
+			req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()), nil)
+			body, err := doRequest(req)
+			if err != nil {
+				return err
+			}
+			var svc struct {
+				ProcessCounts map[string]int `json:"process_counts"`
+			}
+			if err := decodeJSON(body, &svc); err != nil {
+				return fmt.Errorf("parsing response: %w", err)
+			}
+			counts = svc.ProcessCounts
+		} else if spec != "" {
+			return fmt.Errorf("no service specified — provide a slug in the linked environment, <ws>/<proj>/<env>/<svc>, or run `ancla link` first")
+		}
+		warnings := procfileWarnings(entries, counts, ref.String())
+
+		if isJSON() {
+			if err := printJSON(map[string]any{
+				"file":      file,
+				"processes": entries,
+				"errors":    errs,
+				"warnings":  warnings,
+			}); err != nil {
+				return err
+			}
+		} else if !isQuiet() {
+			printProcfileCheck(file, entries, errs, warnings, counts, ref.HasService())
+		}
+		if len(errs) > 0 {
+			return errReported
+		}
+		return nil
+	},
+}
+
+// printProcfileCheck renders the result of `procfile check`.
+func printProcfileCheck(file string, entries []procfileEntry, errs, warnings []procfileProblem, counts map[string]int, compared bool) {
+	for _, e := range errs {
+		where := file
+		if e.Line > 0 {
+			where = fmt.Sprintf("%s:%d", file, e.Line)
+		}
+		fmt.Println("  " + stError.Render(symCross) + " " + where + ": " + e.Message)
+	}
+	if len(errs) == 0 {
+		names := make([]string, len(entries))
+		for i, e := range entries {
+			names[i] = e.Name
+		}
+		fmt.Println(stepDone(fmt.Sprintf("%s: %d processes (%s)", file, len(entries), strings.Join(names, ", "))))
+	}
+	for _, w := range warnings {
+		fmt.Println("  " + stWarning.Render("!") + " " + w.Message)
+	}
+
+	if !compared || len(entries) == 0 {
+		return
+	}
+	fmt.Println()
+	var rows [][]string
+	for _, e := range entries {
+		scale := fmt.Sprintf("%d", counts[e.Name])
+		if _, ok := counts[e.Name]; !ok {
+			scale = stDim.Render("new")
+		}
+		rows = append(rows, []string{e.Name, scale, e.Command})
+	}
+	table([]string{"PROCESS", "SCALE", "COMMAND"}, rows)
+}
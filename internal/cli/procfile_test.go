@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseProcfile(t *testing.T) {
+	src := `# processes
+web: gunicorn app:app --bind 0.0.0.0:$PORT
+
+worker:python -m worker
+bad line
+web: again
+cron job: run
+release:
+`
+	entries, errs := parseProcfile(strings.NewReader(src))
+	want := []procfileEntry{
+		{"web", "gunicorn app:app --bind 0.0.0.0:$PORT", 2},
+		{"worker", "python -m worker", 4},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("entries = %+v, want %+v", entries, want)
+	}
+	var lines []int
+	for _, e := range errs {
+		lines = append(lines, e.Line)
+	}
+	if want := []int{5, 6, 7, 8}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("error lines = %v, want %v (%+v)", lines, want, errs)
+	}
+}
+
+func TestProcfileWarnings(t *testing.T) {
+	entries := []procfileEntry{{Name: "web", Command: "serve"}}
+	counts := map[string]int{"web": 2, "worker": 1, "clock": 0}
+
+	warnings := procfileWarnings(entries, counts, "ws/proj/prod/api")
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "worker is scaled to 1") ||
+		!strings.Contains(warnings[0].Message, "ancla services scale ws/proj/prod/api worker=0") {
+		t.Errorf("warnings = %+v", warnings)
+	}
+
+	warnings = procfileWarnings([]procfileEntry{{Name: "worker", Command: "run"}}, nil, "")
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Message, "no web process") {
+		t.Errorf("warnings without web = %+v", warnings)
+	}
+}
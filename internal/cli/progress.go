@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Progress modes for --progress. In the default mode, following a build or
+// deploy shows a spinner on a terminal; plain and json replace it with one
+// line per status change, for CI logs and scripts.
+const (
+	progressAuto  = "auto"
+	progressPlain = "plain"
+	progressJSON  = "json"
+)
+
+// progressEvent is one --progress json line.
+type progressEvent struct {
+	Stage     string `json:"stage"`
+	ID        string `json:"id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	Log       string `json:"log,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// checkProgressMode validates the --progress flag.
+func checkProgressMode() error {
+	switch progressMode {
+	case progressAuto, progressPlain, progressJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid --progress %q (expected auto, plain, or json)", progressMode)
+}
+
+// progressLines reports whether --progress replaces spinners and step
+// messages with status lines.
+func progressLines() bool {
+	return progressMode == progressPlain || progressMode == progressJSON
+}
+
+// reportProgress records a stage's status change. plain prints a
+// timestamped line; json prints a progressEvent. It does nothing in auto
+// mode, where the spinner and step messages show progress instead.
+func reportProgress(stage, id, status, detail string) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	switch progressMode {
+	case progressPlain:
+		line := now + "  " + stage
+		if id != "" {
+			line += " " + id
+		}
+		line += "  " + status
+		if detail != "" {
+			line += ": " + detail
+		}
+		fmt.Println(line)
+	case progressJSON:
+		writeProgressEvent(progressEvent{Stage: stage, ID: id, Status: status, Detail: detail, Timestamp: now})
+	}
+}
+
+// printStageLog prints log output from a stage. Under --progress json each
+// line becomes an event so stdout stays parseable.
+func printStageLog(stage, id, text string) {
+	if progressMode != progressJSON {
+		fmt.Print(text)
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		writeProgressEvent(progressEvent{Stage: stage, ID: id, Log: line, Timestamp: now})
+	}
+}
+
+func writeProgressEvent(e progressEvent) {
+	data, _ := json.Marshal(e)
+	os.Stdout.Write(append(data, '\n'))
+}
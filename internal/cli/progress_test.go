@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestFollowPipeline_ProgressJSON(t *testing.T) {
+	origCfg, origMode, origStdout := cfg, progressMode, os.Stdout
+	defer func() { cfg, progressMode, os.Stdout = origCfg, origMode, origStdout }()
+
+	responses := []string{
+		`{"build":{"id":"b1","status":"building"}}`,
+		`{"build":{"id":"b1","status":"success"},"deploy":{"id":"d1","build_id":"b1","status":"deploying"}}`,
+		`{"build":{"id":"b1","status":"success"},"deploy":{"id":"d1","build_id":"b1","status":"error","error_detail":"health check failed"}}`,
+	}
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[min(polls, len(responses)-1)]))
+		polls++
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, PollInterval: "1ms"}
+	progressMode = progressJSON
+
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := followPipeline("ws", "proj", "staging", "svc", pipelineIDs{Build: "b1"})
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = origStdout
+
+	if err == nil {
+		t.Fatal("followPipeline() succeeded, want deploy failure")
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		var e progressEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line %q is not a progress event: %v", line, err)
+		}
+		if e.Timestamp == "" {
+			t.Errorf("event %q has no timestamp", line)
+		}
+		got = append(got, e.Stage+":"+e.Status+":"+e.Detail)
+	}
+	want := []string{"build:building:", "build:success:", "deploy:deploying:", "deploy:error:health check failed"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestCheckProgressMode(t *testing.T) {
+	origMode := progressMode
+	defer func() { progressMode = origMode }()
+
+	for _, mode := range []string{"auto", "plain", "json"} {
+		progressMode = mode
+		if err := checkProgressMode(); err != nil {
+			t.Errorf("checkProgressMode(%q) error: %v", mode, err)
+		}
+	}
+	progressMode = "fancy"
+	if err := checkProgressMode(); err == nil {
+		t.Error("checkProgressMode(fancy) succeeded, want error")
+	}
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -15,6 +14,8 @@ func init() {
 	rootCmd.AddCommand(projectsCmd)
 	projectsCmd.AddCommand(projectsListCmd)
 	projectsCmd.AddCommand(projectsGetCmd)
+	addIDOnlyFlag(projectsListCmd, "<workspace>/<project> paths")
+	addIDOnlyFlag(projectsGetCmd, "<workspace>/<project> path")
 }
 
 var projectsCmd = &cobra.Command{
@@ -44,15 +45,15 @@ var projectsListCmd = &cobra.Command{
 		if len(args) == 1 {
 			arg = args[0]
 		}
-		ws, _, _, _, err := config.ResolveServicePath(arg, cfg)
+		ref, err := config.ResolveServiceRef(arg, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" {
+		if ref.Workspace == "" {
 			return fmt.Errorf("workspace is required\n\n  ancla projects <workspace>\n\n  Hierarchy: workspace → project → env → service\n  Hint: run `ancla link` to set a default workspace")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/projects/"), nil)
+		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ref.Workspace+"/projects/"), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
@@ -65,10 +66,16 @@ var projectsListCmd = &cobra.Command{
 			WorkspaceSlug string `json:"workspace_slug"`
 			ServiceCount  int    `json:"service_count"`
 		}
-		if err := json.Unmarshal(body, &projects); err != nil {
+		if err := decodeJSON(body, &projects); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			for _, p := range projects {
+				fmt.Println(p.WorkspaceSlug + "/" + p.Slug)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(projects)
 		}
@@ -110,10 +117,14 @@ var projectsGetCmd = &cobra.Command{
 			Created       string `json:"created"`
 			Updated       string `json:"updated"`
 		}
-		if err := json.Unmarshal(body, &project); err != nil {
+		if err := decodeJSON(body, &project); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			fmt.Println(project.WorkspaceSlug + "/" + project.Slug)
+			return nil
+		}
 		if isJSON() {
 			return printJSON(project)
 		}
@@ -122,7 +133,7 @@ var projectsGetCmd = &cobra.Command{
 		fmt.Printf("Workspace: %s\n", project.WorkspaceName)
 		fmt.Printf("Services: %d\n", project.ServiceCount)
 		if project.Created != "" {
-			fmt.Printf("Created: %s\n", project.Created)
+			fmt.Printf("Created: %s\n", formatTime(project.Created))
 		}
 		if project.Updated != "" {
 			fmt.Printf("Updated: %s\n", project.Updated)
@@ -2,10 +2,20 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 )
 
+// requireInteractive fails under --non-interactive, naming the question
+// that would have been asked.
+func requireInteractive(question string) error {
+	if nonInteractiveFlag {
+		return fmt.Errorf("%q needs an answer, but prompts are off with --non-interactive — pass the value as an argument or flag", strings.TrimSpace(question))
+	}
+	return nil
+}
+
 // promptItem represents a selectable item in an interactive list.
 type promptItem struct {
 	Label string // unused legacy field
@@ -20,6 +30,9 @@ const (
 
 // promptSelect shows an interactive arrow-key selector and returns the chosen slug.
 func promptSelect(label string, items []promptItem, defaultSlug string) (string, error) {
+	if err := requireInteractive(label); err != nil {
+		return "", err
+	}
 	if len(items) == 0 {
 		return "", fmt.Errorf("no items to select")
 	}
@@ -53,6 +66,9 @@ func promptSelect(label string, items []promptItem, defaultSlug string) (string,
 // promptSelectOrCreate shows an interactive selector with an extra "Create new…" option.
 // Returns (slug, true) for an existing item, or ("", false) for create-new.
 func promptSelectOrCreate(label string, items []promptItem, createLabel string) (string, bool, error) {
+	if err := requireInteractive(label); err != nil {
+		return "", false, err
+	}
 	opts := make([]huh.Option[string], 0, len(items)+1)
 	for _, it := range items {
 		display := it.Name
@@ -82,6 +98,9 @@ func promptSelectOrCreate(label string, items []promptItem, createLabel string)
 // promptSelectCreateSkip shows a selector with existing items, a "Create new…" option,
 // and a "Skip" option. Returns the action taken: "existing" (slug set), "create", or "skip".
 func promptSelectCreateSkip(label string, items []promptItem, createLabel, skipLabel string) (slug, action string, err error) {
+	if err := requireInteractive(label); err != nil {
+		return "", "", err
+	}
 	opts := make([]huh.Option[string], 0, len(items)+2)
 	for _, it := range items {
 		display := it.Name
@@ -115,6 +134,12 @@ func promptSelectCreateSkip(label string, items []promptItem, createLabel, skipL
 
 // promptInput asks for a text value with an optional default.
 func promptInput(label, defaultVal string) (string, error) {
+	if nonInteractiveFlag && defaultVal != "" {
+		return defaultVal, nil
+	}
+	if err := requireInteractive(label); err != nil {
+		return "", err
+	}
 	var value string
 	input := huh.NewInput().
 		Title(label).
@@ -132,8 +157,12 @@ func promptInput(label, defaultVal string) (string, error) {
 	return value, nil
 }
 
-// promptConfirm asks a yes/no question, defaulting to yes.
+// promptConfirm asks a yes/no question, defaulting to yes. --yes and
+// --non-interactive take the default without asking.
 func promptConfirm(message string) bool {
+	if yesFlag || nonInteractiveFlag {
+		return true
+	}
 	confirmed := true
 	err := themed(
 		huh.NewConfirm().
@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func init() {
+	envsCmd.AddCommand(envsProtectCmd)
+	envsCmd.AddCommand(envsUnprotectCmd)
+	envsProtectCmd.Flags().StringSlice("reviewer", nil, "Require approval from this workspace member, by email (repeatable)")
+	envsProtectCmd.Flags().Int("approvals", 0, "Number of reviewer approvals each deploy needs (default 1)")
+	envsProtectCmd.Flags().Bool("confirm-production", false, "Require deploys to be confirmed with --confirm-production or by typing the environment name")
+}
+
+// envProtection is the deployment protection of an environment. Deploys
+// to a protected environment wait for RequiredApprovals of the reviewers,
+// and with ConfirmProduction must be confirmed explicitly. The server
+// enforces both; the CLI asks for the confirmation up front.
+type envProtection struct {
+	RequiredReviewers []string `json:"required_reviewers"`
+	RequiredApprovals int      `json:"required_approvals"`
+	ConfirmProduction bool     `json:"confirm_production"`
+}
+
+// enabled reports whether p protects anything. A nil protection is off.
+func (p *envProtection) enabled() bool {
+	return p != nil && (len(p.RequiredReviewers) > 0 || p.ConfirmProduction)
+}
+
+var envsProtectCmd = &cobra.Command{
+	Use:   "protect [<ws>/<proj>/<env>]",
+	Short: "Protect an environment from unreviewed deploys",
+	Long: `Turn on deployment protection for an environment, replacing any rules it
+already has.
+
+With --reviewer, every deploy waits until --approvals of the listed
+workspace members approve it in the dashboard. With --confirm-production,
+deploys must pass --confirm-production, or the person deploying types the
+environment name; --yes does not count as confirmation.`,
+	Example:     "  ancla envs protect my-ws/my-proj/production --confirm-production\n  ancla envs protect --reviewer alice@example.com --reviewer bob@example.com --approvals 1",
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{featureAnnotation: "envs.protection"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveEnv(args)
+		if err != nil {
+			return err
+		}
+		reviewers, _ := cmd.Flags().GetStringSlice("reviewer")
+		approvals, _ := cmd.Flags().GetInt("approvals")
+		confirm, _ := cmd.Flags().GetBool("confirm-production")
+		p := envProtection{RequiredReviewers: reviewers, RequiredApprovals: approvals, ConfirmProduction: confirm}
+		if err := validateProtection(&p); err != nil {
+			return err
+		}
+
+		data, _ := json.Marshal(p)
+		req, _ := http.NewRequest("PUT", apiURL(ref.EnvPath()+"/protection"), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		if err := decodeJSON(body, &p); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(p)
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone("Protected " + ref.String()))
+			printEnvProtection(&p)
+		}
+		return nil
+	},
+}
+
+var envsUnprotectCmd = &cobra.Command{
+	Use:         "unprotect [<ws>/<proj>/<env>]",
+	Short:       "Remove deployment protection from an environment",
+	Example:     "  ancla envs unprotect my-ws/my-proj/production",
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{featureAnnotation: "envs.protection"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveEnv(args)
+		if err != nil {
+			return err
+		}
+		if err := confirmAction(fmt.Sprintf("Deploys to %s will no longer need review or confirmation.", ref)); err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("DELETE", apiURL(ref.EnvPath()+"/protection"), nil)
+		if _, err := doRequest(req); err != nil {
+			return err
+		}
+		if !isQuiet() && !isJSON() {
+			fmt.Println(stepDone("Removed protection from " + ref.String()))
+		}
+		return nil
+	},
+}
+
+// validateProtection checks the protect flags and fills in the default of
+// one approval when reviewers are given.
+func validateProtection(p *envProtection) error {
+	switch {
+	case len(p.RequiredReviewers) == 0 && !p.ConfirmProduction:
+		return fmt.Errorf("nothing to protect with — pass --reviewer, --confirm-production, or both")
+	case len(p.RequiredReviewers) == 0 && p.RequiredApprovals > 0:
+		return fmt.Errorf("--approvals needs at least one --reviewer")
+	case p.RequiredApprovals < 0 || p.RequiredApprovals > len(p.RequiredReviewers):
+		return fmt.Errorf("--approvals must be between 1 and the number of reviewers (%d)", len(p.RequiredReviewers))
+	}
+	if len(p.RequiredReviewers) > 0 && p.RequiredApprovals == 0 {
+		p.RequiredApprovals = 1
+	}
+	return nil
+}
+
+// printEnvProtection renders the rules of a protected environment.
+func printEnvProtection(p *envProtection) {
+	if !p.enabled() {
+		fmt.Println(kv("Protection", "none"))
+		return
+	}
+	if len(p.RequiredReviewers) > 0 {
+		fmt.Println(kv("Approvals", fmt.Sprintf("%d of %s", p.RequiredApprovals, strings.Join(p.RequiredReviewers, ", "))))
+	}
+	if p.ConfirmProduction {
+		fmt.Println(kv("Confirmation", "--confirm-production"))
+	}
+}
+
+// envRules are the deploy rules of an environment: its protection and
+// freeze windows. Servers without them omit the fields.
+type envRules struct {
+	Protection *envProtection `json:"protection"`
+	Freezes    []envFreeze    `json:"freezes"`
+}
+
+// fetchEnvRules returns the deploy rules of the environment ref names.
+func fetchEnvRules(ref config.ServiceRef) (*envRules, error) {
+	req, _ := http.NewRequest("GET", apiURL(ref.EnvPath()), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var rules envRules
+	if err := decodeJSON(body, &rules); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &rules, nil
+}
+
+// deployGuard is what guardDeploy settled for one deploy: the protection
+// of the target environment, nil when there is none, and whether an
+// active freeze is being overridden.
+type deployGuard struct {
+	protection     *envProtection
+	overrideFreeze bool
+}
+
+// guardDeploy checks the rules of the environment a deploy targets before
+// it is triggered. An active freeze refuses the deploy unless
+// --override-freeze is given by an admin. When the environment requires
+// confirmation, it is taken from --confirm-production or by having the
+// user type the environment name; --yes is not enough on its own.
+func guardDeploy(cmd *cobra.Command, ref config.ServiceRef) (*deployGuard, error) {
+	rules, err := fetchEnvRules(ref)
+	if err != nil {
+		return nil, err
+	}
+	g := &deployGuard{}
+	if g.overrideFreeze, err = checkFreezes(cmd, ref, rules.Freezes, time.Now()); err != nil {
+		return nil, err
+	}
+	p := rules.Protection
+	if !p.enabled() {
+		return g, nil
+	}
+	g.protection = p
+	if !p.ConfirmProduction || dryRun || boolFlag(cmd, "confirm-production") {
+		return g, nil
+	}
+	env := config.ServiceRef{Workspace: ref.Workspace, Project: ref.Project, Env: ref.Env}
+	msg := fmt.Sprintf("%s is a protected environment.", env)
+	if yesFlag || !canPrompt() {
+		return nil, fmt.Errorf("%s Pass --confirm-production to deploy to it", msg)
+	}
+	if err := confirmTyped(msg, ref.Env); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// boolFlag returns the value of a bool flag, or false when cmd doesn't
+// define it, for helpers shared by commands with different flags.
+func boolFlag(cmd *cobra.Command, name string) bool {
+	f := cmd.Flags().Lookup(name)
+	return f != nil && f.Value.String() == "true"
+}
+
+// newDeployRequest builds the POST that triggers a deploy of ref, with
+// fields as its JSON body. What g settled is sent along for the server to
+// check: confirm_production for a protected environment and
+// override_freeze for a deploy during a freeze.
+func newDeployRequest(ref config.ServiceRef, g *deployGuard, fields map[string]any) *http.Request {
+	set := func(key string) {
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		fields[key] = true
+	}
+	if g.protection != nil && g.protection.ConfirmProduction {
+		set("confirm_production")
+	}
+	if g.overrideFreeze {
+		set("override_freeze")
+	}
+	if fields == nil {
+		req, _ := http.NewRequest("POST", apiURL(ref.ServicePath()+"/deploy"), nil)
+		return req
+	}
+	data, _ := json.Marshal(fields)
+	req, _ := http.NewRequest("POST", apiURL(ref.ServicePath()+"/deploy"), bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+// pendingApproval reports whether a deploy trigger response says the
+// deploy is waiting for reviewers, and prints where it stands if so.
+func pendingApproval(status string, g *deployGuard) bool {
+	if status != "pending_approval" {
+		return false
+	}
+	if isQuiet() {
+		return true
+	}
+	fmt.Println(stepDone("Deploy requested — waiting for approval."))
+	if p := g.protection; p != nil && len(p.RequiredReviewers) > 0 {
+		fmt.Println(stDim.Render(fmt.Sprintf("  Needs %d approval(s) from %s in the dashboard.", p.RequiredApprovals, strings.Join(p.RequiredReviewers, ", "))))
+	}
+	fmt.Println(stDim.Render("  Run `ancla deploy --attach` to follow it once it starts."))
+	return true
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestValidateProtection(t *testing.T) {
+	p := envProtection{RequiredReviewers: []string{"a@example.com", "b@example.com"}}
+	if err := validateProtection(&p); err != nil || p.RequiredApprovals != 1 {
+		t.Errorf("validateProtection = %v, approvals %d; want nil, 1", err, p.RequiredApprovals)
+	}
+	for _, bad := range []envProtection{
+		{},
+		{ConfirmProduction: true, RequiredApprovals: 1},
+		{RequiredReviewers: []string{"a@example.com"}, RequiredApprovals: 2},
+	} {
+		if err := validateProtection(&bad); err == nil {
+			t.Errorf("validateProtection(%+v) accepted invalid rules", bad)
+		}
+	}
+}
+
+func TestGuardDeploy_RequiresConfirmation(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg; yesFlag, nonInteractiveFlag = false, false }()
+
+	var deployBody map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&deployBody)
+			w.Write([]byte(`{"build_id":"b1"}`))
+			return
+		}
+		w.Write([]byte(`{"slug":"production","protection":{"required_reviewers":[],"confirm_production":true}}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+	ref := config.ServiceRef{Workspace: "ws", Project: "proj", Env: "production", Service: "api"}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("confirm-production", false, "")
+	nonInteractiveFlag = true
+	if _, err := guardDeploy(cmd, ref); err == nil || !strings.Contains(err.Error(), "--confirm-production") {
+		t.Errorf("err = %v, want a hint to pass --confirm-production", err)
+	}
+	nonInteractiveFlag, yesFlag = false, true
+	if _, err := guardDeploy(cmd, ref); err == nil {
+		t.Error("--yes alone confirmed a deploy to a protected environment")
+	}
+
+	cmd.Flags().Set("confirm-production", "true")
+	g, err := guardDeploy(cmd, ref)
+	if err != nil {
+		t.Fatalf("guardDeploy() error: %v", err)
+	}
+	if _, err := doRequest(newDeployRequest(ref, g, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if deployBody["confirm_production"] != true {
+		t.Errorf("deploy body = %v, want confirm_production", deployBody)
+	}
+}
@@ -0,0 +1,465 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// A provisioner walks the preflight ensure chain shared by `ancla deploy`
+// and `ancla link`: log in, then select or create a workspace, project,
+// environment and service. The API, the prompts and the output are
+// interfaces so the chain can run against fakes in tests.
+type provisioner struct {
+	api    provisionAPI
+	prompt provisionPrompter
+	out    provisionOutput
+	// login runs when the API key is missing or rejected.
+	login func() error
+
+	// Defaults offered for new resources.
+	username   string // the personal workspace is named after the user
+	dirName    string // default project and service name
+	githubRepo string // owner/repo recorded on a new service
+
+	// known holds the existence checks made ahead by verify, by path.
+	known map[string]bool
+}
+
+// provisionAPI is the part of the Ancla API the ensure chain uses.
+type provisionAPI interface {
+	// authenticate checks the API key, returning errNoAPIKey without one.
+	authenticate() error
+	exists(path string) bool
+	list(path string) ([]resourceItem, error)
+	create(path string, payload any) (resourceItem, error)
+}
+
+// provisionPrompter asks the questions of the ensure chain.
+type provisionPrompter interface {
+	selectOrCreate(label string, items []promptItem, createLabel string) (slug string, existing bool, err error)
+	selectCreateSkip(label string, items []promptItem, createLabel, skipLabel string) (slug, action string, err error)
+	input(label, defaultVal string) (string, error)
+	choose(label string, items []promptItem, defaultSlug string) (string, error)
+}
+
+// provisionOutput receives the progress lines of the ensure chain.
+type provisionOutput interface {
+	step(msg string) // something needs attention
+	done(msg string) // a level is settled
+	note(msg string) // an aside
+}
+
+// resourceItem is the name and slug every listed or created resource has.
+type resourceItem struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// errNoAPIKey is returned by authenticate when no key is configured.
+var errNoAPIKey = errors.New("no API key")
+
+// newProvisioner returns a provisioner for the configured server that
+// prompts and prints on the terminal.
+func newProvisioner() *provisioner {
+	p := &provisioner{
+		api:     apiProvisioner{},
+		prompt:  terminalPrompter{},
+		out:     terminalOutput{},
+		login:   func() error { return loginBrowser("127.0.0.1", 0) },
+		dirName: currentDirName(),
+	}
+	if cfg != nil {
+		p.username = cfg.Username
+	}
+	p.githubRepo = detectGitHubRepo()
+	return p
+}
+
+// ensureLoggedIn checks that the API key works, and runs the login when
+// there is none or the server rejects it.
+func (p *provisioner) ensureLoggedIn() error {
+	err := p.api.authenticate()
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, errNoAPIKey) {
+		p.out.step("Not logged in.")
+	} else {
+		p.out.step("API key is invalid or expired.")
+	}
+	p.out.note("Opening browser to log in...")
+	if err := p.login(); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	p.out.done("Logged in")
+	return nil
+}
+
+// verify checks every linked level of ref at once, so a fully linked
+// directory costs one round trip before the deploy instead of four. The
+// ensure steps then use the answers rather than asking again.
+func (p *provisioner) verify(ref config.ServiceRef) {
+	var paths []string
+	switch {
+	case ref.Service != "" && ref.Env != "" && ref.Project != "" && ref.Workspace != "":
+		paths = append(paths, ref.ServicePath())
+		fallthrough
+	case ref.Env != "" && ref.Project != "" && ref.Workspace != "":
+		paths = append(paths, ref.EnvPath()+"/")
+		fallthrough
+	case ref.Project != "" && ref.Workspace != "":
+		paths = append(paths, ref.ProjectPath()+"/")
+		fallthrough
+	case ref.Workspace != "":
+		paths = append(paths, "/workspaces/"+ref.Workspace+"/")
+	}
+
+	results := make([]bool, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = p.api.exists(path)
+		}()
+	}
+	wg.Wait()
+
+	if p.known == nil {
+		p.known = make(map[string]bool, len(paths))
+	}
+	for i, path := range paths {
+		p.known[path] = results[i]
+	}
+}
+
+// exists reports whether the resource at path exists, from verify's
+// answers when it has one.
+func (p *provisioner) exists(path string) bool {
+	if ok, checked := p.known[path]; checked {
+		return ok
+	}
+	return p.api.exists(path)
+}
+
+// ensureWorkspace ensures a workspace is selected. Returns the workspace slug.
+func (p *provisioner) ensureWorkspace(current string) (string, error) {
+	if current != "" {
+		if p.exists("/workspaces/" + current + "/") {
+			return current, nil
+		}
+		p.out.step(fmt.Sprintf("Workspace %q not found, re-selecting...", current))
+	}
+
+	workspaces, err := p.api.list("/workspaces/")
+	if err != nil {
+		return "", fmt.Errorf("fetching workspaces: %w", err)
+	}
+
+	switch len(workspaces) {
+	case 0:
+		p.out.step("No workspaces found. Creating a personal workspace...")
+		name := p.username
+		if name == "" {
+			name = "personal"
+		}
+		return p.createWorkspace(name+"'s workspace", true)
+
+	case 1:
+		p.out.done("Workspace: " + stAccent.Render(workspaces[0].Slug))
+		return workspaces[0].Slug, nil
+	}
+
+	slug, existing, err := p.prompt.selectOrCreate("Select a workspace:", promptItems(workspaces), "Create new workspace")
+	if err != nil {
+		return "", err
+	}
+	if existing {
+		p.out.done("Workspace: " + stAccent.Render(slug))
+		return slug, nil
+	}
+	name, err := p.prompt.input("  Workspace name", "")
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("workspace name is required")
+	}
+	return p.createWorkspace(name, false)
+}
+
+func (p *provisioner) createWorkspace(name string, personal bool) (string, error) {
+	ws, err := p.api.create("/workspaces/", map[string]any{
+		"name":     name,
+		"personal": personal,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating workspace: %w", err)
+	}
+	p.out.done("Workspace: " + stAccent.Render(ws.Slug))
+	return ws.Slug, nil
+}
+
+// ensureProject ensures a project is selected within the workspace. It
+// returns "" when the user links to the workspace only.
+func (p *provisioner) ensureProject(ws, current string) (string, error) {
+	if current != "" {
+		if p.exists("/workspaces/" + ws + "/projects/" + current + "/") {
+			return current, nil
+		}
+		p.out.step(fmt.Sprintf("Project %q not found, re-selecting...", current))
+	}
+
+	projects, err := p.api.list("/workspaces/" + ws + "/projects/")
+	if err != nil {
+		return "", fmt.Errorf("fetching projects: %w", err)
+	}
+
+	slug, action, err := p.prompt.selectCreateSkip("Select a project:", promptItems(projects), "Create new project", "Link to workspace only")
+	if err != nil {
+		return "", err
+	}
+	switch action {
+	case "existing":
+		p.out.done("Project: " + stAccent.Render(slug))
+		return slug, nil
+	case "skip":
+		return "", nil
+	}
+
+	name, err := p.prompt.input("  Project name", p.dirName)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("project name is required")
+	}
+
+	proj, err := p.api.create("/workspaces/"+ws+"/projects/", map[string]any{
+		"name": name,
+		"slug": slugify(name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating project: %w", err)
+	}
+	p.out.done("Created project " + stAccent.Render(proj.Name) + stDim.Render(" (environments: production, staging, development)"))
+	return proj.Slug, nil
+}
+
+// ensureEnv ensures an environment is selected within the project. It
+// returns "" when the user links to the project only.
+func (p *provisioner) ensureEnv(ws, proj, current string) (string, error) {
+	if current != "" {
+		if p.exists(envPath(ws, proj, current) + "/") {
+			return current, nil
+		}
+		p.out.step(fmt.Sprintf("Environment %q not found, re-selecting...", current))
+	}
+
+	envs, err := p.api.list("/workspaces/" + ws + "/projects/" + proj + "/envs/")
+	if err != nil {
+		return "", fmt.Errorf("fetching environments: %w", err)
+	}
+
+	slug, action, err := p.prompt.selectCreateSkip("Select an environment:", promptItems(envs), "Create new environment", "Link to project only")
+	if err != nil {
+		return "", err
+	}
+	switch action {
+	case "existing":
+		p.out.done("Environment: " + stAccent.Render(slug))
+		return slug, nil
+	case "skip":
+		return "", nil
+	}
+
+	name, err := p.prompt.input("  Environment name", "production")
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("environment name is required")
+	}
+
+	e, err := p.api.create("/workspaces/"+ws+"/projects/"+proj+"/envs/", map[string]string{"name": name})
+	if err != nil {
+		return "", fmt.Errorf("creating environment: %w", err)
+	}
+	p.out.done("Created environment " + stAccent.Render(e.Name))
+	return e.Slug, nil
+}
+
+// ensureService ensures a service is selected within the environment. It
+// returns "" when the user links to the environment only. A new service
+// is named after the project unless the user says otherwise.
+func (p *provisioner) ensureService(ws, proj, env, current string) (string, error) {
+	if current != "" {
+		if p.exists(servicePath(ws, proj, env, current)) {
+			return current, nil
+		}
+		p.out.step(fmt.Sprintf("Service %q not found, re-selecting...", current))
+	}
+
+	services, err := p.api.list(serviceBasePath(ws, proj, env))
+	if err != nil {
+		return "", fmt.Errorf("fetching services: %w", err)
+	}
+
+	slug, action, err := p.prompt.selectCreateSkip("Select a service:", promptItems(services), "Create new service", "Link to environment only")
+	if err != nil {
+		return "", err
+	}
+	switch action {
+	case "existing":
+		p.out.done("Service: " + stAccent.Render(slug))
+		return slug, nil
+	case "skip":
+		return "", nil
+	}
+
+	defaultName := proj
+	if defaultName == "" {
+		defaultName = p.dirName
+	}
+	name, err := p.prompt.input("  Service name", defaultName)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("service name is required")
+	}
+
+	payload := map[string]any{
+		"name":     name,
+		"slug":     slugify(name),
+		"platform": "wind",
+	}
+	if p.githubRepo != "" {
+		payload["github_repository"] = p.githubRepo
+	}
+	strategyItems := []promptItem{
+		{Slug: "dockerfile", Name: "Dockerfile — build from your Dockerfile"},
+		{Slug: "buildpack", Name: "Buildpack — automatic detection, no Dockerfile required"},
+	}
+	if strategy, err := p.prompt.choose("  Build strategy:", strategyItems, "dockerfile"); err == nil && strategy != "" {
+		payload["build_strategy"] = strategy
+	}
+
+	svc, err := p.api.create(serviceBasePath(ws, proj, env), payload)
+	if err != nil {
+		return "", fmt.Errorf("creating service: %w", err)
+	}
+	p.out.done("Created service " + stAccent.Render(svc.Name))
+	return svc.Slug, nil
+}
+
+// promptItems turns listed resources into selector items.
+func promptItems(resources []resourceItem) []promptItem {
+	items := make([]promptItem, len(resources))
+	for i, r := range resources {
+		items[i] = promptItem{Slug: r.Slug, Name: r.Name}
+	}
+	return items
+}
+
+// apiProvisioner is the provisionAPI of the configured server.
+type apiProvisioner struct{}
+
+// authenticate sends the key check directly rather than through
+// doRequest: a rejected key leads to the chain's own login, not to the
+// re-login offered mid-command.
+func (apiProvisioner) authenticate() error {
+	if cfg.APIKey == "" {
+		return errNoAPIKey
+	}
+	req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
+	resp, err := apiClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return &apiError{Status: resp.StatusCode, Message: apiErrorMessage(resp.StatusCode, nil), Path: req.URL.Path}
+	}
+	return nil
+}
+
+func (apiProvisioner) exists(path string) bool {
+	req, _ := http.NewRequest("GET", apiURL(path), nil)
+	_, err := doRequest(req)
+	return err == nil
+}
+
+func (apiProvisioner) list(path string) ([]resourceItem, error) {
+	req, _ := http.NewRequest("GET", apiURL(path), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var items []resourceItem
+	if err := decodeJSON(body, &items); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return items, nil
+}
+
+func (apiProvisioner) create(path string, payload any) (resourceItem, error) {
+	data, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", apiURL(path), bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doRequest(req)
+	if err != nil {
+		return resourceItem{}, err
+	}
+	var item resourceItem
+	if err := decodeJSON(body, &item); err != nil {
+		return resourceItem{}, fmt.Errorf("parsing response: %w", err)
+	}
+	return item, nil
+}
+
+// terminalPrompter asks with the interactive selectors of prompt.go.
+type terminalPrompter struct{}
+
+func (terminalPrompter) selectOrCreate(label string, items []promptItem, createLabel string) (string, bool, error) {
+	return promptSelectOrCreate(label, items, createLabel)
+}
+
+func (terminalPrompter) selectCreateSkip(label string, items []promptItem, createLabel, skipLabel string) (string, string, error) {
+	return promptSelectCreateSkip(label, items, createLabel, skipLabel)
+}
+
+func (terminalPrompter) input(label, defaultVal string) (string, error) {
+	return promptInput(label, defaultVal)
+}
+
+func (terminalPrompter) choose(label string, items []promptItem, defaultSlug string) (string, error) {
+	return promptSelect(label, items, defaultSlug)
+}
+
+// terminalOutput prints the ensure chain's progress, unless --quiet.
+type terminalOutput struct{}
+
+func (terminalOutput) step(msg string) {
+	if !isQuiet() {
+		fmt.Println(stepActive(msg))
+	}
+}
+
+func (terminalOutput) done(msg string) {
+	if !isQuiet() {
+		fmt.Println(stepDone(msg))
+	}
+}
+
+func (terminalOutput) note(msg string) {
+	if !isQuiet() {
+		fmt.Println(stDim.Render("  " + msg))
+	}
+}
@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// fakeProvisionAPI serves the ensure chain from memory and records what
+// it was asked.
+type fakeProvisionAPI struct {
+	authErr  error
+	existing map[string]bool
+	lists    map[string][]resourceItem
+
+	mu      sync.Mutex
+	checks  []string
+	creates map[string]any
+}
+
+func (f *fakeProvisionAPI) authenticate() error { return f.authErr }
+
+func (f *fakeProvisionAPI) exists(path string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checks = append(f.checks, path)
+	return f.existing[path]
+}
+
+func (f *fakeProvisionAPI) list(path string) ([]resourceItem, error) {
+	items, ok := f.lists[path]
+	if !ok {
+		return nil, errors.New("unexpected list " + path)
+	}
+	return items, nil
+}
+
+func (f *fakeProvisionAPI) create(path string, payload any) (resourceItem, error) {
+	if f.creates == nil {
+		f.creates = map[string]any{}
+	}
+	f.creates[path] = payload
+	name := ""
+	switch p := payload.(type) {
+	case map[string]any:
+		name, _ = p["name"].(string)
+	case map[string]string:
+		name = p["name"]
+	}
+	return resourceItem{Name: name, Slug: slugify(name)}, nil
+}
+
+// fakePrompter answers each question from a script keyed by its label.
+type fakePrompter struct {
+	picks  map[string]string // label → slug, or createNewSlug / skipSlug
+	inputs map[string]string // label → typed answer
+	asked  []string
+}
+
+func (f *fakePrompter) pick(label string) string {
+	f.asked = append(f.asked, label)
+	return f.picks[label]
+}
+
+func (f *fakePrompter) selectOrCreate(label string, _ []promptItem, _ string) (string, bool, error) {
+	slug := f.pick(label)
+	return slug, slug != createNewSlug, nil
+}
+
+func (f *fakePrompter) selectCreateSkip(label string, _ []promptItem, _, _ string) (string, string, error) {
+	switch slug := f.pick(label); slug {
+	case createNewSlug:
+		return "", "create", nil
+	case skipSlug:
+		return "", "skip", nil
+	default:
+		return slug, "existing", nil
+	}
+}
+
+func (f *fakePrompter) input(label, defaultVal string) (string, error) {
+	f.asked = append(f.asked, label)
+	if v, ok := f.inputs[label]; ok {
+		return v, nil
+	}
+	return defaultVal, nil
+}
+
+func (f *fakePrompter) choose(label string, _ []promptItem, defaultSlug string) (string, error) {
+	if slug := f.pick(label); slug != "" {
+		return slug, nil
+	}
+	return defaultSlug, nil
+}
+
+// recordedOutput collects the chain's progress lines.
+type recordedOutput struct{ lines []string }
+
+func (r *recordedOutput) step(msg string) { r.lines = append(r.lines, "step: "+msg) }
+func (r *recordedOutput) done(msg string) { r.lines = append(r.lines, "done: "+msg) }
+func (r *recordedOutput) note(msg string) { r.lines = append(r.lines, "note: "+msg) }
+
+func (r *recordedOutput) contains(s string) bool {
+	for _, l := range r.lines {
+		if strings.Contains(l, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestProvisioner(api *fakeProvisionAPI, prompt *fakePrompter) (*provisioner, *recordedOutput) {
+	out := &recordedOutput{}
+	return &provisioner{
+		api:     api,
+		prompt:  prompt,
+		out:     out,
+		login:   func() error { return errors.New("unexpected login") },
+		dirName: "my-app",
+	}, out
+}
+
+func TestProvisionerLinkedChainChecksOnce(t *testing.T) {
+	ref := config.ServiceRef{Workspace: "ws", Project: "proj", Env: "prod", Service: "web"}
+	api := &fakeProvisionAPI{existing: map[string]bool{
+		"/workspaces/ws/":                                     true,
+		"/workspaces/ws/projects/proj/":                       true,
+		"/workspaces/ws/projects/proj/envs/prod/":             true,
+		"/workspaces/ws/projects/proj/envs/prod/services/web": true,
+	}}
+	prompt := &fakePrompter{}
+	p, _ := newTestProvisioner(api, prompt)
+
+	if err := p.ensureLoggedIn(); err != nil {
+		t.Fatal(err)
+	}
+	p.verify(ref)
+	ws, _ := p.ensureWorkspace(ref.Workspace)
+	proj, _ := p.ensureProject(ws, ref.Project)
+	env, _ := p.ensureEnv(ws, proj, ref.Env)
+	svc, err := p.ensureService(ws, proj, env, ref.Service)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := config.ServiceRef{Workspace: ws, Project: proj, Env: env, Service: svc}
+	if got != ref {
+		t.Errorf("chain = %+v, want %+v", got, ref)
+	}
+	if len(api.checks) != 4 {
+		t.Errorf("existence checks = %v, want each level checked once", api.checks)
+	}
+	if len(prompt.asked) != 0 {
+		t.Errorf("asked %v, want no prompts for a linked directory", prompt.asked)
+	}
+}
+
+func TestProvisionerCreatesPersonalWorkspace(t *testing.T) {
+	api := &fakeProvisionAPI{lists: map[string][]resourceItem{"/workspaces/": nil}}
+	p, out := newTestProvisioner(api, &fakePrompter{})
+	p.username = "ada"
+
+	ws, err := p.ensureWorkspace("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws != "adas-workspace" {
+		t.Errorf("workspace = %q, want adas-workspace", ws)
+	}
+	want := map[string]any{"name": "ada's workspace", "personal": true}
+	if got := api.creates["/workspaces/"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("create payload = %v, want %v", got, want)
+	}
+	if !out.contains("No workspaces found") {
+		t.Errorf("output = %v, want the personal workspace notice", out.lines)
+	}
+}
+
+func TestProvisionerReselectsMissingProject(t *testing.T) {
+	api := &fakeProvisionAPI{lists: map[string][]resourceItem{
+		"/workspaces/ws/projects/": {{Name: "Shop", Slug: "shop"}},
+	}}
+	prompt := &fakePrompter{picks: map[string]string{"Select a project:": "shop"}}
+	p, out := newTestProvisioner(api, prompt)
+
+	proj, err := p.ensureProject("ws", "gone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proj != "shop" {
+		t.Errorf("project = %q, want shop", proj)
+	}
+	if !out.contains(`Project "gone" not found`) {
+		t.Errorf("output = %v, want the not-found notice", out.lines)
+	}
+}
+
+func TestProvisionerCreatesService(t *testing.T) {
+	base := "/workspaces/ws/projects/shop/envs/prod/services/"
+	api := &fakeProvisionAPI{lists: map[string][]resourceItem{base: nil}}
+	prompt := &fakePrompter{picks: map[string]string{
+		"Select a service:": createNewSlug,
+		"  Build strategy:": "buildpack",
+	}}
+	p, _ := newTestProvisioner(api, prompt)
+	p.githubRepo = "acme/shop"
+
+	svc, err := p.ensureService("ws", "shop", "prod", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc != "shop" {
+		t.Errorf("service = %q, want the project name by default", svc)
+	}
+	want := map[string]any{
+		"name":              "shop",
+		"slug":              "shop",
+		"platform":          "wind",
+		"github_repository": "acme/shop",
+		"build_strategy":    "buildpack",
+	}
+	if got := api.creates[base]; !reflect.DeepEqual(got, want) {
+		t.Errorf("create payload = %v, want %v", got, want)
+	}
+}
+
+func TestProvisionerSkip(t *testing.T) {
+	api := &fakeProvisionAPI{lists: map[string][]resourceItem{
+		"/workspaces/ws/projects/shop/envs/": {{Name: "production", Slug: "production"}},
+	}}
+	prompt := &fakePrompter{picks: map[string]string{"Select an environment:": skipSlug}}
+	p, _ := newTestProvisioner(api, prompt)
+
+	env, err := p.ensureEnv("ws", "shop", "")
+	if err != nil || env != "" {
+		t.Errorf("ensureEnv = %q, %v; want a skip", env, err)
+	}
+	if len(api.creates) != 0 {
+		t.Errorf("created %v on skip", api.creates)
+	}
+}
+
+func TestProvisionerLogin(t *testing.T) {
+	tests := []struct {
+		name    string
+		authErr error
+		want    string
+	}{
+		{"no key", errNoAPIKey, "Not logged in."},
+		{"rejected key", &apiError{Status: 401}, "API key is invalid or expired."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, out := newTestProvisioner(&fakeProvisionAPI{authErr: tt.authErr}, &fakePrompter{})
+			logins := 0
+			p.login = func() error { logins++; return nil }
+
+			if err := p.ensureLoggedIn(); err != nil {
+				t.Fatal(err)
+			}
+			if logins != 1 {
+				t.Errorf("logins = %d, want 1", logins)
+			}
+			if !out.contains(tt.want) || !out.contains("Logged in") {
+				t.Errorf("output = %v, want %q and Logged in", out.lines, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// readOnlyFlag refuses mutating requests for this invocation; the
+// read_only setting does the same from the config.
+var readOnlyFlag bool
+
+// readOnly reports whether mutating requests are refused, from
+// --read-only or the read_only setting.
+func readOnly() bool {
+	return readOnlyFlag || (cfg != nil && cfg.ReadOnly)
+}
+
+// readOnlyError is returned by the API transport for a mutating request
+// in read-only mode. Nothing was sent.
+type readOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf("read-only mode: refused to send %s %s — drop --read-only, or run `ancla settings unset read_only`, to make changes", e.Method, e.Path)
+}
+
+// checkReadOnly refuses req if it is mutating and read-only mode is on.
+// It runs in the API transport, so every request the CLI makes passes
+// through it, whichever command builds it.
+func checkReadOnly(req *http.Request) error {
+	if readOnly() && isMutating(req) {
+		return &readOnlyError{Method: req.Method, Path: req.URL.Path}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestDoRequest_ReadOnly(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var hits []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits = append(hits, r.Method)
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, ReadOnly: true}
+
+	req, _ := http.NewRequest("GET", apiURL("/workspaces/"), nil)
+	if _, err := doRequest(req); err != nil {
+		t.Fatalf("GET in read-only mode: %v", err)
+	}
+
+	for _, method := range []string{"POST", "PATCH", "PUT", "DELETE"} {
+		req, _ = http.NewRequest(method, apiURL("/workspaces/ws/config/"), bytes.NewReader([]byte(`{}`)))
+		_, err := doRequest(req)
+		var ro *readOnlyError
+		if !errors.As(err, &ro) || ro.Method != method {
+			t.Errorf("%s in read-only mode: err = %v, want a readOnlyError", method, err)
+		}
+	}
+	if len(hits) != 1 || hits[0] != "GET" {
+		t.Errorf("server saw %v, want only the GET", hits)
+	}
+
+	var out bytes.Buffer
+	req, _ = http.NewRequest("DELETE", apiURL("/workspaces/ws"), nil)
+	_, err := doRequest(req)
+	reportError(&out, err)
+	if got := out.String(); !strings.Contains(got, "refused to send DELETE /api/v1/workspaces/ws") || strings.Contains(got, "request failed") {
+		t.Errorf("reported %q, want the refusal alone", got)
+	}
+}
+
+func TestReadOnlyFlag(t *testing.T) {
+	origCfg, origFlag := cfg, readOnlyFlag
+	defer func() { cfg, readOnlyFlag = origCfg, origFlag }()
+
+	cfg = &config.Config{}
+	if readOnly() {
+		t.Error("read-only without the flag or setting")
+	}
+	readOnlyFlag = true
+	if !readOnly() {
+		t.Error("--read-only did not turn read-only mode on")
+	}
+}
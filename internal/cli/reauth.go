@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	// noReauth is set when a rejected key can't be fixed by logging in
+	// again: the key came from --api-key or ANCLA_API_KEY, or the command
+	// is login itself.
+	noReauth bool
+	// reauthTried is set once the user has been offered a fresh login, so
+	// a key the server keeps rejecting fails instead of looping.
+	reauthTried bool
+)
+
+// askRelogin asks whether to log in again after a rejected key. It is a
+// variable so tests can answer it.
+var askRelogin = func() bool {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	fmt.Fprint(os.Stderr, stWarning.Render("Your API key was rejected — it may have expired or been revoked.")+"\n")
+	fmt.Fprint(os.Stderr, tr("Log in again and continue? [Y/n] "))
+	answer, err := stdinReader().ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	return strings.TrimSpace(answer) == "" || isYes(answer)
+}
+
+// relogin logs in again after a rejected key. It is a variable so tests
+// can stub the browser out; set in init, since the login itself sends
+// requests.
+var relogin func() error
+
+func init() {
+	relogin = reloginBrowser
+}
+
+// reloginBrowser runs the browser login for the key that was rejected: the
+// workspace key when one was in use, otherwise the default key.
+func reloginBrowser() error {
+	saved := asWorkspace
+	asWorkspace = keyWorkspace
+	defer func() { asWorkspace = saved }()
+	return loginBrowser("127.0.0.1", 0)
+}
+
+// canReauth reports whether a 401 may be answered with a fresh login
+// rather than an error: only once, only for a key ancla login manages,
+// and only with someone at a terminal to complete it.
+func canReauth(req *http.Request) bool {
+	if reauthTried || noReauth || !canPrompt() || isJSON() {
+		return false
+	}
+	// A body that can't be replayed can't be retried.
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// sendAPIRequest sends req with the current API key. When the server
+// rejects the key partway through a command — typically one following a
+// long pipeline — it offers to log in again and retries the request with
+// the new key, instead of throwing away the work done so far.
+//
+// A mutating request carries an Idempotency-Key, kept for the retry, so
+// the server runs a deploy or build once however many times it arrives.
+func sendAPIRequest(req *http.Request) (*http.Response, error) {
+	if isMutating(req) && req.Header.Get("Idempotency-Key") == "" {
+		req.Header.Set("Idempotency-Key", rand.Text())
+	}
+	resp, err := apiClient().Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !canReauth(req) {
+		return resp, err
+	}
+	reauthTried = true
+	// Spinners would draw over the prompt and the login output.
+	restoreTerminal()
+	if !askRelogin() {
+		return resp, nil
+	}
+	if err := relogin(); err != nil {
+		fmt.Fprintln(os.Stderr, stWarning.Render("Login failed: "+err.Error()))
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+	fmt.Fprintln(os.Stderr, stDim.Render("Retrying "+req.Method+" "+req.URL.Path+"…"))
+	return apiClient().Do(retry)
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// stubReauth answers the re-login prompt with answer and replaces the
+// browser login with one that issues newKey, restoring everything after
+// the test.
+func stubReauth(t *testing.T, answer bool, newKey string, loginErr error) *int {
+	t.Helper()
+	origCfg, origAsk, origLogin := cfg, askRelogin, relogin
+	origTried, origNo := reauthTried, noReauth
+	t.Cleanup(func() {
+		cfg, askRelogin, relogin = origCfg, origAsk, origLogin
+		reauthTried, noReauth = origTried, origNo
+	})
+	reauthTried, noReauth = false, false
+	logins := 0
+	askRelogin = func() bool { return answer }
+	relogin = func() error {
+		logins++
+		if loginErr != nil {
+			return loginErr
+		}
+		cfg.APIKey = newKey
+		return nil
+	}
+	return &logins
+}
+
+// keyServer answers 200 with the request body for the "good" key and 401
+// for any other.
+func keyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte("ok:" + string(body)))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestDoRequest_ReauthRetries(t *testing.T) {
+	logins := stubReauth(t, true, "good", nil)
+	ts := keyServer(t)
+	cfg = &config.Config{Server: ts.URL, APIKey: "expired"}
+
+	req, _ := http.NewRequest("POST", ts.URL+"/api/v1/deploy", strings.NewReader(`{"x":1}`))
+	body, err := doRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `ok:{"x":1}` {
+		t.Errorf("body = %q, want the retried request's body echoed", body)
+	}
+	if *logins != 1 {
+		t.Errorf("logins = %d, want 1", *logins)
+	}
+
+	// Later requests use the new key without asking again.
+	req, _ = http.NewRequest("GET", ts.URL+"/api/v1/deploy", nil)
+	if _, err := doRequest(req); err != nil {
+		t.Fatalf("second request: %v", err)
+	}
+	if *logins != 1 {
+		t.Errorf("logins = %d after second request, want 1", *logins)
+	}
+}
+
+func TestDoRequest_ReauthOnlyOnce(t *testing.T) {
+	logins := stubReauth(t, true, "still-bad", nil)
+	ts := keyServer(t)
+	cfg = &config.Config{Server: ts.URL, APIKey: "expired"}
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", ts.URL+"/api/v1/deploy", nil)
+		_, err := doRequest(req)
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) || apiErr.Status != 401 {
+			t.Fatalf("request %d: err = %v, want a 401 apiError", i, err)
+		}
+	}
+	if *logins != 1 {
+		t.Errorf("logins = %d, want 1", *logins)
+	}
+}
+
+func TestDoRequest_ReauthSkipped(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer bool
+		setup  func(*testing.T)
+	}{
+		{"declined", false, func(*testing.T) {}},
+		{"pinned key", true, func(*testing.T) { noReauth = true }},
+		{"non-interactive", true, func(t *testing.T) {
+			nonInteractiveFlag = true
+			t.Cleanup(func() { nonInteractiveFlag = false })
+		}},
+		{"json output", true, func(t *testing.T) {
+			outputFormat = "json"
+			t.Cleanup(func() { outputFormat = "table" })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logins := stubReauth(t, tt.answer, "good", nil)
+			ts := keyServer(t)
+			cfg = &config.Config{Server: ts.URL, APIKey: "expired"}
+			tt.setup(t)
+
+			req, _ := http.NewRequest("GET", ts.URL+"/api/v1/deploy", nil)
+			_, err := doRequest(req)
+			var apiErr *apiError
+			if !errors.As(err, &apiErr) || apiErr.Status != 401 {
+				t.Fatalf("err = %v, want a 401 apiError", err)
+			}
+			if *logins != 0 {
+				t.Errorf("logins = %d, want 0", *logins)
+			}
+		})
+	}
+}
+
+func TestDoRequest_ReauthLoginFails(t *testing.T) {
+	stubReauth(t, true, "", errors.New("timed out"))
+	ts := keyServer(t)
+	cfg = &config.Config{Server: ts.URL, APIKey: "expired"}
+
+	req, _ := http.NewRequest("GET", ts.URL+"/api/v1/deploy", nil)
+	_, err := doRequest(req)
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.Status != 401 {
+		t.Fatalf("err = %v, want the original 401", err)
+	}
+}
+
+func TestDoRequest_IdempotencyKey(t *testing.T) {
+	stubReauth(t, true, "good", nil)
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if r.Header.Get("X-API-Key") != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, APIKey: "expired"}
+
+	// The retry after a fresh login repeats the original key.
+	req, _ := http.NewRequest("POST", ts.URL+"/api/v1/deploy", strings.NewReader(`{}`))
+	if _, err := doRequest(req); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[1] != keys[0] {
+		t.Fatalf("keys = %q, want one key sent twice", keys)
+	}
+
+	// Each request gets its own; reads get none.
+	req, _ = http.NewRequest("POST", ts.URL+"/api/v1/deploy", nil)
+	doRequest(req)
+	req, _ = http.NewRequest("GET", ts.URL+"/api/v1/deploy", nil)
+	doRequest(req)
+	if keys[2] == "" || keys[2] == keys[0] || keys[3] != "" {
+		t.Errorf("keys = %q, want a new key for the POST and none for the GET", keys)
+	}
+
+	// One set by the caller, as with `ancla api -H`, is kept.
+	req, _ = http.NewRequest("PATCH", ts.URL+"/api/v1/deploy", nil)
+	req.Header.Set("Idempotency-Key", "mine")
+	doRequest(req)
+	if keys[4] != "mine" {
+		t.Errorf("key = %q, want the caller's", keys[4])
+	}
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func init() {
+	configCmd.AddCommand(configRequireCmd)
+	configRequireCmd.Flags().Bool("remove", false, "Stop requiring the given keys")
+}
+
+// configKeyRe matches the names config variables can be required under.
+var configKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var configRequireCmd = &cobra.Command{
+	Use:   "require [ws/proj/env/svc] [KEY...]",
+	Short: "Declare config vars a service needs to deploy",
+	Long: `Declare configuration variables the service must have before it can
+deploy. ` + "`ancla deploy`" + ` checks that each one is set at some scope — service,
+environment, project, or workspace — and stops with the list of missing
+variables instead of letting the app crash at runtime.
+
+Without keys, list the required variables and whether each is set. With
+keys, add them to the service's requirements, or remove them with
+--remove. A linked directory can also list keys under required_config in
+.ancla/config.yaml; they are checked when deploying the linked service.`,
+	Example: `  ancla config require
+  ancla config require DATABASE_URL SECRET_KEY
+  ancla config require my-ws/my-proj/staging/my-svc --remove LEGACY_TOKEN`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var arg string
+		if len(args) > 0 && strings.Contains(args[0], "/") {
+			arg, args = args[0], args[1:]
+		}
+		ref, err := config.ResolveServiceRef(arg, cfg)
+		if err != nil {
+			return err
+		}
+		if err := ref.RequireService(); err != nil {
+			return err
+		}
+		for _, key := range args {
+			if !configKeyRe.MatchString(key) {
+				return fmt.Errorf("invalid config key %q — use letters, digits, and underscores", key)
+			}
+		}
+
+		required, err := fetchRequiredConfig(ref)
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return printRequiredConfig(ref, required)
+		}
+
+		remove, _ := cmd.Flags().GetBool("remove")
+		updated := required
+		for _, key := range args {
+			switch {
+			case remove:
+				updated = slicesWithout(updated, key)
+			case !contains(updated, key):
+				updated = append(updated, key)
+			}
+		}
+		if updated == nil {
+			updated = []string{}
+		}
+		data, _ := json.Marshal(map[string]any{"required_config": updated})
+		req, _ := http.NewRequest("PATCH", apiURL(ref.ServicePath()), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		if _, err := doRequest(req); err != nil {
+			return err
+		}
+		if isJSON() {
+			return printJSON(map[string]any{"required_config": updated})
+		}
+		if !isQuiet() {
+			verb := "Now requiring"
+			if remove {
+				verb = "No longer requiring"
+			}
+			fmt.Println(stepDone(fmt.Sprintf("%s %s on %s", verb, strings.Join(args, ", "), ref.Service)))
+		}
+		return nil
+	},
+}
+
+// slicesWithout returns list without s.
+func slicesWithout(list []string, s string) []string {
+	var out []string
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// serviceConfigSettings are the service settings that govern its config
+// vars. RedeployOnConfigChange is nil when the server doesn't report it.
+type serviceConfigSettings struct {
+	RequiredConfig         []string `json:"required_config"`
+	RedeployOnConfigChange *bool    `json:"redeploy_on_config_change"`
+}
+
+// fetchServiceConfigSettings reads the config settings of the service.
+func fetchServiceConfigSettings(ref config.ServiceRef) (serviceConfigSettings, error) {
+	var svc serviceConfigSettings
+	req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return svc, err
+	}
+	if err := decodeJSON(body, &svc); err != nil {
+		return svc, fmt.Errorf("parsing response: %w", err)
+	}
+	return svc, nil
+}
+
+// fetchRequiredConfig returns the config keys the service requires.
+func fetchRequiredConfig(ref config.ServiceRef) ([]string, error) {
+	svc, err := fetchServiceConfigSettings(ref)
+	return svc.RequiredConfig, err
+}
+
+// isLinkedService reports whether ref is the service the current directory
+// is linked to, so settings in .ancla/config.yaml apply to it.
+func isLinkedService(ref config.ServiceRef) bool {
+	return cfg.Service == ref.Service && cfg.Env == ref.Env && cfg.Project == ref.Project && cfg.Workspace == ref.Workspace
+}
+
+// requiredConfigStatus maps each required key to the scope it is set at,
+// or "" when it is missing. The service's config list includes the
+// variables it inherits from the scopes above it.
+func requiredConfigStatus(ref config.ServiceRef, required []string) (map[string]string, error) {
+	vars, err := fetchConfigVars(ref.ServicePath() + "/config/")
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]string, len(required))
+	for _, key := range required {
+		status[key] = ""
+	}
+	for _, v := range vars {
+		if _, ok := status[v.Name]; ok && status[v.Name] == "" {
+			scope := v.Scope
+			if scope == "" {
+				scope = "service"
+			}
+			status[v.Name] = scope
+		}
+	}
+	return status, nil
+}
+
+// preflightRequiredConfig fails a deploy of ref when any config var the
+// service requires, or the linked directory's required_config lists, is
+// not set at some scope.
+func preflightRequiredConfig(ref config.ServiceRef) error {
+	missing, err := missingRequiredConfig(ref)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	return fmt.Errorf("%s is missing %d required config var(s): %s\n\n  Set them with `ancla config set %s KEY=value`, or stop requiring one with `ancla config require --remove KEY`",
+		ref, len(missing), strings.Join(missing, ", "), ref)
+}
+
+// missingRequiredConfig returns the required config vars of ref that are
+// not set at any scope, sorted.
+func missingRequiredConfig(ref config.ServiceRef) ([]string, error) {
+	required, err := fetchRequiredConfig(ref)
+	if err != nil {
+		return nil, err
+	}
+	if isLinkedService(ref) {
+		for _, key := range cfg.RequiredConfig {
+			if !contains(required, key) {
+				required = append(required, key)
+			}
+		}
+	}
+	if len(required) == 0 {
+		return nil, nil
+	}
+	status, err := requiredConfigStatus(ref, required)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for key, scope := range status {
+		if scope == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// printRequiredConfig lists the service's required config vars and where
+// each is set, including those from the linked directory.
+func printRequiredConfig(ref config.ServiceRef, required []string) error {
+	source := map[string]string{}
+	for _, key := range required {
+		source[key] = "service"
+	}
+	if isLinkedService(ref) {
+		for _, key := range cfg.RequiredConfig {
+			if _, ok := source[key]; !ok {
+				required = append(required, key)
+				source[key] = ".ancla/config.yaml"
+			}
+		}
+	}
+	if len(required) == 0 {
+		if !isJSON() {
+			fmt.Println("No required config vars — add one with `ancla config require KEY`.")
+			return nil
+		}
+	}
+	status, err := requiredConfigStatus(ref, required)
+	if err != nil {
+		return err
+	}
+
+	if isJSON() {
+		type requirement struct {
+			Name   string `json:"name"`
+			Source string `json:"source"`
+			SetAt  string `json:"set_at,omitempty"`
+		}
+		out := []requirement{}
+		for _, key := range required {
+			out = append(out, requirement{key, source[key], status[key]})
+		}
+		return printJSON(out)
+	}
+	var rows [][]string
+	for _, key := range required {
+		set := stError.Render("missing")
+		if status[key] != "" {
+			set = stSuccess.Render("set") + stDim.Render(" ("+status[key]+")")
+		}
+		rows = append(rows, []string{key, set, source[key]})
+	}
+	table([]string{"NAME", "STATUS", "REQUIRED BY"}, rows)
+	return nil
+}
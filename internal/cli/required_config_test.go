@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestPreflightRequiredConfig(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/config/"):
+			w.Write([]byte(`[{"name":"DATABASE_URL","scope":"env"},{"name":"PORT"}]`))
+		case strings.HasSuffix(r.URL.Path, "/services/api"):
+			w.Write([]byte(`{"slug":"api","required_config":["DATABASE_URL","SECRET_KEY"]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ref := config.ServiceRef{Workspace: "ws", Project: "proj", Env: "staging", Service: "api"}
+	cfg = &config.Config{Server: ts.URL, RequiredConfig: []string{"PORT", "REDIS_URL"}}
+	err := preflightRequiredConfig(ref)
+	if err == nil || !strings.Contains(err.Error(), "missing 1 required config var(s): SECRET_KEY") {
+		t.Errorf("err = %v, want SECRET_KEY reported missing", err)
+	}
+
+	// The local required_config applies only to the linked service.
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "staging", Service: "api", RequiredConfig: []string{"PORT", "REDIS_URL"}}
+	err = preflightRequiredConfig(ref)
+	if err == nil || !strings.Contains(err.Error(), "missing 2 required config var(s): REDIS_URL, SECRET_KEY") {
+		t.Errorf("err = %v, want REDIS_URL and SECRET_KEY reported missing", err)
+	}
+}
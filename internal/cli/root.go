@@ -3,10 +3,14 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,7 +22,18 @@ var (
 	outputFormat string
 	jsonFlag     bool
 	quietFlag    bool
-	cfg          *config.Config
+	asWorkspace  string
+	noHooks      bool
+	dryRun       bool
+	debugBody    bool
+	progressMode string
+
+	pollIntervalFlag time.Duration
+	maxWaitFlag      time.Duration
+	cfg              *config.Config
+
+	// keyWorkspace is the workspace whose stored key is in use, if any.
+	keyWorkspace string
 )
 
 var rootCmd = &cobra.Command{
@@ -33,37 +48,132 @@ environments, services, builds, deploys, and configuration.`,
 		if err != nil {
 			return fmt.Errorf("loading config: %w", err)
 		}
+		if err := expandCommandLine(cmd, args); err != nil {
+			return err
+		}
 		// CLI flags override config file and env vars
 		if s, _ := cmd.Flags().GetString("server"); s != "" {
 			cfg.Server = s
 		}
+		noReauth = cmd == loginCmd
 		if k, _ := cmd.Flags().GetString("api-key"); k != "" {
 			cfg.APIKey = k
+			noReauth = true
+		} else if os.Getenv("ANCLA_API_KEY") != "" {
+			noReauth = true
+		} else if err := applyWorkspaceKey(cmd); err != nil {
+			return err
+		}
+		// Saved preferences apply unless overridden on the command line.
+		if cfg.Output != "" && !cmd.Flags().Changed("output") {
+			outputFormat = cfg.Output
+		}
+		applyColorPreference(cfg.Color)
+		if accessible() {
+			applyAccessibleMode()
 		}
+		if err := checkProgressMode(); err != nil {
+			return err
+		}
+		// Past argument validation, failures are runtime errors — the
+		// usage text is noise next to them.
+		cmd.SilenceUsage = true
+
 		// Non-blocking update check (runs in background goroutine)
 		checkForUpdate()
-		return nil
+		if err := checkServerVersion(cmd); err != nil {
+			return err
+		}
+		return runPreHook(cmd, args)
 	},
 }
 
+// applyWorkspaceKey switches to the API key stored for the workspace named
+// by --as-workspace, or else for the linked or default workspace. Without
+// a stored key the default api_key stays in use, except that an explicit
+// --as-workspace must match one (login is exempt — it creates the key).
+func applyWorkspaceKey(cmd *cobra.Command) error {
+	ws := asWorkspace
+	if ws == "" {
+		ws = cfg.Workspace
+	}
+	if ws == "" {
+		ws = cfg.DefaultWorkspace
+	}
+	if k := cfg.WorkspaceKey(ws); k != "" {
+		cfg.APIKey = k
+		keyWorkspace = ws
+		return nil
+	}
+	if asWorkspace != "" && cmd != loginCmd {
+		return fmt.Errorf("no API key stored for workspace %q — run `ancla login --as-workspace %s`", asWorkspace, asWorkspace)
+	}
+	return nil
+}
+
 // RootCmd returns the root cobra.Command for documentation generation.
 func RootCmd() *cobra.Command {
 	return rootCmd
 }
 
-// Execute runs the root command.
+// errReported is returned by a command that has already printed why it
+// failed; Execute exits non-zero without printing anything more.
+var errReported = errors.New("failure already reported")
+
+// Execute runs the root command. Errors are reported here rather than by
+// cobra so API failures can be rendered as cards with a suggested fix.
 func Execute() error {
-	return rootCmd.Execute()
+	// A panic must not leave the cursor hidden or the terminal raw; the
+	// re-panic keeps the stack trace.
+	defer func() {
+		if r := recover(); r != nil {
+			restoreTerminal()
+			offerBundle(tr("ancla crashed."), r, string(debug.Stack()))
+			panic(r)
+		}
+	}()
+	cmd, err := rootCmd.ExecuteC()
+	err = runPostHook(err)
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	if errors.Is(err, errAborted) {
+		fmt.Println("Aborted.")
+		return nil
+	}
+	// rootCmd is silenced so this is the only reporter; subcommands that
+	// already rendered their own failure (deploy) opt out individually,
+	// or return errReported for just that failure.
+	if err != nil && !errors.Is(err, errReported) && (cmd == rootCmd || !cmd.SilenceErrors) {
+		reportError(os.Stderr, err)
+	}
+	if isFatal(err) {
+		offerBundle(tr("This looks like a bug in ancla or the server."), err, "")
+	}
+	return err
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: ~/.ancla/config.yaml)")
 	rootCmd.PersistentFlags().String("server", "", "Ancla server URL (dev only)")
 	rootCmd.PersistentFlags().String("api-key", "", "API key for authentication")
+	rootCmd.PersistentFlags().StringVar(&asWorkspace, "as-workspace", "", "Use the API key stored for this workspace")
 	_ = rootCmd.PersistentFlags().MarkHidden("server")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table or json")
 	rootCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "Shorthand for --output json")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output")
+	rootCmd.PersistentFlags().BoolVar(&wideFlag, "wide", false, "Print table cells in full instead of truncating them to fit the terminal")
+	rootCmd.PersistentFlags().BoolVar(&absoluteTimeFlag, "absolute-time", false, "Show timestamps as RFC 3339 instead of relative times like \"3m ago\"")
+	rootCmd.PersistentFlags().BoolVar(&accessibleFlag, "accessible", false, "Screen-reader friendly output: no color, symbols, or spinners (or set ANCLA_ACCESSIBLE)")
+	rootCmd.PersistentFlags().BoolVarP(&yesFlag, "yes", "y", false, "Answer yes to confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractiveFlag, "non-interactive", false, "Never prompt; fail where confirmation or input would be needed")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print mutating requests (method, path, payload) instead of sending them")
+	rootCmd.PersistentFlags().BoolVar(&readOnlyFlag, "read-only", false, "Refuse to send requests that change anything, for safely exploring production")
+	rootCmd.PersistentFlags().DurationVar(&pollIntervalFlag, "poll-interval", defaultPollInterval, "Delay between status polls when following")
+	rootCmd.PersistentFlags().DurationVar(&maxWaitFlag, "max-wait", defaultMaxWait, "Stop following after this long and exit 124 (0 for no limit)")
+	rootCmd.PersistentFlags().StringVar(&progressMode, "progress", progressAuto, "How to show build and deploy progress: auto, plain, or json")
+	rootCmd.PersistentFlags().BoolVar(&debugBody, "debug-body", false, "Include the full response body when a response can't be decoded")
+	rootCmd.PersistentFlags().BoolVar(&noHooks, "no-hooks", false, "Skip pre_/post_ command hooks from the config")
 
 	rootCmd.AddGroup(
 		&cobra.Group{ID: "auth", Title: "Auth & Identity:"},
@@ -74,6 +184,7 @@ func init() {
 
 	// Custom help with bold headers and branded banner
 	rootCmd.SetHelpFunc(styledHelp)
+	rootCmd.SilenceErrors = true
 }
 
 // styledHelp renders a fully custom help screen with brand styling.
@@ -91,7 +202,7 @@ func styledHelp(cmd *cobra.Command, _ []string) {
 
 func renderRootHelp(b *strings.Builder, cmd *cobra.Command) {
 	// Banner
-	b.WriteString(stHeading.Render(symAnchor + " Ancla CLI"))
+	b.WriteString(heading("Ancla CLI"))
 	b.WriteString(stDim.Render(" v"+Version) + "\n")
 	b.WriteString(stDim.Render("  Ship it.") + "\n\n")
 
@@ -237,26 +348,59 @@ func printJSON(v any) error {
 	return nil
 }
 
-// apiClient returns an *http.Client with the API key header set.
+// apiClient returns an *http.Client with the API key header set. Clients
+// are cheap; they all share apiTransport and so its connection pool.
 func apiClient() *http.Client {
 	return &http.Client{
 		Transport: &apiKeyTransport{
 			key:  cfg.APIKey,
-			base: http.DefaultTransport,
+			base: apiTransport,
 		},
 	}
 }
 
+// apiTransport carries every API request the process makes, so a
+// ten-minute deploy follow polls over one warm connection instead of
+// paying a TLS handshake each time.
+var apiTransport = newAPITransport()
+
+// newAPITransport tunes a copy of the default transport for the CLI's
+// traffic: short bursts of requests to a single host, then polling.
+func newAPITransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	// Multi-service log tails hold one stream per service; keep enough idle
+	// connections that the next request never has to dial.
+	t.MaxIdleConnsPerHost = 16
+	// Longer than any sensible --poll-interval, so polls always find the
+	// connection still open.
+	t.IdleConnTimeout = 2 * time.Minute
+	// Ping an HTTP/2 connection that has gone quiet, so one silently
+	// dropped by a NAT or proxy is noticed and replaced rather than
+	// hanging the next poll.
+	t.HTTP2 = &http.HTTP2Config{
+		SendPingTimeout: 30 * time.Second,
+		PingTimeout:     15 * time.Second,
+	}
+	return t
+}
+
 type apiKeyTransport struct {
 	key  string
 	base http.RoundTripper
 }
 
 func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := checkReadOnly(req); err != nil {
+		return nil, err
+	}
 	if t.key != "" {
 		req.Header.Set("X-API-Key", t.key)
 	}
-	return t.base.RoundTrip(req)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	recordExchange(req, resp, err, start)
+	return resp, err
 }
 
 // serverURL returns the configured server base URL, ensuring it has a scheme.
@@ -276,7 +420,10 @@ func apiURL(path string) string {
 // doRequest performs an HTTP request and returns the response body.
 // It checks for error status codes and formats API error messages.
 func doRequest(req *http.Request) ([]byte, error) {
-	resp, err := apiClient().Do(req)
+	if dryRun && isMutating(req) {
+		return nil, printDryRun(req)
+	}
+	resp, err := sendAPIRequest(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -284,33 +431,64 @@ func doRequest(req *http.Request) ([]byte, error) {
 	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode >= 400 {
-		switch resp.StatusCode {
-		case 401:
-			return nil, fmt.Errorf("not authenticated — run `ancla login` first")
-		case 403:
-			return nil, fmt.Errorf("permission denied")
-		case 404:
-			return nil, fmt.Errorf("not found")
-		case 500:
-			return nil, fmt.Errorf("server error — try again or check server logs")
-		default:
-			var apiErr struct {
-				Status  int    `json:"status"`
-				Message string `json:"message"`
-				Detail  string `json:"detail"`
-			}
-			if json.Unmarshal(body, &apiErr) == nil {
-				msg := apiErr.Message
-				if msg == "" {
-					msg = apiErr.Detail
-				}
-				if msg != "" {
-					return nil, fmt.Errorf("%s", msg)
-				}
-			}
-			return nil, fmt.Errorf("request failed (%d)", resp.StatusCode)
+		return nil, &apiError{
+			Status:  resp.StatusCode,
+			Message: apiErrorMessage(resp.StatusCode, body),
+			Path:    req.URL.Path,
 		}
 	}
 
 	return body, nil
 }
+
+// doStreamRequest is doRequest for large response bodies. On success the
+// caller reads and closes resp.Body; error statuses are returned as
+// *apiError with the body already consumed.
+func doStreamRequest(req *http.Request) (*http.Response, error) {
+	if dryRun && isMutating(req) {
+		return nil, printDryRun(req)
+	}
+	resp, err := sendAPIRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &apiError{
+			Status:  resp.StatusCode,
+			Message: apiErrorMessage(resp.StatusCode, body),
+			Path:    req.URL.Path,
+		}
+	}
+	return resp, nil
+}
+
+// apiErrorMessage returns the one-line message for an HTTP error response.
+func apiErrorMessage(status int, body []byte) string {
+	switch status {
+	case 401:
+		return "not authenticated — run `ancla login` first"
+	case 403:
+		return "permission denied"
+	case 404:
+		return "not found"
+	case 500:
+		return "server error — try again or check server logs"
+	}
+	var apiErr struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil {
+		msg := apiErr.Message
+		if msg == "" {
+			msg = apiErr.Detail
+		}
+		if msg != "" {
+			return msg
+		}
+	}
+	return fmt.Sprintf("request failed (%d)", status)
+}
@@ -2,8 +2,10 @@ package cli
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/SideQuest-Group/ancla-client/internal/config"
@@ -107,6 +109,39 @@ func TestApiKeyTransport(t *testing.T) {
 	})
 }
 
+func TestDoRequest_ReusesConnection(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var mu sync.Mutex
+	conns := 0
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"building"}`))
+	}))
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	for range 5 {
+		req, _ := http.NewRequest("GET", apiURL("/deploys/d1"), nil)
+		if _, err := doRequest(req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if conns != 1 {
+		t.Errorf("5 polls opened %d connections, want 1", conns)
+	}
+}
+
 func TestDoRequest_Success(t *testing.T) {
 	origCfg := cfg
 	defer func() { cfg = origCfg }()
@@ -291,3 +326,49 @@ func TestIsJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyWorkspaceKey(t *testing.T) {
+	origCfg, origAs, origKeyWs := cfg, asWorkspace, keyWorkspace
+	defer func() { cfg, asWorkspace, keyWorkspace = origCfg, origAs, origKeyWs }()
+
+	newCfg := func() *config.Config {
+		c := &config.Config{Server: "https://ancla.dev", APIKey: "default-key", Workspace: "acme"}
+		c.SetWorkspaceKey("acme", "acme-key")
+		c.SetWorkspaceKey("globex", "globex-key")
+		return c
+	}
+	tests := []struct {
+		name    string
+		as      string
+		linked  string
+		want    string
+		wantErr bool
+	}{
+		{"linked workspace", "", "acme", "acme-key", false},
+		{"flag overrides link", "globex", "acme", "globex-key", false},
+		{"no stored key keeps default", "", "initech", "default-key", false},
+		{"flag without stored key", "initech", "acme", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = newCfg()
+			cfg.Workspace = tt.linked
+			asWorkspace, keyWorkspace = tt.as, ""
+
+			err := applyWorkspaceKey(servicesCmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyWorkspaceKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.APIKey != tt.want {
+				t.Errorf("APIKey = %q, want %q", cfg.APIKey, tt.want)
+			}
+		})
+	}
+
+	// Login is how a missing workspace key gets created.
+	cfg = newCfg()
+	asWorkspace = "initech"
+	if err := applyWorkspaceKey(loginCmd); err != nil {
+		t.Errorf("applyWorkspaceKey(login) error = %v", err)
+	}
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -41,16 +40,16 @@ specified command.`,
 			cmdArgs = args
 		}
 
-		ws, proj, env, svc, err := config.ResolveServicePath(argPath, cfg)
+		ref, err := config.ResolveServiceRef(argPath, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" || proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("not fully linked — run `ancla link <ws>/<proj>/<env>/<svc>` first")
 		}
 
 		// Fetch service config
-		svcPath := "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc + "/config/"
+		svcPath := ref.ServicePath() + "/config/"
 		req, _ := http.NewRequest("GET", apiURL(svcPath), nil)
 		body, err := doRequest(req)
 		if err != nil {
@@ -62,7 +61,7 @@ specified command.`,
 			Value  string `json:"value"`
 			Secret bool   `json:"secret"`
 		}
-		if err := json.Unmarshal(body, &configs); err != nil {
+		if err := decodeJSON(body, &configs); err != nil {
 			return fmt.Errorf("parsing config: %w", err)
 		}
 
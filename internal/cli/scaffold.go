@@ -170,7 +170,9 @@ RUN uv sync --frozen --no-dev --no-install-project
 COPY . .
 RUN uv sync --frozen --no-dev
 
-# Run
+# Run as an unprivileged user
+RUN useradd --create-home app
+USER app
 EXPOSE 8000
 CMD %s
 `, pyVer, cmd)
@@ -189,7 +191,9 @@ RUN pip install --no-cache-dir .
 COPY . .
 RUN pip install --no-cache-dir .
 
-# Run
+# Run as an unprivileged user
+RUN useradd --create-home app
+USER app
 EXPOSE 8000
 CMD %s
 `, pyVer, cmd)
@@ -210,10 +214,8 @@ func ensureDockerfile() error {
 	}
 
 	// Already has a Dockerfile — skip
-	for _, name := range []string{"Dockerfile.ancla", "Dockerfile"} {
-		if _, err := os.Stat(filepath.Join(cwd, name)); err == nil {
-			return nil
-		}
+	if scanBuildFiles(cwd).Dockerfile != "" {
+		return nil
 	}
 
 	// Detect Python project
@@ -260,3 +262,45 @@ func ensureDockerfile() error {
 	fmt.Println("  ✓ Generated Dockerfile.ancla + Procfile.ancla")
 	return nil
 }
+
+// buildFiles records which build inputs a directory has. Each field holds
+// the file name found, preferring the .ancla variant, or "" if neither.
+type buildFiles struct {
+	Dockerfile string // Dockerfile.ancla or Dockerfile
+	Procfile   string // Procfile.ancla or Procfile
+}
+
+// scanBuildFiles looks for a Dockerfile and a Procfile in dir.
+func scanBuildFiles(dir string) buildFiles {
+	first := func(names ...string) string {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return name
+			}
+		}
+		return ""
+	}
+	return buildFiles{
+		Dockerfile: first("Dockerfile.ancla", "Dockerfile"),
+		Procfile:   first("Procfile.ancla", "Procfile"),
+	}
+}
+
+// strategyWarnings lists what in files would trip up a build with strategy.
+func strategyWarnings(strategy string, files buildFiles) []string {
+	var warnings []string
+	switch strategy {
+	case "dockerfile":
+		if files.Dockerfile == "" {
+			warnings = append(warnings, "No Dockerfile or Dockerfile.ancla in this directory — builds fail without one. `ancla deploy` offers to generate one for Python projects.")
+		}
+	case "buildpack":
+		if files.Procfile == "" {
+			warnings = append(warnings, "No Procfile or Procfile.ancla in this directory — the buildpack will guess the web command, and no other processes will run.")
+		}
+		if files.Dockerfile != "" {
+			warnings = append(warnings, files.Dockerfile+" is ignored by buildpack builds.")
+		}
+	}
+	return warnings
+}
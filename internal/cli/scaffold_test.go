@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanBuildFiles(t *testing.T) {
+	dir := t.TempDir()
+	if got := scanBuildFiles(dir); got != (buildFiles{}) {
+		t.Errorf("empty dir = %+v", got)
+	}
+	for _, name := range []string{"Dockerfile", "Dockerfile.ancla", "Procfile"} {
+		os.WriteFile(filepath.Join(dir, name), nil, 0o644)
+	}
+	if got, want := scanBuildFiles(dir), (buildFiles{Dockerfile: "Dockerfile.ancla", Procfile: "Procfile"}); got != want {
+		t.Errorf("scanBuildFiles = %+v, want %+v", got, want)
+	}
+}
+
+func TestStrategyWarnings(t *testing.T) {
+	tests := []struct {
+		strategy string
+		files    buildFiles
+		want     []string // substrings, one per warning
+	}{
+		{"dockerfile", buildFiles{Dockerfile: "Dockerfile"}, nil},
+		{"dockerfile", buildFiles{Procfile: "Procfile"}, []string{"No Dockerfile"}},
+		{"buildpack", buildFiles{Procfile: "Procfile.ancla"}, nil},
+		{"buildpack", buildFiles{Dockerfile: "Dockerfile"}, []string{"No Procfile", "Dockerfile is ignored"}},
+	}
+	for _, tt := range tests {
+		got := strategyWarnings(tt.strategy, tt.files)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s %+v: warnings = %q", tt.strategy, tt.files, got)
+			continue
+		}
+		for i, w := range tt.want {
+			if !strings.Contains(got[i], w) {
+				t.Errorf("%s %+v: warning %d = %q, want it to mention %q", tt.strategy, tt.files, i, got[i], w)
+			}
+		}
+	}
+}
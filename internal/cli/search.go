@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().Int("limit", 10, "Show at most this many commands")
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <keyword>...",
+	Short: "Find commands by keyword",
+	Long: `Search the CLI's commands for a keyword: their names and aliases, their
+descriptions, and their flags. Matching is forgiving — prefixes and small
+typos still match — and with several keywords a command must match all
+of them. The best matches are listed first.`,
+	Example: "  ancla search secret\n  ancla search env vars\n  ancla search --limit 3 logs",
+	GroupID: "workflow",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		var hits []searchHit
+		for _, h := range searchCommands(rootCmd, args) {
+			// Its own examples match every search.
+			if h.cmd != cmd {
+				hits = append(hits, h)
+			}
+		}
+		if limit > 0 && len(hits) > limit {
+			hits = hits[:limit]
+		}
+
+		if isJSON() {
+			type jsonHit struct {
+				Command string   `json:"command"`
+				Short   string   `json:"short"`
+				Flags   []string `json:"flags,omitempty"`
+			}
+			out := make([]jsonHit, len(hits))
+			for i, h := range hits {
+				out[i] = jsonHit{Command: h.cmd.CommandPath(), Short: h.cmd.Short, Flags: h.flags}
+			}
+			return printJSON(out)
+		}
+
+		if len(hits) == 0 {
+			return fmt.Errorf("no commands match %q — see `ancla --help` for the full list", strings.Join(args, " "))
+		}
+		rows := make([][]string, len(hits))
+		for i, h := range hits {
+			desc := h.cmd.Short
+			if len(h.flags) > 0 {
+				desc += stDim.Render(" (" + strings.Join(h.flags, ", ") + ")")
+			}
+			rows[i] = []string{h.cmd.CommandPath(), desc}
+		}
+		tableColumns([]tableColumn{{Header: "COMMAND"}, {Header: "DESCRIPTION"}}, rows)
+		return nil
+	},
+}
+
+// searchHit is a command matching every search keyword.
+type searchHit struct {
+	cmd   *cobra.Command
+	score int
+	flags []string // matching flags, as --name
+}
+
+// Where a keyword matched, weighted by how much that says about the
+// command: a keyword in the name means more than one in the long help.
+const (
+	searchWeightName  = 5
+	searchWeightShort = 3
+	searchWeightFlag  = 2
+	searchWeightLong  = 1
+)
+
+// How well a keyword matched a word.
+const (
+	matchNone = iota
+	matchFuzzy
+	matchSubstring
+	matchPrefix
+	matchExact
+)
+
+// searchCommands scores every available command under root against the
+// keywords and returns those matching all of them, best first.
+func searchCommands(root *cobra.Command, keywords []string) []searchHit {
+	var terms []string
+	for _, k := range keywords {
+		terms = append(terms, searchWords(k)...)
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var hits []searchHit
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			if !sub.IsAvailableCommand() {
+				continue
+			}
+			if hit, ok := scoreCommand(sub, terms); ok {
+				hits = append(hits, hit)
+			}
+			walk(sub)
+		}
+	}
+	walk(root)
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].cmd.CommandPath() < hits[j].cmd.CommandPath()
+	})
+	return hits
+}
+
+// scoreCommand scores one command. Each term scores its best match
+// across the command's fields; a term matching nothing rules the command
+// out.
+func scoreCommand(c *cobra.Command, terms []string) (searchHit, bool) {
+	names := append([]string{c.Name()}, c.Aliases...)
+	if c.HasParent() && c.Parent().HasParent() {
+		// "builds prune" should match both words.
+		names = append(names, c.Parent().Name())
+	}
+	nameWords := searchWords(strings.Join(names, " "))
+	shortWords := searchWords(c.Short)
+	longWords := searchWords(c.Long + " " + c.Example)
+
+	hit := searchHit{cmd: c}
+	seenFlag := map[string]bool{}
+	for _, term := range terms {
+		best := max(
+			searchWeightName*bestMatch(term, nameWords),
+			searchWeightShort*bestMatch(term, shortWords),
+			searchWeightLong*bestMatch(term, longWords),
+		)
+		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			if f.Hidden {
+				return
+			}
+			m := bestMatch(term, searchWords(f.Name+" "+f.Usage))
+			if m < matchPrefix {
+				return
+			}
+			if s := searchWeightFlag * m; s > best {
+				best = s
+			}
+			if !seenFlag[f.Name] {
+				seenFlag[f.Name] = true
+				hit.flags = append(hit.flags, "--"+f.Name)
+			}
+		})
+		if best == 0 {
+			return searchHit{}, false
+		}
+		hit.score += best
+	}
+	return hit, true
+}
+
+// bestMatch returns how well term matches the closest of words. A typo
+// is forgiven in a term of five letters or more, and two from nine; in
+// shorter terms a typo can't be told from another word.
+func bestMatch(term string, words []string) int {
+	best := matchNone
+	allowed := 0
+	switch {
+	case len(term) >= 9:
+		allowed = 2
+	case len(term) >= 5:
+		allowed = 1
+	}
+	for _, w := range words {
+		switch {
+		case w == term:
+			return matchExact
+		case strings.HasPrefix(w, term):
+			best = max(best, matchPrefix)
+		case len(term) >= 3 && strings.Contains(w, term):
+			best = max(best, matchSubstring)
+		case allowed > 0 && abs(len(w)-len(term)) <= allowed && editDistance(term, w) <= allowed:
+			best = max(best, matchFuzzy)
+		}
+	}
+	return best
+}
+
+// searchWords lowercases s and splits it into words, keeping hyphenated
+// words whole as well as their parts, so "set-autodeploy" matches both
+// itself and "autodeploy".
+func searchWords(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+	var words []string
+	for _, f := range fields {
+		f = strings.Trim(f, "-")
+		if f == "" {
+			continue
+		}
+		words = append(words, f)
+		if strings.Contains(f, "-") {
+			words = append(words, strings.Split(f, "-")...)
+		}
+	}
+	return words
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// searchTree builds a small command tree to search.
+func searchTree() *cobra.Command {
+	root := &cobra.Command{Use: "ancla"}
+	builds := &cobra.Command{Use: "builds", Short: "Manage builds", Aliases: []string{"b"}}
+	prune := &cobra.Command{Use: "prune", Short: "Delete old builds and their images", Run: func(*cobra.Command, []string) {}}
+	prune.Flags().Int("keep", 20, "Number of recent builds to keep")
+	list := &cobra.Command{Use: "list", Short: "List builds", Long: "Shows each build's version and status.", Run: func(*cobra.Command, []string) {}}
+	builds.AddCommand(prune, list)
+	config := &cobra.Command{Use: "config", Short: "Manage configuration variables"}
+	set := &cobra.Command{Use: "set", Short: "Set configuration variables", Run: func(*cobra.Command, []string) {}}
+	set.Flags().Bool("secret", false, "Store the values as secrets")
+	hidden := &cobra.Command{Use: "debug-secret", Short: "Internal", Hidden: true, Run: func(*cobra.Command, []string) {}}
+	config.AddCommand(set, hidden)
+	root.AddCommand(builds, config)
+	return root
+}
+
+func searchPaths(hits []searchHit) []string {
+	paths := make([]string, len(hits))
+	for i, h := range hits {
+		paths[i] = h.cmd.CommandPath()
+	}
+	return paths
+}
+
+func TestSearchCommands(t *testing.T) {
+	tests := []struct {
+		keywords []string
+		want     []string
+	}{
+		{[]string{"prune"}, []string{"ancla builds prune"}},
+		// The name outranks a description mention.
+		{[]string{"builds"}, []string{"ancla builds", "ancla builds list", "ancla builds prune"}},
+		{[]string{"PRUNE"}, []string{"ancla builds prune"}},
+		// Prefixes and typos.
+		{[]string{"pru"}, []string{"ancla builds prune"}},
+		{[]string{"confguration"}, []string{"ancla config", "ancla config set"}},
+		// Several keywords must all match.
+		{[]string{"builds", "status"}, []string{"ancla builds list"}},
+		{[]string{"builds prune"}, []string{"ancla builds prune"}},
+		// Flags, but not hidden commands.
+		{[]string{"secret"}, []string{"ancla config set"}},
+		{[]string{"keep"}, []string{"ancla builds prune"}},
+		{[]string{"nothing"}, nil},
+	}
+	for _, tt := range tests {
+		got := searchPaths(searchCommands(searchTree(), tt.keywords))
+		if len(got) == 0 {
+			got = nil
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("search %q = %v, want %v", tt.keywords, got, tt.want)
+		}
+	}
+}
+
+func TestSearchCommandsFlags(t *testing.T) {
+	hits := searchCommands(searchTree(), []string{"secret"})
+	if len(hits) != 1 || !reflect.DeepEqual(hits[0].flags, []string{"--secret"}) {
+		t.Fatalf("hits = %+v, want config set with --secret", hits)
+	}
+}
+
+func TestBestMatch(t *testing.T) {
+	words := []string{"deploy", "environment", "logs"}
+	tests := []struct {
+		term string
+		want int
+	}{
+		{"deploy", matchExact},
+		{"dep", matchPrefix},
+		{"ploy", matchSubstring},
+		{"deplou", matchFuzzy},
+		{"enviornment", matchFuzzy},
+		{"dx", matchNone},
+		// Too short for a typo to count.
+		{"logz", matchNone},
+	}
+	for _, tt := range tests {
+		if got := bestMatch(tt.term, words); got != tt.want {
+			t.Errorf("bestMatch(%q) = %d, want %d", tt.term, got, tt.want)
+		}
+	}
+}
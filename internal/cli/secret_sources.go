@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretSchemes are the --from schemes config set resolves.
+var secretSchemes = []string{"vault", "aws-sm", "op", "sops"}
+
+// secretCommand returns the command that prints the secret ref points to,
+// and for aws-sm the JSON key to pick out of the secret string. References
+// are <scheme>:<path>#<field>; 1Password's op://vault/item/field is taken
+// as is.
+func secretCommand(ref string) (bin string, args []string, jsonKey string, err error) {
+	scheme, rest, ok := strings.Cut(ref, ":")
+	if !ok || rest == "" {
+		return "", nil, "", fmt.Errorf("invalid secret reference %q — expected <scheme>:<path>, e.g. vault:secret/myapp#db_password", ref)
+	}
+	path, field, _ := strings.Cut(rest, "#")
+	switch scheme {
+	case "vault":
+		if path == "" || field == "" {
+			return "", nil, "", fmt.Errorf("invalid Vault reference %q — expected vault:<path>#<field>", ref)
+		}
+		return "vault", []string{"kv", "get", "-field=" + field, path}, "", nil
+	case "aws-sm":
+		if path == "" {
+			return "", nil, "", fmt.Errorf("invalid AWS Secrets Manager reference %q — expected aws-sm:<secret-id>[#<json-key>]", ref)
+		}
+		return "aws", []string{"secretsmanager", "get-secret-value", "--secret-id", path, "--query", "SecretString", "--output", "text"}, field, nil
+	case "op":
+		rest = strings.TrimPrefix(rest, "//")
+		if strings.Count(rest, "/") < 2 {
+			return "", nil, "", fmt.Errorf("invalid 1Password reference %q — expected op://<vault>/<item>/<field>", ref)
+		}
+		return "op", []string{"read", "--no-newline", "op://" + rest}, "", nil
+	case "sops":
+		if path == "" || field == "" {
+			return "", nil, "", fmt.Errorf("invalid sops reference %q — expected sops:<file>#<key>, with nested keys joined by dots", ref)
+		}
+		var extract strings.Builder
+		for _, k := range strings.Split(field, ".") {
+			fmt.Fprintf(&extract, "[%q]", k)
+		}
+		return "sops", []string{"--decrypt", "--extract", extract.String(), path}, "", nil
+	}
+	return "", nil, "", fmt.Errorf("unknown secret source %q — use %s", scheme, strings.Join(secretSchemes, ", "))
+}
+
+// resolveSecretRef runs the secret manager's own CLI to read ref, so the
+// value never passes through shell history or a file. The CLI must be
+// installed and signed in.
+func resolveSecretRef(ref string) (string, error) {
+	bin, args, jsonKey, err := secretCommand(ref)
+	if err != nil {
+		return "", err
+	}
+	if _, err := exec.LookPath(bin); err != nil {
+		return "", fmt.Errorf("resolving %s needs the %s CLI, which is not on your PATH", ref, bin)
+	}
+	var stderr bytes.Buffer
+	c := exec.Command(bin, args...)
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("resolving %s: %s", ref, msg)
+	}
+	value := strings.TrimSuffix(strings.TrimSuffix(string(out), "\n"), "\r")
+	if jsonKey != "" {
+		return secretJSONField(ref, value, jsonKey)
+	}
+	if value == "" {
+		return "", fmt.Errorf("resolving %s: the secret is empty", ref)
+	}
+	return value, nil
+}
+
+// secretJSONField picks key out of a secret stored as a JSON object, the
+// way AWS Secrets Manager holds key/value secrets.
+func secretJSONField(ref, secret, key string) (string, error) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("resolving %s: the secret is not a JSON object, so it has no #%s", ref, key)
+	}
+	v, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("resolving %s: the secret has no key %q", ref, key)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, _ := json.Marshal(v)
+	return string(b), nil
+}
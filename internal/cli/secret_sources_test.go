@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretCommand(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    string
+		jsonKey string
+	}{
+		{"vault:secret/myapp#db_password", "vault kv get -field=db_password secret/myapp", ""},
+		{"aws-sm:prod/myapp", "aws secretsmanager get-secret-value --secret-id prod/myapp --query SecretString --output text", ""},
+		{"aws-sm:prod/myapp#db_password", "aws secretsmanager get-secret-value --secret-id prod/myapp --query SecretString --output text", "db_password"},
+		{"op://Production/myapp/db_password", "op read --no-newline op://Production/myapp/db_password", ""},
+		{"op:Production/myapp/db_password", "op read --no-newline op://Production/myapp/db_password", ""},
+		{"sops:secrets.enc.yaml#db.password", `sops --decrypt --extract ["db"]["password"] secrets.enc.yaml`, ""},
+	}
+	for _, tt := range tests {
+		bin, args, jsonKey, err := secretCommand(tt.ref)
+		if err != nil {
+			t.Errorf("secretCommand(%q): %v", tt.ref, err)
+			continue
+		}
+		if got := bin + " " + strings.Join(args, " "); got != tt.want || jsonKey != tt.jsonKey {
+			t.Errorf("secretCommand(%q) = %q, %q; want %q, %q", tt.ref, got, jsonKey, tt.want, tt.jsonKey)
+		}
+	}
+	for _, bad := range []string{"secret/myapp", "vault:secret/myapp", "op://myapp", "sops:secrets.enc.yaml", "gcp:projects/x"} {
+		if _, _, _, err := secretCommand(bad); err == nil {
+			t.Errorf("secretCommand(%q) accepted an invalid reference", bad)
+		}
+	}
+}
+
+func TestSecretJSONField(t *testing.T) {
+	secret := `{"db_password":"hunter2","port":5432}`
+	if v, err := secretJSONField("ref", secret, "db_password"); err != nil || v != "hunter2" {
+		t.Errorf("db_password = %q, %v", v, err)
+	}
+	if v, err := secretJSONField("ref", secret, "port"); err != nil || v != "5432" {
+		t.Errorf("port = %q, %v", v, err)
+	}
+	if _, err := secretJSONField("ref", secret, "missing"); err == nil {
+		t.Error("expected an error for a missing key")
+	}
+	if _, err := secretJSONField("ref", "plain-text", "db_password"); err == nil {
+		t.Error("expected an error for a secret that is not JSON")
+	}
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func init() {
+	rootCmd.AddCommand(serviceAccountsCmd)
+	serviceAccountsCmd.AddCommand(serviceAccountsListCmd)
+	serviceAccountsCmd.AddCommand(serviceAccountsCreateCmd)
+	serviceAccountsCmd.AddCommand(serviceAccountsRotateCmd)
+	serviceAccountsCmd.AddCommand(serviceAccountsDeleteCmd)
+}
+
+// serviceAccount is a machine user scoped to one project. Key is only
+// returned when the account is created or its key is rotated.
+type serviceAccount struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	KeyPrefix  string `json:"key_prefix"`
+	Key        string `json:"key,omitempty"`
+	CreatedBy  string `json:"created_by"`
+	Created    string `json:"created"`
+	LastUsedAt string `json:"last_used_at"`
+}
+
+var serviceAccountsCmd = &cobra.Command{
+	Use:     "service-accounts",
+	Aliases: []string{"sa"},
+	Short:   "Manage deploy-only machine users for CI",
+	Long: `Manage service accounts: machine users scoped to a single project that
+can build, deploy, and read status there, and nothing else. Give CI a
+service account's key instead of a personal one, so a leaked key can only
+deploy that project and can be revoked without logging anyone out.
+
+The key is printed once, when the account is created or its key is
+rotated. The project defaults to the linked or default one.`,
+	Example: "  ancla service-accounts create github-actions\n  ancla service-accounts list my-ws/my-proj\n  ancla service-accounts rotate github-actions",
+	GroupID: "auth",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return serviceAccountsListCmd.RunE(cmd, args)
+	},
+}
+
+var serviceAccountsListCmd = &cobra.Command{
+	Use:     "list [<ws>/<proj>]",
+	Short:   "List a project's service accounts",
+	Example: "  ancla service-accounts list\n  ancla service-accounts list my-ws/my-proj",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveProject(args)
+		if err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("GET", apiURL(ref.ProjectPath()+"/service-accounts/"), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var accounts []serviceAccount
+		if err := decodeJSON(body, &accounts); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(accounts)
+		}
+		var rows [][]string
+		for _, a := range accounts {
+			lastUsed := formatTime(a.LastUsedAt)
+			if lastUsed == "" {
+				lastUsed = stDim.Render("never")
+			}
+			rows = append(rows, []string{a.Name, a.Role, a.KeyPrefix + "…", lastUsed, a.CreatedBy})
+		}
+		table([]string{"NAME", "ROLE", "KEY", "LAST USED", "CREATED BY"}, rows)
+		return nil
+	},
+}
+
+var serviceAccountsCreateCmd = &cobra.Command{
+	Use:   "create <name> [<ws>/<proj>]",
+	Short: "Create a service account and print its key",
+	Long: `Create a deploy-only service account in a project and print its API key.
+The key is shown once; store it in your CI system's secrets straight away.
+With --quiet only the key is printed, for capturing in a script.`,
+	Example: "  ancla service-accounts create github-actions\n  ancla service-accounts create deploy-bot my-ws/my-proj\n  gh secret set ANCLA_API_KEY --body \"$(ancla sa create github-actions -q)\"",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveProject(args[1:])
+		if err != nil {
+			return err
+		}
+		stop := spin("Creating service account...")
+		payload, _ := json.Marshal(map[string]string{"name": args[0], "role": "deployer"})
+		req, _ := http.NewRequest("POST", apiURL(ref.ProjectPath()+"/service-accounts/"), bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+		return printServiceAccountKey(body, "Created service account "+args[0]+" in "+ref.Workspace+"/"+ref.Project)
+	},
+}
+
+var serviceAccountsRotateCmd = &cobra.Command{
+	Use:   "rotate <name> [<ws>/<proj>]",
+	Short: "Replace a service account's key",
+	Long: `Issue a new key for a service account and revoke the old one immediately.
+Update the key in your CI secrets before the next pipeline runs.`,
+	Example: "  ancla service-accounts rotate github-actions",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveProject(args[1:])
+		if err != nil {
+			return err
+		}
+		if err := confirmAction(fmt.Sprintf("The current key for %s stops working immediately.", args[0])); err != nil {
+			return err
+		}
+		stop := spin("Rotating key...")
+		req, _ := http.NewRequest("POST", apiURL(ref.ProjectPath()+"/service-accounts/"+args[0]+"/rotate"), nil)
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+		return printServiceAccountKey(body, "Rotated the key for "+args[0])
+	},
+}
+
+var serviceAccountsDeleteCmd = &cobra.Command{
+	Use:     "delete <name> [<ws>/<proj>]",
+	Short:   "Delete a service account and revoke its key",
+	Example: "  ancla service-accounts delete github-actions --yes",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveProject(args[1:])
+		if err != nil {
+			return err
+		}
+		if err := confirmAction(fmt.Sprintf("This deletes service account %s from %s and revokes its key.", args[0], ref.Workspace+"/"+ref.Project)); err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("DELETE", apiURL(ref.ProjectPath()+"/service-accounts/"+args[0]), nil)
+		if _, err := doRequest(req); err != nil {
+			return err
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone("Deleted service account " + args[0]))
+		}
+		return nil
+	},
+}
+
+// resolveProject resolves args like resolveServiceRef and requires the
+// result to name a project.
+func resolveProject(args []string) (config.ServiceRef, error) {
+	ref, err := resolveServiceRef(args)
+	if err != nil {
+		return ref, err
+	}
+	if ref.Project == "" {
+		return ref, fmt.Errorf("no project specified — provide <ws>/<proj> or run `ancla link` first")
+	}
+	return ref, nil
+}
+
+// printServiceAccountKey prints a newly issued service account key. Under
+// --quiet only the key is printed, so it can be captured by a script; the
+// reminder that it won't be shown again goes to stderr.
+func printServiceAccountKey(body []byte, done string) error {
+	var sa serviceAccount
+	if err := decodeJSON(body, &sa); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if isJSON() {
+		return printJSON(sa)
+	}
+	if isQuiet() {
+		fmt.Println(sa.Key)
+		return nil
+	}
+	fmt.Println(stepDone(done))
+	fmt.Println(kv("Role", sa.Role))
+	fmt.Println(kv("API key", sa.Key))
+	fmt.Println()
+	fmt.Fprintln(os.Stderr, stWarning.Render("  This key will not be shown again — store it in your CI secrets now."))
+	return nil
+}
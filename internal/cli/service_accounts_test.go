@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestServiceAccountsCreate_QuietPrintsKey(t *testing.T) {
+	origCfg, origQuiet, origStdout := cfg, quietFlag, os.Stdout
+	defer func() { cfg, quietFlag, os.Stdout = origCfg, origQuiet, origStdout }()
+
+	var payload map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/workspaces/ws/projects/proj/service-accounts/" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"id":"sa_1","name":"ci","role":"deployer","key":"ancla_secret"}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "ws", Project: "proj", Env: "prod"}
+	quietFlag = true
+
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := serviceAccountsCreateCmd.RunE(serviceAccountsCreateCmd, []string{"ci"})
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = origStdout
+	if err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "ancla_secret" {
+		t.Errorf("quiet output = %q, want just the key", got)
+	}
+	if payload["name"] != "ci" || payload["role"] != "deployer" {
+		t.Errorf("payload = %v", payload)
+	}
+}
+
+func TestResolveProject_RequiresProject(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+	cfg = &config.Config{Workspace: "ws"}
+
+	if _, err := resolveProject(nil); err == nil {
+		t.Error("expected an error without a project")
+	}
+	ref, err := resolveProject([]string{"ws/other"})
+	if err != nil || ref.Project != "other" {
+		t.Errorf("resolveProject = %+v, %v", ref, err)
+	}
+}
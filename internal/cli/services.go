@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -20,7 +22,22 @@ func init() {
 	servicesCmd.AddCommand(servicesDeployCmd)
 	servicesCmd.AddCommand(servicesScaleCmd)
 	servicesCmd.AddCommand(servicesStatusCmd)
-	servicesScaleCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	servicesCmd.AddCommand(servicesCreateCmd)
+	servicesCmd.AddCommand(servicesDeleteCmd)
+	servicesCmd.AddCommand(servicesRenameCmd)
+	servicesCmd.AddCommand(servicesSetStrategyCmd)
+	servicesCmd.AddCommand(servicesResizeCmd)
+	servicesCmd.AddCommand(servicesSizesCmd)
+	servicesCmd.AddCommand(servicesUpdateCmd)
+	servicesUpdateCmd.Flags().Bool("redeploy-on-config", false, "Redeploy automatically when the service's config vars change")
+	servicesCreateCmd.Flags().String("slug", "", "Service slug (default: derived from the name)")
+	servicesCreateCmd.Flags().String("platform", "wind", "Platform to run the service on")
+	servicesCreateCmd.Flags().String("build-strategy", "dockerfile", "Build strategy: dockerfile or buildpack")
+	servicesCreateCmd.Flags().String("repo", "", "GitHub repository as owner/repo")
+	servicesCreateCmd.Flags().String("branch", "", "Branch that triggers automatic deploys")
+	servicesRenameCmd.Flags().String("slug", "", "Also change the service slug")
+	servicesDeployCmd.Flags().Bool("confirm-production", false, "Confirm a deploy to a protected environment without a prompt")
+	servicesDeployCmd.Flags().Bool("override-freeze", false, "Deploy during a freeze window (admins only)")
 }
 
 var servicesCmd = &cobra.Command{
@@ -31,7 +48,8 @@ var servicesCmd = &cobra.Command{
 
 Services are the deployable units in Ancla. Each service belongs to a
 workspace/project/environment and has its own builds, deploys, and configuration.
-Use sub-commands to list, inspect, deploy, and scale your services.`,
+Use sub-commands to create, list, inspect, deploy, scale, resize, rename,
+and delete your services.`,
 	Example: "  ancla services list my-ws/my-proj/staging\n  ancla services get my-ws/my-proj/staging/my-svc\n  ancla services deploy my-ws/my-proj/staging/my-svc",
 	GroupID: "resources",
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -39,32 +57,66 @@ Use sub-commands to list, inspect, deploy, and scale your services.`,
 	},
 }
 
-// resolveServicePath extracts ws/proj/env/svc from a slash-separated argument,
-// falling back to cfg fields for missing segments. Returns an error if the
-// workspace segment is empty (minimum required context).
-func resolveServicePath(args []string) (ws, proj, env, svc string, err error) {
+// resolveServiceRef resolves the slash-separated path in args[0], falling
+// back to the link and the global defaults for missing segments. Returns an
+// error if the workspace segment is empty (minimum required context).
+func resolveServiceRef(args []string) (config.ServiceRef, error) {
 	var arg string
 	if len(args) > 0 {
 		arg = args[0]
 	}
-	ws, proj, env, svc, err = config.ResolveServicePath(arg, cfg)
+	ref, err := config.ResolveServiceRef(arg, cfg)
 	if err != nil {
-		return
+		return ref, err
 	}
-	if ws == "" {
-		err = fmt.Errorf("workspace is required — provide <ws>/... or run `ancla link`")
+	if ref.Workspace == "" {
+		return ref, fmt.Errorf("workspace is required — provide <ws>/..., run `ancla link`, or set a default with `ancla settings set default_workspace <ws>`")
 	}
-	return
+	return ref, nil
+}
+
+// resolveEnv resolves args like resolveServiceRef and requires the result
+// to name an environment.
+func resolveEnv(args []string) (config.ServiceRef, error) {
+	ref, err := resolveServiceRef(args)
+	if err != nil {
+		return ref, err
+	}
+	return ref, ref.RequireEnv()
+}
+
+// resolveService resolves args like resolveServiceRef and requires the
+// result to name a service.
+func resolveService(args []string) (config.ServiceRef, error) {
+	ref, err := resolveServiceRef(args)
+	if err != nil {
+		return ref, err
+	}
+	return ref, ref.RequireService()
+}
+
+// resolveServiceSegments resolves a service named by a bare slug within
+// the linked environment, a full ws/proj/env/svc path, or an empty spec for
+// the linked service. Segments that can't be resolved are left empty.
+func resolveServiceSegments(spec string) (config.ServiceRef, error) {
+	if strings.Contains(spec, "/") {
+		return config.ResolveServiceRef(spec, cfg)
+	}
+	ref, err := config.ResolveServiceRef("", cfg)
+	if spec != "" {
+		ref.Service = spec
+	}
+	return ref, err
 }
 
 // envPath builds the nested API path prefix up to the environment level.
 func envPath(ws, proj, env string) string {
-	return fmt.Sprintf("/workspaces/%s/projects/%s/envs/%s", ws, proj, env)
+	return config.ServiceRef{Workspace: ws, Project: proj, Env: env}.EnvPath()
 }
 
 // servicePath builds the nested API path prefix for a service resource.
 func servicePath(ws, proj, env, svc string) string {
-	return envPath(ws, proj, env) + "/services/" + svc
+	return config.ServiceRef{Workspace: ws, Project: proj, Env: env, Service: svc}.ServicePath()
 }
 
 // serviceBasePath builds the nested API path prefix up to the environment level.
@@ -75,42 +127,47 @@ func serviceBasePath(ws, proj, env string) string {
 var servicesListCmd = &cobra.Command{
 	Use:               "list <ws>/<proj>/<env>",
 	Short:             "List services in an environment",
-	Example:           "  ancla services list my-ws/my-proj/staging",
+	Example:           "  ancla services list my-ws/my-proj/staging\n  ancla services list my-ws/my-proj/staging -l team=payments\n  ancla services list my-ws/my-proj/staging --sort name",
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: completeProjects,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, _, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" {
+		if !ref.HasEnv() {
 			return fmt.Errorf("usage: services list <ws>/<proj>/<env>")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(serviceBasePath(ws, proj, env)), nil)
-		body, err := doRequest(req)
+		var sel labelSelector
+		if expr, _ := cmd.Flags().GetString("selector"); expr != "" {
+			if sel, err = parseSelector(expr); err != nil {
+				return err
+			}
+		}
+		services, err := listServices(ref.Workspace, ref.Project, ref.Env, sel)
 		if err != nil {
 			return err
 		}
-
-		var services []struct {
-			Name     string `json:"name"`
-			Slug     string `json:"slug"`
-			Platform string `json:"platform"`
-		}
-		if err := json.Unmarshal(body, &services); err != nil {
-			return fmt.Errorf("parsing response: %w", err)
+		if services, err = applyListFlags(cmd, services, serviceListFields); err != nil {
+			return err
 		}
 
+		if idOnly(cmd) {
+			for _, s := range services {
+				fmt.Println(s.Slug)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(services)
 		}
 
 		var rows [][]string
 		for _, s := range services {
-			rows = append(rows, []string{s.Slug, s.Name, s.Platform})
+			rows = append(rows, []string{s.Slug, s.Name, s.Platform, formatLabels(s.Labels)})
 		}
-		table([]string{"SLUG", "NAME", "PLATFORM"}, rows)
+		table([]string{"SLUG", "NAME", "PLATFORM", "LABELS"}, rows)
 		return nil
 	},
 }
@@ -121,15 +178,15 @@ var servicesGetCmd = &cobra.Command{
 	Example: "  ancla services get my-ws/my-proj/staging/my-svc",
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("usage: services get <ws>/<proj>/<env>/<svc>")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(servicePath(ws, proj, env, svc)), nil)
+		req, _ := http.NewRequest("GET", apiURL(ref.ServicePath()), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
@@ -141,12 +198,39 @@ var servicesGetCmd = &cobra.Command{
 			Platform         string         `json:"platform"`
 			GithubRepository string         `json:"github_repository"`
 			AutoDeployBranch string         `json:"auto_deploy_branch"`
+			RedeployOnConfig *bool          `json:"redeploy_on_config_change,omitempty"`
 			ProcessCounts    map[string]int `json:"process_counts"`
-		}
-		if err := json.Unmarshal(body, &service); err != nil {
+			Processes        map[string]struct {
+				Desired       int     `json:"desired"`
+				Running       int     `json:"running"`
+				Size          string  `json:"size,omitempty"`
+				CPULimit      float64 `json:"cpu_limit,omitempty"`
+				MemoryLimitMB int     `json:"memory_limit_mb,omitempty"`
+			} `json:"processes,omitempty"`
+			Autoscaling *struct {
+				Enabled  bool `json:"enabled"`
+				Policies map[string]struct {
+					MinReplicas      int `json:"min_replicas"`
+					MaxReplicas      int `json:"max_replicas"`
+					TargetCPUPercent int `json:"target_cpu_percent,omitempty"`
+				} `json:"policies,omitempty"`
+				LastScaleEvent *struct {
+					Process string `json:"process"`
+					From    int    `json:"from"`
+					To      int    `json:"to"`
+					Reason  string `json:"reason,omitempty"`
+					At      string `json:"at"`
+				} `json:"last_scale_event,omitempty"`
+			} `json:"autoscaling,omitempty"`
+		}
+		if err := decodeJSON(body, &service); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			fmt.Println(service.Slug)
+			return nil
+		}
 		if isJSON() {
 			return printJSON(service)
 		}
@@ -159,7 +243,63 @@ var servicesGetCmd = &cobra.Command{
 		if service.AutoDeployBranch != "" {
 			fmt.Printf("Auto-deploy branch: %s\n", service.AutoDeployBranch)
 		}
-		if len(service.ProcessCounts) > 0 {
+		if service.RedeployOnConfig != nil {
+			state := "off — config changes apply on the next deploy"
+			if *service.RedeployOnConfig {
+				state = "on"
+			}
+			fmt.Printf("Redeploy on config change: %s\n", state)
+		}
+		if as := service.Autoscaling; as != nil {
+			state := "disabled"
+			if as.Enabled {
+				state = "enabled"
+			}
+			fmt.Printf("Autoscaling: %s\n", state)
+			if ev := as.LastScaleEvent; ev != nil {
+				line := fmt.Sprintf("Last scale event: %s %d %s %d at %s", ev.Process, ev.From, symArrow, ev.To, ev.At)
+				if ev.Reason != "" {
+					line += " (" + ev.Reason + ")"
+				}
+				fmt.Println(line)
+			}
+		}
+		if len(service.Processes) > 0 {
+			fmt.Println()
+			procs := make([]string, 0, len(service.Processes))
+			for proc := range service.Processes {
+				procs = append(procs, proc)
+			}
+			sort.Strings(procs)
+			var rows [][]string
+			for _, proc := range procs {
+				p := service.Processes[proc]
+				replicas := fmt.Sprintf("%d/%d", p.Running, p.Desired)
+				if p.Running < p.Desired {
+					replicas = stWarning.Render(replicas)
+				}
+				size, cpu, mem, scale := "-", "-", "-", "-"
+				if p.Size != "" {
+					size = p.Size
+				}
+				if p.CPULimit > 0 {
+					cpu = strconv.FormatFloat(p.CPULimit, 'f', -1, 64) + " vCPU"
+				}
+				if p.MemoryLimitMB > 0 {
+					mem = fmt.Sprintf("%d MiB", p.MemoryLimitMB)
+				}
+				if as := service.Autoscaling; as != nil && as.Enabled {
+					if pol, ok := as.Policies[proc]; ok {
+						scale = fmt.Sprintf("%d-%d", pol.MinReplicas, pol.MaxReplicas)
+						if pol.TargetCPUPercent > 0 {
+							scale += fmt.Sprintf(" @ %d%% CPU", pol.TargetCPUPercent)
+						}
+					}
+				}
+				rows = append(rows, []string{proc, replicas, size, cpu, mem, scale})
+			}
+			table([]string{"PROCESS", "RUNNING", "SIZE", "CPU", "MEMORY", "AUTOSCALE"}, rows)
+		} else if len(service.ProcessCounts) > 0 {
 			fmt.Println("Processes:")
 			for proc, count := range service.ProcessCounts {
 				fmt.Printf("  %s: %d\n", proc, count)
@@ -170,22 +310,27 @@ var servicesGetCmd = &cobra.Command{
 }
 
 var servicesDeployCmd = &cobra.Command{
-	Use:     "deploy <ws>/<proj>/<env>/<svc>",
-	Short:   "Trigger a full deploy for a service",
-	Example: "  ancla services deploy my-ws/my-proj/staging/my-svc",
-	Args:    cobra.ExactArgs(1),
+	Use:         "deploy <ws>/<proj>/<env>/<svc>",
+	Short:       "Trigger a full deploy for a service",
+	Example:     "  ancla services deploy my-ws/my-proj/staging/my-svc",
+	Annotations: map[string]string{expandAnnotation: "true"},
+	Args:        cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("usage: services deploy <ws>/<proj>/<env>/<svc>")
 		}
 
+		guard, err := guardDeploy(cmd, ref)
+		if err != nil {
+			return err
+		}
+
 		stop := spin("Deploying...")
-		req, _ := http.NewRequest("POST", apiURL(servicePath(ws, proj, env, svc)+"/deploy"), nil)
-		body, err := doRequest(req)
+		body, err := doRequest(newDeployRequest(ref, guard, nil))
 		stop()
 		if err != nil {
 			return err
@@ -193,11 +338,15 @@ var servicesDeployCmd = &cobra.Command{
 
 		var result struct {
 			BuildID string `json:"build_id"`
+			Status  string `json:"status"`
 		}
-		if err := json.Unmarshal(body, &result); err != nil {
+		if err := decodeJSON(body, &result); err != nil {
 			fmt.Println("Deploy likely succeeded, but the response could not be parsed (unexpected format).")
 			return nil
 		}
+		if pendingApproval(result.Status, guard) {
+			return nil
+		}
 		fmt.Printf("Deploy triggered. Build ID: %s\n", result.BuildID)
 		return nil
 	},
@@ -209,11 +358,11 @@ var servicesScaleCmd = &cobra.Command{
 	Example: "  ancla services scale my-ws/my-proj/staging/my-svc web=2 worker=1",
 	Args:    cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("usage: services scale <ws>/<proj>/<env>/<svc> <process>=<count> ...")
 		}
 
@@ -234,9 +383,8 @@ var servicesScaleCmd = &cobra.Command{
 		for proc, count := range counts {
 			if count == 0 {
 				msg := fmt.Sprintf("Scaling %q to 0 will stop the process.", proc)
-				if !confirmAction(cmd, msg) {
-					fmt.Println("Aborted.")
-					return nil
+				if err := confirmAction(msg); err != nil {
+					return err
 				}
 				break // only need to confirm once
 			}
@@ -244,7 +392,7 @@ var servicesScaleCmd = &cobra.Command{
 
 		stop := spin("Scaling...")
 		payload, _ := json.Marshal(map[string]any{"process_counts": counts})
-		req, _ := http.NewRequest("POST", apiURL(servicePath(ws, proj, env, svc)+"/scale"), bytes.NewReader(payload))
+		req, _ := http.NewRequest("POST", apiURL(ref.ServicePath()+"/scale"), bytes.NewReader(payload))
 		req.Header.Set("Content-Type", "application/json")
 		if _, err := doRequest(req); err != nil {
 			stop()
@@ -259,31 +407,35 @@ var servicesScaleCmd = &cobra.Command{
 
 var servicesStatusCmd = &cobra.Command{
 	Use:     "status <ws>/<proj>/<env>/<svc>",
-	Short:   "Show pipeline status for a service",
+	Short:   "Show pipeline status and recent crashes for a service",
 	Example: "  ancla services status my-ws/my-proj/staging/my-svc",
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ws, proj, env, svc, err := resolveServicePath(args)
+		ref, err := resolveServiceRef(args)
 		if err != nil {
 			return err
 		}
-		if proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("usage: services status <ws>/<proj>/<env>/<svc>")
 		}
 
-		req, _ := http.NewRequest("GET", apiURL(pipelineStatusPath(ws, proj, env, svc)), nil)
+		req, _ := http.NewRequest("GET", apiURL(pipelineStatusPath(ref.Workspace, ref.Project, ref.Env, ref.Service)), nil)
 		body, err := doRequest(req)
 		if err != nil {
 			return err
 		}
 
 		var status struct {
-			Build  *struct{ Status string } `json:"build"`
-			Deploy *struct{ Status string } `json:"deploy"`
+			Build   *struct{ Status string } `json:"build"`
+			Deploy  *struct{ Status string } `json:"deploy"`
+			Crashes []crashEvent             `json:"crashes,omitempty"`
 		}
-		if err := json.Unmarshal(body, &status); err != nil {
+		if err := decodeJSON(body, &status); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
+		// Crash history is supplementary; a failure here shouldn't hide
+		// the pipeline status.
+		status.Crashes, _ = fetchCrashes(ref.Workspace, ref.Project, ref.Env, ref.Service)
 
 		if isJSON() {
 			return printJSON(status)
@@ -300,6 +452,342 @@ var servicesStatusCmd = &cobra.Command{
 		rows = append(rows, []string{"Build", buildS})
 		rows = append(rows, []string{"Deploy", depS})
 		table([]string{"STAGE", "STATUS"}, rows)
+		if len(status.Crashes) > 0 {
+			fmt.Println()
+			printCrashes(status.Crashes)
+		}
+		return nil
+	},
+}
+
+var servicesCreateCmd = &cobra.Command{
+	Use:   "create <ws>/<proj>/<env> <name>",
+	Short: "Create a service",
+	Long: `Create a service in an environment without going through the deploy
+wizard. The slug is derived from the name unless --slug is given.`,
+	Example: "  ancla services create my-ws/my-proj/staging api\n  ancla services create my-ws/my-proj/staging api --build-strategy buildpack --repo acme/api --branch main",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveServiceRef(args[:1])
+		if err != nil {
+			return err
+		}
+		if !ref.HasEnv() {
+			return fmt.Errorf("usage: services create <ws>/<proj>/<env> <name>")
+		}
+
+		name := args[1]
+		slug, _ := cmd.Flags().GetString("slug")
+		if slug == "" {
+			slug = slugify(name)
+		}
+		if slug == "" {
+			return fmt.Errorf("cannot derive a slug from %q — pass --slug", name)
+		}
+		platform, _ := cmd.Flags().GetString("platform")
+		strategy, _ := cmd.Flags().GetString("build-strategy")
+		if strategy != "dockerfile" && strategy != "buildpack" {
+			return fmt.Errorf("invalid --build-strategy %q (expected dockerfile or buildpack)", strategy)
+		}
+
+		payload := map[string]any{
+			"name":           name,
+			"slug":           slug,
+			"platform":       platform,
+			"build_strategy": strategy,
+		}
+		if repo, _ := cmd.Flags().GetString("repo"); repo != "" {
+			payload["github_repository"] = repo
+		}
+		if branch, _ := cmd.Flags().GetString("branch"); branch != "" {
+			payload["auto_deploy_branch"] = branch
+		}
+
+		stop := spin("Creating service...")
+		svc, err := postService(ref.Workspace, ref.Project, ref.Env, payload)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		if isJSON() {
+			return printJSON(svc)
+		}
+		if isQuiet() {
+			fmt.Println(svc.Slug)
+			return nil
+		}
+		fmt.Printf("Created service: %s (%s)\n", svc.Name, svc.Slug)
+		return nil
+	},
+}
+
+var servicesDeleteCmd = &cobra.Command{
+	Use:   "delete <ws>/<proj>/<env>/<svc>",
+	Short: "Delete a service",
+	Long: `Delete a service along with its builds, deploys, and configuration.
+
+You are asked to type the service slug to confirm; --yes skips the prompt.`,
+	Example: "  ancla services delete my-ws/my-proj/staging/my-svc\n  ancla services delete my-ws/my-proj/staging/my-svc --yes",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveServiceRef(args)
+		if err != nil {
+			return err
+		}
+		if !ref.HasService() {
+			return fmt.Errorf("usage: services delete <ws>/<proj>/<env>/<svc>")
+		}
+
+		msg := fmt.Sprintf("This permanently deletes %s.", ref)
+		if err := confirmTyped(msg, ref.Service); err != nil {
+			return err
+		}
+
+		stop := spin("Deleting service...")
+		req, _ := http.NewRequest("DELETE", apiURL(ref.ServicePath()), nil)
+		_, err = doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+		fmt.Println("Deleted.")
+		return nil
+	},
+}
+
+var servicesRenameCmd = &cobra.Command{
+	Use:     "rename <ws>/<proj>/<env>/<svc> <new-name>",
+	Short:   "Rename a service",
+	Example: "  ancla services rename my-ws/my-proj/staging/my-svc \"Public API\"\n  ancla services rename my-ws/my-proj/staging/my-svc api --slug api",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveServiceRef(args[:1])
+		if err != nil {
+			return err
+		}
+		if !ref.HasService() {
+			return fmt.Errorf("usage: services rename <ws>/<proj>/<env>/<svc> <new-name>")
+		}
+
+		payload := map[string]any{"name": args[1]}
+		newSlug, _ := cmd.Flags().GetString("slug")
+		if newSlug != "" {
+			payload["slug"] = newSlug
+		}
+
+		data, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("PATCH", apiURL(ref.ServicePath()), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+
+		var updated struct {
+			Name string `json:"name"`
+			Slug string `json:"slug"`
+		}
+		if err := decodeJSON(body, &updated); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(updated)
+		}
+		fmt.Printf("Renamed service: %s (%s)\n", updated.Name, updated.Slug)
+		if updated.Slug != ref.Service && cfg.Service == ref.Service {
+			fmt.Println(stDim.Render(fmt.Sprintf("  This directory is linked to %q — run `ancla link %s/%s/%s/%s` to follow the new slug.", ref.Service, ref.Workspace, ref.Project, ref.Env, updated.Slug)))
+		}
+		return nil
+	},
+}
+
+var servicesUpdateCmd = &cobra.Command{
+	Use:   "update [<ws>/<proj>/<env>/<svc>]",
+	Short: "Change service settings",
+	Long: `Change service settings. Only the flags you pass are updated.
+
+--redeploy-on-config controls what happens when the service's config vars
+change: with true, the service restarts with the new values right away;
+with false, they apply on its next deploy.`,
+	Example: "  ancla services update --redeploy-on-config=false\n  ancla services update my-ws/my-proj/staging/api --redeploy-on-config=true",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveService(args)
+		if err != nil {
+			return err
+		}
+		payload := map[string]any{}
+		if cmd.Flags().Changed("redeploy-on-config") {
+			payload["redeploy_on_config_change"] = boolFlag(cmd, "redeploy-on-config")
+		}
+		if len(payload) == 0 {
+			return fmt.Errorf("nothing to update — pass --redeploy-on-config=true or --redeploy-on-config=false")
+		}
+
+		data, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("PATCH", apiURL(ref.ServicePath()), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+
+		var updated struct {
+			Slug             string `json:"slug"`
+			RedeployOnConfig bool   `json:"redeploy_on_config_change"`
+		}
+		if err := decodeJSON(body, &updated); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(updated)
+		}
+		if !isQuiet() {
+			msg := ref.Service + " now redeploys when its config changes"
+			if !updated.RedeployOnConfig {
+				msg = ref.Service + " now applies config changes on its next deploy"
+			}
+			fmt.Println(stepDone(msg))
+		}
+		return nil
+	},
+}
+
+var servicesSetStrategyCmd = &cobra.Command{
+	Use:   "set-strategy <dockerfile|buildpack> [<ws>/<proj>/<env>/<svc>]",
+	Short: "Switch how a service is built",
+	Long: `Switch a service between Dockerfile and buildpack builds. The change
+applies from the next build; the running deploy is not touched.
+
+Before switching, the current directory is checked for the files the new
+strategy needs — a Dockerfile for dockerfile builds, a Procfile for
+buildpack builds — and anything missing is reported as a warning.`,
+	Example:   "  ancla services set-strategy buildpack\n  ancla services set-strategy dockerfile my-ws/my-proj/staging/api",
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: []string{"dockerfile", "buildpack"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy := args[0]
+		if strategy != "dockerfile" && strategy != "buildpack" {
+			return fmt.Errorf("invalid build strategy %q — expected dockerfile or buildpack", strategy)
+		}
+		ref, err := resolveService(args[1:])
+		if err != nil {
+			return err
+		}
+
+		if !isQuiet() && !isJSON() {
+			if cwd, err := os.Getwd(); err == nil {
+				for _, w := range strategyWarnings(strategy, scanBuildFiles(cwd)) {
+					fmt.Fprintln(os.Stderr, stWarning.Render("! "+w))
+				}
+			}
+		}
+
+		data, _ := json.Marshal(map[string]any{"build_strategy": strategy})
+		req, _ := http.NewRequest("PATCH", apiURL(ref.ServicePath()), bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+
+		var updated struct {
+			Slug          string `json:"slug"`
+			BuildStrategy string `json:"build_strategy"`
+		}
+		if err := decodeJSON(body, &updated); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(updated)
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone(fmt.Sprintf("%s now builds with %s — run `ancla deploy` to rebuild", ref.Service, strategy)))
+		}
+		return nil
+	},
+}
+
+var servicesResizeCmd = &cobra.Command{
+	Use:   "resize <ws>/<proj>/<env>/<svc> <process>=<size> ...",
+	Short: "Change process instance sizes",
+	Long: `Change the instance size of one or more process types. Processes not
+named keep their current size. Run "ancla services sizes" to list
+the available sizes and their prices.`,
+	Example: "  ancla services resize my-ws/my-proj/staging/my-svc web=standard-2x worker=small",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveServiceRef(args)
+		if err != nil {
+			return err
+		}
+		if !ref.HasService() {
+			return fmt.Errorf("usage: services resize <ws>/<proj>/<env>/<svc> <process>=<size> ...")
+		}
+
+		sizes := make(map[string]string)
+		for _, arg := range args[1:] {
+			parts := strings.SplitN(arg, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("invalid resize argument %q (expected process=size)", arg)
+			}
+			sizes[parts[0]] = parts[1]
+		}
+
+		stop := spin("Resizing...")
+		payload, _ := json.Marshal(map[string]any{"process_sizes": sizes})
+		req, _ := http.NewRequest("POST", apiURL(ref.ServicePath()+"/resize"), bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		_, err = doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Resized successfully. New sizes apply on the next deploy of each process.")
+		return nil
+	},
+}
+
+var servicesSizesCmd = &cobra.Command{
+	Use:     "sizes",
+	Short:   "List available instance sizes and prices",
+	Example: "  ancla services sizes",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, _ := http.NewRequest("GET", apiURL("/sizes/"), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+
+		var sizes []struct {
+			Name         string  `json:"name"`
+			CPU          float64 `json:"cpu"`
+			MemoryMB     int     `json:"memory_mb"`
+			PriceMonthly float64 `json:"price_monthly"`
+			Currency     string  `json:"currency"`
+		}
+		if err := decodeJSON(body, &sizes); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(sizes)
+		}
+
+		var rows [][]string
+		for _, sz := range sizes {
+			rows = append(rows, []string{
+				sz.Name,
+				strconv.FormatFloat(sz.CPU, 'f', -1, 64) + " vCPU",
+				fmt.Sprintf("%d MiB", sz.MemoryMB),
+				fmt.Sprintf("%.2f %s/mo", sz.PriceMonthly, sz.Currency),
+			})
+		}
+		table([]string{"SIZE", "CPU", "MEMORY", "PRICE"}, rows)
 		return nil
 	},
 }
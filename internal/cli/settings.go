@@ -2,8 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -14,7 +12,9 @@ import (
 func init() {
 	rootCmd.AddCommand(settingsCmd)
 	settingsCmd.AddCommand(settingsShowCmd)
+	settingsCmd.AddCommand(settingsGetCmd)
 	settingsCmd.AddCommand(settingsSetCmd)
+	settingsCmd.AddCommand(settingsUnsetCmd)
 	settingsCmd.AddCommand(settingsEditCmd)
 	settingsCmd.AddCommand(settingsPathCmd)
 }
@@ -23,7 +23,7 @@ var settingsCmd = &cobra.Command{
 	Use:     "settings",
 	Aliases: []string{"setting"},
 	Short:   "Manage CLI settings (~/.ancla/config.yaml)",
-	Example: "  ancla settings show\n  ancla settings set server https://ancla.dev",
+	Example: "  ancla settings show\n  ancla settings set server https://ancla.dev\n  ancla settings unset output",
 	GroupID: "config",
 }
 
@@ -37,42 +37,177 @@ func maskSecret(val string) string {
 }
 
 var settingsShowCmd = &cobra.Command{
-	Use:     "show",
-	Short:   "Show current CLI settings",
-	Example: "  ancla settings show",
+	Use:   "show",
+	Short: "Show effective CLI settings and where each comes from",
+	Long: `Show the effective CLI settings after merging flags, environment
+variables, the local .ancla/config.yaml, the global ~/.ancla/config.yaml,
+and built-in defaults. Each value is annotated with the layer it came from.
+The API key is masked.`,
+	Example: "  ancla settings show\n  ancla settings show --json",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if cfg.APIKey != "" {
-			fmt.Printf("api_key: %s\n", maskSecret(cfg.APIKey))
-		} else {
-			fmt.Printf("api_key: (not set)\n")
+		var flagged []string
+		if cmd.Flags().Changed("server") {
+			flagged = append(flagged, "server")
+		}
+		if cmd.Flags().Changed("api-key") {
+			flagged = append(flagged, "api_key")
+		}
+		origins := config.Origins(flagged...)
+		values := settingValues()
+
+		if isJSON() {
+			type setting struct {
+				Key    string `json:"key"`
+				Value  string `json:"value"`
+				Source string `json:"source"`
+				Origin string `json:"origin,omitempty"`
+			}
+			var out []setting
+			for _, k := range config.Keys {
+				o := origins[k]
+				out = append(out, setting{Key: k, Value: values[k], Source: string(o.Source), Origin: o.Detail})
+			}
+			return printJSON(out)
+		}
+
+		var rows [][]string
+		for _, k := range config.Keys {
+			o := origins[k]
+			val := values[k]
+			if val == "" {
+				val = stDim.Render("(not set)")
+			}
+			src := string(o.Source)
+			if o.Detail != "" {
+				src += stDim.Render(" (" + o.Detail + ")")
+			}
+			rows = append(rows, []string{k, val, src})
+		}
+		table([]string{"KEY", "VALUE", "SOURCE"}, rows)
+		return nil
+	},
+}
+
+// settingValues returns the effective value of every setting, with the
+// API key and GitHub token masked.
+func settingValues() map[string]string {
+	values := map[string]string{
+		"server":   cfg.Server,
+		"api_key":  cfg.APIKey,
+		"username": cfg.Username,
+		"email":    cfg.Email,
+		"output":   cfg.Output,
+		"color":    cfg.Color,
+		"time":     cfg.Time,
+
+		"poll_interval": cfg.PollInterval,
+		"max_wait":      cfg.MaxWait,
+		"github_token":  cfg.GitHubToken,
+
+		"default_workspace": cfg.DefaultWorkspace,
+		"default_project":   cfg.DefaultProject,
+		"default_env":       cfg.DefaultEnv,
+		"workspace":         cfg.Workspace,
+		"project":           cfg.Project,
+		"env":               cfg.Env,
+		"service":           cfg.Service,
+	}
+	for _, k := range []string{"api_key", "github_token"} {
+		if values[k] != "" {
+			values[k] = maskSecret(values[k])
+		}
+	}
+	return values
+}
+
+// settingKeyHelp lists the editable settings for command help text.
+func settingKeyHelp() string {
+	var b strings.Builder
+	for _, s := range config.Settings {
+		line := fmt.Sprintf("  %-18s %s", s.Key, s.Description)
+		if len(s.Allowed) > 0 {
+			line += " (" + strings.Join(s.Allowed, ", ") + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// displaySetting renders a value for output, masking secrets.
+func displaySetting(s config.Setting, value string) string {
+	if s.Secret && value != "" {
+		return maskSecret(value)
+	}
+	return value
+}
+
+var settingsGetCmd = &cobra.Command{
+	Use:     "get <key>",
+	Short:   "Print the value of a CLI setting",
+	Long:    "Print the effective value of a CLI setting.\n\nSettings:\n" + settingKeyHelp(),
+	Example: "  ancla settings get server\n  ancla settings get output",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := config.LookupSetting(args[0])
+		if err != nil {
+			return err
 		}
+		fmt.Println(displaySetting(s, cfg.Get(s.Key)))
 		return nil
 	},
 }
 
 var settingsSetCmd = &cobra.Command{
-	Use:     "set <key> <value>",
-	Short:   "Set a CLI setting (api_key)",
-	Example: "  ancla settings set server https://ancla.dev\n  ancla settings set api_key mykey123",
-	Args:    cobra.ExactArgs(2),
+	Use:   "set <key> <value> | <key>=<value>",
+	Short: "Set a CLI setting in ~/.ancla/config.yaml",
+	Long:  "Set a CLI setting in the global config file. Values are validated\nbefore anything is written.\n\nSettings:\n" + settingKeyHelp(),
+	Example: `  ancla settings set server https://ancla.dev
+  ancla settings set output=json
+  ancla settings set color never`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		key, value := args[0], args[1]
-		switch key {
-		case "server":
-			cfg.Server = value
-		case "api_key":
-			cfg.APIKey = value
-		default:
-			return fmt.Errorf("unknown setting %q (valid: server, api_key)", key)
+		key, value := args[0], ""
+		if len(args) == 2 {
+			value = args[1]
+		} else if k, v, ok := strings.Cut(args[0], "="); ok {
+			key, value = k, v
+		} else {
+			return fmt.Errorf("missing value — use `ancla settings set %s <value>`", key)
+		}
+
+		s, err := config.LookupSetting(key)
+		if err != nil {
+			return err
 		}
-		if err := config.Save(cfg); err != nil {
+		value, err = s.Normalize(value)
+		if err != nil {
+			return err
+		}
+		cfg.Set(s.Key, value)
+		if err := config.Save(cfg, s.Key); err != nil {
 			return fmt.Errorf("saving config: %w", err)
 		}
-		displayValue := value
-		if key == "api_key" {
-			displayValue = maskSecret(value)
+		fmt.Printf("Set %s = %s\n", s.Key, displaySetting(s, value))
+		return nil
+	},
+}
+
+var settingsUnsetCmd = &cobra.Command{
+	Use:     "unset <key>",
+	Short:   "Remove a CLI setting, restoring its default",
+	Long:    "Remove a CLI setting from the global config file.\n\nSettings:\n" + settingKeyHelp(),
+	Example: "  ancla settings unset output\n  ancla settings unset server",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := config.LookupSetting(args[0])
+		if err != nil {
+			return err
+		}
+		cfg.Set(s.Key, "")
+		if err := config.Save(cfg, s.Key); err != nil {
+			return fmt.Errorf("saving config: %w", err)
 		}
-		fmt.Printf("Set %s = %s\n", key, displayValue)
+		fmt.Printf("Unset %s\n", s.Key)
 		return nil
 	},
 }
@@ -82,16 +217,7 @@ var settingsEditCmd = &cobra.Command{
 	Short:   "Open config in $EDITOR",
 	Example: "  ancla settings edit",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		path := config.FilePath()
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = "vi"
-		}
-		c := exec.Command(editor, path)
-		c.Stdin = os.Stdin
-		c.Stdout = os.Stdout
-		c.Stderr = os.Stderr
-		return c.Run()
+		return openInEditor(config.FilePath())
 	},
 }
 
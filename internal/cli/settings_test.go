@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestSettingsSetUnset_OnlyTouchesThatKey(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	path := filepath.Join(tmpHome, ".ancla", "config.yaml")
+	os.MkdirAll(filepath.Dir(path), 0o700)
+	os.WriteFile(path, []byte("api_key: real-key\n"), 0o600)
+
+	// Merged config as seen with ANCLA_API_KEY and --server set.
+	cfg = &config.Config{Server: "http://localhost:9", APIKey: "ci-temp-key"}
+
+	if err := settingsSetCmd.RunE(settingsSetCmd, []string{"color", "never"}); err != nil {
+		t.Fatalf("settings set: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if got := string(data); got != "api_key: real-key\ncolor: never\n" {
+		t.Errorf("after set, config =\n%s", got)
+	}
+
+	if err := settingsUnsetCmd.RunE(settingsUnsetCmd, []string{"color"}); err != nil {
+		t.Fatalf("settings unset: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if got := string(data); strings.Contains(got, "color") || strings.Contains(got, "server") || !strings.Contains(got, "api_key: real-key") {
+		t.Errorf("after unset, config =\n%s", got)
+	}
+}
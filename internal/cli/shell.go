@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -37,11 +36,11 @@ this command uses the platform exec API directly and does not require SSH keys.`
 		if len(args) == 1 {
 			arg = args[0]
 		}
-		ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+		ref, err := config.ResolveServiceRef(arg, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" || proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no service specified — provide an argument or run `ancla link` first")
 		}
 
@@ -49,7 +48,7 @@ this command uses the platform exec API directly and does not require SSH keys.`
 		command, _ := cmd.Flags().GetString("command")
 
 		// Request an exec session from the API
-		svcPath := "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc
+		svcPath := ref.ServicePath()
 		payload := fmt.Sprintf(`{"process":"%s","command":"%s"}`, process, command)
 		req, _ := http.NewRequest("POST", apiURL(svcPath+"/exec"), strings.NewReader(payload))
 		req.Header.Set("Content-Type", "application/json")
@@ -67,7 +66,7 @@ this command uses the platform exec API directly and does not require SSH keys.`
 			Port         int    `json:"port"`
 			Token        string `json:"token"`
 		}
-		if err := json.Unmarshal(body, &session); err != nil {
+		if err := decodeJSON(body, &session); err != nil {
 			return fmt.Errorf("parsing exec response: %w", err)
 		}
 
@@ -25,13 +25,15 @@ func isTTY() bool {
 	return fi.Mode()&os.ModeCharDevice != 0
 }
 
-// spin starts a spinner if stdout is a TTY and JSON output is not requested.
-// Returns a stop function that should be deferred.
+// spin starts a spinner if stdout is a TTY and neither JSON output nor
+// --progress status lines are requested. Returns a stop function that
+// should be deferred. An interrupt while it spins still clears the line and
+// shows the cursor again.
 func spin(msg string) func() {
-	if !isTTY() || isJSON() {
+	if !isTTY() || isJSON() || dryRun || progressLines() {
 		return func() {}
 	}
 	s := newSpinner(msg)
 	s.Start()
-	return func() { s.Stop() }
+	return onTerminalRestore(s.Stop)
 }
@@ -41,25 +41,20 @@ and launches an SSH session to the container running the specified process type.
 		if len(args) == 1 {
 			arg = args[0]
 		}
-		ws, proj, env, svc, err := config.ResolveServicePath(arg, cfg)
+		ref, err := config.ResolveServiceRef(arg, cfg)
 		if err != nil {
 			return err
 		}
-		if ws == "" || proj == "" || env == "" || svc == "" {
+		if !ref.HasService() {
 			return fmt.Errorf("no service specified — provide a service path or link a project first with `ancla link`")
 		}
 
-		// Validate the path has four segments.
-		displayPath := ws + "/" + proj + "/" + env + "/" + svc
-		parts := strings.Split(displayPath, "/")
-		if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
-			return fmt.Errorf("invalid service path %q — expected ws/proj/env/svc", displayPath)
-		}
+		displayPath := ref.String()
 
 		processType, _ := cmd.Flags().GetString("process")
 
 		// Request exec credentials from the API.
-		svcPath := "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc
+		svcPath := ref.ServicePath()
 		payload, _ := json.Marshal(map[string]string{
 			"process": processType,
 		})
@@ -82,7 +77,7 @@ and launches an SSH session to the container running the specified process type.
 			Port  int    `json:"port"`
 			Token string `json:"token"`
 		}
-		if err := json.Unmarshal(body, &connInfo); err != nil {
+		if err := decodeJSON(body, &connInfo); err != nil {
 			return fmt.Errorf("parsing exec response: %w", err)
 		}
 
@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
 )
 
 func init() {
@@ -17,33 +19,37 @@ var statusCmd = &cobra.Command{
 	Short: "Show status of the linked workspace/project/env/service",
 	Long: `Show a unified status view for the currently linked resource.
 
-Requires a linked directory (see ancla link). Displays the workspace, project,
-environment, service details, and current pipeline status in a single view.`,
+Requires a linked directory (see ancla link) or a default_workspace setting. Displays the workspace, project,
+environment, service details, and current pipeline status in a single view.
+For a service, containers that crashed in the last 24 hours (OOM kills,
+non-zero exits, restart loops) are listed with their last exit reason.`,
 	Example: "  ancla status",
 	GroupID: "workflow",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if !cfg.IsLinked() {
+		ref, _ := config.ResolveServiceRef("", cfg)
+		if ref.Workspace == "" {
 			return fmt.Errorf("not linked — run `ancla link <ws>/<proj>/<env>/<svc>` first")
 		}
 
 		type statusOutput struct {
-			Workspace string `json:"workspace"`
-			Project   string `json:"project,omitempty"`
-			Env       string `json:"env,omitempty"`
-			Service   string `json:"service,omitempty"`
-			Build     string `json:"build,omitempty"`
-			Deploy    string `json:"deploy,omitempty"`
+			Workspace string       `json:"workspace"`
+			Project   string       `json:"project,omitempty"`
+			Env       string       `json:"env,omitempty"`
+			Service   string       `json:"service,omitempty"`
+			Build     string       `json:"build,omitempty"`
+			Deploy    string       `json:"deploy,omitempty"`
+			Crashes   []crashEvent `json:"crashes,omitempty"`
 		}
 		out := statusOutput{
-			Workspace: cfg.Workspace,
-			Project:   cfg.Project,
-			Env:       cfg.Env,
-			Service:   cfg.Service,
+			Workspace: ref.Workspace,
+			Project:   ref.Project,
+			Env:       ref.Env,
+			Service:   ref.Service,
 		}
 
 		// If we have a full service path, fetch pipeline status
-		if cfg.Workspace != "" && cfg.Project != "" && cfg.Env != "" && cfg.Service != "" {
-			req, _ := http.NewRequest("GET", apiURL(pipelineStatusPath(cfg.Workspace, cfg.Project, cfg.Env, cfg.Service)), nil)
+		if ref.HasService() {
+			req, _ := http.NewRequest("GET", apiURL(pipelineStatusPath(ref.Workspace, ref.Project, ref.Env, ref.Service)), nil)
 			body, err := doRequest(req)
 			if err == nil {
 				var status struct {
@@ -58,13 +64,14 @@ environment, service details, and current pipeline status in a single view.`,
 					out.Deploy = status.Deploy.Status
 				}
 			}
+			out.Crashes, _ = fetchCrashes(ref.Workspace, ref.Project, ref.Env, ref.Service)
 		}
 
 		if isJSON() {
 			return printJSON(out)
 		}
 
-		fmt.Println(stHeading.Render(symAnchor + " Status"))
+		fmt.Println(heading("Status"))
 		fmt.Println()
 		fmt.Println(kv("Workspace", out.Workspace))
 		if out.Project != "" {
@@ -86,6 +93,10 @@ environment, service details, and current pipeline status in a single view.`,
 				fmt.Println(kv("Deploy", colorStatus(out.Deploy)))
 			}
 		}
+		if len(out.Crashes) > 0 {
+			fmt.Println()
+			printCrashes(out.Crashes)
+		}
 
 		return nil
 	},
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// ─── Did-you-mean ──────────────────────────────────────────────
+// When a nested path 404s we walk it from the workspace down, listing
+// the siblings at each level, to find the first segment that does not
+// exist and the closest slug the user might have meant.
+
+// lookupTimeout bounds the whole walk so a slow server can't hold up
+// the error message.
+const lookupTimeout = 3 * time.Second
+
+// missingSegment describes the first path segment that did not resolve.
+type missingSegment struct {
+	Kind       string // "workspace", "project", "environment", "service"
+	Slug       string
+	Suggestion string            // closest existing sibling, empty if none is close
+	Ref        config.ServiceRef // path truncated at the missing segment
+}
+
+// message returns the one-line explanation, e.g.
+// "service 'web-api' not found — did you mean 'webapi'?".
+func (m *missingSegment) message() string {
+	msg := tr("%s '%s' not found", tr(m.Kind), m.Slug)
+	if m.Suggestion != "" {
+		msg += tr(" — did you mean '%s'?", m.Suggestion)
+	}
+	return msg
+}
+
+// locateMissing finds which segment of r does not exist. It returns nil
+// if every segment resolves, a listing request fails, or ctx expires.
+func locateMissing(ctx context.Context, r config.ServiceRef) *missingSegment {
+	levels := []struct {
+		kind, slug, listPath string
+		ref                  config.ServiceRef
+	}{
+		{"workspace", r.Workspace, "/workspaces/", config.ServiceRef{Workspace: r.Workspace}},
+		{"project", r.Project, "/workspaces/" + r.Workspace + "/projects/", config.ServiceRef{Workspace: r.Workspace, Project: r.Project}},
+		{"environment", r.Env, r.ProjectPath() + "/envs/", config.ServiceRef{Workspace: r.Workspace, Project: r.Project, Env: r.Env}},
+		{"service", r.Service, r.ServicesPath(), r},
+	}
+	for _, l := range levels {
+		if l.slug == "" {
+			return nil
+		}
+		slugs, err := listSlugs(ctx, l.listPath)
+		if err != nil {
+			return nil
+		}
+		if contains(slugs, l.slug) {
+			continue
+		}
+		return &missingSegment{
+			Kind:       l.kind,
+			Slug:       l.slug,
+			Suggestion: closestMatch(l.slug, slugs),
+			Ref:        l.ref,
+		}
+	}
+	return nil
+}
+
+// listSlugs fetches a list endpoint and returns the slug of each item.
+func listSlugs(ctx context.Context, path string) ([]string, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", apiURL(path), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var items []struct {
+		Slug string `json:"slug"`
+	}
+	if err := decodeJSON(body, &items); err != nil {
+		return nil, err
+	}
+	slugs := make([]string, 0, len(items))
+	for _, it := range items {
+		slugs = append(slugs, it.Slug)
+	}
+	return slugs, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// closestMatch returns the candidate nearest to target by edit distance,
+// or "" when nothing is close enough to be a plausible typo.
+func closestMatch(target string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := editDistance(target, c)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	// Allow roughly one edit per three characters, but at least two.
+	limit := len(target) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
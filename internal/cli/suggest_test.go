@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"web", "", 3},
+		{"web-api", "webapi", 1},
+		{"kitten", "sitting", 3},
+		{"staging", "staging", 0},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	candidates := []string{"webapi", "worker", "scheduler"}
+	if got := closestMatch("web-api", candidates); got != "webapi" {
+		t.Errorf("closestMatch(web-api) = %q, want webapi", got)
+	}
+	if got := closestMatch("database", candidates); got != "" {
+		t.Errorf("closestMatch(database) = %q, want no suggestion", got)
+	}
+	if got := closestMatch("web", nil); got != "" {
+		t.Errorf("closestMatch with no candidates = %q", got)
+	}
+}
+
+func TestLocateMissing(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/workspaces/":
+			w.Write([]byte(`[{"slug":"ws"}]`))
+		case "/api/v1/workspaces/ws/projects/":
+			w.Write([]byte(`[{"slug":"proj"}]`))
+		case "/api/v1/workspaces/ws/projects/proj/envs/":
+			w.Write([]byte(`[{"slug":"staging"},{"slug":"production"}]`))
+		case "/api/v1/workspaces/ws/projects/proj/envs/staging/services/":
+			w.Write([]byte(`[{"slug":"webapi"},{"slug":"worker"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	t.Run("mistyped service", func(t *testing.T) {
+		m := locateMissing(context.Background(), config.ServiceRef{Workspace: "ws", Project: "proj", Env: "staging", Service: "web-api"})
+		if m == nil {
+			t.Fatal("expected missing segment")
+		}
+		want := "service 'web-api' not found — did you mean 'webapi'?"
+		if got := m.message(); got != want {
+			t.Errorf("message = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("mistyped env stops before service", func(t *testing.T) {
+		m := locateMissing(context.Background(), config.ServiceRef{Workspace: "ws", Project: "proj", Env: "stagin", Service: "webapi"})
+		if m == nil || m.Kind != "environment" || m.Suggestion != "staging" {
+			t.Fatalf("got %+v", m)
+		}
+		if m.Ref.Service != "" {
+			t.Errorf("Ref should stop at the environment, got %+v", m.Ref)
+		}
+	})
+
+	t.Run("all segments exist", func(t *testing.T) {
+		if m := locateMissing(context.Background(), config.ServiceRef{Workspace: "ws", Project: "proj"}); m != nil {
+			t.Errorf("expected nil, got %+v", m)
+		}
+	})
+}
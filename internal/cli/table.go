@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// wideFlag turns off truncation so every cell is printed in full.
+var wideFlag bool
+
+// ellipsis is where a truncated cell loses characters.
+type ellipsis int
+
+const (
+	ellipsisEnd    ellipsis = iota // "a-long-na…"
+	ellipsisMiddle                 // "a-lo…name"
+	ellipsisStart                  // "…long-name"
+	ellipsisNone                   // "a-long-na", for IDs shown as short prefixes
+)
+
+// tableColumn is a column header and how its cells are cut down to fit.
+type tableColumn struct {
+	Header   string
+	Max      int // widest the column may be, unless --wide; 0 for no limit
+	Ellipsis ellipsis
+}
+
+// shortIDColumn shows IDs cut to a short prefix, like git's short hashes.
+// --wide shows them in full.
+var shortIDColumn = tableColumn{Header: "ID", Max: 8, Ellipsis: ellipsisNone}
+
+// tableGap is the space between columns.
+const tableGap = 2
+
+// tableMinWidth is the narrowest a column is shrunk to when fitting the
+// terminal, so every column keeps a readable stub.
+const tableMinWidth = 6
+
+// spaces is sliced for padding instead of building a string per cell.
+var spaces = strings.Repeat(" ", 256)
+
+// table writes rows under headers, with every column free to shrink from
+// the end when the table is wider than the terminal.
+func table(headers []string, rows [][]string) {
+	cols := make([]tableColumn, len(headers))
+	for i, h := range headers {
+		cols[i] = tableColumn{Header: h}
+	}
+	tableColumns(cols, rows)
+}
+
+// tableColumns writes rows with ANSI-aware column alignment. Column widths
+// are computed from visible string lengths, capped at each column's Max,
+// and then shrunk, widest column first, until the table fits the
+// terminal. --wide turns both off; output that isn't a terminal is never
+// shrunk.
+func tableColumns(cols []tableColumn, rows [][]string) {
+	w := bufio.NewWriter(os.Stdout)
+	writeTable(w, cols, rows, tableWidth())
+	w.Flush()
+}
+
+// tableWidth is the width tables are fitted to: $COLUMNS, or the width of
+// the terminal on stdout. It is 0, for no limit, with --wide or when
+// stdout is piped, so scripts always see whole values.
+func tableWidth() int {
+	if wideFlag {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	if width, _, err := term.GetSize(fd); err == nil {
+		return width
+	}
+	return 0
+}
+
+// writeTable renders the table to w, fitted to limit columns (0 for no
+// limit).
+func writeTable(w io.Writer, cols []tableColumn, rows [][]string, limit int) {
+	widths := columnWidths(cols, rows, limit)
+
+	var hdr strings.Builder
+	for i, c := range cols {
+		writeCell(&hdr, c.Header, widths[i], ellipsisEnd, i == len(cols)-1)
+	}
+	io.WriteString(w, stTableHeader.Render(hdr.String()))
+	io.WriteString(w, "\n")
+
+	for _, row := range rows {
+		for i, c := range cols {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			writeCell(w, cell, widths[i], c.Ellipsis, i == len(cols)-1)
+		}
+		io.WriteString(w, "\n")
+	}
+}
+
+// columnWidths sizes each column to its widest cell, capped at Max, then
+// takes one column off the widest column at a time until the row fits in
+// limit.
+func columnWidths(cols []tableColumn, rows [][]string, limit int) []int {
+	widths := make([]int, len(cols))
+	for i, c := range cols {
+		widths[i] = visLen(c.Header)
+	}
+	for _, row := range rows {
+		for i := 0; i < len(cols) && i < len(row); i++ {
+			if n := visLen(row[i]); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	if wideFlag {
+		return widths
+	}
+	for i, c := range cols {
+		if c.Max > 0 && widths[i] > c.Max {
+			widths[i] = max(c.Max, visLen(c.Header))
+		}
+	}
+	if limit <= 0 {
+		return widths
+	}
+
+	total := tableGap * (len(cols) - 1)
+	for _, n := range widths {
+		total += n
+	}
+	for total > limit {
+		widest := -1
+		for i, n := range widths {
+			if n > tableMinWidth && (widest < 0 || n > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break // as narrow as columns go; let the terminal wrap
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// writeCell writes cell truncated or padded to width, followed by the gap
+// unless it is the last column.
+func writeCell(w io.Writer, cell string, width int, e ellipsis, last bool) {
+	n := visLen(cell)
+	if n > width {
+		cell = truncateCell(cell, width, e)
+		n = runewidth.StringWidth(cell)
+	}
+	io.WriteString(w, cell)
+	if last {
+		return
+	}
+	for pad := width - n + tableGap; pad > 0; pad -= len(spaces) {
+		io.WriteString(w, spaces[:min(pad, len(spaces))])
+	}
+}
+
+// truncateCell cuts s to width display columns, marking the cut with "…"
+// where e says. Colors are dropped from a cell that has to be cut.
+func truncateCell(s string, width int, e ellipsis) string {
+	s = ansiRe.ReplaceAllString(s, "")
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	if width <= 1 || e == ellipsisNone {
+		return runewidth.Truncate(s, max(width, 0), "")
+	}
+	switch e {
+	case ellipsisStart:
+		return "…" + tailWidth(s, width-1)
+	case ellipsisMiddle:
+		tail := (width - 1) / 2
+		return runewidth.Truncate(s, width-1-tail, "") + "…" + tailWidth(s, tail)
+	default:
+		return runewidth.Truncate(s, width, "…")
+	}
+}
+
+// tailWidth returns the longest end of s at most width columns wide.
+func tailWidth(s string, width int) string {
+	i := len(s)
+	for used := 0; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if used+runewidth.RuneWidth(r) > width {
+			break
+		}
+		used += runewidth.RuneWidth(r)
+		i -= size
+	}
+	return s[i:]
+}
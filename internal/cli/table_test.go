@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateCell(t *testing.T) {
+	tests := []struct {
+		in   string
+		e    ellipsis
+		want string
+	}{
+		{"web-platform-api", ellipsisEnd, "web-plat…"},
+		{"web-platform-api", ellipsisMiddle, "web-…-api"},
+		{"web-platform-api", ellipsisStart, "…form-api"},
+		{"web-platform-api", ellipsisNone, "web-platf"},
+		{"short", ellipsisEnd, "short"},
+		{"\x1b[31mweb-platform-api\x1b[0m", ellipsisEnd, "web-plat…"},
+		{"日本語のサービス", ellipsisEnd, "日本語の…"},
+	}
+	for _, tt := range tests {
+		if got := truncateCell(tt.in, 9, tt.e); got != tt.want {
+			t.Errorf("truncateCell(%q, 9, %d) = %q, want %q", tt.in, tt.e, got, tt.want)
+		}
+	}
+}
+
+func TestWriteTable_FitsWidth(t *testing.T) {
+	cols := []tableColumn{shortIDColumn, {Header: "NAME"}, {Header: "URL", Ellipsis: ellipsisMiddle}}
+	rows := [][]string{
+		{"a1b2c3d4e5f6", "api", "https://api.web-platform.example.com/health"},
+		{"f6e5d4c3b2a1", "worker", "-"},
+	}
+
+	var b strings.Builder
+	writeTable(&b, cols, rows, 40)
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		if n := visLen(line); n > 40 {
+			t.Errorf("line is %d columns wide, want at most 40: %q", n, line)
+		}
+	}
+	if !strings.Contains(b.String(), "a1b2c3d4  api") || strings.Contains(b.String(), "a1b2c3d4e") {
+		t.Errorf("IDs not cut to 8 characters:\n%s", b.String())
+	}
+	if !strings.Contains(b.String(), "…") || !strings.HasSuffix(strings.Split(b.String(), "\n")[1], "/health") {
+		t.Errorf("URL not truncated in the middle:\n%s", b.String())
+	}
+
+	// Without a limit, only Max applies.
+	b.Reset()
+	writeTable(&b, cols, rows, 0)
+	if !strings.Contains(b.String(), "https://api.web-platform.example.com/health") {
+		t.Errorf("unlimited table truncated a cell:\n%s", b.String())
+	}
+
+	origWide := wideFlag
+	defer func() { wideFlag = origWide }()
+	wideFlag = true
+	b.Reset()
+	writeTable(&b, cols, rows, 40)
+	if !strings.Contains(b.String(), "a1b2c3d4e5f6") || !strings.Contains(b.String(), "/health") {
+		t.Errorf("--wide truncated a cell:\n%s", b.String())
+	}
+}
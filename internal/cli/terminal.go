@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// terminal tracks the changes the CLI has made to the terminal — a hidden
+// cursor, a half-drawn progress line, raw mode — so they are undone however
+// the process ends. While any change is outstanding, Ctrl-C and SIGTERM
+// restore the terminal before exiting; otherwise commands keep their own
+// signal handling (tunnel and logs tail shut down gracefully).
+var terminal struct {
+	mu       sync.Mutex
+	restores []*terminalRestore
+	signals  chan os.Signal
+}
+
+type terminalRestore struct {
+	fn   func()
+	done bool
+}
+
+// exitOnSignal ends the process after a signal has restored the terminal.
+// Tests replace it.
+var exitOnSignal = os.Exit
+
+// onTerminalRestore registers fn to undo a terminal change and returns a
+// release function that runs fn and unregisters it. Call release when the
+// change is undone normally; it is safe to call more than once.
+func onTerminalRestore(fn func()) (release func()) {
+	r := &terminalRestore{fn: fn}
+	terminal.mu.Lock()
+	terminal.restores = append(terminal.restores, r)
+	if terminal.signals == nil {
+		terminal.signals = make(chan os.Signal, 1)
+		signal.Notify(terminal.signals, os.Interrupt, syscall.SIGTERM)
+		go watchTerminalSignals(terminal.signals)
+	}
+	terminal.mu.Unlock()
+
+	return func() {
+		terminal.mu.Lock()
+		defer terminal.mu.Unlock()
+		if r.done {
+			return
+		}
+		r.done = true
+		r.fn()
+		for i, other := range terminal.restores {
+			if other == r {
+				terminal.restores = append(terminal.restores[:i], terminal.restores[i+1:]...)
+				break
+			}
+		}
+		if len(terminal.restores) == 0 {
+			stopTerminalSignals()
+		}
+	}
+}
+
+// restoreTerminal undoes every outstanding terminal change, newest first.
+func restoreTerminal() {
+	terminal.mu.Lock()
+	defer terminal.mu.Unlock()
+	for i := len(terminal.restores) - 1; i >= 0; i-- {
+		if r := terminal.restores[i]; !r.done {
+			r.done = true
+			r.fn()
+		}
+	}
+	terminal.restores = nil
+	stopTerminalSignals()
+}
+
+// stopTerminalSignals hands signals back to the default handling. The
+// caller holds terminal.mu.
+func stopTerminalSignals() {
+	if terminal.signals != nil {
+		signal.Stop(terminal.signals)
+		close(terminal.signals)
+		terminal.signals = nil
+	}
+}
+
+// watchTerminalSignals restores the terminal on the first signal and exits
+// with the shell's 128+n convention, as the default handler would have.
+func watchTerminalSignals(ch <-chan os.Signal) {
+	sig, ok := <-ch
+	if !ok {
+		return
+	}
+	restoreTerminal()
+	code := 1
+	if s, ok := sig.(syscall.Signal); ok {
+		code = 128 + int(s)
+	}
+	exitOnSignal(code)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestOnTerminalRestore_Release(t *testing.T) {
+	calls := 0
+	release := onTerminalRestore(func() { calls++ })
+	if terminal.signals == nil {
+		t.Fatal("signals are not watched while a change is outstanding")
+	}
+	release()
+	release()
+	if calls != 1 {
+		t.Errorf("restore ran %d times, want 1", calls)
+	}
+	if terminal.signals != nil || len(terminal.restores) != 0 {
+		t.Error("signal handling not handed back after the last release")
+	}
+}
+
+func TestRestoreTerminal_NewestFirst(t *testing.T) {
+	var order []string
+	releaseCursor := onTerminalRestore(func() { order = append(order, "cursor") })
+	onTerminalRestore(func() { order = append(order, "raw") })
+
+	restoreTerminal()
+	releaseCursor()
+	if want := []string{"raw", "cursor"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("restore order = %v, want %v", order, want)
+	}
+	if terminal.signals != nil {
+		t.Error("signals still watched after restore")
+	}
+}
+
+func TestTerminalSignal_RestoresAndExits(t *testing.T) {
+	origExit := exitOnSignal
+	defer func() { exitOnSignal = origExit }()
+	codes := make(chan int, 1)
+	exitOnSignal = func(code int) { codes <- code }
+
+	restored := false
+	onTerminalRestore(func() { restored = true })
+	terminal.mu.Lock()
+	ch := terminal.signals
+	terminal.mu.Unlock()
+	ch <- os.Interrupt
+
+	select {
+	case code := <-codes:
+		if want := 128 + int(syscall.SIGINT); code != want {
+			t.Errorf("exit code = %d, want %d", code, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no exit after the signal")
+	}
+	if !restored {
+		t.Error("terminal not restored before exiting")
+	}
+}
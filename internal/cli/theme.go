@@ -23,7 +23,8 @@ var (
 )
 
 // ─── Symbols ────────────────────────────────────────────────────
-const (
+// Accessible mode swaps these for plain text; see applyAccessibleMode.
+var (
 	symAnchor  = "⚓"
 	symCheck   = "✓"
 	symCross   = "✗"
@@ -31,6 +32,7 @@ const (
 	symArrow   = "→"
 	symCircle  = "○"
 	symPointer = "▸"
+	symBar     = "▌" // error card stripe
 )
 
 // ─── Styles ─────────────────────────────────────────────────────
@@ -48,6 +50,7 @@ var (
 	stValue       = lipgloss.NewStyle().Bold(true)
 	stTableHeader = lipgloss.NewStyle().Bold(true).Foreground(brandDim)
 	stCmdName     = lipgloss.NewStyle().Foreground(brandAccent).Width(14)
+	stMatch       = lipgloss.NewStyle().Bold(true).Foreground(brandWarning)
 )
 
 // ─── Output Helpers ─────────────────────────────────────────────
@@ -66,6 +69,14 @@ func statusDot(status string) string {
 	}
 }
 
+// heading renders a section title behind the anchor: ⚓ Status
+func heading(title string) string {
+	if symAnchor == "" {
+		return stHeading.Render(title)
+	}
+	return stHeading.Render(symAnchor + " " + title)
+}
+
 // stepDone renders a completed step: ✓ message
 func stepDone(msg string) string {
 	return "  " + stSuccess.Render(symCheck) + " " + msg
@@ -107,9 +118,10 @@ func anclaTheme() *huh.Theme {
 	return t
 }
 
-// themed wraps huh fields in a form with the Ancla theme applied.
+// themed wraps huh fields in a form with the Ancla theme applied. In
+// accessible mode the form asks plain numbered questions instead.
 func themed(fields ...huh.Field) *huh.Form {
-	return huh.NewForm(huh.NewGroup(fields...)).WithTheme(anclaTheme())
+	return huh.NewForm(huh.NewGroup(fields...)).WithTheme(anclaTheme()).WithAccessible(accessible())
 }
 
 // ─── Deploy Card ────────────────────────────────────────────────
@@ -127,8 +139,11 @@ func themed(fields ...huh.Field) *huh.Form {
 //	  Environment   production
 //	  Service       web
 //	  Strategy      buildpack
-
-func renderDeployCard(ws, proj, env, svc, strategy string) {
+//
+//	  ! Dockerfile:1: base image python is not pinned — ...
+//
+// warnings, if any, are listed under the grid.
+func renderDeployCard(ws, proj, env, svc, strategy string, warnings []string) {
 	sep := stMuted.Render(" / ")
 	route := stAccent.Render(ws) + sep + stAccent.Render(proj) + sep + stAccent.Render(env) + sep + stBold.Foreground(brandAccent).Render(svc)
 
@@ -147,7 +162,7 @@ func renderDeployCard(ws, proj, env, svc, strategy string) {
 	}
 
 	fmt.Println()
-	fmt.Println(stHeading.Render(symAnchor + " Deploy"))
+	fmt.Println(heading("Deploy"))
 	fmt.Println()
 	fmt.Println("  " + route)
 	fmt.Println("  " + rule)
@@ -159,5 +174,11 @@ func renderDeployCard(ws, proj, env, svc, strategy string) {
 	if strategy != "" {
 		fmt.Println(row("Strategy", strategy))
 	}
+	if len(warnings) > 0 {
+		fmt.Println()
+		for _, w := range warnings {
+			fmt.Println("  " + stWarning.Render("! "+w))
+		}
+	}
 	fmt.Println()
 }
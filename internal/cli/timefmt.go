@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+)
+
+// absoluteTimeFlag shows timestamps as RFC 3339 instead of relative times.
+var absoluteTimeFlag bool
+
+// absoluteTime reports whether timestamps are shown as RFC 3339, from
+// --absolute-time or the time setting.
+func absoluteTime() bool {
+	return absoluteTimeFlag || (cfg != nil && cfg.Time == "absolute")
+}
+
+// formatTime renders an API timestamp for display: "3m ago" by default,
+// or RFC 3339 with --absolute-time. Empty values and values that don't
+// parse are returned as they are.
+func formatTime(s string) string {
+	return formatTimeAt(s, time.Now(), absoluteTime())
+}
+
+func formatTimeAt(s string, now time.Time, absolute bool) string {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return s
+	}
+	if absolute {
+		return t.Format(time.RFC3339)
+	}
+	return relativeTime(t, now)
+}
+
+// relativeTime describes t relative to now in the largest whole unit:
+// "just now", "3m ago", "5h ago", "2d ago", "4mo ago", "1y ago", or
+// "in 3m" for times in the future.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	var n int
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int(d.Minutes()), "m"
+	case d < 24*time.Hour:
+		n, unit = int(d.Hours()), "h"
+	case d < 30*24*time.Hour:
+		n, unit = int(d.Hours()/24), "d"
+	case d < 365*24*time.Hour:
+		n, unit = int(d.Hours()/(24*30)), "mo"
+	default:
+		n, unit = int(d.Hours()/(24*365)), "y"
+	}
+	if future {
+		return fmt.Sprintf("in %d%s", n, unit)
+	}
+	return fmt.Sprintf("%d%s ago", n, unit)
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimeAt(t *testing.T) {
+	now := mustTime("2026-10-16T12:00:00Z")
+	tests := []struct {
+		in       string
+		absolute bool
+		want     string
+	}{
+		{"2026-10-16T11:59:30Z", false, "just now"},
+		{"2026-10-16T11:57:00Z", false, "3m ago"},
+		{"2026-10-16T06:59:59.5Z", false, "5h ago"},
+		{"2026-10-14T12:00:00+02:00", false, "2d ago"},
+		{"2026-06-01T00:00:00Z", false, "4mo ago"},
+		{"2024-10-16T12:00:00Z", false, "2y ago"},
+		{"2026-10-16T12:03:00Z", false, "in 3m"},
+		{"2026-10-16T07:00:00.123456Z", true, "2026-10-16T07:00:00Z"},
+		{"2026-10-14T12:00:00+02:00", true, "2026-10-14T12:00:00+02:00"},
+		{"", false, ""},
+		{"yesterday", false, "yesterday"},
+	}
+	for _, tt := range tests {
+		if got := formatTimeAt(tt.in, now, tt.absolute); got != tt.want {
+			t.Errorf("formatTimeAt(%q, absolute=%v) = %q, want %q", tt.in, tt.absolute, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeTime_Boundaries(t *testing.T) {
+	now := mustTime("2026-10-16T12:00:00Z")
+	if got := relativeTime(now.Add(-59*time.Second), now); got != "just now" {
+		t.Errorf("59s = %q", got)
+	}
+	if got := relativeTime(now.Add(-time.Hour), now); got != "1h ago" {
+		t.Errorf("1h = %q", got)
+	}
+}
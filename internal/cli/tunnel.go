@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tunnelCmd.Flags().String("bind", "127.0.0.1", "Local address to listen on")
+	rootCmd.AddCommand(tunnelCmd)
+}
+
+var tunnelCmd = &cobra.Command{
+	Use:         "tunnel <local-port>:<target> [ws/proj/env/svc]",
+	Short:       "Forward a local port to a data service or private port",
+	Annotations: map[string]string{featureAnnotation: "tunnel"},
+	Long: `Forward a local port to an attached data service or to the service's
+private network, so local tools can reach them without exposing anything
+publicly.
+
+The target is "db" or "cache" for the attached data services, a port
+number on the service itself, or host:port on the private network. Each
+local connection gets its own authenticated WebSocket stream. The tunnel
+listens on 127.0.0.1 unless --bind is given, and runs until Ctrl+C.`,
+	Example: `  ancla tunnel 5432:db
+  psql "postgres://user@localhost:5432/app"
+  ancla tunnel 6379:cache my-ws/my-proj/production/api
+  ancla tunnel 8080:8000
+  ancla tunnel 9200:search.internal:9200`,
+	GroupID: "workflow",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		localPort, target, err := parseTunnelSpec(args[0])
+		if err != nil {
+			return err
+		}
+		ref, err := resolveService(args[1:])
+		if err != nil {
+			return err
+		}
+
+		bind, _ := cmd.Flags().GetString("bind")
+		ln, err := net.Listen("tcp", net.JoinHostPort(bind, strconv.Itoa(localPort)))
+		if err != nil {
+			return fmt.Errorf("listening on %s:%d: %w", bind, localPort, err)
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		go func() {
+			<-ctx.Done()
+			ln.Close()
+		}()
+
+		if !isQuiet() {
+			fmt.Printf("Forwarding %s %s %s — Ctrl+C to stop.\n", stAccent.Render(ln.Addr().String()), symArrow, target)
+		}
+
+		tunnelPath := ref.ServicePath() + "/tunnel"
+		for {
+			local, err := ln.Accept()
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			go func() {
+				defer local.Close()
+				if err := forwardTunnel(tunnelPath, target, local); err != nil {
+					fmt.Fprintf(os.Stderr, "%s %s: %v\n", stError.Render(symCross), local.RemoteAddr(), err)
+				}
+			}()
+		}
+	},
+}
+
+// parseTunnelSpec splits "<local-port>:<target>".
+func parseTunnelSpec(spec string) (int, string, error) {
+	portStr, target, ok := strings.Cut(spec, ":")
+	if !ok || target == "" {
+		return 0, "", fmt.Errorf("invalid tunnel %q (expected <local-port>:<target>, e.g. 5432:db)", spec)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, "", fmt.Errorf("invalid local port %q", portStr)
+	}
+	return port, target, nil
+}
+
+// forwardTunnel opens a tunnel stream for one local connection and copies
+// bytes both ways until either side closes.
+func forwardTunnel(tunnelPath, target string, local net.Conn) error {
+	payload, _ := json.Marshal(map[string]string{"target": target})
+	req, _ := http.NewRequest("POST", apiURL(tunnelPath), bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	body, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("opening tunnel: %w", err)
+	}
+	var session struct {
+		WebSocketURL string `json:"websocket_url"`
+	}
+	if err := decodeJSON(body, &session); err != nil {
+		return fmt.Errorf("parsing tunnel response: %w", err)
+	}
+	if session.WebSocketURL == "" {
+		return fmt.Errorf("tunnel did not return a WebSocket URL")
+	}
+
+	conn, err := dialWebSocket(websocketURL(session.WebSocketURL))
+	if err != nil {
+		return fmt.Errorf("connecting tunnel: %w", err)
+	}
+	defer conn.Close()
+	return pipeWebSocket(conn, local)
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestParseTunnelSpec(t *testing.T) {
+	tests := []struct {
+		spec       string
+		wantPort   int
+		wantTarget string
+		wantErr    bool
+	}{
+		{"5432:db", 5432, "db", false},
+		{"9200:search.internal:9200", 9200, "search.internal:9200", false},
+		{"8080:8000", 8080, "8000", false},
+		{"5432", 0, "", true},
+		{"5432:", 0, "", true},
+		{"db:5432", 0, "", true},
+		{"70000:db", 0, "", true},
+	}
+	for _, tt := range tests {
+		port, target, err := parseTunnelSpec(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseTunnelSpec(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if port != tt.wantPort || target != tt.wantTarget {
+			t.Errorf("parseTunnelSpec(%q) = %d, %q", tt.spec, port, target)
+		}
+	}
+}
+
+func TestForwardTunnel(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotTarget string
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/workspaces/ws/projects/p/envs/e/services/s/tunnel", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Target string }
+		json.NewDecoder(r.Body).Decode(&body)
+		gotTarget = body.Target
+		w.Write([]byte(`{"websocket_url":"/ws/tunnel/t1"}`))
+	})
+	mux.HandleFunc("/ws/tunnel/t1", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, msg, _ := conn.ReadMessage()
+		conn.WriteMessage(websocket.BinaryMessage, append([]byte("pong:"), msg...))
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	client, local := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- forwardTunnel(servicePath("ws", "p", "e", "s")+"/tunnel", "db", local)
+		local.Close()
+	}()
+
+	client.Write([]byte("ping"))
+	got, _ := io.ReadAll(client)
+	if err := <-done; err != nil {
+		t.Fatalf("forwardTunnel() error: %v", err)
+	}
+	if string(got) != "pong:ping" {
+		t.Errorf("received %q", got)
+	}
+	if gotTarget != "db" {
+		t.Errorf("target = %q", gotTarget)
+	}
+}
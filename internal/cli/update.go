@@ -5,12 +5,80 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 )
 
+// releaseAPI is the GitHub endpoint for the latest CLI release.
+var releaseAPI = "https://api.github.com/repos/SideQuest-Group/ancla-client/releases/latest"
+
+// release is the subset of a GitHub release the update check uses.
+type release struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// fetchLatestRelease asks the release API for the newest published release.
+func fetchLatestRelease() (*release, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(releaseAPI)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("release API returned %s", resp.Status)
+	}
+	var rel release
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("parsing release: %w", err)
+	}
+	if rel.TagName == "" {
+		return nil, fmt.Errorf("release API returned no tag")
+	}
+	return &rel, nil
+}
+
+// isNewerVersion reports whether latest is a higher dotted version than
+// current. A leading "v" and any pre-release suffix are ignored; versions
+// that don't parse fall back to a plain inequality check.
+func isNewerVersion(latest, current string) bool {
+	parse := func(v string) ([]int, bool) {
+		v = strings.TrimPrefix(v, "v")
+		v, _, _ = strings.Cut(v, "-")
+		var nums []int
+		for _, p := range strings.Split(v, ".") {
+			n, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, false
+			}
+			nums = append(nums, n)
+		}
+		return nums, true
+	}
+	l, okL := parse(latest)
+	c, okC := parse(current)
+	if !okL || !okC {
+		return strings.TrimPrefix(latest, "v") != strings.TrimPrefix(current, "v")
+	}
+	for i := 0; i < len(l) || i < len(c); i++ {
+		var a, b int
+		if i < len(l) {
+			a = l[i]
+		}
+		if i < len(c) {
+			b = c[i]
+		}
+		if a != b {
+			return a > b
+		}
+	}
+	return false
+}
+
 // checkForUpdate runs a non-blocking check against the GitHub releases API
 // to see if a newer version of the CLI is available. It prints a notice to
 // stderr if an update is found. Errors are silently ignored.
@@ -20,28 +88,13 @@ func checkForUpdate() {
 	}
 
 	go func() {
-		client := &http.Client{Timeout: 2 * time.Second}
-		resp, err := client.Get("https://api.github.com/repos/SideQuest-Group/ancla-client/releases/latest")
+		rel, err := fetchLatestRelease()
 		if err != nil {
 			return
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode != 200 {
-			return
-		}
-
-		var release struct {
-			TagName string `json:"tag_name"`
-			HTMLURL string `json:"html_url"`
-		}
-		if json.NewDecoder(resp.Body).Decode(&release) != nil {
-			return
-		}
-
-		latest := strings.TrimPrefix(release.TagName, "v")
-		current := strings.TrimPrefix(Version, "v")
-		if latest != "" && current != "" && latest != current {
-			notice := fmt.Sprintf("Update available: %s → %s  (%s)", current, latest, release.HTMLURL)
+		if isNewerVersion(rel.TagName, Version) {
+			notice := fmt.Sprintf("Update available: %s → %s  (%s)",
+				strings.TrimPrefix(Version, "v"), strings.TrimPrefix(rel.TagName, "v"), rel.HTMLURL)
 			fmt.Fprintln(os.Stderr, color.YellowString(notice))
 		}
 	}()
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+	usageCmd.Flags().BoolP("watch", "w", false, "Refresh the table until interrupted")
+	usageCmd.Flags().Duration("interval", 10*time.Second, "Refresh interval for --watch")
+}
+
+// Usage at or above these fractions of the plan limit is highlighted.
+const (
+	usageWarnAt = 0.8
+	usageFullAt = 1.0
+)
+
+// workspaceUsage is the response of GET /workspaces/{ws}/usage.
+type workspaceUsage struct {
+	Plan        string `json:"plan"`
+	PeriodStart string `json:"period_start"`
+	PeriodEnd   string `json:"period_end"`
+	Resources   []struct {
+		Name  string  `json:"name"`
+		Unit  string  `json:"unit"`
+		Used  float64 `json:"used"`
+		Limit float64 `json:"limit"` // 0 means unlimited
+	} `json:"resources"`
+}
+
+var usageCmd = &cobra.Command{
+	Use:   "usage [ws]",
+	Short: "Show plan limits and current usage",
+	Long: `Show the workspace's plan limits next to current usage for the billing
+period: services, build minutes, bandwidth, and storage.
+
+Resources at 80% of their limit are highlighted in amber, and those at or
+over the limit in red. With --watch the table is redrawn every --interval
+until interrupted. The workspace defaults to the linked or default one.`,
+	Example:           "  ancla usage\n  ancla usage my-ws --watch\n  ancla usage --json",
+	GroupID:           "resources",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeWorkspaces,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveServiceRef(args)
+		if err != nil {
+			return err
+		}
+
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if watch && isJSON() {
+			return fmt.Errorf("--watch cannot be combined with JSON output")
+		}
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		for {
+			usage, err := fetchUsage(ref.Workspace)
+			if err != nil {
+				return err
+			}
+			if isJSON() {
+				return printJSON(usage)
+			}
+			if watch {
+				// Move home and clear so each refresh replaces the last.
+				fmt.Print("\x1b[H\x1b[2J")
+			}
+			renderUsage(ref.Workspace, usage)
+			if !watch {
+				return nil
+			}
+			fmt.Println()
+			fmt.Println(stDim.Render(fmt.Sprintf("  Refreshing every %s — Ctrl+C to stop.", interval)))
+			time.Sleep(interval)
+		}
+	},
+}
+
+// fetchUsage returns the plan usage for a workspace.
+func fetchUsage(ws string) (*workspaceUsage, error) {
+	req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/usage"), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var usage workspaceUsage
+	if err := decodeJSON(body, &usage); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return &usage, nil
+}
+
+func renderUsage(ws string, u *workspaceUsage) {
+	fmt.Println(heading("Usage"))
+	fmt.Println()
+	fmt.Println(kv("Workspace", ws))
+	if u.Plan != "" {
+		fmt.Println(kv("Plan", u.Plan))
+	}
+	if u.PeriodStart != "" || u.PeriodEnd != "" {
+		fmt.Println(kv("Period", u.PeriodStart+" "+symArrow+" "+u.PeriodEnd))
+	}
+	fmt.Println()
+
+	var rows [][]string
+	for _, r := range u.Resources {
+		limit, pct := "unlimited", "-"
+		if r.Limit > 0 {
+			limit = formatQuantity(r.Limit, r.Unit)
+			pct = usageHighlight(r.Used/r.Limit, fmt.Sprintf("%.0f%%", 100*r.Used/r.Limit))
+		}
+		rows = append(rows, []string{r.Name, formatQuantity(r.Used, r.Unit), limit, pct})
+	}
+	table([]string{"RESOURCE", "USED", "LIMIT", "%"}, rows)
+}
+
+// usageHighlight colors s by how close ratio is to the plan limit.
+func usageHighlight(ratio float64, s string) string {
+	switch {
+	case ratio >= usageFullAt:
+		return stError.Render(s)
+	case ratio >= usageWarnAt:
+		return stWarning.Render(s)
+	default:
+		return s
+	}
+}
+
+// formatQuantity renders a number with its unit, dropping a zero fraction.
+func formatQuantity(v float64, unit string) string {
+	s := fmt.Sprintf("%.1f", v)
+	if v == float64(int64(v)) {
+		s = fmt.Sprintf("%d", int64(v))
+	}
+	if unit == "" {
+		return s
+	}
+	return s + " " + unit
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestFetchUsage(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"plan":"pro","resources":[{"name":"build minutes","unit":"min","used":450,"limit":500}]}`))
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL}
+
+	usage, err := fetchUsage("acme")
+	if err != nil {
+		t.Fatalf("fetchUsage() error: %v", err)
+	}
+	if gotPath != "/api/v1/workspaces/acme/usage" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if usage.Plan != "pro" || len(usage.Resources) != 1 || usage.Resources[0].Used != 450 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestUsageHighlight(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{0.5, "x"},
+		{0.8, stWarning.Render("x")},
+		{1.0, stError.Render("x")},
+		{1.3, stError.Render("x")},
+	}
+	for _, tt := range tests {
+		if got := usageHighlight(tt.ratio, "x"); got != tt.want {
+			t.Errorf("usageHighlight(%v) = %q, want %q", tt.ratio, got, tt.want)
+		}
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	tests := []struct {
+		v    float64
+		unit string
+		want string
+	}{
+		{3, "", "3"},
+		{12.5, "GB", "12.5 GB"},
+		{100, "min", "100 min"},
+	}
+	for _, tt := range tests {
+		if got := formatQuantity(tt.v, tt.unit); got != tt.want {
+			t.Errorf("formatQuantity(%v, %q) = %q, want %q", tt.v, tt.unit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// maxRecentContexts caps how many previous contexts `ancla use` remembers.
+const maxRecentContexts = 10
+
+func init() {
+	rootCmd.AddCommand(useCmd)
+}
+
+var useCmd = &cobra.Command{
+	Use:   "use [<ws>[/<proj>[/<env>]] | -]",
+	Short: "Switch the default workspace/project/env context",
+	Long: `Switch the default context used outside linked directories.
+
+The context is stored as default_workspace, default_project, and default_env
+in ~/.ancla/config.yaml. Previous contexts are remembered; "ancla use -"
+switches back to the last one. With no arguments, the current context and
+recent history are listed.
+
+A local .ancla/config.yaml link (see ancla link) still takes precedence
+inside that directory.`,
+	Example: `  ancla use my-ws/my-proj/staging   # switch context
+  ancla use my-ws                   # workspace only
+  ancla use -                       # toggle back to the previous context
+  ancla use                         # show current and recent contexts`,
+	GroupID:           "config",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: useCompletion,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return showContexts()
+		}
+
+		target := strings.Trim(args[0], "/")
+		if target == "-" {
+			if len(cfg.RecentContexts) == 0 {
+				return fmt.Errorf("no previous context — run `ancla use <ws>[/<proj>[/<env>]]` first")
+			}
+			target = cfg.RecentContexts[0]
+		}
+
+		parts := strings.Split(target, "/")
+		if len(parts) > 3 || contains(parts, "") || strings.ContainsAny(target, " \t") {
+			return fmt.Errorf("invalid context %q — expected <ws>[/<proj>[/<env>]]", args[0])
+		}
+
+		// Verify the target exists so a typo doesn't become the default.
+		path := "/workspaces/" + parts[0]
+		if len(parts) >= 2 {
+			path += "/projects/" + parts[1]
+		}
+		if len(parts) == 3 {
+			path += "/envs/" + parts[2]
+		}
+		req, _ := http.NewRequest("GET", apiURL(path), nil)
+		if _, err := doRequest(req); err != nil {
+			return err
+		}
+
+		prev := currentContext()
+		cfg.DefaultWorkspace, cfg.DefaultProject, cfg.DefaultEnv = parts[0], "", ""
+		if len(parts) >= 2 {
+			cfg.DefaultProject = parts[1]
+		}
+		if len(parts) == 3 {
+			cfg.DefaultEnv = parts[2]
+		}
+		cfg.RecentContexts = pushRecent(cfg.RecentContexts, prev, target)
+
+		if err := config.Save(cfg, "default_workspace", "default_project", "default_env", "recent_contexts"); err != nil {
+			return fmt.Errorf("saving context: %w", err)
+		}
+
+		if isJSON() {
+			return printJSON(map[string]string{"context": target, "previous": prev})
+		}
+		if isQuiet() {
+			return nil
+		}
+		fmt.Println(stSuccess.Render(symCheck) + " Switched to " + stAccent.Render(target))
+		if local := localLinkPath(); local != "" {
+			fmt.Println(stDim.Render("  This directory is linked to " + local + " — the link takes precedence here."))
+		}
+		return nil
+	},
+}
+
+// currentContext returns the default context as "ws[/proj[/env]]".
+func currentContext() string {
+	c := &config.Config{Workspace: cfg.DefaultWorkspace, Project: cfg.DefaultProject}
+	if cfg.DefaultProject != "" {
+		c.Env = cfg.DefaultEnv
+	}
+	return c.ServicePath()
+}
+
+// pushRecent records prev as the most recent context, dropping duplicates
+// and the context being switched to.
+func pushRecent(recent []string, prev, next string) []string {
+	out := []string{}
+	if prev != "" && prev != next {
+		out = append(out, prev)
+	}
+	for _, r := range recent {
+		if r != prev && r != next {
+			out = append(out, r)
+		}
+	}
+	if len(out) > maxRecentContexts {
+		out = out[:maxRecentContexts]
+	}
+	return out
+}
+
+// localLinkPath returns the link path from a local .ancla/config.yaml, if
+// the current directory has one.
+func localLinkPath() string {
+	if _, local := config.Paths(); local == "" {
+		return ""
+	}
+	return cfg.ServicePath()
+}
+
+func showContexts() error {
+	current := currentContext()
+	if isJSON() {
+		return printJSON(map[string]any{"current": current, "recent": cfg.RecentContexts})
+	}
+	if current == "" {
+		fmt.Println(stDim.Render("No default context — run `ancla use <ws>[/<proj>[/<env>]]`."))
+	} else {
+		fmt.Println(stAccent.Render(symPointer) + " " + stBold.Render(current))
+	}
+	for _, r := range cfg.RecentContexts {
+		fmt.Println("  " + r)
+	}
+	return nil
+}
+
+// useCompletion offers "-" and recent contexts, then workspace slugs.
+func useCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	if len(cfg.RecentContexts) > 0 {
+		completions = append(completions, "-\t"+cfg.RecentContexts[0])
+	}
+	completions = append(completions, cfg.RecentContexts...)
+	ws, directive := completeWorkspaces(cmd, args, toComplete)
+	return append(completions, ws...), directive
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestPushRecent(t *testing.T) {
+	tests := []struct {
+		name       string
+		recent     []string
+		prev, next string
+		want       []string
+	}{
+		{"first switch", nil, "", "a", []string{}},
+		{"records previous", nil, "a", "b", []string{"a"}},
+		{"toggle back drops target", []string{"a"}, "b", "a", []string{"b"}},
+		{"dedupes", []string{"c", "a", "d"}, "a", "b", []string{"a", "c", "d"}},
+		{"same context", []string{"x"}, "a", "a", []string{"x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pushRecent(tt.recent, tt.prev, tt.next); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pushRecent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var long []string
+	for i := 0; i < 20; i++ {
+		long = append(long, string(rune('a'+i)))
+	}
+	if got := pushRecent(long, "z", "y"); len(got) != maxRecentContexts {
+		t.Errorf("len = %d, want %d", len(got), maxRecentContexts)
+	}
+}
+
+func TestUseCmd_SwitchAndToggleBack(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/workspaces/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	origDir, _ := os.Getwd()
+	os.Chdir(tmpHome)
+	defer os.Chdir(origDir)
+
+	// Server and API key come from flags/env and must not be persisted.
+	cfg = &config.Config{Server: ts.URL, APIKey: "env-key", DefaultWorkspace: "ws", DefaultProject: "a"}
+
+	if err := useCmd.RunE(useCmd, []string{"ws/b/staging"}); err != nil {
+		t.Fatalf("use ws/b/staging: %v", err)
+	}
+	if got := currentContext(); got != "ws/b/staging" {
+		t.Errorf("context = %q, want ws/b/staging", got)
+	}
+	if err := useCmd.RunE(useCmd, []string{"-"}); err != nil {
+		t.Fatalf("use -: %v", err)
+	}
+	if got := currentContext(); got != "ws/a" {
+		t.Errorf("after toggle, context = %q, want ws/a", got)
+	}
+	if !reflect.DeepEqual(cfg.RecentContexts, []string{"ws/b/staging"}) {
+		t.Errorf("recent = %v", cfg.RecentContexts)
+	}
+
+	if err := useCmd.RunE(useCmd, []string{"missing"}); err == nil {
+		t.Error("expected error switching to a missing workspace")
+	}
+	if err := useCmd.RunE(useCmd, []string{"ws/bad proj"}); err == nil {
+		t.Error("expected error for a slug with a space")
+	}
+
+	data, _ := os.ReadFile(filepath.Join(tmpHome, ".ancla", "config.yaml"))
+	got := string(data)
+	for _, want := range []string{"default_workspace: ws", "default_project: a", "recent_contexts:\n  - ws/b/staging"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config missing %q:\n%s", want, got)
+		}
+	}
+	for _, leaked := range []string{"server:", "api_key:", "default_env:"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("config should not contain %q:\n%s", leaked, got)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -11,16 +13,87 @@ var (
 	Version = "dev"
 	// Commit is set at build time via -ldflags.
 	Commit = "none"
+	// Date is the build time (RFC 3339), set at build time via -ldflags.
+	Date = "unknown"
 )
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().Bool("check", false, "Check the release API for a newer version")
+}
+
+// versionInfo is the build metadata printed by version. Packaging scripts
+// read it with --json, so field names are stable.
+type versionInfo struct {
+	Version         string `json:"version"`
+	Commit          string `json:"commit"`
+	Date            string `json:"date"`
+	GoVersion       string `json:"go_version"`
+	OS              string `json:"os"`
+	Arch            string `json:"arch"`
+	Latest          string `json:"latest,omitempty"`
+	ReleaseURL      string `json:"release_url,omitempty"`
+	UpdateAvailable *bool  `json:"update_available,omitempty"`
 }
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show CLI version",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("ancla %s (%s)\n", Version, Commit)
+	Long: `Show the CLI version and build metadata: commit, build date, Go version,
+and platform. --check asks the release API for the latest version and
+exits non-zero when the check itself fails. --json prints the same fields
+for packaging and update automation.`,
+	Example: "  ancla version\n  ancla version --check\n  ancla version --json",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := versionInfo{
+			Version:   Version,
+			Commit:    Commit,
+			Date:      Date,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		}
+
+		check, _ := cmd.Flags().GetBool("check")
+		if check {
+			stop := spin("Checking for updates...")
+			rel, err := fetchLatestRelease()
+			stop()
+			if err != nil {
+				return fmt.Errorf("checking for updates: %w", err)
+			}
+			newer := Version != "dev" && isNewerVersion(rel.TagName, Version)
+			info.Latest = strings.TrimPrefix(rel.TagName, "v")
+			info.ReleaseURL = rel.HTMLURL
+			info.UpdateAvailable = &newer
+		}
+
+		if isJSON() {
+			return printJSON(info)
+		}
+		if isQuiet() {
+			fmt.Println(info.Version)
+			return nil
+		}
+		fmt.Printf("ancla %s (%s)\n", info.Version, info.Commit)
+		fmt.Println(kv("Built", info.Date))
+		fmt.Println(kv("Go", info.GoVersion))
+		fmt.Println(kv("Platform", info.OS+"/"+info.Arch))
+		if !check {
+			return nil
+		}
+		fmt.Println()
+		switch {
+		case Version == "dev":
+			fmt.Printf("Development build; the latest release is %s.\n", info.Latest)
+		case *info.UpdateAvailable:
+			fmt.Println(stWarning.Render(fmt.Sprintf("Update available: %s → %s", strings.TrimPrefix(Version, "v"), info.Latest)))
+			fmt.Println(stDim.Render("  " + info.ReleaseURL))
+			fmt.Println(stDim.Render("  brew upgrade ancla  ·  scoop update ancla  ·  or download from the release page"))
+		default:
+			fmt.Println(stepDone("You are on the latest version"))
+		}
+		return nil
 	},
 }
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestIsNewerVersion(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.4.0", "1.3.9", true},
+		{"v1.10.0", "v1.9.2", true},
+		{"1.3.0", "1.3.0", false},
+		{"v1.3.0", "1.4.0", false},
+		{"v2.0", "1.9.9", true},
+		{"v1.3.0", "1.3.0-rc.1", false},
+		{"nightly", "1.3.0", true},
+	}
+	for _, tt := range tests {
+		if got := isNewerVersion(tt.latest, tt.current); got != tt.want {
+			t.Errorf("isNewerVersion(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestVersionCmd_CheckJSON(t *testing.T) {
+	origAPI, origVersion, origJSON, origStdout := releaseAPI, Version, jsonFlag, os.Stdout
+	defer func() { releaseAPI, Version, jsonFlag, os.Stdout = origAPI, origVersion, origJSON, origStdout }()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name":"v1.5.0","html_url":"https://example.com/r/v1.5.0"}`))
+	}))
+	defer ts.Close()
+	releaseAPI = ts.URL
+	Version = "1.4.2"
+	jsonFlag = true
+
+	if err := versionCmd.ParseFlags([]string{"--check"}); err != nil {
+		t.Fatal(err)
+	}
+	defer versionCmd.Flags().Set("check", "false")
+
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := versionCmd.RunE(versionCmd, nil)
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = origStdout
+	if err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+
+	var info versionInfo
+	if err := json.Unmarshal(out, &info); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if info.Version != "1.4.2" || info.Latest != "1.5.0" || info.GoVersion == "" {
+		t.Errorf("info = %+v", info)
+	}
+	if info.UpdateAvailable == nil || !*info.UpdateAvailable {
+		t.Errorf("update_available = %v, want true", info.UpdateAvailable)
+	}
+}
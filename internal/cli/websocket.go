@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// websocketURL resolves a WebSocket URL returned by the API. Relative paths
+// are resolved against the configured server with ws:// or wss://.
+func websocketURL(raw string) string {
+	if !strings.HasPrefix(raw, "/") {
+		return raw
+	}
+	base := serverURL()
+	if strings.HasPrefix(base, "https://") {
+		return "wss://" + strings.TrimPrefix(base, "https://") + raw
+	}
+	return "ws://" + strings.TrimPrefix(base, "http://") + raw
+}
+
+// onServerHost reports whether a WebSocket URL points at the configured
+// server, the only host the API key may be sent to. An absolute URL from the
+// API naming another host is dialled without it.
+func onServerHost(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	server, err := url.Parse(serverURL())
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, server.Host)
+}
+
+// dialWebSocket opens an API WebSocket, authenticated with the API key when
+// it is on the configured server.
+func dialWebSocket(url string) (*websocket.Conn, error) {
+	header := http.Header{}
+	if cfg.APIKey != "" && onServerHost(url) {
+		header.Set("X-API-Key", cfg.APIKey)
+	}
+	conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("%s", resp.Status)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// pipeWebSocket copies between a stream and binary WebSocket messages until
+// the remote end closes the socket; a normal close is not an error. Local
+// EOF sends a close frame so the remote side can finish and close.
+func pipeWebSocket(conn *websocket.Conn, stream io.ReadWriter) error {
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := stream.Read(buf)
+			if n > 0 {
+				if werr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					return
+				}
+			}
+			if err != nil {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return closeError(err)
+		}
+		if _, err := stream.Write(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// closeError maps the error that ended a WebSocket read loop: nil for a
+// normal close, the peer's reason when it gave one.
+func closeError(err error) error {
+	var ce *websocket.CloseError
+	if errors.As(err, &ce) && ce.Code == websocket.CloseNormalClosure {
+		return nil
+	}
+	if errors.As(err, &ce) && ce.Text != "" {
+		return fmt.Errorf("closed: %s", ce.Text)
+	}
+	return fmt.Errorf("connection lost: %w", err)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+// identity is what whoami --full reports: the user, the API key in use,
+// and what it can reach.
+type identity struct {
+	Username   string            `json:"username"`
+	Email      string            `json:"email"`
+	Key        *apiKeyInfo       `json:"key"` // null when the server doesn't list it
+	Workspaces []workspaceAccess `json:"workspaces"`
+}
+
+// apiKeyInfo is an API key as /api/keys lists it, without its secret.
+type apiKeyInfo struct {
+	ID         string   `json:"key_id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	Created    string   `json:"created"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	ExpiresAt  string   `json:"expires_at,omitempty"`
+	Revoked    bool     `json:"revoked"`
+}
+
+// workspaceAccess is a workspace the key can see and the role it holds
+// there.
+type workspaceAccess struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`            // empty when the server didn't say
+	Scope string `json:"scope,omitempty"` // where the role was granted
+}
+
+// currentAPIKey finds the key in use among the user's keys by its prefix.
+// It returns nil when none matches, as for a service account's key.
+func currentAPIKey() (*apiKeyInfo, error) {
+	req, _ := http.NewRequest("GET", serverURL()+"/api/keys", nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var keys []apiKeyInfo
+	if err := decodeJSON(body, &keys); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	for _, k := range keys {
+		if !k.Revoked && k.Prefix != "" && strings.HasPrefix(cfg.APIKey, k.Prefix) {
+			return &k, nil
+		}
+	}
+	return nil, nil
+}
+
+// workspaceRoles returns the role the key holds in each workspace of a
+// /workspaces/ listing, as the permission check reports it for viewing
+// the workspace.
+func workspaceRoles(listing []byte) ([]workspaceAccess, error) {
+	var workspaces []struct {
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+	}
+	if err := decodeJSON(listing, &workspaces); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	access := make([]workspaceAccess, 0, len(workspaces))
+	for _, ws := range workspaces {
+		a := workspaceAccess{Slug: ws.Slug, Name: ws.Name}
+		check, err := checkPermission("view", config.ServiceRef{Workspace: ws.Slug})
+		if err != nil {
+			return nil, err
+		}
+		a.Role, a.Scope = check.Role, check.Scope
+		access = append(access, a)
+	}
+	return access, nil
+}
+
+// printIdentity renders whoami --full after the user's own details.
+func printIdentity(id identity) {
+	fmt.Println()
+	fmt.Println(heading("API key"))
+	fmt.Println()
+	if id.Key == nil {
+		fmt.Println(stDim.Render("  Not one of your personal keys, so its details aren't listed."))
+	} else {
+		k := id.Key
+		fmt.Println(kv("Name", k.Name))
+		fmt.Println(kv("Prefix", k.Prefix))
+		scopes := strings.Join(k.Scopes, ", ")
+		if scopes == "" {
+			scopes = "all"
+		}
+		fmt.Println(kv("Scopes", scopes))
+		fmt.Println(kv("Created", formatTime(k.Created)))
+		lastUsed := formatTime(k.LastUsedAt)
+		if lastUsed == "" {
+			lastUsed = "never"
+		}
+		fmt.Println(kv("Last used", lastUsed))
+		if k.ExpiresAt != "" {
+			fmt.Println(kv("Expires", formatTime(k.ExpiresAt)))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(heading("Workspaces"))
+	fmt.Println()
+	if len(id.Workspaces) == 0 {
+		fmt.Println(stDim.Render("  The key can't access any workspace."))
+		return
+	}
+	var rows [][]string
+	for _, ws := range id.Workspaces {
+		role := ws.Role
+		if role == "" {
+			role = "-"
+		}
+		rows = append(rows, []string{ws.Slug, ws.Name, role, ws.Scope})
+	}
+	table([]string{"SLUG", "NAME", "ROLE", "GRANTED ON"}, rows)
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -12,6 +11,8 @@ func init() {
 	rootCmd.AddCommand(workspacesCmd)
 	workspacesCmd.AddCommand(workspacesListCmd)
 	workspacesCmd.AddCommand(workspacesGetCmd)
+	addIDOnlyFlag(workspacesListCmd, "workspace slugs")
+	addIDOnlyFlag(workspacesGetCmd, "workspace slug")
 }
 
 var workspacesCmd = &cobra.Command{
@@ -48,10 +49,16 @@ var workspacesListCmd = &cobra.Command{
 			MemberCount  int    `json:"member_count"`
 			ProjectCount int    `json:"project_count"`
 		}
-		if err := json.Unmarshal(body, &workspaces); err != nil {
+		if err := decodeJSON(body, &workspaces); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			for _, w := range workspaces {
+				fmt.Println(w.Slug)
+			}
+			return nil
+		}
 		if isJSON() {
 			return printJSON(workspaces)
 		}
@@ -90,10 +97,14 @@ var workspacesGetCmd = &cobra.Command{
 				ServiceCount int    `json:"service_count"`
 			} `json:"members"`
 		}
-		if err := json.Unmarshal(body, &ws); err != nil {
+		if err := decodeJSON(body, &ws); err != nil {
 			return fmt.Errorf("parsing response: %w", err)
 		}
 
+		if idOnly(cmd) {
+			fmt.Println(ws.Slug)
+			return nil
+		}
 		if isJSON() {
 			return printJSON(ws)
 		}
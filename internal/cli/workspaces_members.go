@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	workspacesCmd.AddCommand(workspacesMembersCmd)
+	workspacesMembersCmd.AddCommand(workspacesMembersResendCmd)
+	workspacesMembersCmd.AddCommand(workspacesMembersRevokeCmd)
+}
+
+// workspaceMember is an active member of a workspace.
+type workspaceMember struct {
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Admin        bool   `json:"admin"`
+	ServiceCount int    `json:"service_count"`
+}
+
+// workspaceInvitation is an invitation that has not been accepted yet.
+type workspaceInvitation struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Admin     bool   `json:"admin"`
+	InvitedBy string `json:"invited_by"`
+	Created   string `json:"created"`
+	ExpiresAt string `json:"expires_at"`
+	Expired   bool   `json:"expired"`
+}
+
+var workspacesMembersCmd = &cobra.Command{
+	Use:   "members [ws]",
+	Short: "List members and pending invitations",
+	Long: `List the active members of a workspace, followed by invitations that
+have not been accepted yet and when each one expires. The workspace
+defaults to the linked or default one.
+
+Use "members resend" to email an invitation again, which also restarts
+its expiry, and "members revoke" to cancel one.`,
+	Example: "  ancla workspaces members\n  ancla workspaces members my-ws\n  ancla workspaces members resend bob@example.com",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := resolveServiceRef(args)
+		if err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ref.Workspace), nil)
+		body, err := doRequest(req)
+		if err != nil {
+			return err
+		}
+		var ws struct {
+			Members []workspaceMember `json:"members"`
+		}
+		if err := decodeJSON(body, &ws); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		invitations, err := listInvitations(ref.Workspace)
+		if err != nil {
+			return err
+		}
+
+		if isJSON() {
+			return printJSON(map[string]any{"members": ws.Members, "invitations": invitations})
+		}
+
+		var rows [][]string
+		for _, m := range ws.Members {
+			rows = append(rows, []string{m.Username, m.Email, roleName(m.Admin), fmt.Sprintf("%d", m.ServiceCount)})
+		}
+		table([]string{"USERNAME", "EMAIL", "ROLE", "SERVICES"}, rows)
+
+		if len(invitations) == 0 {
+			return nil
+		}
+		fmt.Println()
+		fmt.Println(stBold.Render("Pending invitations"))
+		rows = nil
+		now := time.Now()
+		for _, inv := range invitations {
+			rows = append(rows, []string{inv.ID, inv.Email, roleName(inv.Admin), inv.InvitedBy, formatExpiry(inv, now)})
+		}
+		table([]string{"ID", "EMAIL", "ROLE", "INVITED BY", "EXPIRES"}, rows)
+		return nil
+	},
+}
+
+var workspacesMembersResendCmd = &cobra.Command{
+	Use:   "resend <email|invitation-id> [ws]",
+	Short: "Email a pending invitation again",
+	Long: `Send a pending invitation again. The expiry restarts from now, so this
+also revives an invitation that has expired.`,
+	Example: "  ancla workspaces members resend bob@example.com\n  ancla workspaces members resend inv_123 my-ws",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, inv, err := findInvitation(args[0], args[1:])
+		if err != nil {
+			return err
+		}
+		stop := spin("Resending invitation...")
+		req, _ := http.NewRequest("POST", apiURL("/workspaces/"+ws+"/invitations/"+inv.ID+"/resend"), nil)
+		body, err := doRequest(req)
+		stop()
+		if err != nil {
+			return err
+		}
+		var sent workspaceInvitation
+		if err := decodeJSON(body, &sent); err != nil {
+			return fmt.Errorf("parsing response: %w", err)
+		}
+		if isJSON() {
+			return printJSON(sent)
+		}
+		if isQuiet() {
+			return nil
+		}
+		fmt.Println(stepDone(fmt.Sprintf("Resent the invitation to %s (expires %s)", sent.Email, formatExpiry(sent, time.Now()))))
+		return nil
+	},
+}
+
+var workspacesMembersRevokeCmd = &cobra.Command{
+	Use:     "revoke <email|invitation-id> [ws]",
+	Short:   "Cancel a pending invitation",
+	Long:    `Cancel a pending invitation so its link stops working. Members who have already joined are not affected.`,
+	Example: "  ancla workspaces members revoke bob@example.com\n  ancla workspaces members revoke inv_123 my-ws --yes",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ws, inv, err := findInvitation(args[0], args[1:])
+		if err != nil {
+			return err
+		}
+		if err := confirmAction(fmt.Sprintf("This revokes the invitation for %s to %s.", inv.Email, ws)); err != nil {
+			return err
+		}
+		req, _ := http.NewRequest("DELETE", apiURL("/workspaces/"+ws+"/invitations/"+inv.ID), nil)
+		if _, err := doRequest(req); err != nil {
+			return err
+		}
+		if !isQuiet() {
+			fmt.Println(stepDone("Revoked the invitation for " + inv.Email))
+		}
+		return nil
+	},
+}
+
+// listInvitations returns the pending invitations of workspace ws.
+func listInvitations(ws string) ([]workspaceInvitation, error) {
+	req, _ := http.NewRequest("GET", apiURL("/workspaces/"+ws+"/invitations/"), nil)
+	body, err := doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var invitations []workspaceInvitation
+	if err := decodeJSON(body, &invitations); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	return invitations, nil
+}
+
+// findInvitation resolves the workspace in args and looks up the pending
+// invitation named by key, which is an invitation ID or an email address.
+func findInvitation(key string, args []string) (string, *workspaceInvitation, error) {
+	ref, err := resolveServiceRef(args)
+	if err != nil {
+		return "", nil, err
+	}
+	invitations, err := listInvitations(ref.Workspace)
+	if err != nil {
+		return "", nil, err
+	}
+	for i, inv := range invitations {
+		if inv.ID == key || strings.EqualFold(inv.Email, key) {
+			return ref.Workspace, &invitations[i], nil
+		}
+	}
+	return "", nil, fmt.Errorf("no pending invitation for %q in %s — run `ancla workspaces members %s` to see them", key, ref.Workspace, ref.Workspace)
+}
+
+// roleName is the role shown for a member or invitation.
+func roleName(admin bool) string {
+	if admin {
+		return "admin"
+	}
+	return "member"
+}
+
+// formatExpiry describes when an invitation expires relative to now:
+// "in 3d", "in 5h", or "expired" when it can no longer be accepted.
+func formatExpiry(inv workspaceInvitation, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, inv.ExpiresAt)
+	if inv.Expired || (err == nil && !t.After(now)) {
+		return stWarning.Render("expired")
+	}
+	if err != nil {
+		return inv.ExpiresAt
+	}
+	left := t.Sub(now)
+	switch {
+	case left >= 48*time.Hour:
+		return fmt.Sprintf("in %dd", int(left.Hours()/24))
+	case left >= time.Hour:
+		return fmt.Sprintf("in %dh", int(left.Hours()))
+	default:
+		return fmt.Sprintf("in %dm", max(1, int(left.Minutes())))
+	}
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SideQuest-Group/ancla-client/internal/config"
+)
+
+func TestFormatExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		inv  workspaceInvitation
+		want string
+	}{
+		{workspaceInvitation{ExpiresAt: "2026-10-20T12:00:00Z"}, "in 4d"},
+		{workspaceInvitation{ExpiresAt: "2026-10-16T17:30:00Z"}, "in 5h"},
+		{workspaceInvitation{ExpiresAt: "2026-10-16T12:00:20Z"}, "in 1m"},
+		{workspaceInvitation{ExpiresAt: "2026-10-15T12:00:00Z"}, "expired"},
+		{workspaceInvitation{ExpiresAt: "2026-10-20T12:00:00Z", Expired: true}, "expired"},
+	}
+	for _, tt := range tests {
+		if got := ansiRe.ReplaceAllString(formatExpiry(tt.inv, now), ""); got != tt.want {
+			t.Errorf("formatExpiry(%+v) = %q, want %q", tt.inv, got, tt.want)
+		}
+	}
+}
+
+func TestWorkspacesMembersResend_ByEmail(t *testing.T) {
+	origCfg := cfg
+	defer func() { cfg = origCfg }()
+
+	var resent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/workspaces/acme/invitations/":
+			w.Write([]byte(`[{"id":"inv1","email":"Bob@Example.com","expires_at":"2026-10-01T00:00:00Z","expired":true}]`))
+		case r.Method == "POST":
+			resent = r.URL.Path
+			w.Write([]byte(`{"id":"inv1","email":"Bob@Example.com","expires_at":"2099-01-01T00:00:00Z"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	cfg = &config.Config{Server: ts.URL, Workspace: "acme"}
+
+	if err := workspacesMembersResendCmd.RunE(workspacesMembersResendCmd, []string{"bob@example.com"}); err != nil {
+		t.Fatalf("RunE error: %v", err)
+	}
+	if want := "/api/v1/workspaces/acme/invitations/inv1/resend"; resent != want {
+		t.Errorf("resent %q, want %q", resent, want)
+	}
+
+	err := workspacesMembersResendCmd.RunE(workspacesMembersResendCmd, []string{"carol@example.com"})
+	if err == nil {
+		t.Fatal("expected an error for an unknown invitation")
+	}
+}
@@ -19,11 +19,93 @@ type Config struct {
 	Username string `mapstructure:"username"`
 	Email    string `mapstructure:"email"`
 
+	// Preferences — stored in the global config only
+	Output string `mapstructure:"output"` // default output format: table or json
+	Color  string `mapstructure:"color"`  // auto, always, or never
+	Time   string `mapstructure:"time"`   // relative or absolute timestamps in tables
+
+	// Polling for --follow — durations like "5s"; max_wait "0" disables
+	// the limit. Stored in the global config only.
+	PollInterval string `mapstructure:"poll_interval"`
+	MaxWait      string `mapstructure:"max_wait"`
+
+	// Refuse to send requests that change anything. Also honoured in a
+	// local .ancla/config.yaml, so a directory linked to production can
+	// be read-only by default.
+	ReadOnly bool `mapstructure:"read_only"`
+
+	// GitHub token `ancla deploy` posts commit statuses with — stored in
+	// the global config only
+	GitHubToken string `mapstructure:"github_token"`
+
+	// Fallback link context for directories without a local link —
+	// stored in the global config only
+	DefaultWorkspace string `mapstructure:"default_workspace"`
+	DefaultProject   string `mapstructure:"default_project"`
+	DefaultEnv       string `mapstructure:"default_env"`
+
+	// Previously used default contexts, most recent first (see `ancla use`)
+	RecentContexts []string `mapstructure:"recent_contexts"`
+
+	// API keys scoped to one workspace, used instead of APIKey when that
+	// workspace is active — stored in the global config only
+	WorkspaceKeys []WorkspaceKey `mapstructure:"workspace_keys"`
+
+	// Shell commands run before/after CLI commands, keyed by
+	// pre_<command> / post_<command> (e.g. pre_deploy, post_services_scale)
+	Hooks map[string]string `mapstructure:"hooks"`
+
 	// Link context — stored in local .ancla/config.yaml only
 	Workspace string `mapstructure:"workspace"`
 	Project   string `mapstructure:"project"`
 	Env       string `mapstructure:"env"`
 	Service   string `mapstructure:"service"`
+
+	// Config vars the linked service must have before it deploys —
+	// stored in local .ancla/config.yaml only
+	RequiredConfig []string `mapstructure:"required_config"`
+}
+
+// WorkspaceKey is an API key stored for one workspace on one server.
+type WorkspaceKey struct {
+	Server    string `mapstructure:"server" yaml:"server"`
+	Workspace string `mapstructure:"workspace" yaml:"workspace"`
+	APIKey    string `mapstructure:"api_key" yaml:"api_key"`
+}
+
+// sameServer compares server URLs, ignoring a trailing slash.
+func sameServer(a, b string) bool {
+	return strings.TrimRight(a, "/") == strings.TrimRight(b, "/")
+}
+
+// WorkspaceKey returns the API key stored for workspace on the configured
+// server, or "" if there is none.
+func (c *Config) WorkspaceKey(workspace string) string {
+	if workspace == "" {
+		return ""
+	}
+	for _, k := range c.WorkspaceKeys {
+		if k.Workspace == workspace && sameServer(k.Server, c.Server) {
+			return k.APIKey
+		}
+	}
+	return ""
+}
+
+// SetWorkspaceKey stores key for workspace on the configured server,
+// replacing any previous key; an empty key removes it. Persist the change
+// with Save(cfg, "workspace_keys").
+func (c *Config) SetWorkspaceKey(workspace, key string) {
+	out := c.WorkspaceKeys[:0:0]
+	for _, k := range c.WorkspaceKeys {
+		if k.Workspace != workspace || !sameServer(k.Server, c.Server) {
+			out = append(out, k)
+		}
+	}
+	if key != "" {
+		out = append(out, WorkspaceKey{Server: c.Server, Workspace: workspace, APIKey: key})
+	}
+	c.WorkspaceKeys = out
 }
 
 // homeConfigDir returns the path to ~/.ancla/.
@@ -62,23 +144,37 @@ func findLocalConfigDir() string {
 //  3. Local .ancla/config.yaml (nearest parent directory)
 //  4. ~/.ancla/config.yaml
 //  5. Built-in defaults
+//
+// String values in either file may use ${env:NAME} and ${file:path}
+// substitutions, which are expanded as the file is read. One that can't be
+// expanded is left as written, with a warning.
 func Load() (*Config, error) {
 	v := viper.New()
-	v.SetConfigName("config")
-	v.SetConfigType("yaml")
 	v.SetEnvPrefix("ANCLA")
 	v.AutomaticEnv()
 
 	// Defaults
 	v.SetDefault("server", "https://ancla.dev")
 	v.SetDefault("api_key", "")
+	// Known to viper so ANCLA_GITHUB_TOKEN is read in CI without a config file.
+	v.SetDefault("github_token", "")
+	v.SetDefault("read_only", false)
 
 	// Load global config first (~/.ancla/config.yaml)
-	v.AddConfigPath(homeConfigDir())
-	if err := v.ReadInConfig(); err != nil {
+	global := viper.New()
+	global.SetConfigName("config")
+	global.SetConfigType("yaml")
+	global.AddConfigPath(homeConfigDir())
+	if err := global.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 			return nil, fmt.Errorf("reading config: %w", err)
 		}
+	} else {
+		settings := global.AllSettings()
+		warnUnexpanded(global.ConfigFileUsed(), interpolate(settings, homeConfigDir(), ""))
+		if err := v.MergeConfigMap(settings); err != nil {
+			return nil, fmt.Errorf("merging global config: %w", err)
+		}
 	}
 
 	// Layer local config on top (.ancla/config.yaml from cwd or parent)
@@ -91,6 +187,9 @@ func Load() (*Config, error) {
 			settings := local.AllSettings()
 			// Auto-migrate old config keys
 			migrateOldKeys(settings)
+			// The local file is committed with the repository, so it may
+			// only read files from inside it.
+			warnUnexpanded(local.ConfigFileUsed(), interpolate(settings, localDir, filepath.Dir(localDir)))
 			if err := v.MergeConfigMap(settings); err != nil {
 				return nil, fmt.Errorf("merging local config: %w", err)
 			}
@@ -104,9 +203,26 @@ func Load() (*Config, error) {
 	return &cfg, nil
 }
 
+// warnUnexpanded prints a warning for each substitution in file that was
+// left as written.
+func warnUnexpanded(file string, errs []error) {
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "warning: %s: %v — left unexpanded\n", file, err)
+	}
+}
+
 // migrateOldKeys detects old config keys (org, app) and remaps them to
-// the new names (workspace, service). Modifies the map in place.
+// the new names (workspace, service), warning when it does so. Modifies
+// the map in place.
 func migrateOldKeys(settings map[string]any) {
+	if remapOldKeys(settings) {
+		fmt.Fprintln(os.Stderr, "warning: migrated old config keys (org→workspace, app→service) — re-run `ancla link` to update")
+	}
+}
+
+// remapOldKeys performs the org→workspace and app→service rename and
+// reports whether anything changed.
+func remapOldKeys(settings map[string]any) bool {
 	migrated := false
 	if v, ok := settings["org"]; ok {
 		if _, hasNew := settings["workspace"]; !hasNew {
@@ -122,9 +238,7 @@ func migrateOldKeys(settings map[string]any) {
 		delete(settings, "app")
 		migrated = true
 	}
-	if migrated {
-		fmt.Fprintln(os.Stderr, "warning: migrated old config keys (org→workspace, app→service) — re-run `ancla link` to update")
-	}
+	return migrated
 }
 
 // FilePath returns the active config file path. If a local .ancla/ exists
@@ -146,29 +260,57 @@ func Paths() (global string, local string) {
 	return
 }
 
-// Save writes the current configuration to ~/.ancla/config.yaml.
-func Save(cfg *Config) error {
+// LinkKeys are the link-context keys stored in a local .ancla/config.yaml.
+var LinkKeys = []string{"workspace", "project", "env", "service"}
+
+// Save writes the named keys of cfg to ~/.ancla/config.yaml; a key whose
+// value is empty is removed. Only those keys are touched, so values cfg
+// picked up from flags, environment variables, or defaults are never
+// persisted, and concurrent saves of different keys don't undo each
+// other. Keys the CLI does not manage, and any comments, are preserved.
+func Save(cfg *Config, keys ...string) error {
+	updates := make(map[string]any, len(keys))
+	for _, key := range keys {
+		if key == "recent_contexts" {
+			updates[key] = optionalList(cfg.RecentContexts)
+			continue
+		}
+		if key == "workspace_keys" {
+			if len(cfg.WorkspaceKeys) == 0 {
+				updates[key] = nil
+			} else {
+				updates[key] = cfg.WorkspaceKeys
+			}
+			continue
+		}
+		if !contains(Keys, key) || contains(LinkKeys, key) {
+			return fmt.Errorf("%s is not a global config key", key)
+		}
+		updates[key] = optional(cfg.Get(key))
+	}
 	dir := homeConfigDir()
 	if err := os.MkdirAll(dir, 0o700); err != nil {
 		return fmt.Errorf("creating config dir: %w", err)
 	}
-	v := viper.New()
-	v.Set("server", cfg.Server)
-	v.Set("api_key", cfg.APIKey)
-	if cfg.Username != "" {
-		v.Set("username", cfg.Username)
-	}
-	if cfg.Email != "" {
-		v.Set("email", cfg.Email)
-	}
-	path := filepath.Join(dir, "config.yaml")
-	return v.WriteConfigAs(path)
+	// The global config holds the API key, so keep it owner-only.
+	return writeConfig(filepath.Join(dir, "config.yaml"), updates, 0o600)
 }
 
-// SaveLocal writes link context (workspace, project, env, service) to
+// SaveLocal writes the named link-context keys of cfg (see LinkKeys) to
 // .ancla/config.yaml in the current working directory, creating the
-// directory if needed.
-func SaveLocal(cfg *Config) error {
+// directory if needed. Other keys and comments in the file are kept.
+func SaveLocal(cfg *Config, keys ...string) error {
+	updates := map[string]any{
+		// Pre-rename keys are superseded by whatever is written now.
+		"org": nil,
+		"app": nil,
+	}
+	for _, key := range keys {
+		if !contains(LinkKeys, key) {
+			return fmt.Errorf("%s is not a link config key", key)
+		}
+		updates[key] = optional(cfg.Get(key))
+	}
 	dir, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("getting working directory: %w", err)
@@ -177,21 +319,24 @@ func SaveLocal(cfg *Config) error {
 	if err := os.MkdirAll(localDir, 0o755); err != nil {
 		return fmt.Errorf("creating .ancla directory: %w", err)
 	}
-	v := viper.New()
-	if cfg.Workspace != "" {
-		v.Set("workspace", cfg.Workspace)
-	}
-	if cfg.Project != "" {
-		v.Set("project", cfg.Project)
-	}
-	if cfg.Env != "" {
-		v.Set("env", cfg.Env)
+	return writeConfig(filepath.Join(localDir, "config.yaml"), updates, 0o644)
+}
+
+// optional maps an empty string to nil so writeConfig removes the key
+// instead of writing an empty value.
+func optional(s string) any {
+	if s == "" {
+		return nil
 	}
-	if cfg.Service != "" {
-		v.Set("service", cfg.Service)
+	return s
+}
+
+// optionalList is optional for string slices.
+func optionalList(l []string) any {
+	if len(l) == 0 {
+		return nil
 	}
-	path := filepath.Join(localDir, "config.yaml")
-	return v.WriteConfigAs(path)
+	return l
 }
 
 // RemoveLocal deletes the .ancla/config.yaml in the current working directory.
@@ -233,29 +378,53 @@ func (c *Config) ServicePath() string {
 	return strings.Join(parts, "/")
 }
 
-// ResolveServicePath extracts workspace, project, env, and service from a
-// slash-separated positional argument, falling back to link context for
-// missing segments. Returns an error if required segments are missing.
-func ResolveServicePath(arg string, cfg *Config) (ws, proj, env, svc string, err error) {
-	ws = cfg.Workspace
-	proj = cfg.Project
-	env = cfg.Env
-	svc = cfg.Service
-
-	if arg != "" {
-		parts := strings.Split(arg, "/")
-		if len(parts) >= 1 && parts[0] != "" {
-			ws = parts[0]
-		}
-		if len(parts) >= 2 && parts[1] != "" {
-			proj = parts[1]
+// ResolveServiceRef resolves a slash-separated positional argument into a
+// ServiceRef, falling back to link context for missing segments. Without a
+// local link, the global default_workspace, default_project, and
+// default_env fill in; each default only applies while the segments above
+// it are also the defaults. Returns an error if the argument is malformed.
+func ResolveServiceRef(arg string, cfg *Config) (ServiceRef, error) {
+	ref := ServiceRef{Workspace: cfg.Workspace, Project: cfg.Project, Env: cfg.Env, Service: cfg.Service}
+
+	defaultedProj, defaultedEnv := false, false
+	if ref.Workspace == "" && cfg.DefaultWorkspace != "" {
+		ref.Workspace = cfg.DefaultWorkspace
+		if ref.Project == "" && cfg.DefaultProject != "" {
+			ref.Project = cfg.DefaultProject
+			defaultedProj = true
+			if ref.Env == "" && cfg.DefaultEnv != "" {
+				ref.Env = cfg.DefaultEnv
+				defaultedEnv = true
+			}
 		}
-		if len(parts) >= 3 && parts[2] != "" {
-			env = parts[2]
+	}
+
+	given, err := ParseServiceRef(arg)
+	if err != nil {
+		return ServiceRef{}, err
+	}
+	if given.Workspace != "" {
+		ref.Workspace = given.Workspace
+		if ref.Workspace != cfg.DefaultWorkspace {
+			if defaultedProj {
+				ref.Project = ""
+			}
+			if defaultedEnv {
+				ref.Env = ""
+			}
 		}
-		if len(parts) >= 4 && parts[3] != "" {
-			svc = parts[3]
+	}
+	if given.Project != "" {
+		ref.Project = given.Project
+		if defaultedEnv && ref.Project != cfg.DefaultProject {
+			ref.Env = ""
 		}
 	}
-	return
+	if given.Env != "" {
+		ref.Env = given.Env
+	}
+	if given.Service != "" {
+		ref.Service = given.Service
+	}
+	return ref, nil
 }
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -166,6 +170,113 @@ func TestLoad_LocalConfigOverridesGlobal(t *testing.T) {
 	}
 }
 
+func TestLoad_ReadOnly(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_READ_ONLY", "")
+
+	projectDir := filepath.Join(tmpHome, "prod-app")
+	os.MkdirAll(filepath.Join(projectDir, ".ancla"), 0o755)
+	os.WriteFile(filepath.Join(projectDir, ".ancla", "config.yaml"), []byte("env: production\nread_only: true\n"), 0o644)
+	t.Chdir(tmpHome)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ReadOnly {
+		t.Error("ReadOnly set outside the read-only directory")
+	}
+
+	t.Setenv("ANCLA_READ_ONLY", "true")
+	if cfg, _ = Load(); !cfg.ReadOnly {
+		t.Error("ANCLA_READ_ONLY=true did not set ReadOnly")
+	}
+	t.Setenv("ANCLA_READ_ONLY", "")
+
+	os.Chdir(projectDir)
+	if cfg, _ = Load(); !cfg.ReadOnly {
+		t.Error("read_only in the local config did not set ReadOnly")
+	}
+	if got := cfg.Get("read_only"); got != "true" {
+		t.Errorf(`Get("read_only") = %q, want "true"`, got)
+	}
+}
+
+func TestLoad_Interpolation(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_API_KEY", "")
+	t.Setenv("ANCLA_SERVER", "")
+	t.Setenv("CI_ANCLA_KEY", "ci-key-123")
+
+	projectDir := filepath.Join(tmpHome, "app")
+	anclaDir := filepath.Join(projectDir, ".ancla")
+	os.MkdirAll(anclaDir, 0o755)
+	os.WriteFile(filepath.Join(anclaDir, "workspace"), []byte("acme\n"), 0o644)
+	os.WriteFile(filepath.Join(anclaDir, "config.yaml"), []byte(
+		"api_key: ${env:CI_ANCLA_KEY}\nworkspace: ${file:workspace}\nserver: https://${env:CI_ANCLA_HOST}\n"), 0o644)
+	t.Chdir(projectDir)
+
+	// An unset variable is left in place rather than failing every command.
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error with an unset variable: %v", err)
+	}
+	if cfg.Server != "https://${env:CI_ANCLA_HOST}" {
+		t.Errorf("Server = %q, want the substitution left as written", cfg.Server)
+	}
+
+	t.Setenv("CI_ANCLA_HOST", "ancla.example.com")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.APIKey != "ci-key-123" {
+		t.Errorf("APIKey = %q, want the value of CI_ANCLA_KEY", cfg.APIKey)
+	}
+	if cfg.Workspace != "acme" {
+		t.Errorf("Workspace = %q, want the file's contents without the newline", cfg.Workspace)
+	}
+	if cfg.Server != "https://ancla.example.com" {
+		t.Errorf("Server = %q, want the substitution inside the URL", cfg.Server)
+	}
+}
+
+func TestLoad_InterpolationFileOutsideRepo(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_API_KEY", "")
+	t.Setenv("ANCLA_GITHUB_TOKEN", "")
+
+	os.WriteFile(filepath.Join(tmpHome, "token"), []byte("gh-secret\n"), 0o600)
+	projectDir := filepath.Join(tmpHome, "app")
+	anclaDir := filepath.Join(projectDir, ".ancla")
+	os.MkdirAll(anclaDir, 0o755)
+	os.WriteFile(filepath.Join(anclaDir, "config.yaml"), []byte("github_token: ${file:../../token}\n"), 0o644)
+	t.Chdir(projectDir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.GitHubToken != "${file:../../token}" {
+		t.Errorf("GitHubToken = %q, want a repository's config kept from reading outside it", cfg.GitHubToken)
+	}
+
+	// The global config is the user's own and may read any file.
+	os.MkdirAll(filepath.Join(tmpHome, ".ancla"), 0o755)
+	os.WriteFile(filepath.Join(tmpHome, ".ancla", "config.yaml"), []byte("github_token: ${file:../token}\n"), 0o644)
+	os.WriteFile(filepath.Join(anclaDir, "config.yaml"), []byte("workspace: acme\n"), 0o644)
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.GitHubToken != "gh-secret" {
+		t.Errorf("GitHubToken = %q, want the global config's file read", cfg.GitHubToken)
+	}
+}
+
 func TestFindLocalConfigDir_WalksUp(t *testing.T) {
 	tmpDir := resolveSymlinks(t, t.TempDir())
 
@@ -261,7 +372,7 @@ func TestSave_CreatesConfigFile(t *testing.T) {
 		Email:    "saved@example.com",
 	}
 
-	err := Save(cfg)
+	err := Save(cfg, "server", "api_key", "username", "email")
 	if err != nil {
 		t.Fatalf("Save() error: %v", err)
 	}
@@ -306,7 +417,7 @@ func TestSaveLocal_CreatesLocalConfig(t *testing.T) {
 		Service:   "my-svc",
 	}
 
-	err := SaveLocal(cfg)
+	err := SaveLocal(cfg, LinkKeys...)
 	if err != nil {
 		t.Fatalf("SaveLocal() error: %v", err)
 	}
@@ -327,7 +438,7 @@ func TestRemoveLocal(t *testing.T) {
 
 	// Create local config first.
 	cfg := &Config{Workspace: "test-ws"}
-	SaveLocal(cfg)
+	SaveLocal(cfg, LinkKeys...)
 
 	err := RemoveLocal()
 	if err != nil {
@@ -396,7 +507,7 @@ func TestConfig_ServicePath(t *testing.T) {
 	}
 }
 
-func TestResolveServicePath(t *testing.T) {
+func TestResolveServiceRef(t *testing.T) {
 	tests := []struct {
 		name                               string
 		arg                                string
@@ -421,16 +532,368 @@ func TestResolveServicePath(t *testing.T) {
 			cfg:    &Config{Workspace: "x", Project: "y", Env: "z", Service: "w"},
 			wantWs: "x", wantProj: "y", wantEnv: "z", wantSvc: "w",
 		},
+		{
+			name:   "global defaults fill unlinked directory",
+			arg:    "",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp"},
+			wantWs: "dw", wantProj: "dp",
+		},
+		{
+			name:   "local link beats global defaults",
+			arg:    "",
+			cfg:    &Config{Workspace: "x", DefaultWorkspace: "dw", DefaultProject: "dp"},
+			wantWs: "x",
+		},
+		{
+			name:   "default project dropped for another workspace",
+			arg:    "other",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp"},
+			wantWs: "other",
+		},
+		{
+			name:   "default project kept when arg names default workspace",
+			arg:    "dw//staging",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp"},
+			wantWs: "dw", wantProj: "dp", wantEnv: "staging",
+		},
+		{
+			name:   "default env fills with default project",
+			arg:    "",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp", DefaultEnv: "de"},
+			wantWs: "dw", wantProj: "dp", wantEnv: "de",
+		},
+		{
+			name:   "default env ignored without default project",
+			arg:    "",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultEnv: "de"},
+			wantWs: "dw",
+		},
+		{
+			name:   "default env dropped for another project",
+			arg:    "dw/other",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp", DefaultEnv: "de"},
+			wantWs: "dw", wantProj: "other",
+		},
+		{
+			name:   "default env dropped for another workspace",
+			arg:    "other",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp", DefaultEnv: "de"},
+			wantWs: "other",
+		},
+		{
+			name:   "default env kept for default project with service",
+			arg:    "dw/dp//web",
+			cfg:    &Config{DefaultWorkspace: "dw", DefaultProject: "dp", DefaultEnv: "de"},
+			wantWs: "dw", wantProj: "dp", wantEnv: "de", wantSvc: "web",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ws, proj, env, svc, err := ResolveServicePath(tt.arg, tt.cfg)
+			ref, err := ResolveServiceRef(tt.arg, tt.cfg)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if ws != tt.wantWs || proj != tt.wantProj || env != tt.wantEnv || svc != tt.wantSvc {
-				t.Errorf("got (%q,%q,%q,%q), want (%q,%q,%q,%q)", ws, proj, env, svc, tt.wantWs, tt.wantProj, tt.wantEnv, tt.wantSvc)
+			want := ServiceRef{Workspace: tt.wantWs, Project: tt.wantProj, Env: tt.wantEnv, Service: tt.wantSvc}
+			if ref != want {
+				t.Errorf("got %+v, want %+v", ref, want)
 			}
 		})
 	}
 }
+
+func TestResolveServiceRefRejectsBadPaths(t *testing.T) {
+	for _, arg := range []string{"a/b/c/d/e", "a/b?x=1", "a/../c"} {
+		if _, err := ResolveServiceRef(arg, &Config{}); err == nil {
+			t.Errorf("ResolveServiceRef(%q): expected an error", arg)
+		}
+	}
+}
+
+func TestServiceRef(t *testing.T) {
+	ref, err := ParseServiceRef("ws/proj/staging/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ref.HasService() || ref.RequireService() != nil {
+		t.Errorf("expected %+v to name a service", ref)
+	}
+	if got := ref.String(); got != "ws/proj/staging/api" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := ref.ServicePath(); got != "/workspaces/ws/projects/proj/envs/staging/services/api" {
+		t.Errorf("ServicePath() = %q", got)
+	}
+
+	env := ServiceRef{Workspace: "ws", Project: "proj", Env: "staging"}
+	if env.HasService() || env.RequireEnv() != nil {
+		t.Errorf("expected %+v to name an environment only", env)
+	}
+	if err := (ServiceRef{Workspace: "ws"}).RequireEnv(); err == nil {
+		t.Error("expected RequireEnv to fail without project and env")
+	}
+}
+
+func TestOrigins(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_SERVER", "")
+	t.Setenv("ANCLA_API_KEY", "")
+	t.Setenv("ANCLA_USERNAME", "env-user")
+
+	globalDir := filepath.Join(tmpHome, ".ancla")
+	os.MkdirAll(globalDir, 0o755)
+	os.WriteFile(filepath.Join(globalDir, "config.yaml"), []byte("api_key: global-key\nemail: a@b.c\n"), 0o644)
+
+	projectDir := filepath.Join(tmpHome, "projects", "myapp")
+	os.MkdirAll(filepath.Join(projectDir, ".ancla"), 0o755)
+	os.WriteFile(filepath.Join(projectDir, ".ancla", "config.yaml"), []byte("org: old-ws\nproject: p\n"), 0o644)
+
+	origDir, _ := os.Getwd()
+	os.Chdir(projectDir)
+	defer os.Chdir(origDir)
+
+	got := Origins("api_key")
+	want := map[string]Source{
+		"server":    SourceDefault,
+		"api_key":   SourceFlag,
+		"username":  SourceEnv,
+		"email":     SourceGlobal,
+		"workspace": SourceLocal, // migrated from "org"
+		"project":   SourceLocal,
+		"env":       SourceUnset,
+		"service":   SourceUnset,
+	}
+	for k, w := range want {
+		if got[k].Source != w {
+			t.Errorf("Origins()[%q] = %q, want %q", k, got[k].Source, w)
+		}
+	}
+	if got["username"].Detail != "ANCLA_USERNAME" {
+		t.Errorf("username detail = %q", got["username"].Detail)
+	}
+}
+
+func TestSettingNormalize(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string
+		wantErr    bool
+	}{
+		{"server", "https://ancla.example.com/", "https://ancla.example.com", false},
+		{"server", "ancla.example.com", "", true},
+		{"output", "JSON", "json", false},
+		{"output", "yaml", "", true},
+		{"color", "never", "never", false},
+		{"api_key", "", "", true},
+		{"default_workspace", "my-ws", "my-ws", false},
+		{"default_workspace", "bad/slug", "", true},
+		{"default_project", "my proj", "", true},
+	}
+	for _, tt := range tests {
+		s, err := LookupSetting(tt.key)
+		if err != nil {
+			t.Fatalf("LookupSetting(%q): %v", tt.key, err)
+		}
+		got, err := s.Normalize(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s=%q: err = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s=%q: got %q, want %q", tt.key, tt.value, got, tt.want)
+		}
+	}
+
+	for _, key := range []string{"username", "telemetry"} {
+		if _, err := LookupSetting(key); err == nil {
+			t.Errorf("expected error for non-editable key %q", key)
+		}
+	}
+}
+
+func TestSave_ConcurrentWritesStayValid(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_SERVER", "")
+	t.Setenv("ANCLA_API_KEY", "")
+
+	origDir, _ := os.Getwd()
+	os.Chdir(tmpHome)
+	defer os.Chdir(origDir)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- Save(&Config{
+				Server:   "https://ancla.example.com",
+				APIKey:   fmt.Sprintf("key-%d", i),
+				Username: strings.Repeat("u", i*50),
+			}, "server", "api_key", "username")
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Save() error: %v", err)
+		}
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() after concurrent saves: %v", err)
+	}
+	if !strings.HasPrefix(loaded.APIKey, "key-") {
+		t.Errorf("APIKey = %q, want one of the written keys", loaded.APIKey)
+	}
+
+	path := filepath.Join(tmpHome, ".ancla", "config.yaml")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm != 0o600 {
+		t.Errorf("global config perm = %o, want 600", perm)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, e := range entries {
+		if e.Name() != "config.yaml" {
+			t.Errorf("leftover file %s", e.Name())
+		}
+	}
+}
+
+func TestSave_WritesOnlyNamedKeys(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_SERVER", "")
+	t.Setenv("ANCLA_API_KEY", "")
+
+	path := filepath.Join(tmpHome, ".ancla", "config.yaml")
+	os.MkdirAll(filepath.Dir(path), 0o700)
+	os.WriteFile(path, []byte("api_key: real-key\n"), 0o600)
+
+	// Each process starts from its own merged view: one picked up a
+	// temporary key from the environment, neither has the other's change.
+	var wg sync.WaitGroup
+	for _, save := range []func() error{
+		func() error { return Save(&Config{APIKey: "ci-temp-key", Output: "json"}, "output") },
+		func() error { return Save(&Config{Server: "http://localhost:9", Color: "never"}, "color") },
+	} {
+		wg.Add(1)
+		go func(save func() error) {
+			defer wg.Done()
+			if err := save(); err != nil {
+				t.Errorf("Save() error: %v", err)
+			}
+		}(save)
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{"api_key: real-key", "output: json", "color: never"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "server:") {
+		t.Errorf("config should not contain server:\n%s", got)
+	}
+
+	if err := Save(&Config{}, "workspace"); err == nil {
+		t.Error("expected error saving a link key globally")
+	}
+}
+
+func TestWorkspaceKeys_SaveAndLoad(t *testing.T) {
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	t.Setenv("ANCLA_SERVER", "")
+	t.Setenv("ANCLA_API_KEY", "")
+	origDir, _ := os.Getwd()
+	os.Chdir(tmpHome)
+	defer os.Chdir(origDir)
+
+	cfg := &Config{Server: "https://ancla.dev"}
+	cfg.SetWorkspaceKey("acme", "acme-key")
+	cfg.SetWorkspaceKey("globex", "globex-key")
+	cfg.SetWorkspaceKey("acme", "acme-key-2")
+	if err := Save(cfg, "workspace_keys"); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := loaded.WorkspaceKey("acme"); got != "acme-key-2" {
+		t.Errorf("WorkspaceKey(acme) = %q, want acme-key-2", got)
+	}
+	if got := loaded.WorkspaceKey("globex"); got != "globex-key" {
+		t.Errorf("WorkspaceKey(globex) = %q, want globex-key", got)
+	}
+	if got := loaded.WorkspaceKey("initech"); got != "" {
+		t.Errorf("WorkspaceKey(initech) = %q, want empty", got)
+	}
+
+	// Keys are scoped to the server they were created on.
+	loaded.Server = "http://localhost:8000/"
+	if got := loaded.WorkspaceKey("acme"); got != "" {
+		t.Errorf("WorkspaceKey on another server = %q, want empty", got)
+	}
+
+	loaded.Server = "https://ancla.dev/"
+	loaded.SetWorkspaceKey("acme", "")
+	loaded.SetWorkspaceKey("globex", "")
+	if err := Save(loaded, "workspace_keys"); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpHome, ".ancla", "config.yaml"))
+	if strings.Contains(string(data), "workspace_keys") {
+		t.Errorf("expected workspace_keys to be removed:\n%s", data)
+	}
+}
+
+func TestSaveLocal_PreservesCommentsAndUnknownKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	os.MkdirAll(filepath.Join(tmpDir, ".ancla"), 0o755)
+	path := filepath.Join(tmpDir, ".ancla", "config.yaml")
+	original := `# Linked by hand for the staging box.
+workspace: old-ws # keep me
+project: old-proj
+hooks:
+  pre_deploy: make test
+org: legacy
+`
+	os.WriteFile(path, []byte(original), 0o644)
+
+	if err := SaveLocal(&Config{Workspace: "new-ws", Env: "staging"}, LinkKeys...); err != nil {
+		t.Fatalf("SaveLocal() error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{
+		"# Linked by hand for the staging box.",
+		"workspace: new-ws # keep me",
+		"hooks:\n  pre_deploy: make test",
+		"env: staging",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config missing %q:\n%s", want, got)
+		}
+	}
+	for _, gone := range []string{"project:", "org:"} {
+		if strings.Contains(got, gone) {
+			t.Errorf("config should not contain %q:\n%s", gone, got)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// reference matches a ${env:NAME} or ${file:path} substitution in a
+// config value.
+var reference = regexp.MustCompile(`\$\{(env|file):([^}]*)\}`)
+
+// ExpandReferences replaces the ${env:NAME} and ${file:path} substitutions
+// in s, reading a relative path from dir. An unset variable or unreadable
+// file is an error rather than an empty value, so a missing secret fails
+// loudly instead of sending a blank key.
+func ExpandReferences(s, dir string) (string, error) {
+	return expandReferences(s, dir, "")
+}
+
+// interpolate replaces the substitutions in the string values of settings,
+// read from a config file in dir, so CI can inject keys and secrets
+// without templating the file. ${env:NAME} is the variable's value and
+// ${file:path} the file's contents without a trailing newline; a relative
+// path is taken from dir. When root is set, ${file:} may only read files
+// inside it.
+//
+// A substitution that can't be expanded is left in place and returned as
+// an error for the caller to warn about, so one missing secret doesn't
+// stop every command, including the ones that would fix it. Modifies the
+// map in place.
+func interpolate(settings map[string]any, dir, root string) []error {
+	var errs []error
+	for key, v := range settings {
+		expanded, err := interpolateValue(v, dir, root)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+		settings[key] = expanded
+	}
+	return errs
+}
+
+func interpolateValue(v any, dir, root string) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return expandReferences(v, dir, root)
+	case map[string]any:
+		return v, errors.Join(interpolate(v, dir, root)...)
+	case []any:
+		var errs []error
+		for i, item := range v {
+			expanded, err := interpolateValue(item, dir, root)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			v[i] = expanded
+		}
+		return v, errors.Join(errs...)
+	}
+	return v, nil
+}
+
+// expandReferences replaces every substitution in s it can. One that
+// names an unset variable, an unreadable file, or a file outside root
+// (when root is set) is kept as written and reported in the error.
+func expandReferences(s, dir, root string) (string, error) {
+	var errs []error
+	out := reference.ReplaceAllStringFunc(s, func(ref string) string {
+		m := reference.FindStringSubmatch(ref)
+		kind, arg := m[1], m[2]
+		switch kind {
+		case "env":
+			val, ok := os.LookupEnv(arg)
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: environment variable %s is not set", ref, arg))
+				return ref
+			}
+			return val
+		default:
+			path := arg
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			if root != "" && !insideDir(path, root) {
+				errs = append(errs, fmt.Errorf("%s: a repository's config may only read files inside %s", ref, root))
+				return ref
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", ref, err))
+				return ref
+			}
+			return strings.TrimRight(string(data), "\r\n")
+		}
+	})
+	return out, errors.Join(errs...)
+}
+
+// insideDir reports whether path, with symlinks resolved, is in root. A
+// path that doesn't resolve is outside, so a link out of the repository
+// can't be followed.
+func insideDir(path, root string) bool {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,37 @@
+//go:build windows
+
+package config
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockDir takes an exclusive lock for the config directory dir and blocks
+// until the lock is available. Windows cannot lock a directory handle, so
+// the lock is held on a hidden file that is deleted when the last handle
+// to it closes. The returned function releases the lock.
+func lockDir(dir string) (func(), error) {
+	name, err := windows.UTF16PtrFromString(filepath.Join(dir, ".lock"))
+	if err != nil {
+		return nil, err
+	}
+	h, err := windows.CreateFile(name,
+		windows.GENERIC_READ|windows.GENERIC_WRITE,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_ALWAYS,
+		windows.FILE_ATTRIBUTE_HIDDEN|windows.FILE_FLAG_DELETE_ON_CLOSE, 0)
+	if err != nil {
+		return nil, err
+	}
+	ol := new(windows.Overlapped)
+	if err := windows.LockFileEx(h, windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, ol); err != nil {
+		windows.CloseHandle(h)
+		return nil, err
+	}
+	return func() {
+		windows.UnlockFileEx(h, 0, 1, 0, ol)
+		windows.CloseHandle(h)
+	}, nil
+}
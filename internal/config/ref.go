@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// slugRe matches a single path segment. It is looser than the server's
+// slug rules; it only keeps out characters that would change the meaning
+// of the API URL a ref is turned into.
+var slugRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ServiceRef names a service by its workspace, project, environment, and
+// service slugs. Trailing fields may be empty, in which case the ref names
+// the environment, project, or workspace above. It mirrors ServiceRef in
+// the Go SDK (sdks/go), which the CLI does not import.
+type ServiceRef struct {
+	Workspace string `json:"workspace"`
+	Project   string `json:"project,omitempty"`
+	Env       string `json:"env,omitempty"`
+	Service   string `json:"service,omitempty"`
+}
+
+// ParseServiceRef parses a "ws[/proj[/env[/svc]]]" path. Empty segments
+// are allowed ("ws//staging") and left empty for the caller to fill in.
+func ParseServiceRef(path string) (ServiceRef, error) {
+	var ref ServiceRef
+	if path == "" {
+		return ref, nil
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) > 4 {
+		return ref, fmt.Errorf("invalid path %q: expected at most ws/proj/env/svc", path)
+	}
+	fields := []*string{&ref.Workspace, &ref.Project, &ref.Env, &ref.Service}
+	for i, p := range parts {
+		*fields[i] = p
+	}
+	return ref, ref.Validate()
+}
+
+// Validate reports a segment that is not a valid slug.
+func (r ServiceRef) Validate() error {
+	for _, seg := range []struct{ kind, slug string }{
+		{"workspace", r.Workspace}, {"project", r.Project}, {"environment", r.Env}, {"service", r.Service},
+	} {
+		if seg.slug != "" && !slugRe.MatchString(seg.slug) {
+			return fmt.Errorf("invalid %s slug %q", seg.kind, seg.slug)
+		}
+	}
+	return nil
+}
+
+// HasEnv reports whether the ref names an environment.
+func (r ServiceRef) HasEnv() bool {
+	return r.Workspace != "" && r.Project != "" && r.Env != ""
+}
+
+// HasService reports whether the ref names a service.
+func (r ServiceRef) HasService() bool {
+	return r.HasEnv() && r.Service != ""
+}
+
+// RequireEnv returns an error unless the ref names an environment.
+func (r ServiceRef) RequireEnv() error {
+	if !r.HasEnv() {
+		return fmt.Errorf("no environment specified — provide <ws>/<proj>/<env> or run `ancla link` first")
+	}
+	return nil
+}
+
+// RequireService returns an error unless the ref names a service.
+func (r ServiceRef) RequireService() error {
+	if !r.HasService() {
+		return fmt.Errorf("no service specified — provide <ws>/<proj>/<env>/<svc> or run `ancla link` first")
+	}
+	return nil
+}
+
+// String returns the ref as a slash-separated path, stopping at the first
+// empty segment.
+func (r ServiceRef) String() string {
+	var parts []string
+	for _, s := range []string{r.Workspace, r.Project, r.Env, r.Service} {
+		if s == "" {
+			break
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, "/")
+}
+
+// ProjectPath returns the API path of the project.
+func (r ServiceRef) ProjectPath() string {
+	return "/workspaces/" + r.Workspace + "/projects/" + r.Project
+}
+
+// EnvPath returns the API path of the environment.
+func (r ServiceRef) EnvPath() string {
+	return r.ProjectPath() + "/envs/" + r.Env
+}
+
+// ServicesPath returns the API path of the environment's service
+// collection, with a trailing slash.
+func (r ServiceRef) ServicesPath() string {
+	return r.EnvPath() + "/services/"
+}
+
+// ServicePath returns the API path of the service.
+func (r ServiceRef) ServicePath() string {
+	return r.ServicesPath() + r.Service
+}
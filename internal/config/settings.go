@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Setting describes a user-editable key in the global config file.
+type Setting struct {
+	Key         string
+	Description string
+	Allowed     []string // permitted values; empty means free-form
+	Secret      bool     // mask when displayed
+}
+
+// Settings lists the keys `ancla settings get/set/unset` accept.
+var Settings = []Setting{
+	{Key: "server", Description: "Ancla server URL"},
+	{Key: "api_key", Description: "API key used for authentication", Secret: true},
+	{Key: "output", Description: "Default output format", Allowed: []string{"table", "json"}},
+	{Key: "color", Description: "Color output", Allowed: []string{"auto", "always", "never"}},
+	{Key: "time", Description: "Timestamps in tables: relative (3m ago) or absolute (RFC 3339)", Allowed: []string{"relative", "absolute"}},
+	{Key: "poll_interval", Description: "Delay between status polls when following (e.g. 5s)"},
+	{Key: "max_wait", Description: "Give up following after this long (e.g. 45m, 0 for no limit)"},
+	{Key: "read_only", Description: "Refuse to send requests that change anything", Allowed: []string{"true", "false"}},
+	{Key: "github_token", Description: "GitHub token for posting deploy statuses on commits", Secret: true},
+	{Key: "default_workspace", Description: "Workspace used outside linked directories"},
+	{Key: "default_project", Description: "Project used with the default workspace"},
+	{Key: "default_env", Description: "Environment used with the default project"},
+}
+
+// LookupSetting returns the setting with the given key.
+func LookupSetting(key string) (Setting, error) {
+	for _, s := range Settings {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+	keys := make([]string, len(Settings))
+	for i, s := range Settings {
+		keys[i] = s.Key
+	}
+	return Setting{}, fmt.Errorf("unknown setting %q (valid: %s)", key, strings.Join(keys, ", "))
+}
+
+// Normalize validates value for this setting and returns its canonical
+// form (e.g. "JSON" → "json" for output).
+func (s Setting) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch s.Key {
+	case "server":
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return "", fmt.Errorf("invalid server %q — expected a URL like https://ancla.dev", value)
+		}
+		return strings.TrimRight(value, "/"), nil
+	case "poll_interval", "max_wait":
+		d, err := time.ParseDuration(value)
+		if value == "0" {
+			d, err = 0, nil
+		}
+		if err != nil || d < 0 || (d == 0 && s.Key == "poll_interval") {
+			return "", fmt.Errorf("invalid %s %q — expected a duration like 5s or 10m", s.Key, value)
+		}
+		return value, nil
+	case "default_workspace", "default_project", "default_env":
+		if strings.ContainsAny(value, "/ \t") {
+			return "", fmt.Errorf("invalid %s %q — expected a single slug without slashes or spaces", s.Key, value)
+		}
+	}
+	if len(s.Allowed) == 0 {
+		if value == "" {
+			return "", fmt.Errorf("%s cannot be empty — use `ancla settings unset %s`", s.Key, s.Key)
+		}
+		return value, nil
+	}
+	v := strings.ToLower(value)
+	for _, a := range s.Allowed {
+		if v == a {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s %q (valid: %s)", s.Key, value, strings.Join(s.Allowed, ", "))
+}
+
+// Get returns the value of a config key (see Keys).
+func (c *Config) Get(key string) string {
+	switch key {
+	case "server":
+		return c.Server
+	case "api_key":
+		return c.APIKey
+	case "username":
+		return c.Username
+	case "email":
+		return c.Email
+	case "output":
+		return c.Output
+	case "color":
+		return c.Color
+	case "time":
+		return c.Time
+	case "poll_interval":
+		return c.PollInterval
+	case "max_wait":
+		return c.MaxWait
+	case "read_only":
+		if c.ReadOnly {
+			return "true"
+		}
+		return ""
+	case "github_token":
+		return c.GitHubToken
+	case "default_workspace":
+		return c.DefaultWorkspace
+	case "default_project":
+		return c.DefaultProject
+	case "default_env":
+		return c.DefaultEnv
+	case "workspace":
+		return c.Workspace
+	case "project":
+		return c.Project
+	case "env":
+		return c.Env
+	case "service":
+		return c.Service
+	}
+	return ""
+}
+
+// Set assigns a setting key. Callers should validate with Normalize first.
+func (c *Config) Set(key, value string) {
+	switch key {
+	case "server":
+		c.Server = value
+	case "api_key":
+		c.APIKey = value
+	case "output":
+		c.Output = value
+	case "color":
+		c.Color = value
+	case "time":
+		c.Time = value
+	case "poll_interval":
+		c.PollInterval = value
+	case "max_wait":
+		c.MaxWait = value
+	case "read_only":
+		c.ReadOnly = value == "true"
+	case "github_token":
+		c.GitHubToken = value
+	case "default_workspace":
+		c.DefaultWorkspace = value
+	case "default_project":
+		c.DefaultProject = value
+	case "default_env":
+		c.DefaultEnv = value
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Source identifies which layer supplied an effective setting.
+type Source string
+
+const (
+	SourceFlag    Source = "flag"
+	SourceEnv     Source = "env"
+	SourceLocal   Source = "local file"
+	SourceGlobal  Source = "global file"
+	SourceDefault Source = "default"
+	SourceUnset   Source = "unset"
+)
+
+// Keys lists every setting Load understands, in display order.
+var Keys = []string{"server", "api_key", "username", "email", "output", "color", "time", "poll_interval", "max_wait", "read_only", "github_token", "default_workspace", "default_project", "default_env", "workspace", "project", "env", "service"}
+
+// Origin describes where a single setting was resolved from. Detail is
+// the file path or environment variable name, when there is one.
+type Origin struct {
+	Source Source
+	Detail string
+}
+
+// Origins reports the source of each key in Keys following the same
+// precedence as Load. flagged names keys that were overridden by a CLI
+// flag; Load does not see flags, so the caller must supply them.
+func Origins(flagged ...string) map[string]Origin {
+	globalPath, localPath := Paths()
+	global := readSettings(globalPath)
+	local := readSettings(localPath)
+	remapOldKeys(local)
+
+	out := make(map[string]Origin, len(Keys))
+	for _, key := range Keys {
+		envName := "ANCLA_" + strings.ToUpper(key)
+		switch {
+		case contains(flagged, key):
+			out[key] = Origin{Source: SourceFlag, Detail: "--" + strings.ReplaceAll(key, "_", "-")}
+		case os.Getenv(envName) != "":
+			out[key] = Origin{Source: SourceEnv, Detail: envName}
+		case hasKey(local, key):
+			out[key] = Origin{Source: SourceLocal, Detail: localPath}
+		case hasKey(global, key):
+			out[key] = Origin{Source: SourceGlobal, Detail: globalPath}
+		case key == "server":
+			out[key] = Origin{Source: SourceDefault}
+		default:
+			out[key] = Origin{Source: SourceUnset}
+		}
+	}
+	return out
+}
+
+// readSettings reads a single YAML config file into a map. A missing or
+// unreadable file yields an empty map.
+func readSettings(path string) map[string]any {
+	if path == "" {
+		return map[string]any{}
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return map[string]any{}
+	}
+	return v.AllSettings()
+}
+
+func hasKey(settings map[string]any, key string) bool {
+	v, ok := settings[key]
+	return ok && v != nil && v != ""
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
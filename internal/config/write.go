@@ -0,0 +1,157 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeConfig applies updates to the YAML file at path. Keys with a nil
+// value are removed; everything else in the file — unknown keys, comments,
+// ordering — is left as the user wrote it. The file is read, updated, and
+// replaced while holding an advisory lock on its directory, so concurrent
+// ancla processes each apply their updates on top of the other's. The
+// write goes through a temp file + rename so readers never observe a
+// partially written config.
+func writeConfig(path string, updates map[string]any, perm os.FileMode) error {
+	unlock, err := lockDir(filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("locking config: %w", err)
+	}
+	defer unlock()
+
+	doc, err := readConfigNode(path)
+	if err != nil {
+		return err
+	}
+	if err := applyUpdates(doc.Content[0], updates); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	return writeFileAtomic(path, buf.Bytes(), perm)
+}
+
+// readConfigNode parses path into a YAML document node whose root is a
+// mapping. A missing or empty file yields an empty mapping.
+func readConfigNode(path string) (*yaml.Node, error) {
+	empty := &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return empty, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		// Comments-only or blank file: keep any head comment.
+		empty.HeadComment = doc.HeadComment
+		return empty, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s is not a YAML mapping — fix or remove it", path)
+	}
+	return &doc, nil
+}
+
+// applyUpdates sets or removes top-level keys in a mapping node. Existing
+// keys are updated in place, keeping their comments; new keys are
+// appended in sorted order.
+func applyUpdates(m *yaml.Node, updates map[string]any) error {
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := updates[key]
+		idx := -1
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			if m.Content[i].Value == key {
+				idx = i
+				break
+			}
+		}
+		if value == nil {
+			if idx >= 0 {
+				// A comment above the removed key usually introduces the
+				// file or section, so hand it to whatever follows.
+				if hc := m.Content[idx].HeadComment; hc != "" && idx+2 < len(m.Content) {
+					next := m.Content[idx+2]
+					next.HeadComment = strings.TrimSpace(hc + "\n" + next.HeadComment)
+				}
+				m.Content = append(m.Content[:idx], m.Content[idx+2:]...)
+			}
+			continue
+		}
+		var n yaml.Node
+		if err := n.Encode(value); err != nil {
+			return fmt.Errorf("encoding %s: %w", key, err)
+		}
+		if idx >= 0 {
+			old := m.Content[idx+1]
+			n.HeadComment, n.LineComment, n.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			*old = n
+			continue
+		}
+		m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &n)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temp file in the same directory, syncs
+// it, and renames it over path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	cleanup := func() { os.Remove(tmpName) }
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("writing config: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("setting config permissions: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		cleanup()
+		return fmt.Errorf("syncing config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return fmt.Errorf("closing config: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		cleanup()
+		return fmt.Errorf("replacing config: %w", err)
+	}
+	return nil
+}
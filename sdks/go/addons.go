@@ -0,0 +1,37 @@
+package ancla
+
+import "context"
+
+// addonsPath builds the base path for the addons attached to a service.
+func addonsPath(ws, proj, env, svc string) string {
+	return servicePath(ws, proj, env) + svc + "/addons/"
+}
+
+// ListAddons returns the databases and caches attached to a service.
+func (c *Client) ListAddons(ctx context.Context, ws, proj, env, svc string) ([]Addon, error) {
+	var addons []Addon
+	if err := c.do(ctx, "GET", addonsPath(ws, proj, env, svc), nil, &addons); err != nil {
+		return nil, err
+	}
+	return addons, nil
+}
+
+// CreateAddon provisions a database or cache and attaches it to a service.
+// Provisioning is asynchronous; poll ListAddons until Status is "ready".
+func (c *Client) CreateAddon(ctx context.Context, ws, proj, env, svc string, req CreateAddonRequest) (*Addon, error) {
+	var addon Addon
+	if err := c.do(ctx, "POST", addonsPath(ws, proj, env, svc), req, &addon); err != nil {
+		return nil, err
+	}
+	return &addon, nil
+}
+
+// GetAddonCredentials returns the connection details of an addon. The
+// result contains secrets and should not be logged.
+func (c *Client) GetAddonCredentials(ctx context.Context, ws, proj, env, svc, addonID string) (*AddonCredentials, error) {
+	var creds AddonCredentials
+	if err := c.do(ctx, "GET", addonsPath(ws, proj, env, svc)+addonID+"/credentials", nil, &creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
@@ -5,16 +5,22 @@
 //
 //	client := ancla.New("your-api-key")
 //	workspaces, err := client.ListWorkspaces(ctx)
+//
+// Every endpoint is also available, generated from the API's OpenAPI spec,
+// through the lower-level client that API returns.
 package ancla
 
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/sidequest-labs/ancla-go/api"
 )
 
 const defaultServer = "https://ancla.dev"
@@ -80,6 +86,46 @@ func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return t.base.RoundTrip(req)
 }
 
+// API returns the generated low-level client, which has a method for
+// every endpoint in the API's OpenAPI spec, including those Client has no
+// method for yet. Its requests go through c: they use c's server, API key,
+// and HTTP client, and fail with an *APIError.
+func (c *Client) API() *api.Client {
+	return api.New(apiDoer{c})
+}
+
+// apiDoer sends the api package's requests with a Client.
+type apiDoer struct {
+	c *Client
+}
+
+func (d apiDoer) Do(ctx context.Context, method, path string, body, dst any) error {
+	return d.c.doURL(ctx, method, d.c.server+path, body, dst)
+}
+
+// idempotencyKeyCtx is the context key WithIdempotencyKey stores under.
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a copy of ctx whose mutating requests send
+// key as their Idempotency-Key, instead of a new random key per call.
+// Reuse it when retrying a call whose outcome is unknown, such as after a
+// timeout, and the server acts on it only once:
+//
+//	ctx := ancla.WithIdempotencyKey(ctx, "deploy-"+commitSHA)
+//	res, err := client.TriggerDeploy(ctx, ref, ancla.DeployOptions{})
+//	// on a timeout, calling TriggerDeploy with ctx again is safe
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// idempotencyKey returns the key for a mutating request made with ctx.
+func idempotencyKey(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyCtx{}).(string); ok && key != "" {
+		return key
+	}
+	return rand.Text()
+}
+
 // apiURL returns the full API v1 URL for the given path.
 func (c *Client) apiURL(path string) string {
 	return c.server + "/api/v1" + path
@@ -88,6 +134,11 @@ func (c *Client) apiURL(path string) string {
 // do performs an HTTP request and decodes the JSON response into dst.
 // If dst is nil, the response body is discarded (useful for DELETE/POST with no response body).
 func (c *Client) do(ctx context.Context, method, path string, body any, dst any) error {
+	return c.doURL(ctx, method, c.apiURL(path), body, dst)
+}
+
+// doURL is do for endpoints outside the versioned API, such as /api/keys.
+func (c *Client) doURL(ctx context.Context, method, url string, body any, dst any) error {
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -97,13 +148,16 @@ func (c *Client) do(ctx context.Context, method, path string, body any, dst any)
 		bodyReader = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.apiURL(path), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if method != "GET" && method != "HEAD" {
+		req.Header.Set("Idempotency-Key", idempotencyKey(ctx))
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
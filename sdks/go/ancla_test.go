@@ -3,9 +3,14 @@ package ancla
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/sidequest-labs/ancla-go/api"
 )
 
 // newTestClient creates a Client pointed at the given httptest.Server.
@@ -59,6 +64,70 @@ func TestAPIKeyHeader(t *testing.T) {
 	}
 }
 
+func TestIdempotencyKeyHeader(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(200)
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	ref := ServiceRef{Workspace: "ws", Project: "proj", Env: "prod", Service: "web"}
+	ctx := context.Background()
+	_, _ = c.TriggerDeploy(ctx, ref, DeployOptions{})
+	_, _ = c.TriggerDeploy(ctx, ref, DeployOptions{})
+	_, _ = c.GetWorkspace(ctx, "ws")
+	if keys[0] == "" || keys[1] == "" || keys[0] == keys[1] {
+		t.Errorf("expected a new key per mutating call, got %q", keys[:2])
+	}
+	if keys[2] != "" {
+		t.Errorf("expected no key on GET, got %q", keys[2])
+	}
+
+	pinned := WithIdempotencyKey(ctx, "deploy-abc123")
+	_, _ = c.TriggerDeploy(pinned, ref, DeployOptions{})
+	_, _ = c.TriggerDeploy(pinned, ref, DeployOptions{})
+	if keys[3] != "deploy-abc123" || keys[4] != "deploy-abc123" {
+		t.Errorf("expected the pinned key on both calls, got %q", keys[3:])
+	}
+}
+
+func TestAPIGoesThroughClient(t *testing.T) {
+	var gotPath, gotKey string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotKey = r.URL.RequestURI(), r.Header.Get("X-API-Key")
+		if r.URL.Query().Get("env") == "missing" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{"build":{"status":"success"}}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	ctx := context.Background()
+	st, err := c.API().PipelineStatus(ctx, "ws", "my proj", api.PipelineStatusParams{Service: "web", Env: "prod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/api/v1/workspaces/ws/projects/my%20proj/pipeline/status?env=prod&service=web"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if gotKey != "test-api-key" {
+		t.Errorf("X-API-Key = %q, want the client's key", gotKey)
+	}
+	if st.Build == nil || st.Build.Status != "success" || st.Deploy != nil {
+		t.Errorf("status = %+v, want a successful build and no deploy", st)
+	}
+
+	_, err = c.API().PipelineStatus(ctx, "ws", "proj", api.PipelineStatusParams{Service: "web", Env: "missing"})
+	if !IsNotFound(err) {
+		t.Errorf("expected a not-found *APIError, got %v", err)
+	}
+}
+
 // --- Workspace CRUD tests ---
 
 func TestListWorkspaces(t *testing.T) {
@@ -191,6 +260,113 @@ func TestDeleteWorkspace(t *testing.T) {
 	}
 }
 
+func TestResendInvitation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/workspaces/acme/invitations/inv1/resend" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(WorkspaceInvitation{ID: "inv1", Email: "bob@example.com", ExpiresAt: "2026-10-23T00:00:00Z"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	inv, err := c.ResendInvitation(context.Background(), "acme", "inv1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.Email != "bob@example.com" || inv.Expired {
+		t.Errorf("unexpected invitation %+v", inv)
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v1/workspaces/acme/invitations/inv1" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	if err := c.RevokeInvitation(context.Background(), "acme", "inv1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// --- Auth tests ---
+
+func TestGetCurrentUser(t *testing.T) {
+	authenticated := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/session" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if !authenticated {
+			json.NewEncoder(w).Encode(Session{})
+			return
+		}
+		json.NewEncoder(w).Encode(Session{Authenticated: true, User: &User{Username: "ci-bot", Email: "ci@example.com"}})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	u, err := c.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Username != "ci-bot" {
+		t.Errorf("unexpected user %+v", u)
+	}
+
+	authenticated = false
+	if _, err := c.GetCurrentUser(context.Background()); !IsUnauthorized(err) {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	var created CreateAPIKeyRequest
+	var revoked string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/keys":
+			json.NewEncoder(w).Encode([]APIKey{
+				{ID: "key_1", Name: "deploy", Scopes: []string{"deploy"}, Metadata: map[string]string{"project": "acme/web"}},
+			})
+		case r.Method == "POST" && r.URL.Path == "/api/keys":
+			json.NewDecoder(r.Body).Decode(&created)
+			json.NewEncoder(w).Encode(APIKey{ID: "key_2", Key: "secret", Name: created.Name})
+		case r.Method == "POST" && r.URL.Path == "/api/keys/key_1/revoke":
+			revoked = "key_1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	key, err := c.RotateAPIKey(context.Background(), "key_1", "2027-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != "key_2" || key.Key != "secret" {
+		t.Errorf("unexpected key %+v", key)
+	}
+	if created.Name != "deploy" || created.Metadata["project"] != "acme/web" || created.ExpiresAt != "2027-01-01T00:00:00Z" {
+		t.Errorf("new key created with %+v", created)
+	}
+	if revoked != "key_1" {
+		t.Error("old key was not revoked")
+	}
+
+	if _, err := c.RotateAPIKey(context.Background(), "key_9", ""); !IsNotFound(err) {
+		t.Errorf("expected not found for an unknown key, got %v", err)
+	}
+}
+
 // --- Service and Config tests ---
 
 func TestListServices(t *testing.T) {
@@ -215,6 +391,179 @@ func TestListServices(t *testing.T) {
 	}
 }
 
+func TestGetServiceAutoscaling(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"slug": "web",
+			"processes": {"web": {"desired": 3, "running": 2, "size": "standard-1x", "cpu_limit": 1, "memory_limit_mb": 512}},
+			"autoscaling": {
+				"enabled": true,
+				"policies": {"web": {"min_replicas": 2, "max_replicas": 6, "target_cpu_percent": 70}},
+				"last_scale_event": {"process": "web", "from": 2, "to": 3, "reason": "cpu", "at": "2026-01-02T03:04:05Z"}
+			}
+		}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	svc, err := c.GetService(context.Background(), "acme", "myproj", "production", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	web := svc.Processes["web"]
+	if web.Desired != 3 || web.Running != 2 || web.MemoryLimitMB != 512 {
+		t.Errorf("unexpected process state: %+v", web)
+	}
+	if svc.Autoscaling == nil || !svc.Autoscaling.Enabled {
+		t.Fatalf("expected autoscaling enabled, got %+v", svc.Autoscaling)
+	}
+	if p := svc.Autoscaling.Policies["web"]; p.MaxReplicas != 6 || p.TargetCPUPercent != 70 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+	if ev := svc.Autoscaling.LastScaleEvent; ev == nil || ev.To != 3 {
+		t.Errorf("unexpected last scale event: %+v", ev)
+	}
+}
+
+func TestPatchService(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 2 {
+			t.Errorf("expected exactly 2 fields, got %+v", body)
+		}
+		if body["name"] != "API" {
+			t.Errorf("expected name %q, got %v", "API", body["name"])
+		}
+		if v, ok := body["github_repository"]; !ok || v != nil {
+			t.Errorf("expected github_repository to be null, got %v (present=%v)", v, ok)
+		}
+		json.NewEncoder(w).Encode(Service{Name: "API", Slug: "web"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	upd := NewServiceUpdate().SetName("API").ClearGithubRepository()
+	result, err := c.PatchService(context.Background(), "acme", "myproj", "production", "web", upd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Name != "API" {
+		t.Errorf("expected name %q, got %q", "API", result.Name)
+	}
+}
+
+func TestUpdateServiceBuildStrategy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["build_strategy"] != BuildStrategyBuildpack {
+			t.Errorf("unexpected body %+v", body)
+		}
+		json.NewEncoder(w).Encode(Service{Slug: "web", BuildStrategy: BuildStrategyBuildpack})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	strategy := BuildStrategyBuildpack
+	svc, err := c.UpdateService(context.Background(), "acme", "myproj", "production", "web", UpdateServiceOptions{BuildStrategy: &strategy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.BuildStrategy != BuildStrategyBuildpack {
+		t.Errorf("unexpected build strategy %q", svc.BuildStrategy)
+	}
+}
+
+func TestPatchServiceEmpty(t *testing.T) {
+	c := New("k")
+	if _, err := c.PatchService(context.Background(), "acme", "myproj", "production", "web", NewServiceUpdate()); err == nil {
+		t.Fatal("expected error for empty update")
+	}
+}
+
+func TestListServicesBySelector(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("label_selector"); got != "team=payments,tier!=batch" {
+			t.Errorf("unexpected label_selector %q", got)
+		}
+		fmt.Fprint(w, `[{"slug": "api", "labels": {"team": "payments"}}]`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	result, err := c.ListServicesBySelector(context.Background(), "acme", "myproj", "production", "team=payments,tier!=batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0].Labels["team"] != "payments" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestLabelService(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"slug": "web", "labels": {"team": "search", "canary": "true", "tier": "web"}}`)
+			return
+		}
+		var body struct {
+			Labels map[string]string `json:"labels"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]string{"team": "payments", "tier": "web"}
+		if len(body.Labels) != len(want) || body.Labels["team"] != "payments" || body.Labels["tier"] != "web" {
+			t.Errorf("expected labels %v, got %v", want, body.Labels)
+		}
+		json.NewEncoder(w).Encode(Service{Slug: "web", Labels: body.Labels})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	svc, err := c.LabelService(context.Background(), "acme", "myproj", "production", "web",
+		map[string]string{"team": "payments"}, "canary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.Labels["team"] != "payments" {
+		t.Errorf("unexpected labels %v", svc.Labels)
+	}
+}
+
+func TestGetPipelineStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/pipeline/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("service") != "web" || q.Get("env") != "production" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{
+			"build": {"id": "b2", "version": 12, "status": "success"},
+			"deploy": {"id": "d2", "build_id": "b2", "status": "deploying"}
+		}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	status, err := c.GetPipelineStatus(context.Background(), "acme", "myproj", "production", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Build.Succeeded() || status.Build.Version != 12 {
+		t.Errorf("unexpected build stage: %+v", status.Build)
+	}
+	if status.Deploy.Done() || status.Deploy.BuildID != "b2" {
+		t.Errorf("unexpected deploy stage: %+v", status.Deploy)
+	}
+}
+
 func TestScaleService(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -257,7 +606,7 @@ func TestSetConfig(t *testing.T) {
 	defer ts.Close()
 
 	c := newTestClient(t, ts)
-	result, err := c.SetConfig(context.Background(), "acme", "myproj", "production", "web", "DB_URL", "postgres://localhost", false)
+	result, err := c.SetConfig(context.Background(), ScopeService, "acme", "myproj", "production", "web", "DB_URL", "postgres://localhost", false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -266,6 +615,90 @@ func TestSetConfig(t *testing.T) {
 	}
 }
 
+func TestConfigScopes(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+	c := newTestClient(t, ts)
+	ctx := context.Background()
+
+	for _, scope := range []Scope{ScopeWorkspace, ScopeProject, ScopeEnvironment} {
+		if _, err := c.ListConfig(ctx, scope, "acme", "myproj", "production", ""); err != nil {
+			t.Fatalf("ListConfig(%s): %v", scope, err)
+		}
+	}
+	want := []string{
+		"/api/v1/workspaces/acme/config/",
+		"/api/v1/workspaces/acme/projects/myproj/config/",
+		"/api/v1/workspaces/acme/projects/myproj/envs/production/config/",
+	}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	paths = nil
+	if _, err := c.ListConfig(ctx, "services", "acme", "myproj", "production", "web"); !errors.Is(err, ErrInvalidScope) {
+		t.Errorf("unknown scope: err = %v, want ErrInvalidScope", err)
+	}
+	if _, err := c.SetConfig(ctx, ScopeService, "acme", "myproj", "production", "", "K", "v", false); err == nil {
+		t.Error("service scope without a service slug was accepted")
+	}
+	if len(paths) > 0 {
+		t.Errorf("invalid calls sent requests: %v", paths)
+	}
+}
+
+func TestCreateAddon(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/addons/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var body CreateAddonRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Kind != "database" || body.Engine != "postgres" || body.Plan != "standard-1" {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		json.NewEncoder(w).Encode(Addon{ID: "a1", Kind: "database", Engine: "postgres", Version: "16", Status: "provisioning"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	addon, err := c.CreateAddon(context.Background(), "acme", "myproj", "production", "web", CreateAddonRequest{
+		Kind: "database", Engine: "postgres", Plan: "standard-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addon.ID != "a1" || addon.Version != "16" {
+		t.Errorf("unexpected addon: %+v", addon)
+	}
+}
+
+func TestGetAddonCredentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/addons/a1/credentials" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"connection_url":"redis://:pw@cache.internal:6379","host":"cache.internal","port":6379,"password":"pw"}`))
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	creds, err := c.GetAddonCredentials(context.Background(), "acme", "myproj", "production", "web", "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.ConnectionURL != "redis://:pw@cache.internal:6379" || creds.Port != 6379 {
+		t.Errorf("unexpected credentials: %+v", creds)
+	}
+}
+
 func TestGetDeploy(t *testing.T) {
 	dpl := Deploy{
 		ID:       "dep-1",
@@ -281,7 +714,8 @@ func TestGetDeploy(t *testing.T) {
 	defer ts.Close()
 
 	c := newTestClient(t, ts)
-	result, err := c.GetDeploy(context.Background(), "ws", "proj", "env", "dep-1")
+	ref := ServiceRef{Workspace: "ws", Project: "proj", Env: "env", Service: "web"}
+	result, err := c.GetDeploy(context.Background(), ref, "dep-1")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -387,3 +821,231 @@ func TestAPIErrorString(t *testing.T) {
 		t.Errorf("expected %q, got %q", expected2, err2.Error())
 	}
 }
+
+func TestAPIErrorIsSentinel(t *testing.T) {
+	cases := []struct {
+		status int
+		target error
+	}{
+		{404, ErrNotFound},
+		{401, ErrUnauthorized},
+		{403, ErrForbidden},
+		{429, ErrRateLimited},
+	}
+	for _, tc := range cases {
+		wrapped := fmt.Errorf("fetching service: %w", &APIError{StatusCode: tc.status})
+		if !errors.Is(wrapped, tc.target) {
+			t.Errorf("status %d: expected errors.Is(%v) to match", tc.status, tc.target)
+		}
+	}
+
+	if errors.Is(&APIError{StatusCode: 500}, ErrNotFound) {
+		t.Error("500 should not match ErrNotFound")
+	}
+	if !IsRateLimited(fmt.Errorf("wrap: %w", &APIError{StatusCode: 429})) {
+		t.Error("expected IsRateLimited to match wrapped 429")
+	}
+
+	var apiErr *APIError
+	if !errors.As(fmt.Errorf("wrap: %w", &APIError{StatusCode: 404}), &apiErr) || apiErr.StatusCode != 404 {
+		t.Error("expected errors.As to recover *APIError")
+	}
+}
+
+func TestParseServiceRef(t *testing.T) {
+	ref, err := ParseServiceRef("acme/myproj/production/web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ServiceRef{Workspace: "acme", Project: "myproj", Env: "production", Service: "web"}
+	if ref != want {
+		t.Errorf("got %+v, want %+v", ref, want)
+	}
+	if ref.String() != "acme/myproj/production/web" {
+		t.Errorf("String() = %q", ref.String())
+	}
+	if got := ref.ServicePath(); got != "/workspaces/acme/projects/myproj/envs/production/services/web" {
+		t.Errorf("ServicePath() = %q", got)
+	}
+	for _, bad := range []string{"acme/myproj/production", "acme//production/web", "a/b/c/d/e", "acme/myproj/production/web?x=1"} {
+		if _, err := ParseServiceRef(bad); err == nil {
+			t.Errorf("ParseServiceRef(%q): expected error", bad)
+		}
+	}
+}
+
+func TestGetServiceByID(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/resources/svc_123":
+			json.NewEncoder(w).Encode(map[string]string{
+				"id": "svc_123", "kind": "service", "slug": "web",
+				"workspace_slug": "acme", "project_slug": "myproj", "env_slug": "production", "service_slug": "web",
+			})
+		case "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web":
+			json.NewEncoder(w).Encode(Service{ID: "svc_123", Slug: "web"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	svc, err := c.GetServiceByID(context.Background(), "svc_123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.ID != "svc_123" {
+		t.Errorf("unexpected service: %+v", svc)
+	}
+}
+
+func TestResolveRejectsOtherKinds(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"id": "prj_1", "kind": "project", "slug": "myproj", "workspace_slug": "acme"})
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	if _, err := c.Resolve(context.Background(), "prj_1"); err == nil {
+		t.Fatal("expected an error resolving a project ID as a service")
+	}
+}
+
+func TestTriggerBuild(t *testing.T) {
+	var gotBody map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/builds/trigger" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		gotBody = nil
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		fmt.Fprint(w, `{"build_id": "b3", "version": 13}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	ref := ServiceRef{Workspace: "acme", Project: "myproj", Env: "production", Service: "web"}
+	result, err := c.TriggerBuild(context.Background(), ref, BuildStrategyBuildpack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.BuildID != "b3" || result.Version != 13 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if gotBody["strategy"] != BuildStrategyBuildpack {
+		t.Errorf("unexpected body: %v", gotBody)
+	}
+
+	if _, err := c.TriggerBuild(context.Background(), ref, ""); err != nil {
+		t.Fatal(err)
+	}
+	if gotBody != nil {
+		t.Errorf("expected no body without a strategy, got %v", gotBody)
+	}
+
+	if _, err := c.TriggerBuild(context.Background(), ServiceRef{Workspace: "acme"}, ""); err == nil {
+		t.Error("expected an error for an incomplete ref")
+	}
+}
+
+func TestListBuildsAndLog(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/builds/":
+			fmt.Fprint(w, `{"items": [
+				{"id": "b3", "version": 13, "built": false, "error": false},
+				{"id": "b2", "version": 12, "built": true, "strategy": "buildpack"},
+				{"id": "b1", "version": 11, "built": false, "error": true}
+			]}`)
+		case "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/builds/12/log":
+			fmt.Fprint(w, `{"status": "success", "version": 12, "log_text": "done\n"}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	ref := ServiceRef{Workspace: "acme", Project: "myproj", Env: "production", Service: "web"}
+	builds, err := c.ListBuilds(context.Background(), ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BuildStatus{BuildStatusBuilding, BuildStatusSuccess, BuildStatusError}
+	for i, b := range builds.Items {
+		if b.Status() != want[i] {
+			t.Errorf("build %s: status %q, want %q", b.ID, b.Status(), want[i])
+		}
+	}
+	if builds.Items[1].Strategy != BuildStrategyBuildpack {
+		t.Errorf("unexpected strategy %q", builds.Items[1].Strategy)
+	}
+
+	log, err := c.GetBuildLog(context.Background(), ref, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if log.Status != BuildStatusSuccess || !log.Status.Done() || log.LogText != "done\n" {
+		t.Errorf("unexpected log: %+v", log)
+	}
+}
+
+func TestTriggerDeploy(t *testing.T) {
+	var gotBody map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/deploy" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		gotBody = nil
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		fmt.Fprint(w, `{"deploy_id": "d4", "status": "deploying"}`)
+	}))
+	defer ts.Close()
+
+	c := newTestClient(t, ts)
+	ref := ServiceRef{Workspace: "acme", Project: "myproj", Env: "production", Service: "web"}
+	result, err := c.TriggerDeploy(context.Background(), ref, DeployOptions{ConfigOnly: true, Message: "rotate keys"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DeployID != "d4" || result.BuildID != "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(gotBody) != 2 || gotBody["config_only"] != true || gotBody["message"] != "rotate keys" {
+		t.Errorf("unexpected body: %v", gotBody)
+	}
+
+	if _, err := c.TriggerDeploy(context.Background(), ref, DeployOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if gotBody != nil {
+		t.Errorf("expected no body without options, got %v", gotBody)
+	}
+}
+
+func TestListDeploys(t *testing.T) {
+	for _, body := range []string{
+		`[{"id": "d2", "complete": true}, {"id": "d1", "error": true}]`,
+		`{"items": [{"id": "d2", "complete": true}, {"id": "d1", "error": true}]}`,
+	} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/workspaces/acme/projects/myproj/envs/production/services/web/deploys/" {
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+			fmt.Fprint(w, body)
+		}))
+
+		c := newTestClient(t, ts)
+		ref := ServiceRef{Workspace: "acme", Project: "myproj", Env: "production", Service: "web"}
+		deploys, err := c.ListDeploys(context.Background(), ref)
+		ts.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deploys.Items) != 2 || !deploys.Items[0].Complete || !deploys.Items[1].Error {
+			t.Errorf("unexpected deploys from %s: %+v", body, deploys.Items)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package api is the low-level Ancla API client, generated from the
+// OpenAPI spec: one method per endpoint, with the spec's request and
+// response types. It covers new endpoints as soon as the spec has them.
+//
+// Most programs want the ergonomic client in the parent package instead,
+// and reach this one through its API method for endpoints it doesn't wrap
+// yet:
+//
+//	client := ancla.New(apiKey)
+//	metrics, err := client.API().PipelineMetrics(ctx, "acme", "shop",
+//		api.PipelineMetricsParams{Service: "web", Env: "production"})
+//
+// Regenerate it with make sdk-go after updating openapi.json.
+package api
+
+//go:generate go run ../internal/apigen -spec ../../../openapi.enriched.json -out api_gen.go
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// Doer sends an API request. path is from the server root, with the
+// query; body, if non-nil, is sent as JSON and the response is decoded
+// into dst, if non-nil. Error responses are returned as errors.
+type Doer interface {
+	Do(ctx context.Context, method, path string, body, dst any) error
+}
+
+// Client calls the Ancla API through a Doer, which handles the transport,
+// authentication, and errors.
+type Client struct {
+	doer Doer
+}
+
+// New returns a Client that sends its requests with d.
+func New(d Doer) *Client {
+	return &Client{doer: d}
+}
+
+// expand fills in the {name} placeholders of a path template from
+// name/value pairs, escaping the values.
+func expand(path string, pairs ...string) string {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		path = strings.ReplaceAll(path, "{"+pairs[i]+"}", url.PathEscape(pairs[i+1]))
+	}
+	return path
+}
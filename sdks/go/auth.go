@@ -0,0 +1,90 @@
+package ancla
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetSession returns who the client is authenticated as. A key the server
+// does not recognise yields a Session with Authenticated false rather than
+// an error.
+func (c *Client) GetSession(ctx context.Context) (*Session, error) {
+	var s Session
+	if err := c.do(ctx, "GET", "/auth/session", nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetCurrentUser returns the user the API key belongs to. It fails with an
+// error matching ErrUnauthorized when the session is not authenticated.
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	s, err := c.GetSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Authenticated || s.User == nil {
+		return nil, &APIError{StatusCode: http.StatusUnauthorized, Message: "not authenticated"}
+	}
+	return s.User, nil
+}
+
+// ListAPIKeys returns the API keys of the authenticated user, without
+// their secrets.
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	if err := c.doURL(ctx, "GET", c.server+"/api/keys", nil, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateAPIKey mints an API key. The returned key's Key field holds the
+// secret; it cannot be retrieved again.
+func (c *Client) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*APIKey, error) {
+	var key APIKey
+	if err := c.doURL(ctx, "POST", c.server+"/api/keys", req, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey deactivates an API key. Requests made with it fail from
+// then on.
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) error {
+	return c.doURL(ctx, "POST", c.server+"/api/keys/"+id+"/revoke", nil, nil)
+}
+
+// RotateAPIKey replaces an API key: it creates a key with the same name,
+// scopes, and metadata, then revokes the old one. expiresAt (RFC 3339) is
+// the new key's expiry, or "" for none. If revoking the old key fails, the
+// new key is returned along with the error so its secret is not lost.
+func (c *Client) RotateAPIKey(ctx context.Context, id, expiresAt string) (*APIKey, error) {
+	keys, err := c.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var old *APIKey
+	for i := range keys {
+		if keys[i].ID == id {
+			old = &keys[i]
+			break
+		}
+	}
+	if old == nil {
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "API key " + id + " not found"}
+	}
+	key, err := c.CreateAPIKey(ctx, CreateAPIKeyRequest{
+		Name:      old.Name,
+		Scopes:    old.Scopes,
+		ExpiresAt: expiresAt,
+		Metadata:  old.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.RevokeAPIKey(ctx, id); err != nil {
+		return key, err
+	}
+	return key, nil
+}
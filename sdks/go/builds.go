@@ -5,29 +5,45 @@ import (
 	"fmt"
 )
 
-// ListBuilds returns all builds for a service.
-func (c *Client) ListBuilds(ctx context.Context, ws, proj, env, svc string) (*BuildList, error) {
+// ListBuilds returns the builds of a service, newest first.
+func (c *Client) ListBuilds(ctx context.Context, ref ServiceRef) (*BuildList, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
 	var result BuildList
-	if err := c.do(ctx, "GET", servicePath(ws, proj, env)+svc+"/builds/", nil, &result); err != nil {
+	if err := c.do(ctx, "GET", ref.ServicePath()+"/builds/", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// GetBuildLog returns build log details by version number.
-func (c *Client) GetBuildLog(ctx context.Context, ws, proj, env, svc string, version int) (*BuildLog, error) {
+// GetBuildLog returns the log and status of a build by version number.
+// Poll it until Status.Done() to follow a build.
+func (c *Client) GetBuildLog(ctx context.Context, ref ServiceRef, version int) (*BuildLog, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
 	var result BuildLog
-	path := fmt.Sprintf("%s%s/builds/%d/log", servicePath(ws, proj, env), svc, version)
+	path := fmt.Sprintf("%s/builds/%d/log", ref.ServicePath(), version)
 	if err := c.do(ctx, "GET", path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// TriggerBuild triggers a new build for a service.
-func (c *Client) TriggerBuild(ctx context.Context, ws, proj, env, svc string) (*BuildResult, error) {
+// TriggerBuild starts a build of a service without deploying it. strategy
+// is BuildStrategyDockerfile or BuildStrategyBuildpack for this build
+// only, or "" for the service's own build strategy.
+func (c *Client) TriggerBuild(ctx context.Context, ref ServiceRef, strategy string) (*BuildResult, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+	var body any
+	if strategy != "" {
+		body = map[string]string{"strategy": strategy}
+	}
 	var result BuildResult
-	if err := c.do(ctx, "POST", servicePath(ws, proj, env)+svc+"/builds/", nil, &result); err != nil {
+	if err := c.do(ctx, "POST", ref.ServicePath()+"/builds/trigger", body, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -1,31 +1,98 @@
 package ancla
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
-// ListConfig returns all configuration variables for a service.
-func (c *Client) ListConfig(ctx context.Context, ws, proj, env, svc string) ([]ConfigVar, error) {
+// Scope is the level of the hierarchy a configuration variable is set at.
+// Variables cascade down, and the most specific scope wins.
+type Scope string
+
+// Config scopes, from least to most specific.
+const (
+	ScopeWorkspace   Scope = "workspace"
+	ScopeProject     Scope = "project"
+	ScopeEnvironment Scope = "env"
+	ScopeService     Scope = "service"
+)
+
+// ErrInvalidScope is returned, wrapped, for a Scope that is not one of the
+// Scope constants. No request is sent.
+var ErrInvalidScope = errors.New("ancla: invalid config scope")
+
+// Validate reports whether s is one of the Scope constants.
+func (s Scope) Validate() error {
+	switch s {
+	case ScopeWorkspace, ScopeProject, ScopeEnvironment, ScopeService:
+		return nil
+	}
+	return fmt.Errorf("%w %q: use ScopeWorkspace, ScopeProject, ScopeEnvironment or ScopeService", ErrInvalidScope, string(s))
+}
+
+// configPath returns the config collection path for scope. Only the slugs
+// the scope needs are used, and each of those must be set.
+func configPath(scope Scope, ws, proj, env, svc string) (string, error) {
+	if err := scope.Validate(); err != nil {
+		return "", err
+	}
+	segs := []struct{ kind, slug string }{{"workspace", ws}, {"project", proj}, {"environment", env}, {"service", svc}}
+	depth := map[Scope]int{ScopeWorkspace: 1, ScopeProject: 2, ScopeEnvironment: 3, ScopeService: 4}[scope]
+	for _, seg := range segs[:depth] {
+		if seg.slug == "" {
+			return "", fmt.Errorf("ancla: %s scope needs a %s slug", scope, seg.kind)
+		}
+	}
+	switch scope {
+	case ScopeWorkspace:
+		return "/workspaces/" + ws + "/config/", nil
+	case ScopeProject:
+		return "/workspaces/" + ws + "/projects/" + proj + "/config/", nil
+	case ScopeEnvironment:
+		return envPathSDK(ws, proj, env) + "/config/", nil
+	default:
+		return servicePath(ws, proj, env) + svc + "/config/", nil
+	}
+}
+
+// ListConfig returns the configuration variables set at scope. Slugs below
+// the scope are ignored, e.g. svc for ScopeEnvironment.
+func (c *Client) ListConfig(ctx context.Context, scope Scope, ws, proj, env, svc string) ([]ConfigVar, error) {
+	path, err := configPath(scope, ws, proj, env, svc)
+	if err != nil {
+		return nil, err
+	}
 	var configs []ConfigVar
-	if err := c.do(ctx, "GET", servicePath(ws, proj, env)+svc+"/config/", nil, &configs); err != nil {
+	if err := c.do(ctx, "GET", path, nil, &configs); err != nil {
 		return nil, err
 	}
 	return configs, nil
 }
 
-// SetConfig creates or updates a configuration variable for a service.
-func (c *Client) SetConfig(ctx context.Context, ws, proj, env, svc, key, value string, secret bool) (*ConfigVar, error) {
+// SetConfig creates or updates a configuration variable at scope.
+func (c *Client) SetConfig(ctx context.Context, scope Scope, ws, proj, env, svc, key, value string, secret bool) (*ConfigVar, error) {
+	path, err := configPath(scope, ws, proj, env, svc)
+	if err != nil {
+		return nil, err
+	}
 	body := SetConfigRequest{
 		Name:   key,
 		Value:  value,
 		Secret: secret,
 	}
 	var config ConfigVar
-	if err := c.do(ctx, "POST", servicePath(ws, proj, env)+svc+"/config/", body, &config); err != nil {
+	if err := c.do(ctx, "POST", path, body, &config); err != nil {
 		return nil, err
 	}
 	return &config, nil
 }
 
-// DeleteConfig deletes a configuration variable by ID.
-func (c *Client) DeleteConfig(ctx context.Context, ws, proj, env, svc, configID string) error {
-	return c.do(ctx, "DELETE", servicePath(ws, proj, env)+svc+"/config/"+configID, nil, nil)
+// DeleteConfig deletes a configuration variable at scope by ID.
+func (c *Client) DeleteConfig(ctx context.Context, scope Scope, ws, proj, env, svc, configID string) error {
+	path, err := configPath(scope, ws, proj, env, svc)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, "DELETE", path+configID, nil, nil)
 }
@@ -2,28 +2,67 @@ package ancla
 
 import "context"
 
-// ListDeploys returns all deploys for a service.
-func (c *Client) ListDeploys(ctx context.Context, ws, proj, env, svc string) (*DeployList, error) {
+// DeployOptions are the optional parts of a deploy trigger.
+type DeployOptions struct {
+	// ConfigOnly restarts the service with its current config vars
+	// instead of building and deploying new code.
+	ConfigOnly bool `json:"config_only,omitempty"`
+	// Ref is the git branch, tag, or commit to build. Empty builds the
+	// service's default branch.
+	Ref string `json:"ref,omitempty"`
+	// Message is shown with the deploy in its history.
+	Message string `json:"message,omitempty"`
+}
+
+// TriggerDeploy starts the pipeline for a service: a build and then a
+// deploy of it, or only a deploy with opts.ConfigOnly. Follow it with
+// GetPipelineStatus.
+func (c *Client) TriggerDeploy(ctx context.Context, ref ServiceRef, opts DeployOptions) (*DeployResult, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+	var body any
+	if opts != (DeployOptions{}) {
+		body = opts
+	}
+	var result DeployResult
+	if err := c.do(ctx, "POST", ref.ServicePath()+"/deploy", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeploys returns the deploys of a service, newest first.
+func (c *Client) ListDeploys(ctx context.Context, ref ServiceRef) (*DeployList, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
 	var result DeployList
-	if err := c.do(ctx, "GET", servicePath(ws, proj, env)+svc+"/deploys/", nil, &result); err != nil {
+	if err := c.do(ctx, "GET", ref.ServicePath()+"/deploys/", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// GetDeploy returns details for a specific deploy (env-level endpoint).
-func (c *Client) GetDeploy(ctx context.Context, ws, proj, env, deployID string) (*Deploy, error) {
+// GetDeploy returns details for a deploy of a service.
+func (c *Client) GetDeploy(ctx context.Context, ref ServiceRef, deployID string) (*Deploy, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
 	var dpl Deploy
-	if err := c.do(ctx, "GET", envPathSDK(ws, proj, env)+"/deploys/"+deployID, nil, &dpl); err != nil {
+	if err := c.do(ctx, "GET", ref.EnvPath()+"/deploys/"+deployID, nil, &dpl); err != nil {
 		return nil, err
 	}
 	return &dpl, nil
 }
 
-// GetDeployLog returns the log for a specific deploy (env-level endpoint).
-func (c *Client) GetDeployLog(ctx context.Context, ws, proj, env, deployID string) (*DeployLog, error) {
+// GetDeployLog returns the log for a deploy of a service.
+func (c *Client) GetDeployLog(ctx context.Context, ref ServiceRef, deployID string) (*DeployLog, error) {
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
 	var result DeployLog
-	if err := c.do(ctx, "GET", envPathSDK(ws, proj, env)+"/deploys/"+deployID+"/log", nil, &result); err != nil {
+	if err := c.do(ctx, "GET", ref.EnvPath()+"/deploys/"+deployID+"/log", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -3,6 +3,19 @@ package ancla
 import (
 	"errors"
 	"fmt"
+	"net/http"
+)
+
+// Sentinel errors for common API failure classes. An *APIError with the
+// matching status code satisfies errors.Is against these, including when it
+// has been wrapped by the caller:
+//
+//	if errors.Is(err, ancla.ErrNotFound) { ... }
+var (
+	ErrNotFound     = errors.New("ancla api: not found")
+	ErrUnauthorized = errors.New("ancla api: unauthorized")
+	ErrForbidden    = errors.New("ancla api: forbidden")
+	ErrRateLimited  = errors.New("ancla api: rate limited")
 )
 
 // APIError represents an error response from the Ancla API.
@@ -19,29 +32,38 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("ancla api: %d", e.StatusCode)
 }
 
-// IsNotFound reports whether the error is a 404 Not Found response.
-func IsNotFound(err error) bool {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		return apiErr.StatusCode == 404
+// Is reports whether the error matches one of the package sentinel errors
+// based on its status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
 	}
 	return false
 }
 
+// IsNotFound reports whether the error is a 404 Not Found response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
 // IsUnauthorized reports whether the error is a 401 Unauthorized response.
 func IsUnauthorized(err error) bool {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		return apiErr.StatusCode == 401
-	}
-	return false
+	return errors.Is(err, ErrUnauthorized)
 }
 
 // IsForbidden reports whether the error is a 403 Forbidden response.
 func IsForbidden(err error) bool {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		return apiErr.StatusCode == 403
-	}
-	return false
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsRateLimited reports whether the error is a 429 Too Many Requests response.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
 }
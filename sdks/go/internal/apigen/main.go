@@ -0,0 +1,454 @@
+// Command apigen generates the low-level api package from the enriched
+// OpenAPI spec: a type for every schema the /api/v1 endpoints use, and a
+// method on api.Client for every endpoint.
+//
+// It runs from go generate in sdks/go/api, after scripts/enrich-openapi.py
+// has written the enriched spec:
+//
+//	make sdk-go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// apiPrefix selects the endpoints the SDK covers; the admin API is not
+// part of it.
+const apiPrefix = "/api/v1/"
+
+type spec struct {
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Parameters  []parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaType         `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	OneOf                []*schema          `json:"oneOf"`
+	AnyOf                []*schema          `json:"anyOf"`
+}
+
+// schemaType is a schema's type. OpenAPI 3.1 allows a list, as in
+// ["string", "null"]; it is read as its first type other than null.
+type schemaType string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return json.Unmarshal(data, (*string)(t))
+	}
+	*t = ""
+	for _, typ := range list {
+		if typ != "null" {
+			*t = schemaType(typ)
+			break
+		}
+	}
+	return nil
+}
+
+func main() {
+	specPath := flag.String("spec", "openapi.enriched.json", "enriched OpenAPI spec to read")
+	out := flag.String("out", "api_gen.go", "Go file to write")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatalf("reading spec: %v", err)
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		log.Fatalf("parsing spec: %v", err)
+	}
+	src, err := generate(&s)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("writing %s: %v", *out, err)
+	}
+}
+
+// endpoint is one operation and where it lives.
+type endpoint struct {
+	method, path string
+	op           operation
+}
+
+// generate returns the formatted source of the api package's generated
+// file.
+func generate(s *spec) ([]byte, error) {
+	var endpoints []endpoint
+	for path, item := range s.Paths {
+		if !strings.HasPrefix(path, apiPrefix) {
+			continue
+		}
+		for method, op := range item {
+			switch method {
+			case "get", "post", "put", "patch", "delete":
+				endpoints = append(endpoints, endpoint{strings.ToUpper(method), path, op})
+			}
+		}
+	}
+	slices.SortFunc(endpoints, func(a, b endpoint) int {
+		return strings.Compare(a.op.OperationID, b.op.OperationID)
+	})
+
+	g := &generator{
+		spec:    s,
+		imports: map[string]bool{"context": true},
+		names:   map[string]string{},
+		used:    map[string]bool{},
+	}
+	var methods strings.Builder
+	for _, e := range endpoints {
+		if err := g.endpoint(&methods, e); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", e.method, e.path, err)
+		}
+	}
+	decls := map[string]string{}
+	for len(g.pending) > 0 {
+		name := g.pending[0]
+		g.pending = g.pending[1:]
+		var decl strings.Builder
+		g.typeDecl(&decl, name)
+		decls[g.names[name]] = decl.String()
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by apigen from the enriched OpenAPI spec. DO NOT EDIT.\n\n")
+	b.WriteString("package api\n\n")
+	b.WriteString("import (\n")
+	for _, pkg := range slices.Sorted(maps.Keys(g.imports)) {
+		fmt.Fprintf(&b, "\t%q\n", pkg)
+	}
+	b.WriteString(")\n\n")
+	b.WriteString(methods.String())
+	for _, name := range slices.Sorted(maps.Keys(decls)) {
+		b.WriteString(decls[name])
+	}
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, b.String())
+	}
+	return src, nil
+}
+
+type generator struct {
+	spec    *spec
+	imports map[string]bool   // packages the generated code uses
+	names   map[string]string // schema name → Go type name
+	used    map[string]bool   // Go type names taken
+	pending []string          // schemas referenced but not yet declared
+}
+
+// endpoint writes the Client method for e, and its params struct if it
+// takes query parameters.
+func (g *generator) endpoint(b *strings.Builder, e endpoint) error {
+	name := exported(e.op.OperationID)
+	if name == "" {
+		return fmt.Errorf("no operationId")
+	}
+
+	args := []string{"ctx context.Context"}
+	expand := []string{fmt.Sprintf("%q", e.path)}
+	var query []parameter
+	for _, p := range e.op.Parameters {
+		switch p.In {
+		case "path":
+			arg := identifier(p.Name)
+			value := arg
+			if isInt(p.Schema) {
+				args = append(args, arg+" int")
+				value = "strconv.Itoa(" + arg + ")"
+				g.imports["strconv"] = true
+			} else {
+				args = append(args, arg+" string")
+			}
+			expand = append(expand, fmt.Sprintf("%q", p.Name), value)
+		case "query":
+			query = append(query, p)
+		}
+	}
+	if len(query) > 0 {
+		args = append(args, "params "+name+"Params")
+		g.paramsDecl(b, name, query)
+	}
+	bodyArg := "nil"
+	if body := jsonSchema(e.op.RequestBody); body != nil {
+		args = append(args, "body "+g.goType(body))
+		bodyArg = "body"
+	}
+	result := g.resultType(e.op)
+
+	fmt.Fprintf(b, "// %s calls %s %s.\n", name, e.method, e.path)
+	if result == "" {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(args, ", "), result)
+	}
+	fmt.Fprintf(b, "\tpath := expand(%s)\n", strings.Join(expand, ", "))
+	if len(query) > 0 {
+		g.queryEncode(b, query)
+	}
+	if result == "" {
+		fmt.Fprintf(b, "\treturn c.doer.Do(ctx, %q, path, %s, nil)\n}\n\n", e.method, bodyArg)
+		return nil
+	}
+	fmt.Fprintf(b, "\tvar out %s\n", strings.TrimPrefix(result, "*"))
+	fmt.Fprintf(b, "\tif err := c.doer.Do(ctx, %q, path, %s, &out); err != nil {\n\t\treturn nil, err\n\t}\n", e.method, bodyArg)
+	if strings.HasPrefix(result, "*") {
+		b.WriteString("\treturn &out, nil\n}\n\n")
+	} else {
+		b.WriteString("\treturn out, nil\n}\n\n")
+	}
+	return nil
+}
+
+// resultType returns the Go type an operation's success response decodes
+// into, or "" if it has no body. Structs are returned by pointer; every
+// result type is nilable.
+func (g *generator) resultType(op operation) string {
+	for _, code := range []string{"200", "201", "202"} {
+		r, ok := op.Responses[code]
+		if !ok {
+			continue
+		}
+		s := r.Content["application/json"].Schema
+		if s == nil {
+			return ""
+		}
+		t := g.goType(s)
+		if s.Ref != "" && g.isStruct(s.Ref) {
+			return "*" + t
+		}
+		return t
+	}
+	return ""
+}
+
+// paramsDecl writes the struct holding an operation's query parameters.
+func (g *generator) paramsDecl(b *strings.Builder, name string, query []parameter) {
+	fmt.Fprintf(b, "// %sParams are the query parameters of %s. Optional\n// parameters are left out when zero.\n", name, name)
+	fmt.Fprintf(b, "type %sParams struct {\n", name)
+	for _, p := range query {
+		t := "string"
+		if isInt(p.Schema) {
+			t = "int"
+		}
+		comment := ""
+		if p.Required {
+			comment = " // required"
+		}
+		fmt.Fprintf(b, "\t%s %s%s\n", exported(p.Name), t, comment)
+	}
+	b.WriteString("}\n\n")
+}
+
+// queryEncode writes the code appending params to path.
+func (g *generator) queryEncode(b *strings.Builder, query []parameter) {
+	g.imports["net/url"] = true
+	b.WriteString("\tq := url.Values{}\n")
+	for _, p := range query {
+		field := "params." + exported(p.Name)
+		value, zero := field, `""`
+		if isInt(p.Schema) {
+			value, zero = "strconv.Itoa("+field+")", "0"
+			g.imports["strconv"] = true
+		}
+		if p.Required {
+			fmt.Fprintf(b, "\tq.Set(%q, %s)\n", p.Name, value)
+		} else {
+			fmt.Fprintf(b, "\tif %s != %s {\n\t\tq.Set(%q, %s)\n\t}\n", field, zero, p.Name, value)
+		}
+	}
+	b.WriteString("\tif len(q) > 0 {\n\t\tpath += \"?\" + q.Encode()\n\t}\n")
+}
+
+func isInt(s *schema) bool {
+	return s != nil && s.Type == "integer"
+}
+
+// jsonSchema returns the JSON schema of a request body, or nil if it has
+// none.
+func jsonSchema(body *struct {
+	Content map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"content"`
+}) *schema {
+	if body == nil {
+		return nil
+	}
+	return body.Content["application/json"].Schema
+}
+
+// goType returns the Go type for s, queueing the schemas it refers to for
+// declaration.
+func (g *generator) goType(s *schema) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		return g.typeName(strings.TrimPrefix(s.Ref, "#/components/schemas/"))
+	}
+	if alts := append(s.OneOf, s.AnyOf...); len(alts) > 0 {
+		// X-or-null is the one union Go has a type for.
+		var nonNull []*schema
+		for _, a := range alts {
+			if a.Type != "null" {
+				nonNull = append(nonNull, a)
+			}
+		}
+		if len(nonNull) == 1 && len(alts) == 2 {
+			t := g.goType(nonNull[0])
+			if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "any" {
+				return t
+			}
+			return "*" + t
+		}
+		return "any"
+	}
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + g.goType(s.AdditionalProperties)
+		}
+		return "map[string]any"
+	}
+	return "any"
+}
+
+// typeName returns the Go name of a component schema, queueing it for
+// declaration the first time it is seen. Schemas namespaced by the
+// server's module path, like ancla_server_api_dtos_auth_LoginRequest, are
+// named by their last segment.
+func (g *generator) typeName(schemaName string) string {
+	if name, ok := g.names[schemaName]; ok {
+		return name
+	}
+	base := schemaName
+	if i := strings.LastIndex(base, "_"); i >= 0 {
+		base = base[i+1:]
+	}
+	name := exported(base)
+	for n := 2; g.used[name]; n++ {
+		name = fmt.Sprintf("%s%d", exported(base), n)
+	}
+	g.names[schemaName] = name
+	g.used[name] = true
+	g.pending = append(g.pending, schemaName)
+	return name
+}
+
+// isStruct reports whether a $ref names a schema declared as a struct.
+func (g *generator) isStruct(ref string) bool {
+	s := g.spec.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+	return s != nil && s.Type == "object" && len(s.Properties) > 0
+}
+
+// typeDecl writes the declaration of a component schema.
+func (g *generator) typeDecl(b *strings.Builder, schemaName string) {
+	s := g.spec.Components.Schemas[schemaName]
+	name := g.names[schemaName]
+	if !g.isStruct(schemaName) {
+		fmt.Fprintf(b, "// %s is the %s schema.\ntype %s = map[string]any\n\n", name, schemaName, name)
+		return
+	}
+	fmt.Fprintf(b, "// %s is the %s schema.\ntype %s struct {\n", name, schemaName, name)
+	props := make([]string, 0, len(s.Properties))
+	for p := range s.Properties {
+		props = append(props, p)
+	}
+	slices.Sort(props)
+	for _, p := range props {
+		prop, tag, t := s.Properties[p], p, g.goType(s.Properties[p])
+		if !slices.Contains(s.Required, p) {
+			tag += ",omitempty"
+			if prop.Ref != "" && g.isStruct(prop.Ref) {
+				t = "*" + t
+			}
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", exported(p), t, tag)
+	}
+	b.WriteString("}\n\n")
+}
+
+// initialisms are written in capitals in Go names, as golint has them.
+var initialisms = map[string]string{"id": "ID", "url": "URL", "api": "API", "json": "JSON", "http": "HTTP"}
+
+// exported turns a snake_case or camelCase name into an exported Go name:
+// deploy_id → DeployID, listWorkspaces → ListWorkspaces.
+func exported(s string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' }) {
+		if up, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(up)
+			continue
+		}
+		r := []rune(word)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// identifier turns a parameter name into an unexported Go identifier:
+// deploy_id → deployID.
+func identifier(s string) string {
+	e := exported(s)
+	for _, up := range initialisms {
+		if strings.HasPrefix(e, up) {
+			return strings.ToLower(up) + e[len(up):]
+		}
+	}
+	r := []rune(e)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -1,5 +1,10 @@
 package ancla
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 // Workspace represents a workspace on the Ancla platform.
 type Workspace struct {
 	ID           string            `json:"id"`
@@ -9,6 +14,9 @@ type Workspace struct {
 	ProjectCount int               `json:"project_count"`
 	ServiceCount int               `json:"service_count"`
 	Members      []WorkspaceMember `json:"members,omitempty"`
+	// Invitations lists invitations that have not been accepted yet,
+	// including expired ones until they are revoked or resent.
+	Invitations []WorkspaceInvitation `json:"invitations,omitempty"`
 }
 
 // WorkspaceMember represents a member within a workspace.
@@ -18,6 +26,17 @@ type WorkspaceMember struct {
 	Admin    bool   `json:"admin"`
 }
 
+// WorkspaceInvitation is a pending invitation to join a workspace.
+type WorkspaceInvitation struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Admin     bool   `json:"admin"`
+	InvitedBy string `json:"invited_by"`
+	Created   string `json:"created"`
+	ExpiresAt string `json:"expires_at"` // RFC 3339
+	Expired   bool   `json:"expired"`
+}
+
 // Project represents a project within a workspace.
 type Project struct {
 	ID            string `json:"id"`
@@ -39,6 +58,12 @@ type Environment struct {
 	Created      string `json:"created"`
 }
 
+// Build strategies for Service.BuildStrategy.
+const (
+	BuildStrategyDockerfile = "dockerfile" // build the image from a Dockerfile
+	BuildStrategyBuildpack  = "buildpack"  // detect the language and build without a Dockerfile
+)
+
 // Service represents a service within an environment.
 type Service struct {
 	ID               string         `json:"id"`
@@ -47,16 +72,86 @@ type Service struct {
 	Platform         string         `json:"platform"`
 	GithubRepository string         `json:"github_repository,omitempty"`
 	AutoDeployBranch string         `json:"auto_deploy_branch,omitempty"`
+	BuildStrategy    string         `json:"build_strategy,omitempty"` // BuildStrategyDockerfile or BuildStrategyBuildpack
 	ProcessCounts    map[string]int `json:"process_counts,omitempty"`
+
+	// Labels are free-form key/value pairs used to select services for
+	// bulk operations. See ListServicesBySelector and LabelService.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Processes reports live replica counts and resource limits per
+	// process type. Autoscaling is nil when the service has never had an
+	// autoscaling policy.
+	Processes   map[string]ProcessState `json:"processes,omitempty"`
+	Autoscaling *Autoscaling            `json:"autoscaling,omitempty"`
+}
+
+// ProcessState is the observed state of one process type.
+type ProcessState struct {
+	Desired       int     `json:"desired"`
+	Running       int     `json:"running"`
+	Size          string  `json:"size,omitempty"`
+	CPULimit      float64 `json:"cpu_limit,omitempty"`       // vCPUs
+	MemoryLimitMB int     `json:"memory_limit_mb,omitempty"` // MiB
+}
+
+// Autoscaling describes a service's autoscaling configuration.
+type Autoscaling struct {
+	Enabled        bool                         `json:"enabled"`
+	Policies       map[string]AutoscalingPolicy `json:"policies,omitempty"`
+	LastScaleEvent *ScaleEvent                  `json:"last_scale_event,omitempty"`
+}
+
+// AutoscalingPolicy bounds the replica count of one process type.
+type AutoscalingPolicy struct {
+	MinReplicas      int `json:"min_replicas"`
+	MaxReplicas      int `json:"max_replicas"`
+	TargetCPUPercent int `json:"target_cpu_percent,omitempty"`
+}
+
+// ScaleEvent records a replica change made by the autoscaler.
+type ScaleEvent struct {
+	Process string `json:"process"`
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	Reason  string `json:"reason,omitempty"`
+	At      string `json:"at"`
+}
+
+// BuildStatus is the state of a build.
+type BuildStatus string
+
+// Build statuses, as reported by Build.Status and BuildLog.Status.
+const (
+	BuildStatusBuilding BuildStatus = "building"
+	BuildStatusSuccess  BuildStatus = "success"
+	BuildStatusError    BuildStatus = "error"
+)
+
+// Done reports whether the build has finished, successfully or not.
+func (s BuildStatus) Done() bool {
+	return s == BuildStatusSuccess || s == BuildStatusError
 }
 
 // Build represents a container build for a service.
 type Build struct {
-	ID      string `json:"id"`
-	Version int    `json:"version"`
-	Built   bool   `json:"built"`
-	Error   bool   `json:"error"`
-	Created string `json:"created"`
+	ID       string `json:"id"`
+	Version  int    `json:"version"`
+	Built    bool   `json:"built"`
+	Error    bool   `json:"error"`
+	Strategy string `json:"strategy,omitempty"` // BuildStrategyDockerfile or BuildStrategyBuildpack
+	Created  string `json:"created"`
+}
+
+// Status returns the build's state from its Built and Error flags.
+func (b Build) Status() BuildStatus {
+	switch {
+	case b.Error:
+		return BuildStatusError
+	case b.Built:
+		return BuildStatusSuccess
+	}
+	return BuildStatusBuilding
 }
 
 // BuildList wraps the paginated build response.
@@ -72,9 +167,9 @@ type BuildResult struct {
 
 // BuildLog contains build log information.
 type BuildLog struct {
-	Status  string `json:"status"`
-	Version int    `json:"version"`
-	LogText string `json:"log_text"`
+	Status  BuildStatus `json:"status"`
+	Version int         `json:"version"`
+	LogText string      `json:"log_text"`
 }
 
 // Deploy represents a deploy for a service.
@@ -99,6 +194,25 @@ type DeployList struct {
 	Items []Deploy `json:"items"`
 }
 
+// UnmarshalJSON accepts the service deploys endpoint's plain array as well
+// as the paginated {"items": [...]} shape.
+func (l *DeployList) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(data, &l.Items)
+	}
+	type plain DeployList
+	return json.Unmarshal(data, (*plain)(l))
+}
+
+// DeployResult is the response from triggering a deploy. BuildID is empty
+// for a config-only deploy; DeployID is empty when the server creates the
+// deploy only once the build has finished.
+type DeployResult struct {
+	BuildID  string `json:"build_id,omitempty"`
+	DeployID string `json:"deploy_id,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
 // ConfigVar represents a configuration variable with scope.
 type ConfigVar struct {
 	ID        string `json:"id"`
@@ -106,7 +220,7 @@ type ConfigVar struct {
 	Value     string `json:"value"`
 	Secret    bool   `json:"secret"`
 	Buildtime bool   `json:"buildtime"`
-	Scope     string `json:"scope"`
+	Scope     Scope  `json:"scope"`
 }
 
 // SetConfigRequest is the payload for setting a configuration variable.
@@ -116,6 +230,56 @@ type SetConfigRequest struct {
 	Secret bool   `json:"secret,omitempty"`
 }
 
+// Addon represents a managed database or cache attached to a service.
+type Addon struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"` // "database" or "cache"
+	Engine  string `json:"engine"`
+	Version string `json:"version"`
+	Plan    string `json:"plan"`
+	Status  string `json:"status"`
+	Created string `json:"created"`
+}
+
+// AddonCredentials holds the connection details of an addon.
+type AddonCredentials struct {
+	ConnectionURL string `json:"connection_url"`
+	Host          string `json:"host"`
+	Port          int    `json:"port"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	Database      string `json:"database,omitempty"`
+}
+
+// User is an Ancla user account.
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Admin    bool   `json:"admin"`
+}
+
+// Session describes who the client is authenticated as.
+type Session struct {
+	Authenticated bool  `json:"authenticated"`
+	User          *User `json:"user"`
+}
+
+// APIKey is an API key belonging to the authenticated user. Key holds the
+// secret, which the server only returns when the key is created.
+type APIKey struct {
+	ID         string            `json:"key_id"`
+	Key        string            `json:"key,omitempty"`
+	Name       string            `json:"name"`
+	Prefix     string            `json:"prefix,omitempty"` // first characters of the key, for recognising it
+	Scopes     []string          `json:"scopes"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Created    string            `json:"created"`
+	LastUsedAt string            `json:"last_used_at,omitempty"` // RFC 3339
+	ExpiresAt  string            `json:"expires_at,omitempty"`   // RFC 3339
+	Revoked    bool              `json:"revoked"`
+}
+
 // PipelineStatus represents the pipeline status for a service.
 type PipelineStatus struct {
 	Build  *StageStatus `json:"build"`
@@ -124,7 +288,25 @@ type PipelineStatus struct {
 
 // StageStatus represents the status of a single pipeline stage.
 type StageStatus struct {
-	Status string `json:"status"`
+	ID          string `json:"id,omitempty"`
+	BuildID     string `json:"build_id,omitempty"` // deploy stage only: the build being deployed
+	Version     int    `json:"version,omitempty"`  // build stage only
+	Status      string `json:"status"`
+	ErrorDetail string `json:"error_detail,omitempty"`
+}
+
+// Done reports whether the stage has finished, successfully or not.
+func (s *StageStatus) Done() bool {
+	switch s.Status {
+	case "", "success", "complete", "error", "failed", "cancelled":
+		return true
+	}
+	return false
+}
+
+// Succeeded reports whether the stage finished successfully.
+func (s *StageStatus) Succeeded() bool {
+	return s.Status == "success" || s.Status == "complete"
 }
 
 // ScaleRequest is the payload for scaling service processes.
@@ -163,9 +345,27 @@ type CreateServiceRequest struct {
 	Platform string `json:"platform"`
 }
 
+// CreateAddonRequest is the payload for provisioning an addon.
+type CreateAddonRequest struct {
+	Kind    string `json:"kind"`
+	Engine  string `json:"engine"`
+	Version string `json:"version,omitempty"`
+	Plan    string `json:"plan"`
+}
+
+// CreateAPIKeyRequest is the payload for creating an API key. An empty
+// ExpiresAt creates a key that does not expire.
+type CreateAPIKeyRequest struct {
+	Name      string            `json:"name"`
+	Scopes    []string          `json:"scopes,omitempty"`
+	ExpiresAt string            `json:"expires_at,omitempty"` // RFC 3339
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
 // UpdateServiceOptions holds optional fields for updating a service.
 type UpdateServiceOptions struct {
 	Name             *string `json:"name,omitempty"`
 	GithubRepository *string `json:"github_repository,omitempty"`
 	AutoDeployBranch *string `json:"auto_deploy_branch,omitempty"`
+	BuildStrategy    *string `json:"build_strategy,omitempty"`
 }
@@ -0,0 +1,112 @@
+package ancla
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// slugRe matches a single path segment. It only keeps out characters that
+// would change the meaning of the API URL a ref is turned into.
+var slugRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ServiceRef identifies a service by the slugs of it and its parents. The
+// CLI has a ServiceRef of the same shape, so paths parse the same way in
+// both.
+type ServiceRef struct {
+	Workspace string
+	Project   string
+	Env       string
+	Service   string
+}
+
+// ParseServiceRef parses a "ws/proj/env/svc" path into a ServiceRef.
+func ParseServiceRef(path string) (ServiceRef, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 4 {
+		return ServiceRef{}, fmt.Errorf("invalid service path %q: expected ws/proj/env/svc", path)
+	}
+	ref := ServiceRef{Workspace: parts[0], Project: parts[1], Env: parts[2], Service: parts[3]}
+	if err := ref.Validate(); err != nil {
+		return ServiceRef{}, fmt.Errorf("invalid service path %q: %w", path, err)
+	}
+	return ref, nil
+}
+
+// Validate reports a missing segment or one that is not a valid slug.
+func (r ServiceRef) Validate() error {
+	for _, seg := range []struct{ kind, slug string }{
+		{"workspace", r.Workspace}, {"project", r.Project}, {"environment", r.Env}, {"service", r.Service},
+	} {
+		if seg.slug == "" {
+			return fmt.Errorf("missing %s slug", seg.kind)
+		}
+		if !slugRe.MatchString(seg.slug) {
+			return fmt.Errorf("invalid %s slug %q", seg.kind, seg.slug)
+		}
+	}
+	return nil
+}
+
+// String returns the ref as a "ws/proj/env/svc" path.
+func (r ServiceRef) String() string {
+	return r.Workspace + "/" + r.Project + "/" + r.Env + "/" + r.Service
+}
+
+// EnvPath returns the API path of the service's environment.
+func (r ServiceRef) EnvPath() string {
+	return envPathSDK(r.Workspace, r.Project, r.Env)
+}
+
+// ServicePath returns the API path of the service.
+func (r ServiceRef) ServicePath() string {
+	return servicePath(r.Workspace, r.Project, r.Env) + r.Service
+}
+
+// resourceLocation is where the resource with a given ID currently lives.
+// Slugs that don't apply to its kind are empty.
+type resourceLocation struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`
+	Slug          string `json:"slug"`
+	WorkspaceSlug string `json:"workspace_slug"`
+	ProjectSlug   string `json:"project_slug"`
+	EnvSlug       string `json:"env_slug"`
+	ServiceSlug   string `json:"service_slug"`
+}
+
+// Resolve returns the ServiceRef for a "ws/proj/env/svc" path or a service
+// ID. IDs are looked up on the server, so they keep working after the
+// service or any of its parents is renamed.
+func (c *Client) Resolve(ctx context.Context, pathOrID string) (ServiceRef, error) {
+	if strings.Contains(pathOrID, "/") {
+		return ParseServiceRef(pathOrID)
+	}
+	var loc resourceLocation
+	if err := c.do(ctx, "GET", "/resources/"+pathOrID, nil, &loc); err != nil {
+		return ServiceRef{}, err
+	}
+	if loc.Kind != "service" {
+		return ServiceRef{}, fmt.Errorf("%s is a %s, not a service", pathOrID, loc.Kind)
+	}
+	svc := loc.ServiceSlug
+	if svc == "" {
+		svc = loc.Slug
+	}
+	ref := ServiceRef{Workspace: loc.WorkspaceSlug, Project: loc.ProjectSlug, Env: loc.EnvSlug, Service: svc}
+	return ref, ref.Validate()
+}
+
+// GetServiceByID returns the service with the given ID.
+func (c *Client) GetServiceByID(ctx context.Context, id string) (*Service, error) {
+	ref, err := c.Resolve(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var s Service
+	if err := c.do(ctx, "GET", ref.ServicePath(), nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
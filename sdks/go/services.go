@@ -2,7 +2,9 @@ package ancla
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // envPathSDK builds the path prefix up to the environment level.
@@ -24,6 +26,18 @@ func (c *Client) ListServices(ctx context.Context, ws, proj, env string) ([]Serv
 	return services, nil
 }
 
+// ListServicesBySelector returns the services within an environment whose
+// labels match selector, e.g. "team=payments,tier!=batch". A bare key
+// requires the label to be set and "!key" requires it to be absent.
+func (c *Client) ListServicesBySelector(ctx context.Context, ws, proj, env, selector string) ([]Service, error) {
+	var services []Service
+	path := servicePath(ws, proj, env) + "?label_selector=" + url.QueryEscape(selector)
+	if err := c.do(ctx, "GET", path, nil, &services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
 // GetService returns details for a single service.
 func (c *Client) GetService(ctx context.Context, ws, proj, env, slug string) (*Service, error) {
 	var svc Service
@@ -52,6 +66,117 @@ func (c *Client) UpdateService(ctx context.Context, ws, proj, env, slug string,
 	return &svc, nil
 }
 
+// ServiceUpdate is a typed partial update for a service. Only fields that
+// have been set or cleared are serialized, so unset fields are left untouched
+// on the server. Build one with NewServiceUpdate and pass it to PatchService:
+//
+//	upd := ancla.NewServiceUpdate().SetName("api").ClearGithubRepository()
+//	svc, err := client.PatchService(ctx, "ws", "proj", "staging", "web", upd)
+type ServiceUpdate struct {
+	fields map[string]any
+}
+
+// NewServiceUpdate returns an empty ServiceUpdate.
+func NewServiceUpdate() *ServiceUpdate {
+	return &ServiceUpdate{fields: make(map[string]any)}
+}
+
+func (u *ServiceUpdate) set(key string, value any) *ServiceUpdate {
+	if u.fields == nil {
+		u.fields = make(map[string]any)
+	}
+	u.fields[key] = value
+	return u
+}
+
+// SetName sets the service display name.
+func (u *ServiceUpdate) SetName(name string) *ServiceUpdate {
+	return u.set("name", name)
+}
+
+// SetGithubRepository links the service to a GitHub repository ("owner/repo").
+func (u *ServiceUpdate) SetGithubRepository(repo string) *ServiceUpdate {
+	return u.set("github_repository", repo)
+}
+
+// ClearGithubRepository unlinks the service from its GitHub repository.
+func (u *ServiceUpdate) ClearGithubRepository() *ServiceUpdate {
+	return u.set("github_repository", nil)
+}
+
+// SetAutoDeployBranch sets the branch that triggers automatic deploys.
+func (u *ServiceUpdate) SetAutoDeployBranch(branch string) *ServiceUpdate {
+	return u.set("auto_deploy_branch", branch)
+}
+
+// ClearAutoDeployBranch disables automatic deploys from a branch.
+func (u *ServiceUpdate) ClearAutoDeployBranch() *ServiceUpdate {
+	return u.set("auto_deploy_branch", nil)
+}
+
+// SetBuildStrategy sets how the service is built: BuildStrategyDockerfile
+// or BuildStrategyBuildpack. The change applies from the next build.
+func (u *ServiceUpdate) SetBuildStrategy(strategy string) *ServiceUpdate {
+	return u.set("build_strategy", strategy)
+}
+
+// SetLabels replaces all of the service's labels. Use LabelService to
+// change individual labels.
+func (u *ServiceUpdate) SetLabels(labels map[string]string) *ServiceUpdate {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return u.set("labels", labels)
+}
+
+// IsEmpty reports whether no fields have been set or cleared.
+func (u *ServiceUpdate) IsEmpty() bool {
+	return u == nil || len(u.fields) == 0
+}
+
+// MarshalJSON serializes only the fields that were set or cleared.
+// Cleared fields are sent as JSON null.
+func (u *ServiceUpdate) MarshalJSON() ([]byte, error) {
+	if u == nil || u.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(u.fields)
+}
+
+// PatchService applies a partial update to a service. Fields not touched on
+// the ServiceUpdate are omitted from the request body.
+func (c *Client) PatchService(ctx context.Context, ws, proj, env, slug string, upd *ServiceUpdate) (*Service, error) {
+	if upd.IsEmpty() {
+		return nil, fmt.Errorf("service update has no fields set")
+	}
+	var svc Service
+	if err := c.do(ctx, "PATCH", servicePath(ws, proj, env)+slug, upd, &svc); err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
+
+// LabelService sets the labels in set and removes the keys in remove,
+// leaving the service's other labels as they are. It returns the updated
+// service.
+func (c *Client) LabelService(ctx context.Context, ws, proj, env, slug string, set map[string]string, remove ...string) (*Service, error) {
+	svc, err := c.GetService(ctx, ws, proj, env, slug)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string, len(svc.Labels)+len(set))
+	for k, v := range svc.Labels {
+		labels[k] = v
+	}
+	for k, v := range set {
+		labels[k] = v
+	}
+	for _, k := range remove {
+		delete(labels, k)
+	}
+	return c.PatchService(ctx, ws, proj, env, slug, NewServiceUpdate().SetLabels(labels))
+}
+
 // DeleteService deletes a service.
 func (c *Client) DeleteService(ctx context.Context, ws, proj, env, slug string) error {
 	return c.do(ctx, "DELETE", servicePath(ws, proj, env)+slug, nil, nil)
@@ -59,6 +184,8 @@ func (c *Client) DeleteService(ctx context.Context, ws, proj, env, slug string)
 
 // DeployService triggers a full deploy for a service.
 // The svcID is the service's unique identifier (not the slug path).
+//
+// Deprecated: use TriggerDeploy, which takes a ServiceRef and options.
 func (c *Client) DeployService(ctx context.Context, ws, proj, env, svcID string) (*BuildResult, error) {
 	var result BuildResult
 	if err := c.do(ctx, "POST", servicePath(ws, proj, env)+svcID+"/deploy", nil, &result); err != nil {
@@ -73,7 +200,21 @@ func (c *Client) ScaleService(ctx context.Context, ws, proj, env, svcID string,
 	return c.do(ctx, "POST", servicePath(ws, proj, env)+svcID+"/scale", ScaleRequest{ProcessCounts: counts}, nil)
 }
 
+// GetPipelineStatus returns the latest build and deploy of a service, as
+// shown by "ancla status". Either stage is nil when the service has never
+// been built or deployed.
+func (c *Client) GetPipelineStatus(ctx context.Context, ws, proj, env, svc string) (*PipelineStatus, error) {
+	q := url.Values{"service": {svc}, "env": {env}}
+	var status PipelineStatus
+	if err := c.do(ctx, "GET", "/workspaces/"+ws+"/projects/"+proj+"/pipeline/status?"+q.Encode(), nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
 // GetServiceStatus returns the pipeline status for a service.
+//
+// Deprecated: use GetPipelineStatus, which addresses the service by slug.
 func (c *Client) GetServiceStatus(ctx context.Context, ws, proj, env, svcID string) (*PipelineStatus, error) {
 	var status PipelineStatus
 	if err := c.do(ctx, "GET", servicePath(ws, proj, env)+svcID+"/pipeline-status", nil, &status); err != nil {
@@ -42,3 +42,26 @@ func (c *Client) UpdateWorkspace(ctx context.Context, slug string, name string)
 func (c *Client) DeleteWorkspace(ctx context.Context, slug string) error {
 	return c.do(ctx, "DELETE", "/workspaces/"+slug, nil, nil)
 }
+
+// ListInvitations returns the pending invitations of a workspace.
+func (c *Client) ListInvitations(ctx context.Context, workspace string) ([]WorkspaceInvitation, error) {
+	var invitations []WorkspaceInvitation
+	if err := c.do(ctx, "GET", "/workspaces/"+workspace+"/invitations/", nil, &invitations); err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// ResendInvitation emails an invitation again and restarts its expiry.
+func (c *Client) ResendInvitation(ctx context.Context, workspace, id string) (*WorkspaceInvitation, error) {
+	var inv WorkspaceInvitation
+	if err := c.do(ctx, "POST", "/workspaces/"+workspace+"/invitations/"+id+"/resend", nil, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// RevokeInvitation cancels an invitation so its link no longer works.
+func (c *Client) RevokeInvitation(ctx context.Context, workspace, id string) error {
+	return c.do(ctx, "DELETE", "/workspaces/"+workspace+"/invitations/"+id, nil, nil)
+}
@@ -0,0 +1,79 @@
+// Command gen-import-examples writes the import example of every
+// importable resource to examples/resources/<type>/import.sh, from the
+// import ID formats the resources parse, for tfplugindocs to include in
+// the generated docs.
+//
+// Usage:
+//
+//	go run ./cmd/gen-import-examples --out examples/resources
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/resources"
+)
+
+// sampleValues fills in each kind of import ID segment.
+var sampleValues = map[string]string{
+	"workspace_slug": "my-ws",
+	"project_slug":   "web-platform",
+	"env_slug":       "production",
+	"service_slug":   "api",
+}
+
+// sampleID stands in for any resource ID.
+const sampleID = "01234567-89ab-cdef-0123-456789abcdef"
+
+func main() {
+	out := flag.String("out", "examples/resources", "directory to write the examples to")
+	flag.Parse()
+
+	names := make([]string, 0, len(resources.ImportFormats))
+	for name := range resources.ImportFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		typ := "ancla_" + name
+		dir := filepath.Join(*out, typ)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			log.Fatalf("creating %s: %v", dir, err)
+		}
+		file := filepath.Join(dir, "import.sh")
+		if err := os.WriteFile(file, []byte(importExample(typ, resources.ImportFormats[name])), 0o644); err != nil {
+			log.Fatalf("writing %s: %v", file, err)
+		}
+		fmt.Println("wrote", file)
+	}
+}
+
+// importExample renders the import commands for a resource type: by slug
+// path, then by ID.
+func importExample(typ string, f resources.ImportFormat) string {
+	segments := make([]string, len(f.Segments))
+	for i, s := range f.Segments {
+		v, ok := sampleValues[s]
+		if !ok {
+			v = sampleID
+		}
+		segments[i] = v
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Import by slug path: %s", f)
+	if f.Note != "" {
+		fmt.Fprintf(&b, " (%s)", f.Note)
+	}
+	fmt.Fprintf(&b, "\nterraform import %s.example %s\n\n", typ, strings.Join(segments, "/"))
+	fmt.Fprintf(&b, "# Import by ID, which keeps working after a rename: id:<%s>\n", f.ID)
+	fmt.Fprintf(&b, "terraform import %s.example id:%s\n", typ, sampleID)
+	return b.String()
+}
@@ -0,0 +1,9 @@
+data "ancla_environment" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  slug           = "production"
+}
+
+output "production_services" {
+  value = data.ancla_environment.example.service_count
+}
@@ -0,0 +1,10 @@
+data "ancla_latest_build" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "staging"
+  service_slug   = "api"
+}
+
+output "staging_api_image" {
+  value = data.ancla_latest_build.example.image
+}
@@ -0,0 +1,17 @@
+data "ancla_pipeline_status" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "staging"
+  service_slug   = "api"
+}
+
+resource "terraform_data" "promote" {
+  input = data.ancla_pipeline_status.example.deploy_build_id
+
+  lifecycle {
+    precondition {
+      condition     = data.ancla_pipeline_status.example.deploy_status == "success"
+      error_message = "Staging is not running a successful deploy."
+    }
+  }
+}
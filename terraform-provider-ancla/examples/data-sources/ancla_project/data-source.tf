@@ -0,0 +1,8 @@
+data "ancla_project" "example" {
+  workspace_slug = "my-ws"
+  slug           = "web-platform"
+}
+
+output "project_name" {
+  value = data.ancla_project.example.name
+}
@@ -0,0 +1,10 @@
+data "ancla_service" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  slug           = "api"
+}
+
+output "api_processes" {
+  value = data.ancla_service.example.process_counts
+}
@@ -0,0 +1,7 @@
+data "ancla_workspace" "example" {
+  slug = "my-ws"
+}
+
+output "workspace_name" {
+  value = data.ancla_workspace.example.name
+}
@@ -0,0 +1,12 @@
+ephemeral "ancla_deploy_token" "example" {
+  scopes         = ["deploy"]
+  ttl            = "30m"
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "staging"
+}
+
+provider "ancla" {
+  alias   = "deployer"
+  api_key = ephemeral.ancla_deploy_token.example.token
+}
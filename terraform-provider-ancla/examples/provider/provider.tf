@@ -0,0 +1,13 @@
+terraform {
+  required_providers {
+    ancla = {
+      source = "sidequest-labs/ancla"
+    }
+  }
+}
+
+# The API key is best left to the ANCLA_API_KEY environment variable.
+provider "ancla" {
+  workspace = "my-ws"
+  project   = "web-platform"
+}
@@ -0,0 +1,17 @@
+resource "ancla_api_token" "example" {
+  name           = "github-actions"
+  scopes         = ["deploy"]
+  rotation_days  = 30
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "github_actions_secret" "ancla" {
+  repository      = "web-platform"
+  secret_name     = "ANCLA_API_KEY"
+  plaintext_value = ancla_api_token.example.token
+}
@@ -0,0 +1,5 @@
+# Import by slug path: <workspace_slug>/<project_slug>/<env_slug>/<service_slug>/<cache_id>
+terraform import ancla_cache.example my-ws/web-platform/production/api/01234567-89ab-cdef-0123-456789abcdef
+
+# Import by ID, which keeps working after a rename: id:<cache_id>
+terraform import ancla_cache.example id:01234567-89ab-cdef-0123-456789abcdef
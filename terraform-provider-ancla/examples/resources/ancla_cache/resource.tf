@@ -0,0 +1,19 @@
+resource "ancla_cache" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  service_slug   = ancla_service.example.slug
+  engine         = "redis"
+  version        = "7"
+  plan           = "small"
+}
+
+resource "ancla_config_var" "redis_url" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  service_slug   = ancla_service.example.slug
+  name           = "REDIS_URL"
+  value          = ancla_cache.example.connection_url
+  secret         = true
+}
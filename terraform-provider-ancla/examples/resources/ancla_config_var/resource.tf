@@ -0,0 +1,8 @@
+resource "ancla_config_var" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  service_slug   = ancla_service.example.slug
+  name           = "LOG_LEVEL"
+  value          = "info"
+}
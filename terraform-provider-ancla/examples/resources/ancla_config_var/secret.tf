@@ -0,0 +1,15 @@
+variable "secret_key" {
+  type      = string
+  sensitive = true
+}
+
+resource "ancla_config_var" "secret_key" {
+  workspace_slug   = "my-ws"
+  project_slug     = "web-platform"
+  env_slug         = "production"
+  service_slug     = ancla_service.example.slug
+  name             = "SECRET_KEY"
+  value            = var.secret_key
+  secret           = true
+  trigger_redeploy = true
+}
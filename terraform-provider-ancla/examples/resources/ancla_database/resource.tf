@@ -0,0 +1,19 @@
+resource "ancla_database" "example" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  service_slug   = ancla_service.example.slug
+  engine         = "postgres"
+  version        = "16"
+  plan           = "standard-1"
+}
+
+resource "ancla_config_var" "database_url" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  service_slug   = ancla_service.example.slug
+  name           = "DATABASE_URL"
+  value          = ancla_database.example.connection_url
+  secret         = true
+}
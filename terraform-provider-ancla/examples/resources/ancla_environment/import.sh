@@ -0,0 +1,5 @@
+# Import by slug path: <workspace_slug>/<project_slug>/<env_slug>
+terraform import ancla_environment.example my-ws/web-platform/production
+
+# Import by ID, which keeps working after a rename: id:<env_id>
+terraform import ancla_environment.example id:01234567-89ab-cdef-0123-456789abcdef
@@ -0,0 +1,20 @@
+resource "ancla_environment" "example" {
+  name           = "production"
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+
+  required_reviewers = ["alice@example.com", "bob@example.com"]
+  required_approvals = 1
+  confirm_production = true
+
+  freeze_windows = [
+    {
+      window = "Fri 17:00-Mon 09:00"
+      reason = "No weekend deploys"
+    },
+    {
+      window   = "Mon 00:00-Mon 06:00"
+      timezone = "Europe/London"
+    },
+  ]
+}
@@ -0,0 +1,5 @@
+# Import by slug path: <workspace_slug>/<project_slug>
+terraform import ancla_project.example my-ws/web-platform
+
+# Import by ID, which keeps working after a rename: id:<project_id>
+terraform import ancla_project.example id:01234567-89ab-cdef-0123-456789abcdef
@@ -0,0 +1,4 @@
+resource "ancla_project" "example" {
+  name           = "Web Platform"
+  workspace_slug = ancla_workspace.example.slug
+}
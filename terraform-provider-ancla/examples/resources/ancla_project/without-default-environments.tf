@@ -0,0 +1,11 @@
+resource "ancla_project" "example" {
+  name                 = "Web Platform"
+  workspace_slug       = ancla_workspace.example.slug
+  default_environments = []
+}
+
+resource "ancla_environment" "prod" {
+  name           = "prod"
+  workspace_slug = ancla_workspace.example.slug
+  project_slug   = ancla_project.example.slug
+}
@@ -0,0 +1,10 @@
+resource "ancla_service" "example" {
+  name     = "API"
+  env_slug = "production"
+  platform = "wind"
+
+  buildpacks = [
+    "heroku/nodejs",
+    "heroku/python@0.19.1",
+  ]
+}
@@ -0,0 +1,5 @@
+# Import by slug path: <workspace_slug>/<project_slug>/<env_slug>/<service_slug>
+terraform import ancla_service.example my-ws/web-platform/production/api
+
+# Import by ID, which keeps working after a rename: id:<service_id>
+terraform import ancla_service.example id:01234567-89ab-cdef-0123-456789abcdef
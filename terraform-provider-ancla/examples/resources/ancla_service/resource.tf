@@ -0,0 +1,19 @@
+resource "ancla_service" "example" {
+  name           = "API"
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  platform       = "wind"
+
+  github_repository  = "sidequest-labs/api-service"
+  auto_deploy_branch = "main"
+
+  process_counts = {
+    web    = 2
+    worker = 1
+  }
+
+  labels = {
+    team = "payments"
+  }
+}
@@ -0,0 +1,8 @@
+resource "ancla_service_domain_verification" "www" {
+  workspace_slug = "my-ws"
+  project_slug   = "web-platform"
+  env_slug       = "production"
+  service_slug   = ancla_service.example.slug
+  domain         = "www.example.com"
+  timeout        = "30m"
+}
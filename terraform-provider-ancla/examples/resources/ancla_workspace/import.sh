@@ -0,0 +1,5 @@
+# Import by slug path: <workspace_slug>
+terraform import ancla_workspace.example my-ws
+
+# Import by ID, which keeps working after a rename: id:<workspace_id>
+terraform import ancla_workspace.example id:01234567-89ab-cdef-0123-456789abcdef
@@ -0,0 +1,3 @@
+resource "ancla_workspace" "example" {
+  name = "My Workspace"
+}
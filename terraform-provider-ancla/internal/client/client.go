@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -15,6 +16,11 @@ type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// DefaultWorkspace and DefaultProject come from the provider block and
+	// are used by resources and data sources that leave their slugs unset.
+	DefaultWorkspace string
+	DefaultProject   string
 }
 
 // New creates a new Ancla API client.
@@ -205,6 +211,119 @@ func (c *Client) DeleteWorkspace(slug string) error {
 	return err
 }
 
+// --- Resource lookup API ---
+
+// ResourceRef locates a resource by its ID: its kind ("workspace",
+// "project", "environment", "service", "config", "database", or "cache")
+// and the slugs of it and its parents. Slugs that don't apply are empty.
+type ResourceRef struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`
+	Slug          string `json:"slug"`
+	WorkspaceSlug string `json:"workspace_slug"`
+	ProjectSlug   string `json:"project_slug"`
+	EnvSlug       string `json:"env_slug"`
+	ServiceSlug   string `json:"service_slug"`
+}
+
+// LookupResource returns where the resource with the given ID currently
+// lives. Unlike slugs, IDs survive renames.
+func (c *Client) LookupResource(id string) (*ResourceRef, error) {
+	req, err := http.NewRequest("GET", c.apiURL("/resources/"+id), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var ref ResourceRef
+	if err := json.Unmarshal(body, &ref); err != nil {
+		return nil, fmt.Errorf("parsing resource lookup response: %w", err)
+	}
+	return &ref, nil
+}
+
+// --- Pipeline API ---
+
+// PipelineStage is the latest build or deploy of a service.
+type PipelineStage struct {
+	ID          string `json:"id"`
+	BuildID     string `json:"build_id"`
+	Version     int    `json:"version"`
+	Status      string `json:"status"`
+	ErrorDetail string `json:"error_detail"`
+}
+
+// PipelineStatus is the current build and deploy state of a service. A
+// stage is nil when the service has never been built or deployed.
+type PipelineStatus struct {
+	Build  *PipelineStage `json:"build"`
+	Deploy *PipelineStage `json:"deploy"`
+}
+
+// GetPipelineStatus returns the pipeline status of a service.
+func (c *Client) GetPipelineStatus(ws, proj, env, svcSlug string) (*PipelineStatus, error) {
+	q := url.Values{"service": {svcSlug}, "env": {env}}
+	req, err := http.NewRequest("GET", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/pipeline/status?"+q.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var status PipelineStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("parsing pipeline status response: %w", err)
+	}
+	return &status, nil
+}
+
+// --- Builds API ---
+
+// Build is a build of a service. Image is the registry reference of the
+// image it produced, empty until the build succeeds.
+type Build struct {
+	ID       string `json:"id"`
+	Version  int    `json:"version"`
+	Built    bool   `json:"built"`
+	Error    bool   `json:"error"`
+	Image    string `json:"image"`
+	Strategy string `json:"strategy"`
+	Created  string `json:"created"`
+}
+
+// Status returns "success", "error", or "building".
+func (b Build) Status() string {
+	switch {
+	case b.Error:
+		return "error"
+	case b.Built:
+		return "success"
+	}
+	return "building"
+}
+
+// ListBuilds returns the most recent builds of a service, newest first.
+func (c *Client) ListBuilds(ws, proj, env, svcSlug string) ([]Build, error) {
+	req, err := http.NewRequest("GET", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+env+"/services/"+svcSlug+"/builds/?per_page=100"), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Items []Build `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parsing builds response: %w", err)
+	}
+	return result.Items, nil
+}
+
 // --- Project API ---
 
 // Project represents an Ancla project.
@@ -253,9 +372,16 @@ func (c *Client) GetProject(ws, projectSlug string) (*Project, error) {
 	return &project, nil
 }
 
-// CreateProject creates a new project under a workspace.
-func (c *Client) CreateProject(ws, name string) (*Project, error) {
-	payload, _ := json.Marshal(map[string]string{"name": name})
+// CreateProject creates a new project under a workspace. The server
+// creates production, staging, and development environments with it unless
+// defaultEnvs is non-nil, in which case it creates exactly those (none for
+// an empty slice).
+func (c *Client) CreateProject(ws, name string, defaultEnvs []string) (*Project, error) {
+	fields := map[string]any{"name": name}
+	if defaultEnvs != nil {
+		fields["default_environments"] = defaultEnvs
+	}
+	payload, _ := json.Marshal(fields)
 	req, err := http.NewRequest("POST", c.apiURL("/workspaces/"+ws+"/projects/"), bytes.NewReader(payload))
 	if err != nil {
 		return nil, err
@@ -305,11 +431,31 @@ func (c *Client) DeleteProject(ws, projectSlug string) error {
 
 // Environment represents an Ancla environment within a project.
 type Environment struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	Slug         string `json:"slug"`
-	ServiceCount int    `json:"service_count"`
-	Created      string `json:"created"`
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Slug         string                 `json:"slug"`
+	ServiceCount int                    `json:"service_count"`
+	Created      string                 `json:"created"`
+	Protection   *EnvironmentProtection `json:"protection,omitempty"`
+	Freezes      []FreezeWindow         `json:"freezes,omitempty"`
+}
+
+// EnvironmentProtection holds the deployment protection rules of an
+// environment: reviewers whose approval deploys wait for, and whether
+// deploys must be explicitly confirmed.
+type EnvironmentProtection struct {
+	RequiredReviewers []string `json:"required_reviewers"`
+	RequiredApprovals int      `json:"required_approvals"`
+	ConfirmProduction bool     `json:"confirm_production"`
+}
+
+// FreezeWindow is a weekly window, e.g. "Fri 17:00-Mon 09:00", during
+// which deploys to an environment are refused.
+type FreezeWindow struct {
+	ID       string `json:"id,omitempty"`
+	Window   string `json:"window"`
+	Timezone string `json:"timezone"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // ListEnvironments returns all environments in a project.
@@ -394,20 +540,76 @@ func (c *Client) DeleteEnvironment(ws, proj, envSlug string) error {
 	return err
 }
 
+// SetEnvironmentProtection replaces the protection rules of an environment.
+func (c *Client) SetEnvironmentProtection(ws, proj, envSlug string, p EnvironmentProtection) error {
+	if p.RequiredReviewers == nil {
+		p.RequiredReviewers = []string{}
+	}
+	payload, _ := json.Marshal(p)
+	req, err := http.NewRequest("PUT", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+envSlug+"/protection"), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = c.doRequest(req)
+	return err
+}
+
+// DeleteEnvironmentProtection removes the protection rules of an environment.
+func (c *Client) DeleteEnvironmentProtection(ws, proj, envSlug string) error {
+	req, err := http.NewRequest("DELETE", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+envSlug+"/protection"), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req)
+	return err
+}
+
+// CreateFreezeWindow adds a freeze window to an environment.
+func (c *Client) CreateFreezeWindow(ws, proj, envSlug string, f FreezeWindow) (*FreezeWindow, error) {
+	payload, _ := json.Marshal(f)
+	req, err := http.NewRequest("POST", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+envSlug+"/freezes/"), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var created FreezeWindow
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("parsing freeze window response: %w", err)
+	}
+	return &created, nil
+}
+
+// DeleteFreezeWindow removes a freeze window from an environment.
+func (c *Client) DeleteFreezeWindow(ws, proj, envSlug, id string) error {
+	req, err := http.NewRequest("DELETE", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+envSlug+"/freezes/"+id), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req)
+	return err
+}
+
 // --- Service API ---
 
 // Service represents an Ancla service (formerly application).
 type Service struct {
-	ID               string         `json:"id"`
-	Name             string         `json:"name"`
-	Slug             string         `json:"slug"`
-	WorkspaceSlug    string         `json:"workspace_slug"`
-	ProjectSlug      string         `json:"project_slug"`
-	EnvSlug          string         `json:"env_slug"`
-	Platform         string         `json:"platform"`
-	GithubRepository string         `json:"github_repository"`
-	AutoDeployBranch string         `json:"auto_deploy_branch"`
-	ProcessCounts    map[string]int `json:"process_counts"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Slug             string            `json:"slug"`
+	WorkspaceSlug    string            `json:"workspace_slug"`
+	ProjectSlug      string            `json:"project_slug"`
+	EnvSlug          string            `json:"env_slug"`
+	Platform         string            `json:"platform"`
+	GithubRepository string            `json:"github_repository"`
+	AutoDeployBranch string            `json:"auto_deploy_branch"`
+	ProcessCounts    map[string]int    `json:"process_counts"`
+	Labels           map[string]string `json:"labels"`
+	Buildpacks       []string          `json:"buildpacks"` // pinned, as "<id>[@<version>]"; empty means detected
 }
 
 // ListServices returns all services in an environment.
@@ -507,6 +709,29 @@ func (c *Client) ScaleService(ws, proj, env, svcSlug string, processCounts map[s
 	return err
 }
 
+// TriggerConfigDeploy starts a config-only deploy of a service, restarting
+// it with its current configuration variables without a rebuild. It
+// returns the ID of the deploy.
+func (c *Client) TriggerConfigDeploy(ws, proj, env, svcSlug string) (string, error) {
+	payload, _ := json.Marshal(map[string]any{"config_only": true})
+	req, err := http.NewRequest("POST", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+env+"/services/"+svcSlug+"/deploy"), bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		DeployID string `json:"deploy_id"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("parsing deploy response: %w", err)
+	}
+	return result.DeployID, nil
+}
+
 // --- Configuration API ---
 
 // ConfigVar represents a configuration variable with scope.
@@ -588,3 +813,246 @@ func (c *Client) DeleteConfig(ws, proj, env, svc, scope, configID string) error
 	_, err = c.doRequest(req)
 	return err
 }
+
+// --- Addon API ---
+
+// Addon represents a managed database or cache attached to a service.
+type Addon struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Engine  string `json:"engine"`
+	Version string `json:"version"`
+	Plan    string `json:"plan"`
+	Status  string `json:"status"`
+}
+
+// AddonCredentials holds the connection details of an addon.
+type AddonCredentials struct {
+	ConnectionURL string `json:"connection_url"`
+}
+
+// addonsPath returns the API path for the addons of a service.
+func (c *Client) addonsPath(ws, proj, env, svc string) string {
+	return "/workspaces/" + ws + "/projects/" + proj + "/envs/" + env + "/services/" + svc + "/addons/"
+}
+
+// GetAddon returns an addon by ID.
+func (c *Client) GetAddon(ws, proj, env, svc, addonID string) (*Addon, error) {
+	req, err := http.NewRequest("GET", c.apiURL(c.addonsPath(ws, proj, env, svc)+addonID), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var addon Addon
+	if err := json.Unmarshal(body, &addon); err != nil {
+		return nil, fmt.Errorf("parsing addon response: %w", err)
+	}
+	return &addon, nil
+}
+
+// CreateAddon provisions a database or cache and attaches it to a service.
+func (c *Client) CreateAddon(ws, proj, env, svc, kind, engine, version, plan string) (*Addon, error) {
+	fields := map[string]string{
+		"kind":   kind,
+		"engine": engine,
+		"plan":   plan,
+	}
+	if version != "" {
+		fields["version"] = version
+	}
+	payload, _ := json.Marshal(fields)
+	req, err := http.NewRequest("POST", c.apiURL(c.addonsPath(ws, proj, env, svc)), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var addon Addon
+	if err := json.Unmarshal(body, &addon); err != nil {
+		return nil, fmt.Errorf("parsing addon response: %w", err)
+	}
+	return &addon, nil
+}
+
+// UpdateAddon changes the plan or version of an addon.
+func (c *Client) UpdateAddon(ws, proj, env, svc, addonID string, fields map[string]any) (*Addon, error) {
+	payload, _ := json.Marshal(fields)
+	req, err := http.NewRequest("PATCH", c.apiURL(c.addonsPath(ws, proj, env, svc)+addonID), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var addon Addon
+	if err := json.Unmarshal(body, &addon); err != nil {
+		return nil, fmt.Errorf("parsing addon response: %w", err)
+	}
+	return &addon, nil
+}
+
+// GetAddonCredentials returns the connection details of an addon.
+func (c *Client) GetAddonCredentials(ws, proj, env, svc, addonID string) (*AddonCredentials, error) {
+	req, err := http.NewRequest("GET", c.apiURL(c.addonsPath(ws, proj, env, svc)+addonID+"/credentials"), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var creds AddonCredentials
+	if err := json.Unmarshal(body, &creds); err != nil {
+		return nil, fmt.Errorf("parsing addon credentials response: %w", err)
+	}
+	return &creds, nil
+}
+
+// DeleteAddon detaches and deprovisions an addon.
+func (c *Client) DeleteAddon(ws, proj, env, svc, addonID string) error {
+	req, err := http.NewRequest("DELETE", c.apiURL(c.addonsPath(ws, proj, env, svc)+addonID), nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req)
+	return err
+}
+
+// --- Domain API ---
+
+// DNSRecord is a record the platform needs in DNS to verify a custom
+// domain or issue its certificate. Status is "ok" once the record is seen
+// with the expected value, "missing" while it isn't.
+type DNSRecord struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Status string `json:"status"`
+}
+
+// Domain is a custom domain of a service. VerificationStatus is
+// "pending", "verified", or "failed"; CertificateStatus is "pending",
+// "issued", or "failed".
+type Domain struct {
+	ID                 string      `json:"id"`
+	Domain             string      `json:"domain"`
+	VerificationStatus string      `json:"verification_status"`
+	CertificateStatus  string      `json:"certificate_status"`
+	ErrorDetail        string      `json:"error_detail"`
+	DNSRecords         []DNSRecord `json:"dns_records"`
+}
+
+// MissingRecords returns the DNS records that aren't in place yet.
+func (d Domain) MissingRecords() []DNSRecord {
+	var missing []DNSRecord
+	for _, r := range d.DNSRecords {
+		if r.Status != "ok" {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// GetDomain returns a custom domain of a service, with the DNS records it
+// needs and whether each is in place.
+func (c *Client) GetDomain(ws, proj, env, svc, domain string) (*Domain, error) {
+	req, err := http.NewRequest("GET", c.apiURL("/workspaces/"+ws+"/projects/"+proj+"/envs/"+env+"/services/"+svc+"/domains/"+url.PathEscape(domain)), nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var d Domain
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, fmt.Errorf("parsing domain response: %w", err)
+	}
+	return &d, nil
+}
+
+// --- API key API ---
+
+// CreatedAPIKey is a newly minted API key. Key holds the secret, which the
+// server only returns on creation.
+type CreatedAPIKey struct {
+	ID        string   `json:"key_id"`
+	Key       string   `json:"key"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	Created   string   `json:"created"`
+	ExpiresAt string   `json:"expires_at"`
+}
+
+// APIKey is an existing API key, without its secret.
+type APIKey struct {
+	ID        string   `json:"key_id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	Created   string   `json:"created"`
+	ExpiresAt string   `json:"expires_at"`
+	Revoked   bool     `json:"revoked"`
+}
+
+// CreateAPIKey mints an API key that expires at expiresAt (RFC 3339), or
+// never when expiresAt is empty. Metadata is stored with the key to record
+// what it is scoped to.
+func (c *Client) CreateAPIKey(name string, scopes []string, expiresAt string, metadata map[string]string) (*CreatedAPIKey, error) {
+	payload := map[string]any{"name": name, "scopes": scopes}
+	if expiresAt != "" {
+		payload["expires_at"] = expiresAt
+	}
+	if len(metadata) > 0 {
+		payload["metadata"] = metadata
+	}
+	data, _ := json.Marshal(payload)
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/keys", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var key CreatedAPIKey
+	if err := json.Unmarshal(body, &key); err != nil {
+		return nil, fmt.Errorf("parsing API key response: %w", err)
+	}
+	return &key, nil
+}
+
+// ListAPIKeys returns the API keys of the authenticated user.
+func (c *Client) ListAPIKeys() ([]APIKey, error) {
+	req, err := http.NewRequest("GET", c.BaseURL+"/api/keys", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, fmt.Errorf("parsing API keys response: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey deactivates an API key.
+func (c *Client) RevokeAPIKey(keyID string) error {
+	req, err := http.NewRequest("POST", c.BaseURL+"/api/keys/"+keyID+"/revoke", nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doRequest(req)
+	return err
+}
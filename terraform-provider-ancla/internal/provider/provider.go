@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -14,11 +15,13 @@ import (
 	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
 	"github.com/sidequest-labs/terraform-provider-ancla/internal/resources"
 	datasources "github.com/sidequest-labs/terraform-provider-ancla/internal/resources/datasources"
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/resources/ephemerals"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &AnclaProvider{}
+	_ provider.Provider                       = &AnclaProvider{}
+	_ provider.ProviderWithEphemeralResources = &AnclaProvider{}
 )
 
 // AnclaProvider is the provider implementation.
@@ -28,8 +31,10 @@ type AnclaProvider struct {
 
 // AnclaProviderModel maps provider schema data to a Go type.
 type AnclaProviderModel struct {
-	Server types.String `tfsdk:"server"`
-	APIKey types.String `tfsdk:"api_key"`
+	Server    types.String `tfsdk:"server"`
+	APIKey    types.String `tfsdk:"api_key"`
+	Workspace types.String `tfsdk:"workspace"`
+	Project   types.String `tfsdk:"project"`
 }
 
 // New returns a function that creates new provider instances.
@@ -59,6 +64,14 @@ func (p *AnclaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"workspace": schema.StringAttribute{
+				Description: "The default workspace slug for resources and data sources that don't set workspace_slug.",
+				Optional:    true,
+			},
+			"project": schema.StringAttribute{
+				Description: "The default project slug for resources and data sources that don't set project_slug. Not applied to ancla_config_var, where leaving project_slug unset selects workspace scope.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -96,8 +109,11 @@ func (p *AnclaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 
 	c := client.New(server, apiKey)
+	c.DefaultWorkspace = config.Workspace.ValueString()
+	c.DefaultProject = config.Project.ValueString()
 	resp.DataSourceData = c
 	resp.ResourceData = c
+	resp.EphemeralResourceData = c
 }
 
 func (p *AnclaProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -107,6 +123,10 @@ func (p *AnclaProvider) Resources(_ context.Context) []func() resource.Resource
 		resources.NewEnvironmentResource,
 		resources.NewServiceResource,
 		resources.NewConfigResource,
+		resources.NewDatabaseResource,
+		resources.NewCacheResource,
+		resources.NewAPITokenResource,
+		resources.NewDomainVerificationResource,
 	}
 }
 
@@ -116,5 +136,13 @@ func (p *AnclaProvider) DataSources(_ context.Context) []func() datasource.DataS
 		datasources.NewProjectDataSource,
 		datasources.NewEnvironmentDataSource,
 		datasources.NewServiceDataSource,
+		datasources.NewPipelineStatusDataSource,
+		datasources.NewLatestBuildDataSource,
+	}
+}
+
+func (p *AnclaProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemerals.NewDeployTokenEphemeralResource,
 	}
 }
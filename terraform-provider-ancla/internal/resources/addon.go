@@ -0,0 +1,312 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var (
+	_ resource.Resource                = &AddonResource{}
+	_ resource.ResourceWithImportState = &AddonResource{}
+	_ resource.ResourceWithModifyPlan  = &AddonResource{}
+)
+
+// AddonResource manages a managed data service attached to an Ancla
+// service. The same implementation backs ancla_database and ancla_cache;
+// kind selects which one.
+type AddonResource struct {
+	client *client.Client
+	kind   string // "database" or "cache"
+	noun   string // used in descriptions and error messages
+}
+
+// AddonResourceModel maps the resource schema data.
+type AddonResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
+	ProjectSlug   types.String `tfsdk:"project_slug"`
+	EnvSlug       types.String `tfsdk:"env_slug"`
+	ServiceSlug   types.String `tfsdk:"service_slug"`
+	Engine        types.String `tfsdk:"engine"`
+	Version       types.String `tfsdk:"version"`
+	Plan          types.String `tfsdk:"plan"`
+	Status        types.String `tfsdk:"status"`
+	ConnectionURL types.String `tfsdk:"connection_url"`
+}
+
+func NewDatabaseResource() resource.Resource {
+	return &AddonResource{kind: "database", noun: "database"}
+}
+
+func NewCacheResource() resource.Resource {
+	return &AddonResource{kind: "cache", noun: "cache"}
+}
+
+func (r *AddonResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind
+}
+
+func (r *AddonResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	engines := "postgres or mysql"
+	if r.kind == "cache" {
+		engines = "redis or valkey"
+	}
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages a managed %s attached to an Ancla service.", r.noun),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: fmt.Sprintf("The unique identifier of the %s.", r.noun),
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_slug": schema.StringAttribute{
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				Description: "The slug of the project. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"env_slug": schema.StringAttribute{
+				Description: "The slug of the environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_slug": schema.StringAttribute{
+				Description: fmt.Sprintf("The slug of the service the %s is attached to.", r.noun),
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"engine": schema.StringAttribute{
+				Description: fmt.Sprintf("The %s engine: %s.", r.noun, engines),
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				Description: "The engine version. Defaults to the platform's current default; can be upgraded in place.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"plan": schema.StringAttribute{
+				Description: "The plan (size) of the instance. Can be changed in place.",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "The provisioning status reported by the platform.",
+				Computed:    true,
+			},
+			"connection_url": schema.StringAttribute{
+				Description: fmt.Sprintf("The URL used to connect to the %s, including credentials.", r.noun),
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *AddonResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *AddonResource) addonSlugs(model *AddonResourceModel) (ws, proj, env, svc string) {
+	return model.WorkspaceSlug.ValueString(),
+		model.ProjectSlug.ValueString(),
+		model.EnvSlug.ValueString(),
+		model.ServiceSlug.ValueString()
+}
+
+func (r *AddonResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyProviderDefaults(ctx, r.client, req, resp, "workspace_slug", "project_slug")
+}
+
+func (r *AddonResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AddonResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ws, proj, env, svc := r.addonSlugs(&plan)
+	version := ""
+	if !plan.Version.IsNull() && !plan.Version.IsUnknown() {
+		version = plan.Version.ValueString()
+	}
+
+	addon, err := r.client.CreateAddon(ws, proj, env, svc, r.kind,
+		plan.Engine.ValueString(), version, plan.Plan.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating "+r.noun, err.Error())
+		return
+	}
+
+	r.mapAddonToState(addon, &plan)
+	r.readConnectionURL(&plan, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AddonResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AddonResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ws, proj, env, svc := r.addonSlugs(&state)
+
+	addon, err := r.client.GetAddon(ws, proj, env, svc, state.ID.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading "+r.noun, err.Error())
+		return
+	}
+
+	r.mapAddonToState(addon, &state)
+	r.readConnectionURL(&state, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AddonResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AddonResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state AddonResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fields := map[string]any{
+		"plan": plan.Plan.ValueString(),
+	}
+	if !plan.Version.IsNull() && !plan.Version.IsUnknown() {
+		fields["version"] = plan.Version.ValueString()
+	}
+
+	ws, proj, env, svc := r.addonSlugs(&state)
+
+	addon, err := r.client.UpdateAddon(ws, proj, env, svc, state.ID.ValueString(), fields)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating "+r.noun, err.Error())
+		return
+	}
+
+	r.mapAddonToState(addon, &plan)
+	// Credentials can rotate when an instance is moved to a new plan.
+	r.readConnectionURL(&plan, &resp.Diagnostics)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AddonResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AddonResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ws, proj, env, svc := r.addonSlugs(&state)
+
+	if err := r.client.DeleteAddon(ws, proj, env, svc, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error deleting "+r.noun, err.Error())
+		return
+	}
+}
+
+func (r *AddonResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: ws/proj/env/svc/addon-id or id:<addon-id>
+	if importByID(ctx, r.client, r.kind, req, resp, func(ref *client.ResourceRef) map[string]string {
+		return map[string]string{
+			"workspace_slug": ref.WorkspaceSlug,
+			"project_slug":   ref.ProjectSlug,
+			"env_slug":       ref.EnvSlug,
+			"service_slug":   ref.ServiceSlug,
+		}
+	}) {
+		return
+	}
+	parts := strings.SplitN(req.ID, "/", 5)
+	if len(parts) != 5 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" || parts[4] == "" {
+		ImportFormats[r.kind].addError(resp)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_slug"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_slug"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("env_slug"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_slug"), parts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[4])...)
+}
+
+func (r *AddonResource) mapAddonToState(addon *client.Addon, model *AddonResourceModel) {
+	model.ID = types.StringValue(addon.ID)
+	model.Engine = types.StringValue(addon.Engine)
+	model.Version = types.StringValue(addon.Version)
+	model.Plan = types.StringValue(addon.Plan)
+	model.Status = types.StringValue(addon.Status)
+}
+
+// readConnectionURL fetches the credentials of the addon in model. The
+// URL is kept out of the addon payload so it is only read when needed.
+func (r *AddonResource) readConnectionURL(model *AddonResourceModel, diags *diag.Diagnostics) {
+	ws, proj, env, svc := r.addonSlugs(model)
+	creds, err := r.client.GetAddonCredentials(ws, proj, env, svc, model.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading "+r.noun+" credentials", err.Error())
+		return
+	}
+	model.ConnectionURL = types.StringValue(creds.ConnectionURL)
+}
@@ -0,0 +1,271 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var (
+	_ resource.Resource               = &APITokenResource{}
+	_ resource.ResourceWithModifyPlan = &APITokenResource{}
+)
+
+// APITokenResource manages a long-lived, scoped API key whose secret is
+// kept in state. Unlike the ancla_deploy_token ephemeral resource it
+// outlives the run, so it can be handed to systems outside Terraform.
+type APITokenResource struct {
+	client *client.Client
+}
+
+// APITokenResourceModel maps the resource schema data.
+type APITokenResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Scopes        types.List   `tfsdk:"scopes"`
+	RotationDays  types.Int64  `tfsdk:"rotation_days"`
+	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
+	ProjectSlug   types.String `tfsdk:"project_slug"`
+	EnvSlug       types.String `tfsdk:"env_slug"`
+	ServiceSlug   types.String `tfsdk:"service_slug"`
+	Token         types.String `tfsdk:"token"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	RotateAt      types.String `tfsdk:"rotate_at"`
+}
+
+func NewAPITokenResource() resource.Resource {
+	return &APITokenResource{}
+}
+
+func (r *APITokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *APITokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	keepComputed := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	replaceScope := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	resp.Schema = schema.Schema{
+		Description: "Manages a scoped API key. The token is stored in state as a sensitive value. " +
+			"With rotation_days set, the token is replaced on the first plan after it is due.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The ID of the API key.",
+				Computed:      true,
+				PlanModifiers: keepComputed,
+			},
+			"name": schema.StringAttribute{
+				Description:   "A name for the key, shown in the key list. Changing this forces a new token.",
+				Required:      true,
+				PlanModifiers: replaceScope,
+			},
+			"scopes": schema.ListAttribute{
+				Description: `The permissions granted to the token. Defaults to ["deploy"]. Changing this forces a new token.`,
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_days": schema.Int64Attribute{
+				Description: "Replace the token this many days after it was created. The replacement happens on the first apply after it is due. Changing this forces a new token.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"workspace_slug": schema.StringAttribute{
+				Description:   "The slug of the workspace the token is for, recorded with the key.",
+				Optional:      true,
+				PlanModifiers: replaceScope,
+			},
+			"project_slug": schema.StringAttribute{
+				Description:   "The slug of the project the token is for, recorded with the key.",
+				Optional:      true,
+				PlanModifiers: replaceScope,
+			},
+			"env_slug": schema.StringAttribute{
+				Description:   "The slug of the environment the token is for, recorded with the key.",
+				Optional:      true,
+				PlanModifiers: replaceScope,
+			},
+			"service_slug": schema.StringAttribute{
+				Description:   "The slug of the service the token is for, recorded with the key.",
+				Optional:      true,
+				PlanModifiers: replaceScope,
+			},
+			"token": schema.StringAttribute{
+				Description:   "The API key itself.",
+				Computed:      true,
+				Sensitive:     true,
+				PlanModifiers: keepComputed,
+			},
+			"created_at": schema.StringAttribute{
+				Description:   "When the token was created, in RFC 3339 format.",
+				Computed:      true,
+				PlanModifiers: keepComputed,
+			},
+			"rotate_at": schema.StringAttribute{
+				Description:   "When the token is due for replacement, in RFC 3339 format. Empty without rotation_days.",
+				Computed:      true,
+				PlanModifiers: keepComputed,
+			},
+		},
+	}
+}
+
+func (r *APITokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+// ModifyPlan validates rotation_days and forces a replacement once the
+// token in state is past its rotate_at time.
+func (r *APITokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var days types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotation_days"), &days)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !days.IsNull() && !days.IsUnknown() && days.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("rotation_days"), "Invalid rotation_days",
+			fmt.Sprintf("Expected at least 1 day, got %d.", days.ValueInt64()))
+		return
+	}
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var rotateAt types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("rotate_at"), &rotateAt)...)
+	if resp.Diagnostics.HasError() || rotateAt.ValueString() == "" {
+		return
+	}
+	due, err := time.Parse(time.RFC3339, rotateAt.ValueString())
+	if err != nil || time.Now().Before(due) {
+		return
+	}
+	for _, attr := range []string{"id", "token", "created_at", "rotate_at"} {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attr), types.StringUnknown())...)
+	}
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rotate_at"))
+}
+
+func (r *APITokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan APITokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scopes := []string{"deploy"}
+	if !plan.Scopes.IsNull() && !plan.Scopes.IsUnknown() {
+		resp.Diagnostics.Append(plan.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	metadata := map[string]string{"created_by": "terraform"}
+	for key, v := range map[string]types.String{
+		"workspace": plan.WorkspaceSlug,
+		"project":   plan.ProjectSlug,
+		"env":       plan.EnvSlug,
+		"service":   plan.ServiceSlug,
+	} {
+		if !v.IsNull() && !v.IsUnknown() {
+			metadata[key] = v.ValueString()
+		}
+	}
+
+	key, err := r.client.CreateAPIKey(plan.Name.ValueString(), scopes, "", metadata)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	created := time.Now().UTC()
+	if t, err := time.Parse(time.RFC3339, key.Created); err == nil {
+		created = t.UTC()
+	}
+	plan.ID = types.StringValue(key.ID)
+	plan.Token = types.StringValue(key.Key)
+	plan.CreatedAt = types.StringValue(created.Format(time.RFC3339))
+	plan.RotateAt = types.StringValue("")
+	if !plan.RotationDays.IsNull() {
+		days := int(plan.RotationDays.ValueInt64())
+		plan.RotateAt = types.StringValue(created.AddDate(0, 0, days).Format(time.RFC3339))
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *APITokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state APITokenResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := r.client.ListAPIKeys()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading API token", err.Error())
+		return
+	}
+	for _, key := range keys {
+		if key.ID == state.ID.ValueString() && !key.Revoked {
+			return
+		}
+	}
+	// Revoked or deleted outside Terraform: plan a new token.
+	resp.State.RemoveResource(ctx)
+}
+
+// Update only runs when nothing but computed values would change, since
+// every configurable attribute forces a new token.
+func (r *APITokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan APITokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *APITokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state APITokenResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.RevokeAPIKey(state.ID.ValueString()); err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Error revoking API token", err.Error())
+	}
+}
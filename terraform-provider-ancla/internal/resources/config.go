@@ -20,6 +20,7 @@ import (
 var (
 	_ resource.Resource                = &ConfigResource{}
 	_ resource.ResourceWithImportState = &ConfigResource{}
+	_ resource.ResourceWithModifyPlan  = &ConfigResource{}
 )
 
 // ConfigResource manages an Ancla configuration variable.
@@ -29,16 +30,17 @@ type ConfigResource struct {
 
 // ConfigResourceModel maps the resource schema data.
 type ConfigResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
-	ProjectSlug   types.String `tfsdk:"project_slug"`
-	EnvSlug       types.String `tfsdk:"env_slug"`
-	ServiceSlug   types.String `tfsdk:"service_slug"`
-	Name          types.String `tfsdk:"name"`
-	Value         types.String `tfsdk:"value"`
-	Secret        types.Bool   `tfsdk:"secret"`
-	Buildtime     types.Bool   `tfsdk:"buildtime"`
-	Scope         types.String `tfsdk:"scope"`
+	ID              types.String `tfsdk:"id"`
+	WorkspaceSlug   types.String `tfsdk:"workspace_slug"`
+	ProjectSlug     types.String `tfsdk:"project_slug"`
+	EnvSlug         types.String `tfsdk:"env_slug"`
+	ServiceSlug     types.String `tfsdk:"service_slug"`
+	Name            types.String `tfsdk:"name"`
+	Value           types.String `tfsdk:"value"`
+	Secret          types.Bool   `tfsdk:"secret"`
+	Buildtime       types.Bool   `tfsdk:"buildtime"`
+	Scope           types.String `tfsdk:"scope"`
+	TriggerRedeploy types.Bool   `tfsdk:"trigger_redeploy"`
 }
 
 func NewConfigResource() resource.Resource {
@@ -61,9 +63,11 @@ func (r *ConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace.",
-				Required:    true,
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -121,6 +125,12 @@ func (r *ConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"trigger_redeploy": schema.BoolAttribute{
+				Description: "Whether to trigger a config-only deploy of the service after the variable is created, changed, or deleted, so the running service picks it up without waiting for its next deploy. Only valid for service scope.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -150,6 +160,34 @@ func (r *ConfigResource) configSlugs(model *ConfigResourceModel) (ws, proj, env,
 	return
 }
 
+// ModifyPlan fills in the provider's workspace and rejects trigger_redeploy
+// outside service scope, where there is no single service to redeploy.
+func (r *ConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyProviderDefaults(ctx, r.client, req, resp, "workspace_slug")
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	var redeploy types.Bool
+	var scope types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("trigger_redeploy"), &redeploy)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("scope"), &scope)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if redeploy.ValueBool() && !scope.IsUnknown() && scope.ValueString() != "service" {
+		resp.Diagnostics.AddAttributeError(path.Root("trigger_redeploy"), "Invalid trigger_redeploy",
+			fmt.Sprintf("trigger_redeploy needs service scope, but scope is %q. Set it on the service's own variables instead.", scope.ValueString()))
+	}
+}
+
+// redeploy triggers a config-only deploy of the service model belongs to,
+// the way `ancla config set --restart` does.
+func (r *ConfigResource) redeploy(model *ConfigResourceModel) error {
+	ws, proj, env, svc, _ := r.configSlugs(model)
+	_, err := r.client.TriggerConfigDeploy(ws, proj, env, svc)
+	return err
+}
+
 func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ConfigResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -180,6 +218,12 @@ func (r *ConfigResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	if plan.TriggerRedeploy.ValueBool() {
+		if err := r.redeploy(&plan); err != nil {
+			resp.Diagnostics.AddError("Error triggering config-only deploy", err.Error())
+		}
+	}
 }
 
 func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -220,6 +264,10 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.Name = types.StringValue(found.Name)
 	state.Secret = types.BoolValue(found.Secret)
 	state.Buildtime = types.BoolValue(found.Buildtime)
+	if state.TriggerRedeploy.IsNull() {
+		// Imported variables start out not redeploying.
+		state.TriggerRedeploy = types.BoolValue(false)
+	}
 	// Only update value if it is not a secret (secrets come back masked).
 	if !found.Secret {
 		state.Value = types.StringValue(found.Value)
@@ -230,12 +278,16 @@ func (r *ConfigResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ConfigResourceModel
+	var plan, state ConfigResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Turning trigger_redeploy on or off alone changes nothing the service sees.
+	changed := !plan.Value.Equal(state.Value) || !plan.Secret.Equal(state.Secret) || !plan.Buildtime.Equal(state.Buildtime)
 
 	ws, proj, env, svc, scope := r.configSlugs(&plan)
 
@@ -260,6 +312,12 @@ func (r *ConfigResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	if changed && plan.TriggerRedeploy.ValueBool() {
+		if err := r.redeploy(&plan); err != nil {
+			resp.Diagnostics.AddError("Error triggering config-only deploy", err.Error())
+		}
+	}
 }
 
 func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -276,15 +334,34 @@ func (r *ConfigResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		resp.Diagnostics.AddError("Error deleting config variable", err.Error())
 		return
 	}
+
+	// The variable is gone either way, so a failed redeploy only warns. The
+	// service may be being destroyed in the same apply.
+	if state.TriggerRedeploy.ValueBool() {
+		if err := r.redeploy(&state); err != nil && !client.IsNotFound(err) {
+			resp.Diagnostics.AddWarning("Error triggering config-only deploy",
+				fmt.Sprintf("%s was deleted, but the service was not redeployed: %s", state.Name.ValueString(), err))
+		}
+	}
 }
 
 func (r *ConfigResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: ws-slug/proj-slug/env-slug/svc-slug/config-id
-	// For non-service scopes, use "-" as placeholder for unused segments.
+	// Import ID format: ws-slug/proj-slug/env-slug/svc-slug/config-id or
+	// id:<config_id>. For non-service scopes, use "-" as placeholder for
+	// unused segments.
+	if importByID(ctx, r.client, "config", req, resp, func(ref *client.ResourceRef) map[string]string {
+		return map[string]string{
+			"workspace_slug": ref.WorkspaceSlug,
+			"project_slug":   ref.ProjectSlug,
+			"env_slug":       ref.EnvSlug,
+			"service_slug":   ref.ServiceSlug,
+		}
+	}) {
+		return
+	}
 	parts := strings.SplitN(req.ID, "/", 5)
 	if len(parts) != 5 || parts[0] == "" || parts[4] == "" {
-		resp.Diagnostics.AddError("Invalid import ID",
-			"Expected import ID format: <workspace_slug>/<project_slug>/<env_slug>/<service_slug>/<config_id>. Use '-' for unused scope segments.")
+		ImportFormats["config_var"].addError(resp)
 		return
 	}
 
@@ -0,0 +1,21 @@
+package datasources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// slugOrDefault sets an unset slug attribute to def, the value of the
+// provider argument named arg, so the lookup and the state both use it.
+func slugOrDefault(v *types.String, def, attr, arg string, diags *diag.Diagnostics) {
+	if !v.IsNull() {
+		return
+	}
+	if def == "" {
+		diags.AddAttributeError(path.Root(attr), "Missing "+attr,
+			"Set "+attr+" on the data source, or "+arg+" on the provider.")
+		return
+	}
+	*v = types.StringValue(def)
+}
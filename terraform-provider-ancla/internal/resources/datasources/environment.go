@@ -54,12 +54,14 @@ func (d *EnvironmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 				Required:    true,
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace.",
-				Required:    true,
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"project_slug": schema.StringAttribute{
-				Description: "The slug of the project.",
-				Required:    true,
+				Description: "The slug of the project. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"service_count": schema.Int64Attribute{
 				Description: "The number of services in the environment.",
@@ -94,6 +96,12 @@ func (d *EnvironmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
+	slugOrDefault(&config.WorkspaceSlug, d.client.DefaultWorkspace, "workspace_slug", "workspace", &resp.Diagnostics)
+	slugOrDefault(&config.ProjectSlug, d.client.DefaultProject, "project_slug", "project", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	env, err := d.client.GetEnvironment(
 		config.WorkspaceSlug.ValueString(),
 		config.ProjectSlug.ValueString(),
@@ -0,0 +1,185 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var _ datasource.DataSource = &LatestBuildDataSource{}
+
+// LatestBuildDataSource reads the newest build of a service with a given
+// status, so promotion can be keyed on an actual image.
+type LatestBuildDataSource struct {
+	client *client.Client
+}
+
+// LatestBuildDataSourceModel maps the data source schema data.
+type LatestBuildDataSourceModel struct {
+	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
+	ProjectSlug   types.String `tfsdk:"project_slug"`
+	EnvSlug       types.String `tfsdk:"env_slug"`
+	ServiceSlug   types.String `tfsdk:"service_slug"`
+	Status        types.String `tfsdk:"status"`
+	ID            types.String `tfsdk:"id"`
+	Version       types.Int64  `tfsdk:"version"`
+	Image         types.String `tfsdk:"image"`
+	Strategy      types.String `tfsdk:"strategy"`
+	Created       types.String `tfsdk:"created"`
+}
+
+// buildStatuses are the values the status filter accepts.
+var buildStatuses = []string{"success", "error", "building"}
+
+func NewLatestBuildDataSource() datasource.DataSource {
+	return &LatestBuildDataSource{}
+}
+
+func (d *LatestBuildDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_latest_build"
+}
+
+func (d *LatestBuildDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the newest build of an Ancla service, by default the newest successful one.",
+		Attributes: map[string]schema.Attribute{
+			"workspace_slug": schema.StringAttribute{
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"project_slug": schema.StringAttribute{
+				Description: "The slug of the project. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"env_slug": schema.StringAttribute{
+				Description: "The slug of the environment.",
+				Required:    true,
+			},
+			"service_slug": schema.StringAttribute{
+				Description: "The slug of the service.",
+				Required:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Only consider builds with this status: success, error, or building. Defaults to success.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The ID of the build.",
+				Computed:    true,
+			},
+			"version": schema.Int64Attribute{
+				Description: "The version number of the build.",
+				Computed:    true,
+			},
+			"image": schema.StringAttribute{
+				Description: "The registry reference of the image the build produced. Empty unless the build succeeded.",
+				Computed:    true,
+			},
+			"strategy": schema.StringAttribute{
+				Description: "How the service was built: dockerfile or buildpack.",
+				Computed:    true,
+			},
+			"created": schema.StringAttribute{
+				Description: "When the build was started, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *LatestBuildDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	d.client = c
+}
+
+func (d *LatestBuildDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config LatestBuildDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slugOrDefault(&config.WorkspaceSlug, d.client.DefaultWorkspace, "workspace_slug", "workspace", &resp.Diagnostics)
+	slugOrDefault(&config.ProjectSlug, d.client.DefaultProject, "project_slug", "project", &resp.Diagnostics)
+	if config.Status.IsNull() {
+		config.Status = types.StringValue("success")
+	}
+	status := config.Status.ValueString()
+	if !validBuildStatus(status) {
+		resp.Diagnostics.AddAttributeError(path.Root("status"), "Invalid status",
+			fmt.Sprintf("status must be one of %v, got %q.", buildStatuses, status))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	builds, err := d.client.ListBuilds(
+		config.WorkspaceSlug.ValueString(),
+		config.ProjectSlug.ValueString(),
+		config.EnvSlug.ValueString(),
+		config.ServiceSlug.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading builds", err.Error())
+		return
+	}
+
+	build := latestBuild(builds, status)
+	if build == nil {
+		resp.Diagnostics.AddError("No matching build",
+			fmt.Sprintf("Service %q in environment %q has no build with status %q.",
+				config.ServiceSlug.ValueString(), config.EnvSlug.ValueString(), status))
+		return
+	}
+	strategy := build.Strategy
+	if strategy == "" {
+		strategy = "dockerfile"
+	}
+	config.ID = types.StringValue(build.ID)
+	config.Version = types.Int64Value(int64(build.Version))
+	config.Image = types.StringValue(build.Image)
+	config.Strategy = types.StringValue(strategy)
+	config.Created = types.StringValue(build.Created)
+
+	diags = resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func validBuildStatus(status string) bool {
+	for _, s := range buildStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// latestBuild returns the highest-versioned build with the given status,
+// or nil if there is none.
+func latestBuild(builds []client.Build, status string) *client.Build {
+	var latest *client.Build
+	for i, b := range builds {
+		if b.Status() == status && (latest == nil || b.Version > latest.Version) {
+			latest = &builds[i]
+		}
+	}
+	return latest
+}
@@ -0,0 +1,175 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var _ datasource.DataSource = &PipelineStatusDataSource{}
+
+// PipelineStatusDataSource reads the current build and deploy state of a
+// service, so promotion can be gated on it.
+type PipelineStatusDataSource struct {
+	client *client.Client
+}
+
+// PipelineStatusDataSourceModel maps the data source schema data.
+type PipelineStatusDataSourceModel struct {
+	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
+	ProjectSlug   types.String `tfsdk:"project_slug"`
+	EnvSlug       types.String `tfsdk:"env_slug"`
+	ServiceSlug   types.String `tfsdk:"service_slug"`
+	BuildID       types.String `tfsdk:"build_id"`
+	BuildVersion  types.Int64  `tfsdk:"build_version"`
+	BuildStatus   types.String `tfsdk:"build_status"`
+	BuildError    types.String `tfsdk:"build_error"`
+	DeployID      types.String `tfsdk:"deploy_id"`
+	DeployBuildID types.String `tfsdk:"deploy_build_id"`
+	DeployStatus  types.String `tfsdk:"deploy_status"`
+	DeployError   types.String `tfsdk:"deploy_error"`
+	InProgress    types.Bool   `tfsdk:"in_progress"`
+}
+
+func NewPipelineStatusDataSource() datasource.DataSource {
+	return &PipelineStatusDataSource{}
+}
+
+func (d *PipelineStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline_status"
+}
+
+func (d *PipelineStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the latest build and deploy of an Ancla service.",
+		Attributes: map[string]schema.Attribute{
+			"workspace_slug": schema.StringAttribute{
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"project_slug": schema.StringAttribute{
+				Description: "The slug of the project. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"env_slug": schema.StringAttribute{
+				Description: "The slug of the environment.",
+				Required:    true,
+			},
+			"service_slug": schema.StringAttribute{
+				Description: "The slug of the service.",
+				Required:    true,
+			},
+			"build_id": schema.StringAttribute{
+				Description: "The ID of the latest build. Empty if the service has never been built.",
+				Computed:    true,
+			},
+			"build_version": schema.Int64Attribute{
+				Description: "The version number of the latest build.",
+				Computed:    true,
+			},
+			"build_status": schema.StringAttribute{
+				Description: "The status of the latest build, e.g. building, success, or error.",
+				Computed:    true,
+			},
+			"build_error": schema.StringAttribute{
+				Description: "The error detail of the latest build, if it failed.",
+				Computed:    true,
+			},
+			"deploy_id": schema.StringAttribute{
+				Description: "The ID of the latest deploy. Empty if the service has never been deployed.",
+				Computed:    true,
+			},
+			"deploy_build_id": schema.StringAttribute{
+				Description: "The ID of the build the latest deploy is running.",
+				Computed:    true,
+			},
+			"deploy_status": schema.StringAttribute{
+				Description: "The status of the latest deploy, e.g. deploying, success, or error.",
+				Computed:    true,
+			},
+			"deploy_error": schema.StringAttribute{
+				Description: "The error detail of the latest deploy, if it failed.",
+				Computed:    true,
+			},
+			"in_progress": schema.BoolAttribute{
+				Description: "Whether a build or deploy is still running.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PipelineStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	d.client = c
+}
+
+func (d *PipelineStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PipelineStatusDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slugOrDefault(&config.WorkspaceSlug, d.client.DefaultWorkspace, "workspace_slug", "workspace", &resp.Diagnostics)
+	slugOrDefault(&config.ProjectSlug, d.client.DefaultProject, "project_slug", "project", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := d.client.GetPipelineStatus(
+		config.WorkspaceSlug.ValueString(),
+		config.ProjectSlug.ValueString(),
+		config.EnvSlug.ValueString(),
+		config.ServiceSlug.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading pipeline status", err.Error())
+		return
+	}
+
+	build, deploy := status.Build, status.Deploy
+	if build == nil {
+		build = &client.PipelineStage{}
+	}
+	if deploy == nil {
+		deploy = &client.PipelineStage{}
+	}
+	config.BuildID = types.StringValue(build.ID)
+	config.BuildVersion = types.Int64Value(int64(build.Version))
+	config.BuildStatus = types.StringValue(build.Status)
+	config.BuildError = types.StringValue(build.ErrorDetail)
+	config.DeployID = types.StringValue(deploy.ID)
+	config.DeployBuildID = types.StringValue(deploy.BuildID)
+	config.DeployStatus = types.StringValue(deploy.Status)
+	config.DeployError = types.StringValue(deploy.ErrorDetail)
+	config.InProgress = types.BoolValue(stageRunning(build.Status) || stageRunning(deploy.Status))
+
+	diags = resp.State.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// stageRunning reports whether a stage status means it hasn't finished.
+func stageRunning(status string) bool {
+	switch status {
+	case "", "success", "complete", "error", "failed", "cancelled":
+		return false
+	}
+	return true
+}
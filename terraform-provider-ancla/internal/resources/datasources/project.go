@@ -54,8 +54,9 @@ func (d *ProjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Required:    true,
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace this project belongs to.",
-				Required:    true,
+				Description: "The slug of the workspace this project belongs to. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"service_count": schema.Int64Attribute{
 				Description: "The number of services in the project.",
@@ -94,6 +95,11 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	slugOrDefault(&config.WorkspaceSlug, d.client.DefaultWorkspace, "workspace_slug", "workspace", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	project, err := d.client.GetProject(config.WorkspaceSlug.ValueString(), config.Slug.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading project", err.Error())
@@ -57,12 +57,14 @@ func (d *ServiceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Required:    true,
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace.",
-				Required:    true,
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"project_slug": schema.StringAttribute{
-				Description: "The slug of the project.",
-				Required:    true,
+				Description: "The slug of the project. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"env_slug": schema.StringAttribute{
 				Description: "The slug of the environment.",
@@ -110,6 +112,12 @@ func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
+	slugOrDefault(&config.WorkspaceSlug, d.client.DefaultWorkspace, "workspace_slug", "workspace", &resp.Diagnostics)
+	slugOrDefault(&config.ProjectSlug, d.client.DefaultProject, "project_slug", "project", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	svc, err := d.client.GetService(
 		config.WorkspaceSlug.ValueString(),
 		config.ProjectSlug.ValueString(),
@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+// applyProviderDefaults fills the given slug attributes from the
+// provider's workspace and project arguments when a new resource leaves
+// them unset. Existing resources keep the slugs in state (see
+// UseStateForUnknown on those attributes), so changing the provider
+// defaults never moves or replaces them.
+func applyProviderDefaults(ctx context.Context, c *client.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attrs ...string) {
+	if c == nil || req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+	for _, attr := range attrs {
+		var v types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attr), &v)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !v.IsNull() {
+			continue
+		}
+		def, arg := c.DefaultWorkspace, "workspace"
+		if attr == "project_slug" {
+			def, arg = c.DefaultProject, "project"
+		}
+		if def == "" {
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Missing "+attr,
+				"Set "+attr+" on the resource, or "+arg+" on the provider.")
+			continue
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attr), def)...)
+	}
+}
@@ -0,0 +1,291 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var (
+	_ resource.Resource               = &DomainVerificationResource{}
+	_ resource.ResourceWithModifyPlan = &DomainVerificationResource{}
+)
+
+const (
+	defaultDomainTimeout      = "15m"
+	defaultDomainPollInterval = 10 * time.Second
+)
+
+// DomainVerificationResource waits for a custom domain of a service to be
+// verified and get its certificate. It manages nothing on the platform:
+// creating it is the wait, and destroying it only drops it from state.
+type DomainVerificationResource struct {
+	client       *client.Client
+	pollInterval time.Duration
+}
+
+// DomainVerificationResourceModel maps the resource schema data.
+type DomainVerificationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	WorkspaceSlug      types.String `tfsdk:"workspace_slug"`
+	ProjectSlug        types.String `tfsdk:"project_slug"`
+	EnvSlug            types.String `tfsdk:"env_slug"`
+	ServiceSlug        types.String `tfsdk:"service_slug"`
+	Domain             types.String `tfsdk:"domain"`
+	Timeout            types.String `tfsdk:"timeout"`
+	VerificationStatus types.String `tfsdk:"verification_status"`
+	CertificateStatus  types.String `tfsdk:"certificate_status"`
+}
+
+func NewDomainVerificationResource() resource.Resource {
+	return &DomainVerificationResource{pollInterval: defaultDomainPollInterval}
+}
+
+func (r *DomainVerificationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_domain_verification"
+}
+
+func (r *DomainVerificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Waits until a custom domain of a service is verified and its certificate is issued, " +
+			"so resources that depend on it only run once the domain serves traffic. " +
+			"The apply fails with the DNS records that are still missing if that doesn't happen within timeout. " +
+			"Destroying it only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workspace_slug": schema.StringAttribute{
+				Description: "The slug of the workspace. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_slug": schema.StringAttribute{
+				Description: "The slug of the project. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"env_slug": schema.StringAttribute{
+				Description: "The slug of the environment.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_slug": schema.StringAttribute{
+				Description: "The slug of the service the domain belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "The custom domain to wait for, e.g. www.example.com.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait, as a Go duration such as 10m or 1h. Defaults to " + defaultDomainTimeout + ".",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultDomainTimeout),
+			},
+			"verification_status": schema.StringAttribute{
+				Description: "The DNS verification status of the domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_status": schema.StringAttribute{
+				Description: "The certificate status of the domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainVerificationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *DomainVerificationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyProviderDefaults(ctx, r.client, req, resp, "workspace_slug", "project_slug")
+}
+
+func (r *DomainVerificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DomainVerificationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := time.ParseDuration(plan.Timeout.ValueString())
+	if err != nil || timeout <= 0 {
+		resp.Diagnostics.AddError("Invalid timeout",
+			fmt.Sprintf("Expected a positive duration such as 10m or 1h, got %q.", plan.Timeout.ValueString()))
+		return
+	}
+
+	domain, err := r.waitForDomain(ctx, &plan, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Domain "+plan.Domain.ValueString()+" is not ready", err.Error())
+		return
+	}
+
+	mapDomainToState(domain, &plan)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *DomainVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DomainVerificationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.GetDomain(state.WorkspaceSlug.ValueString(), state.ProjectSlug.ValueString(),
+		state.EnvSlug.ValueString(), state.ServiceSlug.ValueString(), state.Domain.ValueString())
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading domain", err.Error())
+		return
+	}
+	// A domain that is no longer ready, e.g. because its records were
+	// removed, is dropped so the next apply waits for it again.
+	if !domainReady(domain) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	mapDomainToState(domain, &state)
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *DomainVerificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only timeout can change in place, and it only matters while waiting.
+	var plan DomainVerificationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *DomainVerificationResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Nothing to delete: the domain itself is managed elsewhere.
+}
+
+// waitForDomain polls the domain in model until it is verified and its
+// certificate is issued. It gives up when the platform reports a failure
+// or timeout passes, with an error that lists the DNS records still
+// missing.
+func (r *DomainVerificationResource) waitForDomain(ctx context.Context, model *DomainVerificationResourceModel, timeout time.Duration) (*client.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		domain, err := r.client.GetDomain(model.WorkspaceSlug.ValueString(), model.ProjectSlug.ValueString(),
+			model.EnvSlug.ValueString(), model.ServiceSlug.ValueString(), model.Domain.ValueString())
+		if err != nil {
+			if client.IsNotFound(err) {
+				return nil, fmt.Errorf("service %s has no domain %s; add it to the service first", model.ServiceSlug.ValueString(), model.Domain.ValueString())
+			}
+			return nil, err
+		}
+		switch {
+		case domainReady(domain):
+			return domain, nil
+		case domain.VerificationStatus == "failed":
+			return nil, fmt.Errorf("DNS verification failed%s", domainProblem(domain))
+		case domain.CertificateStatus == "failed":
+			return nil, fmt.Errorf("certificate issuance failed%s", domainProblem(domain))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("still not ready after %s (verification %s, certificate %s)%s",
+				timeout, domain.VerificationStatus, domain.CertificateStatus, domainProblem(domain))
+		}
+	}
+}
+
+// domainReady reports whether the domain is verified and has its
+// certificate.
+func domainReady(d *client.Domain) bool {
+	return d.VerificationStatus == "verified" && d.CertificateStatus == "issued"
+}
+
+// domainProblem describes what is holding the domain up: the platform's
+// error detail and each DNS record that isn't in place, exactly as it
+// must be created.
+func domainProblem(d *client.Domain) string {
+	var b strings.Builder
+	if d.ErrorDetail != "" {
+		b.WriteString(": " + d.ErrorDetail)
+	}
+	if missing := d.MissingRecords(); len(missing) > 0 {
+		b.WriteString("\n\nCreate these DNS records:")
+		for _, rec := range missing {
+			fmt.Fprintf(&b, "\n  %s %s %s", rec.Type, rec.Name, rec.Value)
+		}
+	}
+	return b.String()
+}
+
+func mapDomainToState(d *client.Domain, model *DomainVerificationResourceModel) {
+	model.ID = types.StringValue(d.ID)
+	if d.ID == "" {
+		model.ID = types.StringValue(model.Domain.ValueString())
+	}
+	model.VerificationStatus = types.StringValue(d.VerificationStatus)
+	model.CertificateStatus = types.StringValue(d.CertificateStatus)
+}
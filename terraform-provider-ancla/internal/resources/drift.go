@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+// locateByID looks up where the resource with the given ID lives now, so
+// Read follows renames made outside Terraform instead of losing the
+// resource. It returns nil when the ID is not known yet or the server has
+// no record of it; callers then fall back to the slugs in state, which
+// also decides whether the resource is really gone.
+func locateByID(c *client.Client, id types.String) (*client.ResourceRef, error) {
+	if id.IsNull() || id.IsUnknown() || id.ValueString() == "" {
+		return nil, nil
+	}
+	ref, err := c.LookupResource(id.ValueString())
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+	return ref, err
+}
+
+// slugFollowsName keeps a computed slug from the prior state unless the
+// name is changing, in which case the server may derive a new slug and the
+// plan shows it as known after apply.
+func slugFollowsName() planmodifier.String {
+	return slugFollowsNameModifier{}
+}
+
+type slugFollowsNameModifier struct{}
+
+func (m slugFollowsNameModifier) Description(_ context.Context) string {
+	return "Uses the prior slug unless the name changes."
+}
+
+func (m slugFollowsNameModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m slugFollowsNameModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+	var planName, stateName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if planName.Equal(stateName) {
+		resp.PlanValue = req.StateValue
+	}
+}
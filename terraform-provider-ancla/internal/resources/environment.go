@@ -3,12 +3,16 @@ package resources
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -18,6 +22,7 @@ import (
 var (
 	_ resource.Resource                = &EnvironmentResource{}
 	_ resource.ResourceWithImportState = &EnvironmentResource{}
+	_ resource.ResourceWithModifyPlan  = &EnvironmentResource{}
 )
 
 // EnvironmentResource manages an Ancla environment.
@@ -33,8 +38,27 @@ type EnvironmentResourceModel struct {
 	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
 	ProjectSlug   types.String `tfsdk:"project_slug"`
 	ServiceCount  types.Int64  `tfsdk:"service_count"`
+
+	RequiredReviewers types.List  `tfsdk:"required_reviewers"`
+	RequiredApprovals types.Int64 `tfsdk:"required_approvals"`
+	ConfirmProduction types.Bool  `tfsdk:"confirm_production"`
+	FreezeWindows     types.List  `tfsdk:"freeze_windows"`
+}
+
+// EnvironmentFreezeModel maps one entry of freeze_windows.
+type EnvironmentFreezeModel struct {
+	Window   types.String `tfsdk:"window"`
+	Timezone types.String `tfsdk:"timezone"`
+	Reason   types.String `tfsdk:"reason"`
 }
 
+// freezeWindowType is the object type of a freeze_windows entry.
+var freezeWindowType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"window":   types.StringType,
+	"timezone": types.StringType,
+	"reason":   types.StringType,
+}}
+
 func NewEnvironmentResource() resource.Resource {
 	return &EnvironmentResource{}
 }
@@ -66,16 +90,20 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace this environment belongs to.",
-				Required:    true,
+				Description: "The slug of the workspace this environment belongs to. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"project_slug": schema.StringAttribute{
-				Description: "The slug of the project this environment belongs to.",
-				Required:    true,
+				Description: "The slug of the project this environment belongs to. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -83,6 +111,41 @@ func (r *EnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Description: "The number of services in the environment.",
 				Computed:    true,
 			},
+			"required_reviewers": schema.ListAttribute{
+				Description: "Workspace members, by email, whose approval deploys to the environment wait for. Protection rules are only managed when one of required_reviewers, required_approvals or confirm_production is set.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"required_approvals": schema.Int64Attribute{
+				Description: "How many of required_reviewers must approve each deploy. Defaults to 1.",
+				Optional:    true,
+			},
+			"confirm_production": schema.BoolAttribute{
+				Description: "Whether deploys must be confirmed explicitly, with --confirm-production or by typing the environment name.",
+				Optional:    true,
+			},
+			"freeze_windows": schema.ListNestedAttribute{
+				Description: "Weekly windows during which deploys are refused. Only managed when set; an empty list removes every window.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"window": schema.StringAttribute{
+							Description: `The window, as "<Day> <HH:MM>-<Day> <HH:MM>" with three-letter days, e.g. "Fri 17:00-Mon 09:00".`,
+							Required:    true,
+						},
+						"timezone": schema.StringAttribute{
+							Description: "The IANA time zone of the window. Defaults to UTC.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("UTC"),
+						},
+						"reason": schema.StringAttribute{
+							Description: "Why deploys are frozen, shown to anyone who tries to deploy.",
+							Optional:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -100,6 +163,10 @@ func (r *EnvironmentResource) Configure(_ context.Context, req resource.Configur
 	r.client = c
 }
 
+func (r *EnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyProviderDefaults(ctx, r.client, req, resp, "workspace_slug", "project_slug")
+}
+
 func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan EnvironmentResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -118,11 +185,22 @@ func (r *EnvironmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	plan.ID = types.StringValue(env.ID)
-	plan.Slug = types.StringValue(env.Slug)
-	plan.Name = types.StringValue(env.Name)
-	plan.ServiceCount = types.Int64Value(int64(env.ServiceCount))
+	ws, proj := plan.WorkspaceSlug.ValueString(), plan.ProjectSlug.ValueString()
+	if managesProtection(&plan) || !plan.FreezeWindows.IsNull() {
+		r.applyDeployRules(ctx, ws, proj, env, &plan, nil, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			// The environment exists; keep it in state so it isn't orphaned.
+			r.mapEnvironmentToState(ctx, env, &plan, &resp.Diagnostics)
+			resp.State.Set(ctx, plan)
+			return
+		}
+		if env, err = r.client.GetEnvironment(ws, proj, env.Slug); err != nil {
+			resp.Diagnostics.AddError("Error reading environment", err.Error())
+			return
+		}
+	}
 
+	r.mapEnvironmentToState(ctx, env, &plan, &resp.Diagnostics)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -135,6 +213,18 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	// Follow renames: the ID is the key, slugs are refreshed from it.
+	ref, err := locateByID(r.client, state.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading environment", err.Error())
+		return
+	}
+	if ref != nil {
+		state.WorkspaceSlug = types.StringValue(ref.WorkspaceSlug)
+		state.ProjectSlug = types.StringValue(ref.ProjectSlug)
+		state.Slug = types.StringValue(ref.Slug)
+	}
+
 	env, err := r.client.GetEnvironment(
 		state.WorkspaceSlug.ValueString(),
 		state.ProjectSlug.ValueString(),
@@ -149,11 +239,7 @@ func (r *EnvironmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	state.ID = types.StringValue(env.ID)
-	state.Name = types.StringValue(env.Name)
-	state.Slug = types.StringValue(env.Slug)
-	state.ServiceCount = types.Int64Value(int64(env.ServiceCount))
-
+	r.mapEnvironmentToState(ctx, env, &state, &resp.Diagnostics)
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -173,26 +259,193 @@ func (r *EnvironmentResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	env, err := r.client.UpdateEnvironment(
-		state.WorkspaceSlug.ValueString(),
-		state.ProjectSlug.ValueString(),
-		state.Slug.ValueString(),
-		plan.Name.ValueString(),
-	)
+	ws, proj := state.WorkspaceSlug.ValueString(), state.ProjectSlug.ValueString()
+	env, err := r.client.UpdateEnvironment(ws, proj, state.Slug.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating environment", err.Error())
 		return
 	}
 
-	plan.ID = types.StringValue(env.ID)
-	plan.Slug = types.StringValue(env.Slug)
-	plan.Name = types.StringValue(env.Name)
-	plan.ServiceCount = types.Int64Value(int64(env.ServiceCount))
+	if managesProtection(&plan) || managesProtection(&state) || !plan.FreezeWindows.IsNull() || !state.FreezeWindows.IsNull() {
+		// The update response may leave out the rules; compare against
+		// what the server has now.
+		current, err := r.client.GetEnvironment(ws, proj, env.Slug)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading environment", err.Error())
+			return
+		}
+		r.applyDeployRules(ctx, ws, proj, current, &plan, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if env, err = r.client.GetEnvironment(ws, proj, env.Slug); err != nil {
+			resp.Diagnostics.AddError("Error reading environment", err.Error())
+			return
+		}
+	}
 
+	r.mapEnvironmentToState(ctx, env, &plan, &resp.Diagnostics)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// managesProtection reports whether the configuration manages the
+// environment's protection rules.
+func managesProtection(m *EnvironmentResourceModel) bool {
+	return !m.RequiredReviewers.IsNull() || !m.RequiredApprovals.IsNull() || !m.ConfirmProduction.IsNull()
+}
+
+// applyDeployRules brings the protection rules and freeze windows of env
+// in line with plan. state is the prior state, nil on create: rules it
+// managed that plan no longer sets are removed.
+func (r *EnvironmentResource) applyDeployRules(ctx context.Context, ws, proj string, env *client.Environment, plan, state *EnvironmentResourceModel, diags *diag.Diagnostics) {
+	slug := env.Slug
+	switch {
+	case managesProtection(plan):
+		var p client.EnvironmentProtection
+		if !plan.RequiredReviewers.IsNull() {
+			diags.Append(plan.RequiredReviewers.ElementsAs(ctx, &p.RequiredReviewers, false)...)
+			if diags.HasError() {
+				return
+			}
+		}
+		p.ConfirmProduction = plan.ConfirmProduction.ValueBool()
+		p.RequiredApprovals = int(plan.RequiredApprovals.ValueInt64())
+		if plan.RequiredApprovals.IsNull() && len(p.RequiredReviewers) > 0 {
+			p.RequiredApprovals = 1
+		}
+		var err error
+		if len(p.RequiredReviewers) == 0 && !p.ConfirmProduction {
+			err = r.client.DeleteEnvironmentProtection(ws, proj, slug)
+		} else {
+			err = r.client.SetEnvironmentProtection(ws, proj, slug, p)
+		}
+		if err != nil && !client.IsNotFound(err) {
+			diags.AddError("Error setting environment protection", err.Error())
+			return
+		}
+	case state != nil && managesProtection(state):
+		if err := r.client.DeleteEnvironmentProtection(ws, proj, slug); err != nil && !client.IsNotFound(err) {
+			diags.AddError("Error removing environment protection", err.Error())
+			return
+		}
+	}
+
+	if plan.FreezeWindows.IsNull() && (state == nil || state.FreezeWindows.IsNull()) {
+		return
+	}
+	var want []EnvironmentFreezeModel
+	if !plan.FreezeWindows.IsNull() {
+		diags.Append(plan.FreezeWindows.ElementsAs(ctx, &want, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+	key := func(window, tz, reason string) string { return window + "|" + tz + "|" + reason }
+	wanted := map[string]bool{}
+	for _, f := range want {
+		wanted[key(f.Window.ValueString(), f.Timezone.ValueString(), f.Reason.ValueString())] = true
+	}
+	have := map[string]bool{}
+	for _, f := range env.Freezes {
+		k := key(f.Window, f.Timezone, f.Reason)
+		if wanted[k] && !have[k] {
+			have[k] = true
+			continue
+		}
+		if err := r.client.DeleteFreezeWindow(ws, proj, slug, f.ID); err != nil && !client.IsNotFound(err) {
+			diags.AddError("Error removing freeze window", err.Error())
+			return
+		}
+	}
+	for _, f := range want {
+		k := key(f.Window.ValueString(), f.Timezone.ValueString(), f.Reason.ValueString())
+		if have[k] {
+			continue
+		}
+		have[k] = true
+		if _, err := r.client.CreateFreezeWindow(ws, proj, slug, client.FreezeWindow{
+			Window:   f.Window.ValueString(),
+			Timezone: f.Timezone.ValueString(),
+			Reason:   f.Reason.ValueString(),
+		}); err != nil {
+			diags.AddError("Error adding freeze window", fmt.Sprintf("%s: %s", f.Window.ValueString(), err))
+			return
+		}
+	}
+}
+
+func (r *EnvironmentResource) mapEnvironmentToState(ctx context.Context, env *client.Environment, model *EnvironmentResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(env.ID)
+	model.Name = types.StringValue(env.Name)
+	model.Slug = types.StringValue(env.Slug)
+	model.ServiceCount = types.Int64Value(int64(env.ServiceCount))
+
+	// Protection rules and freeze windows are only tracked when the config
+	// manages them, so rules set with the CLI don't show up as drift.
+	p := env.Protection
+	if p == nil {
+		p = &client.EnvironmentProtection{}
+	}
+	if !model.RequiredReviewers.IsNull() {
+		reviewers := p.RequiredReviewers
+		if reviewers == nil {
+			reviewers = []string{}
+		}
+		listVal, d := types.ListValueFrom(ctx, types.StringType, reviewers)
+		diags.Append(d...)
+		model.RequiredReviewers = listVal
+	}
+	if !model.RequiredApprovals.IsNull() {
+		model.RequiredApprovals = types.Int64Value(int64(p.RequiredApprovals))
+	}
+	if !model.ConfirmProduction.IsNull() {
+		model.ConfirmProduction = types.BoolValue(p.ConfirmProduction)
+	}
+
+	if !model.FreezeWindows.IsNull() {
+		// Keep the order of the prior model, which follows the config, so
+		// a server that lists windows differently doesn't cause a diff.
+		var prior []EnvironmentFreezeModel
+		diags.Append(model.FreezeWindows.ElementsAs(ctx, &prior, false)...)
+		rank := map[string]int{}
+		for i := len(prior) - 1; i >= 0; i-- {
+			rank[prior[i].Window.ValueString()+"|"+prior[i].Timezone.ValueString()] = i
+		}
+		serverFreezes := append([]client.FreezeWindow(nil), env.Freezes...)
+		sort.SliceStable(serverFreezes, func(i, j int) bool {
+			ri, ok := rank[serverFreezes[i].Window+"|"+serverFreezes[i].Timezone]
+			if !ok {
+				ri = len(prior)
+			}
+			rj, ok := rank[serverFreezes[j].Window+"|"+serverFreezes[j].Timezone]
+			if !ok {
+				rj = len(prior)
+			}
+			return ri < rj
+		})
+
+		freezes := make([]EnvironmentFreezeModel, 0, len(serverFreezes))
+		for _, f := range serverFreezes {
+			m := EnvironmentFreezeModel{
+				Window:   types.StringValue(f.Window),
+				Timezone: types.StringValue(f.Timezone),
+				Reason:   types.StringNull(),
+			}
+			if f.Timezone == "" {
+				m.Timezone = types.StringValue("UTC")
+			}
+			if f.Reason != "" {
+				m.Reason = types.StringValue(f.Reason)
+			}
+			freezes = append(freezes, m)
+		}
+		listVal, d := types.ListValueFrom(ctx, freezeWindowType, freezes)
+		diags.Append(d...)
+		model.FreezeWindows = listVal
+	}
+}
+
 func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state EnvironmentResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -212,11 +465,15 @@ func (r *EnvironmentResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *EnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: ws-slug/proj-slug/env-slug
+	// Import ID format: ws-slug/proj-slug/env-slug or id:<env_id>
+	if importByID(ctx, r.client, "environment", req, resp, func(ref *client.ResourceRef) map[string]string {
+		return map[string]string{"workspace_slug": ref.WorkspaceSlug, "project_slug": ref.ProjectSlug, "slug": ref.Slug}
+	}) {
+		return
+	}
 	parts := strings.SplitN(req.ID, "/", 3)
 	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
-		resp.Diagnostics.AddError("Invalid import ID",
-			"Expected import ID format: <workspace_slug>/<project_slug>/<env_slug>")
+		ImportFormats["environment"].addError(resp)
 		return
 	}
 
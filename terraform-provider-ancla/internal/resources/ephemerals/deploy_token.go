@@ -0,0 +1,206 @@
+// Package ephemerals implements the provider's ephemeral resources, which
+// Terraform opens for the duration of a run and never writes to state.
+package ephemerals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &DeployTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &DeployTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &DeployTokenEphemeralResource{}
+)
+
+const (
+	defaultTokenName = "terraform-deploy-token"
+	defaultTokenTTL  = time.Hour
+	maxTokenTTL      = 24 * time.Hour
+
+	// privateKeyID is the private data key that carries the key ID from
+	// Open to Close so the token can be revoked at the end of the run.
+	privateKeyID = "key_id"
+)
+
+// DeployTokenEphemeralResource mints a short-lived, scoped API key for the
+// duration of a Terraform run and revokes it when the run is done.
+type DeployTokenEphemeralResource struct {
+	client *client.Client
+}
+
+// DeployTokenEphemeralResourceModel maps the ephemeral resource schema data.
+type DeployTokenEphemeralResourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	Scopes        types.List   `tfsdk:"scopes"`
+	TTL           types.String `tfsdk:"ttl"`
+	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
+	ProjectSlug   types.String `tfsdk:"project_slug"`
+	EnvSlug       types.String `tfsdk:"env_slug"`
+	ServiceSlug   types.String `tfsdk:"service_slug"`
+	ID            types.String `tfsdk:"id"`
+	Token         types.String `tfsdk:"token"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+}
+
+func NewDeployTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &DeployTokenEphemeralResource{}
+}
+
+func (r *DeployTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deploy_token"
+}
+
+func (r *DeployTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mints a short-lived, scoped API key for the duration of a Terraform run. " +
+			"The token is never written to state or plan, and is revoked when Terraform is done with it. " +
+			"Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "A name for the key, shown in the key list while it exists. Defaults to " + defaultTokenName + ".",
+				Optional:    true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: `The permissions granted to the token. Defaults to ["deploy"].`,
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ttl": schema.StringAttribute{
+				Description: "How long the token is valid, as a Go duration such as 30m or 2h. Defaults to 1h; at most 24h.",
+				Optional:    true,
+			},
+			"workspace_slug": schema.StringAttribute{
+				Description: "The slug of the workspace the token is for, recorded with the key.",
+				Optional:    true,
+			},
+			"project_slug": schema.StringAttribute{
+				Description: "The slug of the project the token is for, recorded with the key.",
+				Optional:    true,
+			},
+			"env_slug": schema.StringAttribute{
+				Description: "The slug of the environment the token is for, recorded with the key.",
+				Optional:    true,
+			},
+			"service_slug": schema.StringAttribute{
+				Description: "The slug of the service the token is for, recorded with the key.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The ID of the API key.",
+				Computed:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The API key itself.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "When the token expires, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *DeployTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *DeployTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config DeployTokenEphemeralResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := defaultTokenName
+	if !config.Name.IsNull() && !config.Name.IsUnknown() {
+		name = config.Name.ValueString()
+	}
+	scopes := []string{"deploy"}
+	if !config.Scopes.IsNull() && !config.Scopes.IsUnknown() {
+		resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	ttl := defaultTokenTTL
+	if !config.TTL.IsNull() && !config.TTL.IsUnknown() {
+		d, err := time.ParseDuration(config.TTL.ValueString())
+		if err != nil || d <= 0 || d > maxTokenTTL {
+			resp.Diagnostics.AddError("Invalid ttl",
+				fmt.Sprintf("Expected a duration between 1s and 24h, such as 30m or 2h, got %q.", config.TTL.ValueString()))
+			return
+		}
+		ttl = d
+	}
+
+	metadata := map[string]string{"created_by": "terraform"}
+	for key, v := range map[string]types.String{
+		"workspace": config.WorkspaceSlug,
+		"project":   config.ProjectSlug,
+		"env":       config.EnvSlug,
+		"service":   config.ServiceSlug,
+	} {
+		if !v.IsNull() && !v.IsUnknown() {
+			metadata[key] = v.ValueString()
+		}
+	}
+
+	expires := time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	key, err := r.client.CreateAPIKey(name, scopes, expires, metadata)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating deploy token", err.Error())
+		return
+	}
+
+	config.ID = types.StringValue(key.ID)
+	config.Token = types.StringValue(key.Key)
+	config.ExpiresAt = types.StringValue(key.ExpiresAt)
+	if key.ExpiresAt == "" {
+		config.ExpiresAt = types.StringValue(expires)
+	}
+
+	keyID, _ := json.Marshal(key.ID)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyID, keyID)...)
+	diags = resp.Result.Set(ctx, config)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *DeployTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	data, diags := req.Private.GetKey(ctx, privateKeyID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || data == nil {
+		return
+	}
+	var keyID string
+	if err := json.Unmarshal(data, &keyID); err != nil {
+		resp.Diagnostics.AddError("Error revoking deploy token", fmt.Sprintf("reading key ID from private data: %s", err))
+		return
+	}
+
+	if err := r.client.RevokeAPIKey(keyID); err != nil && !client.IsNotFound(err) {
+		// The key still expires on its own, so a failed revoke is not fatal.
+		resp.Diagnostics.AddWarning("Error revoking deploy token",
+			fmt.Sprintf("API key %s could not be revoked and stays valid until it expires: %s", keyID, err))
+	}
+}
@@ -0,0 +1,98 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+// importIDPrefix marks an import ID that is a resource ID rather than a
+// slug path, e.g. "id:3f2b…". Slug paths break when something is renamed;
+// IDs don't.
+const importIDPrefix = "id:"
+
+// ImportFormat describes the slug-path import ID of a resource, one
+// segment per slug. ImportState reports it for an import ID that doesn't
+// parse, and cmd/gen-import-examples writes the docs' import examples
+// from it, so the two can't disagree.
+type ImportFormat struct {
+	Segments []string // what each path segment holds, e.g. workspace_slug
+	ID       string   // what id:<...> takes, e.g. project_id
+	Note     string   // extra guidance, if any
+}
+
+// ImportFormats holds the import ID format of every importable resource,
+// by type name without the provider prefix.
+var ImportFormats = map[string]ImportFormat{
+	"workspace":   {Segments: []string{"workspace_slug"}, ID: "workspace_id"},
+	"project":     {Segments: []string{"workspace_slug", "project_slug"}, ID: "project_id"},
+	"environment": {Segments: []string{"workspace_slug", "project_slug", "env_slug"}, ID: "env_id"},
+	"service":     {Segments: []string{"workspace_slug", "project_slug", "env_slug", "service_slug"}, ID: "service_id"},
+	"config_var": {
+		Segments: []string{"workspace_slug", "project_slug", "env_slug", "service_slug", "config_id"},
+		ID:       "config_id",
+		Note:     "use '-' for unused scope segments",
+	},
+	"database": {Segments: []string{"workspace_slug", "project_slug", "env_slug", "service_slug", "database_id"}, ID: "database_id"},
+	"cache":    {Segments: []string{"workspace_slug", "project_slug", "env_slug", "service_slug", "cache_id"}, ID: "cache_id"},
+}
+
+// String returns the slug path, e.g. "<workspace_slug>/<project_slug>".
+func (f ImportFormat) String() string {
+	segments := make([]string, len(f.Segments))
+	for i, s := range f.Segments {
+		segments[i] = "<" + s + ">"
+	}
+	return strings.Join(segments, "/")
+}
+
+// addError reports an import ID that matches neither form.
+func (f ImportFormat) addError(resp *resource.ImportStateResponse) {
+	msg := "Expected import ID format: " + f.String()
+	if f.Note != "" {
+		msg += " (" + f.Note + ")"
+	}
+	resp.Diagnostics.AddError("Invalid import ID", msg+" or "+importIDPrefix+"<"+f.ID+">")
+}
+
+// importByID imports a resource from an "id:<uuid>" import ID. It looks the
+// ID up, checks that it is a resource of the given kind, and stores the ID
+// and the attributes returned by attrs (empty values are skipped). It
+// returns false, doing nothing, when the import ID is a slug path.
+func importByID(ctx context.Context, c *client.Client, kind string, req resource.ImportStateRequest, resp *resource.ImportStateResponse, attrs func(*client.ResourceRef) map[string]string) bool {
+	id, ok := strings.CutPrefix(req.ID, importIDPrefix)
+	if !ok {
+		return false
+	}
+	if id == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected id:<%s_id> with a non-empty ID.", kind))
+		return true
+	}
+
+	ref, err := c.LookupResource(id)
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddError("Cannot import "+kind, fmt.Sprintf("No resource with ID %q was found.", id))
+			return true
+		}
+		resp.Diagnostics.AddError("Error looking up "+kind, err.Error())
+		return true
+	}
+	if ref.Kind != kind {
+		resp.Diagnostics.AddError("Cannot import "+kind, fmt.Sprintf("ID %q belongs to a %s, not a %s.", id, ref.Kind, kind))
+		return true
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	for attr, v := range attrs(ref) {
+		if v != "" {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attr), v)...)
+		}
+	}
+	return true
+}
@@ -18,6 +18,7 @@ import (
 var (
 	_ resource.Resource                = &ProjectResource{}
 	_ resource.ResourceWithImportState = &ProjectResource{}
+	_ resource.ResourceWithModifyPlan  = &ProjectResource{}
 )
 
 // ProjectResource manages an Ancla project.
@@ -32,6 +33,8 @@ type ProjectResourceModel struct {
 	Slug          types.String `tfsdk:"slug"`
 	WorkspaceSlug types.String `tfsdk:"workspace_slug"`
 	ServiceCount  types.Int64  `tfsdk:"service_count"`
+
+	DefaultEnvironments types.List `tfsdk:"default_environments"`
 }
 
 func NewProjectResource() resource.Resource {
@@ -58,16 +61,18 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required:    true,
 			},
 			"slug": schema.StringAttribute{
-				Description: "The URL-friendly slug of the project.",
+				Description: "The URL-friendly slug of the project. Derived from the name, so it can change on rename; it is refreshed from the API, and the project is tracked by id.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					slugFollowsName(),
 				},
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace this project belongs to.",
-				Required:    true,
+				Description: "The slug of the workspace this project belongs to. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -75,6 +80,11 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Description: "The number of services in the project.",
 				Computed:    true,
 			},
+			"default_environments": schema.ListAttribute{
+				Description: "Environments to create with the project, by name. Defaults to production, staging, and development; set to [] to create none and manage them with ancla_environment. Only used when the project is created.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -92,6 +102,10 @@ func (r *ProjectResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = c
 }
 
+func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyProviderDefaults(ctx, r.client, req, resp, "workspace_slug")
+}
+
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ProjectResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -100,7 +114,18 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	project, err := r.client.CreateProject(plan.WorkspaceSlug.ValueString(), plan.Name.ValueString())
+	var defaultEnvs []string
+	if !plan.DefaultEnvironments.IsNull() && !plan.DefaultEnvironments.IsUnknown() {
+		resp.Diagnostics.Append(plan.DefaultEnvironments.ElementsAs(ctx, &defaultEnvs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if defaultEnvs == nil {
+			defaultEnvs = []string{}
+		}
+	}
+
+	project, err := r.client.CreateProject(plan.WorkspaceSlug.ValueString(), plan.Name.ValueString(), defaultEnvs)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating project", err.Error())
 		return
@@ -124,6 +149,17 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// Follow renames: the ID is the key, slugs are refreshed from it.
+	ref, err := locateByID(r.client, state.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading project", err.Error())
+		return
+	}
+	if ref != nil {
+		state.WorkspaceSlug = types.StringValue(ref.WorkspaceSlug)
+		state.Slug = types.StringValue(ref.Slug)
+	}
+
 	project, err := r.client.GetProject(state.WorkspaceSlug.ValueString(), state.Slug.ValueString())
 	if err != nil {
 		if client.IsNotFound(err) {
@@ -194,11 +230,15 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: ws-slug/project-slug
+	// Import ID format: ws-slug/project-slug or id:<project_id>
+	if importByID(ctx, r.client, "project", req, resp, func(ref *client.ResourceRef) map[string]string {
+		return map[string]string{"workspace_slug": ref.WorkspaceSlug, "slug": ref.Slug}
+	}) {
+		return
+	}
 	parts := strings.SplitN(req.ID, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		resp.Diagnostics.AddError("Invalid import ID",
-			"Expected import ID format: <workspace_slug>/<project_slug>")
+		ImportFormats["project"].addError(resp)
 		return
 	}
 
@@ -19,6 +19,7 @@ import (
 var (
 	_ resource.Resource                = &ServiceResource{}
 	_ resource.ResourceWithImportState = &ServiceResource{}
+	_ resource.ResourceWithModifyPlan  = &ServiceResource{}
 )
 
 // ServiceResource manages an Ancla service.
@@ -38,6 +39,8 @@ type ServiceResourceModel struct {
 	GithubRepository types.String `tfsdk:"github_repository"`
 	AutoDeployBranch types.String `tfsdk:"auto_deploy_branch"`
 	ProcessCounts    types.Map    `tfsdk:"process_counts"`
+	Labels           types.Map    `tfsdk:"labels"`
+	Buildpacks       types.List   `tfsdk:"buildpacks"`
 }
 
 func NewServiceResource() resource.Resource {
@@ -71,16 +74,20 @@ func (r *ServiceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"workspace_slug": schema.StringAttribute{
-				Description: "The slug of the workspace this service belongs to.",
-				Required:    true,
+				Description: "The slug of the workspace this service belongs to. Defaults to the provider's workspace.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"project_slug": schema.StringAttribute{
-				Description: "The slug of the project this service belongs to.",
-				Required:    true,
+				Description: "The slug of the project this service belongs to. Defaults to the provider's project.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -114,6 +121,16 @@ func (r *ServiceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:    true,
 				ElementType: types.Int64Type,
 			},
+			"labels": schema.MapAttribute{
+				Description: "Labels on the service, used by label selectors (e.g. team=payments). When set, Terraform manages all of the service's labels; when omitted, labels are left alone.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"buildpacks": schema.ListAttribute{
+				Description: "Buildpacks to build with, in order, as <id> or <id>@<version> (e.g. heroku/python@0.19.1). Only used by services with the buildpack build strategy. When set, exactly these run; an empty list or omitting the attribute after setting it goes back to detecting them on each build. When never set, buildpacks pinned elsewhere are left alone.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -131,6 +148,10 @@ func (r *ServiceResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = c
 }
 
+func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyProviderDefaults(ctx, r.client, req, resp, "workspace_slug", "project_slug")
+}
+
 func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ServiceResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -151,6 +172,30 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	fields := map[string]any{}
+	if labels, ok := r.planLabels(ctx, plan, &resp.Diagnostics); ok {
+		fields["labels"] = labels
+	}
+	if buildpacks, ok := r.planBuildpacks(ctx, plan, &resp.Diagnostics); ok {
+		fields["buildpacks"] = buildpacks
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(fields) > 0 {
+		svc, err = r.client.UpdateService(
+			plan.WorkspaceSlug.ValueString(),
+			plan.ProjectSlug.ValueString(),
+			plan.EnvSlug.ValueString(),
+			svc.Slug,
+			fields,
+		)
+		if err != nil {
+			resp.Diagnostics.AddError("Error configuring service", err.Error())
+			return
+		}
+	}
+
 	r.mapServiceToState(ctx, svc, &plan, &resp.Diagnostics)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -164,6 +209,19 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// Follow renames: the ID is the key, slugs are refreshed from it.
+	ref, err := locateByID(r.client, state.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading service", err.Error())
+		return
+	}
+	if ref != nil {
+		state.WorkspaceSlug = types.StringValue(ref.WorkspaceSlug)
+		state.ProjectSlug = types.StringValue(ref.ProjectSlug)
+		state.EnvSlug = types.StringValue(ref.EnvSlug)
+		state.Slug = types.StringValue(ref.Slug)
+	}
+
 	svc, err := r.client.GetService(
 		state.WorkspaceSlug.ValueString(),
 		state.ProjectSlug.ValueString(),
@@ -208,6 +266,21 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 	if !plan.AutoDeployBranch.IsNull() && !plan.AutoDeployBranch.IsUnknown() {
 		fields["auto_deploy_branch"] = plan.AutoDeployBranch.ValueString()
 	}
+	if labels, ok := r.planLabels(ctx, plan, &resp.Diagnostics); ok {
+		fields["labels"] = labels
+	} else if !state.Labels.IsNull() {
+		// Removing the attribute clears the labels Terraform set.
+		fields["labels"] = map[string]string{}
+	}
+	if buildpacks, ok := r.planBuildpacks(ctx, plan, &resp.Diagnostics); ok {
+		fields["buildpacks"] = buildpacks
+	} else if !state.Buildpacks.IsNull() {
+		// Removing the attribute goes back to detected buildpacks.
+		fields["buildpacks"] = []string{}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	svc, err := r.client.UpdateService(
 		state.WorkspaceSlug.ValueString(),
@@ -282,11 +355,20 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: ws/proj/env/svc
+	// Import ID format: ws/proj/env/svc or id:<service_id>
+	if importByID(ctx, r.client, "service", req, resp, func(ref *client.ResourceRef) map[string]string {
+		return map[string]string{
+			"workspace_slug": ref.WorkspaceSlug,
+			"project_slug":   ref.ProjectSlug,
+			"env_slug":       ref.EnvSlug,
+			"slug":           ref.Slug,
+		}
+	}) {
+		return
+	}
 	parts := strings.SplitN(req.ID, "/", 4)
 	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
-		resp.Diagnostics.AddError("Invalid import ID",
-			"Expected import ID format: <workspace_slug>/<project_slug>/<env_slug>/<service_slug>")
+		ImportFormats["service"].addError(resp)
 		return
 	}
 
@@ -296,6 +378,32 @@ func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportSt
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slug"), parts[3])...)
 }
 
+// planLabels returns the labels configured in plan. ok is false when the
+// labels attribute is not set.
+func (r *ServiceResource) planLabels(ctx context.Context, plan ServiceResourceModel, diags *diag.Diagnostics) (labels map[string]string, ok bool) {
+	if plan.Labels.IsNull() || plan.Labels.IsUnknown() {
+		return nil, false
+	}
+	diags.Append(plan.Labels.ElementsAs(ctx, &labels, false)...)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return labels, true
+}
+
+// planBuildpacks returns the buildpacks configured in plan. ok is false
+// when the buildpacks attribute is not set.
+func (r *ServiceResource) planBuildpacks(ctx context.Context, plan ServiceResourceModel, diags *diag.Diagnostics) (buildpacks []string, ok bool) {
+	if plan.Buildpacks.IsNull() || plan.Buildpacks.IsUnknown() {
+		return nil, false
+	}
+	diags.Append(plan.Buildpacks.ElementsAs(ctx, &buildpacks, false)...)
+	if buildpacks == nil {
+		buildpacks = []string{}
+	}
+	return buildpacks, true
+}
+
 func (r *ServiceResource) mapServiceToState(ctx context.Context, svc *client.Service, model *ServiceResourceModel, diags *diag.Diagnostics) {
 	model.ID = types.StringValue(svc.ID)
 	model.Name = types.StringValue(svc.Name)
@@ -325,4 +433,27 @@ func (r *ServiceResource) mapServiceToState(ctx context.Context, svc *client.Ser
 	} else {
 		model.ProcessCounts = types.MapNull(types.Int64Type)
 	}
+
+	// Labels are only tracked when the config manages them, so labels
+	// added with the CLI don't show up as drift on unmanaged services.
+	if !model.Labels.IsNull() {
+		labels := svc.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		mapVal, d := types.MapValueFrom(ctx, types.StringType, labels)
+		diags.Append(d...)
+		model.Labels = mapVal
+	}
+
+	// Buildpacks follow the same rule as labels.
+	if !model.Buildpacks.IsNull() {
+		buildpacks := svc.Buildpacks
+		if buildpacks == nil {
+			buildpacks = []string{}
+		}
+		listVal, d := types.ListValueFrom(ctx, types.StringType, buildpacks)
+		diags.Append(d...)
+		model.Buildpacks = listVal
+	}
 }
@@ -120,6 +120,16 @@ func (r *WorkspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Follow renames: the ID is the key, the slug is refreshed from it.
+	ref, err := locateByID(r.client, state.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading workspace", err.Error())
+		return
+	}
+	if ref != nil {
+		state.Slug = types.StringValue(ref.Slug)
+	}
+
 	ws, err := r.client.GetWorkspace(state.Slug.ValueString())
 	if err != nil {
 		if client.IsNotFound(err) {
@@ -186,5 +196,11 @@ func (r *WorkspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *WorkspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: <workspace_slug> or id:<workspace_id>
+	if importByID(ctx, r.client, "workspace", req, resp, func(ref *client.ResourceRef) map[string]string {
+		return map[string]string{"slug": ref.Slug}
+	}) {
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("slug"), req, resp)
 }
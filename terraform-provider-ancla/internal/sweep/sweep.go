@@ -0,0 +1,175 @@
+// Package sweep deletes what acceptance tests leave behind when a run
+// fails before destroy: services, environments, projects and workspaces
+// whose slug starts with Prefix.
+//
+// Sweepers are run with
+//
+//	ANCLA_API_KEY=... go test ./internal/sweep -sweep
+//
+// and may be narrowed with -sweep-run, e.g. -sweep-run=ancla_service.
+// The provider doesn't depend on terraform-plugin-testing, so this keeps
+// its conventions without its sweeper registry.
+package sweep
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+// Prefix starts the name of everything acceptance tests create.
+const Prefix = "tf-acc-"
+
+// A Sweeper deletes the resources of one type whose slug starts with a
+// prefix, returning the paths it deleted.
+type Sweeper struct {
+	Name  string // the Terraform resource type
+	Sweep func(c *client.Client, prefix string) ([]string, error)
+}
+
+// Sweepers run in order, children first, so a swept parent never leaves
+// a half-deleted child behind and a child isn't swept twice.
+var Sweepers = []Sweeper{
+	{Name: "ancla_service", Sweep: sweepServices},
+	{Name: "ancla_environment", Sweep: sweepEnvironments},
+	{Name: "ancla_project", Sweep: sweepProjects},
+	{Name: "ancla_workspace", Sweep: sweepWorkspaces},
+}
+
+// Run runs the sweepers named in only, or all of them when only is empty,
+// and returns everything deleted. It carries on past a failed deletion and
+// reports every failure at the end.
+func Run(c *client.Client, prefix string, only ...string) ([]string, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("refusing to sweep without a prefix")
+	}
+	var deleted []string
+	var errs []error
+	for _, s := range Sweepers {
+		if len(only) > 0 && !contains(only, s.Name) {
+			continue
+		}
+		paths, err := s.Sweep(c, prefix)
+		deleted = append(deleted, paths...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name, err))
+		}
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// eachProject calls fn with every project of every workspace. A listing
+// error ends the walk.
+func eachProject(c *client.Client, fn func(ws, proj string) error) error {
+	workspaces, err := c.ListWorkspaces()
+	if err != nil {
+		return err
+	}
+	for _, ws := range workspaces {
+		projects, err := c.ListProjects(ws.Slug)
+		if err != nil {
+			return err
+		}
+		for _, p := range projects {
+			if err := fn(ws.Slug, p.Slug); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sweepLog collects what a sweeper deleted and what it failed to. A
+// resource already gone counts as deleted.
+type sweepLog struct {
+	deleted []string
+	errs    []error
+}
+
+func (l *sweepLog) record(path string, err error) {
+	if err != nil && !client.IsNotFound(err) {
+		l.errs = append(l.errs, fmt.Errorf("deleting %s: %w", path, err))
+		return
+	}
+	l.deleted = append(l.deleted, path)
+}
+
+func (l *sweepLog) result(err error) ([]string, error) {
+	return l.deleted, errors.Join(append(l.errs, err)...)
+}
+
+func sweepServices(c *client.Client, prefix string) ([]string, error) {
+	var log sweepLog
+	err := eachProject(c, func(ws, proj string) error {
+		envs, err := c.ListEnvironments(ws, proj)
+		if err != nil {
+			return err
+		}
+		for _, e := range envs {
+			services, err := c.ListServices(ws, proj, e.Slug)
+			if err != nil {
+				return err
+			}
+			for _, s := range services {
+				if strings.HasPrefix(s.Slug, prefix) {
+					log.record(ws+"/"+proj+"/"+e.Slug+"/"+s.Slug, c.DeleteService(ws, proj, e.Slug, s.Slug))
+				}
+			}
+		}
+		return nil
+	})
+	return log.result(err)
+}
+
+func sweepEnvironments(c *client.Client, prefix string) ([]string, error) {
+	var log sweepLog
+	err := eachProject(c, func(ws, proj string) error {
+		envs, err := c.ListEnvironments(ws, proj)
+		if err != nil {
+			return err
+		}
+		for _, e := range envs {
+			if strings.HasPrefix(e.Slug, prefix) {
+				log.record(ws+"/"+proj+"/"+e.Slug, c.DeleteEnvironment(ws, proj, e.Slug))
+			}
+		}
+		return nil
+	})
+	return log.result(err)
+}
+
+func sweepProjects(c *client.Client, prefix string) ([]string, error) {
+	var log sweepLog
+	err := eachProject(c, func(ws, proj string) error {
+		if strings.HasPrefix(proj, prefix) {
+			log.record(ws+"/"+proj, c.DeleteProject(ws, proj))
+		}
+		return nil
+	})
+	return log.result(err)
+}
+
+func sweepWorkspaces(c *client.Client, prefix string) ([]string, error) {
+	workspaces, err := c.ListWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	var log sweepLog
+	for _, ws := range workspaces {
+		if strings.HasPrefix(ws.Slug, prefix) {
+			log.record(ws.Slug, c.DeleteWorkspace(ws.Slug))
+		}
+	}
+	return log.result(nil)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
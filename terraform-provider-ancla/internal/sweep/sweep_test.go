@@ -0,0 +1,104 @@
+package sweep
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sidequest-labs/terraform-provider-ancla/internal/client"
+)
+
+var (
+	sweepFlag = flag.Bool("sweep", false, "delete everything named with the acceptance test prefix instead of running tests")
+	sweepRun  = flag.String("sweep-run", "", "comma-separated sweepers to run, e.g. ancla_service (default all)")
+)
+
+// TestMain runs the sweepers against ANCLA_SERVER with ANCLA_API_KEY when
+// -sweep is given, and the tests otherwise.
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !*sweepFlag {
+		os.Exit(m.Run())
+	}
+
+	server := os.Getenv("ANCLA_SERVER")
+	if server == "" {
+		server = "https://ancla.dev"
+	}
+	if os.Getenv("ANCLA_API_KEY") == "" {
+		fmt.Fprintln(os.Stderr, "sweep: ANCLA_API_KEY must be set")
+		os.Exit(1)
+	}
+	var only []string
+	if *sweepRun != "" {
+		only = strings.Split(*sweepRun, ",")
+	}
+	deleted, err := Run(client.New(server, os.Getenv("ANCLA_API_KEY")), Prefix, only...)
+	for _, path := range deleted {
+		fmt.Println("swept", path)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sweep:", err)
+		os.Exit(1)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	var deletes []string
+	listings := map[string]string{
+		"/api/v1/workspaces/":                                             `[{"slug":"acme"},{"slug":"tf-acc-ws1"}]`,
+		"/api/v1/workspaces/acme/projects/":                               `[{"slug":"shop"},{"slug":"tf-acc-proj"}]`,
+		"/api/v1/workspaces/tf-acc-ws1/projects/":                         `[]`,
+		"/api/v1/workspaces/acme/projects/shop/envs/":                     `[{"slug":"production"}]`,
+		"/api/v1/workspaces/acme/projects/tf-acc-proj/envs/":              `[]`,
+		"/api/v1/workspaces/acme/projects/shop/envs/production/services/": `[{"slug":"web"},{"slug":"tf-acc-svc"},{"slug":"tf-acc-gone"}]`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			mu.Lock()
+			deletes = append(deletes, r.URL.Path)
+			mu.Unlock()
+			if strings.HasSuffix(r.URL.Path, "/tf-acc-gone") {
+				http.NotFound(w, r)
+			}
+			return
+		}
+		body, ok := listings[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	deleted, err := Run(client.New(ts.URL, "test-key"), Prefix)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	want := []string{"acme/shop/production/tf-acc-svc", "acme/shop/production/tf-acc-gone", "acme/tf-acc-proj", "tf-acc-ws1"}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted = %q, want %q", deleted, want)
+	}
+	wantDeletes := []string{
+		"/api/v1/workspaces/acme/projects/shop/envs/production/services/tf-acc-svc",
+		"/api/v1/workspaces/acme/projects/shop/envs/production/services/tf-acc-gone",
+		"/api/v1/workspaces/acme/projects/tf-acc-proj",
+		"/api/v1/workspaces/tf-acc-ws1",
+	}
+	if !reflect.DeepEqual(deletes, wantDeletes) {
+		t.Errorf("DELETE requests = %q, want children before parents: %q", deletes, wantDeletes)
+	}
+
+	if _, err := Run(client.New(ts.URL, "test-key"), ""); err == nil {
+		t.Error("Run with an empty prefix swept everything")
+	}
+}
@@ -10,6 +10,13 @@ import (
 	"github.com/sidequest-labs/terraform-provider-ancla/internal/provider"
 )
 
+// The registry docs in docs/ are generated from the provider schema, the
+// examples, and templates/; the import examples come from the import ID
+// formats in code. Run `go generate` (or `make tf-docs` from the repo root)
+// after changing any of them.
+//go:generate go run ./cmd/gen-import-examples --out examples/resources
+//go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs@v0.24.0 generate --provider-name ancla --rendered-provider-name Ancla
+
 var version = "dev"
 
 func main() {